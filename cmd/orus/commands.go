@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/extractor"
+	"github.com/MiltonJ23/Orus/internal/adapters/httpapi"
+	"github.com/MiltonJ23/Orus/internal/adapters/httpserve"
+	notifier "github.com/MiltonJ23/Orus/internal/adapters/notifier"
+	"github.com/MiltonJ23/Orus/internal/adapters/opds"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/adapters/webreader"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+const usage = `usage: orus [--log-level NIVEAU] [--lang LANGUE] [--demo] [commande]
+
+Sans commande, ouvre la bibliothèque.
+
+  --log-level NIVEAU  debug, info, warn ou error (défaut info) ; journal
+                      dans logs/orus.log, à côté de la base
+  --lang LANGUE       en ou fr ; retenue dans settings.json (défaut : langue
+                      du système, sinon fr)
+  --demo              ouvre une bibliothèque d'exemple en mémoire, sans
+                      toucher à orus.db (captures d'écran, découverte)
+
+  backup            sauvegarde la base maintenant
+  backups           liste les sauvegardes
+  restore FICHIER   remplace la base par une sauvegarde vérifiée
+                    (quittez Orus avant de restaurer)
+  serve [ADRESSE]   expose l'API REST/JSON en local (défaut 127.0.0.1:7878) ;
+                    jeton dans api-token, phrase de passe dans ORUS_PASSPHRASE
+  opds [ADRESSE]    catalogue OPDS pour liseuses du reseau local (défaut :7879) ;
+                    utilisateur orus, mot de passe dans opds-password
+  web [ADRESSE]     lecteur web pour tablettes du reseau local (défaut :7880) ;
+                    utilisateur orus, mot de passe dans web-password
+`
+
+// runCommand runs a maintenance command instead of the UI and returns the
+// process exit code.
+func runCommand(dbPath string, logger *slog.Logger, args []string) int {
+	switch {
+	case args[0] == "restore" && len(args) == 2:
+		previous, err := sqlite.Restore(args[1], dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "restauration impossible : %v\n", err)
+			return 1
+		}
+		fmt.Printf("Base restauree depuis %s\n", args[1])
+		if previous != "" {
+			fmt.Printf("Ancienne base conservee dans %s\n", previous)
+		}
+		return 0
+	case args[0] == "backup" && len(args) == 1:
+		store, err := sqlite.NewStorage(dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "base indisponible : %v\n", err)
+			return 1
+		}
+		defer store.Close()
+		backups := service.NewBackupService(store, sqlite.BackupDir(dbPath), logger, clock.NewSystemClock())
+		backup, err := backups.BackupNow(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "sauvegarde impossible : %v\n", err)
+			return 1
+		}
+		fmt.Printf("Sauvegarde -> %s\n", backup.Path)
+		return 0
+	case args[0] == "backups" && len(args) == 1:
+		backups := service.NewBackupService(nil, sqlite.BackupDir(dbPath), logger, clock.NewSystemClock())
+		list, err := backups.List()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, b := range list {
+			fmt.Printf("%s  %-5s  %8d  %s\n", b.Time.Format("2006-01-02 15:04"), b.Kind, b.Size, b.Path)
+		}
+		return 0
+	case args[0] == "serve" && len(args) <= 2:
+		addr := httpapi.DefaultAddr
+		if len(args) == 2 {
+			addr = args[1]
+		}
+		return serve(dbPath, logger, addr)
+	case args[0] == "opds" && len(args) <= 2:
+		addr := opds.DefaultAddr
+		if len(args) == 2 {
+			addr = args[1]
+		}
+		return serveOPDS(dbPath, logger, addr)
+	case args[0] == "web" && len(args) <= 2:
+		addr := webreader.DefaultAddr
+		if len(args) == 2 {
+			addr = args[1]
+		}
+		return serveWeb(dbPath, logger, addr)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+}
+
+// serve runs the local API until interrupted. Reminders are not scheduled
+// here: the desktop app keeps ringing them.
+func serve(dbPath string, logger *slog.Logger, addr string) int {
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "base indisponible : %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	if !unlockFromEnv(store, "fiches et notes indisponibles (423)") {
+		return 1
+	}
+
+	tokenPath := filepath.Join(filepath.Dir(dbPath), "api-token")
+	token, err := httpserve.LoadOrCreateToken(tokenPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	systemClock := clock.NewSystemClock()
+	library := service.NewLibraryService(store, store, store, extractor.NewLocalFileExtractor(), logger, systemClock)
+	library.SetUnitOfWork(store)
+	api, err := httpapi.NewServer(
+		library,
+		service.NewTrackerService(store, store, systemClock),
+		service.NewReadingSheetService(store, store, systemClock),
+		service.NewReminderService(store, store, notifier.NewLogNotifier(logger), logger, systemClock),
+		service.NewAnnotationService(store, store, logger, systemClock),
+		token, logger,
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("API Orus sur http://%s/api (description : /api/openapi.json)\n", addr)
+	fmt.Printf("Jeton : %s (Authorization: Bearer ..., dans %s)\n", token, tokenPath)
+	if err := api.ListenAndServe(ctx, addr); err != nil {
+		fmt.Fprintf(os.Stderr, "serveur arrete : %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// unlockFromEnv unlocks an encrypted database with ORUS_PASSPHRASE. Without
+// it the server still runs, missing what the warning says; a wrong
+// passphrase returns false.
+func unlockFromEnv(store *sqlite.Storage, missing string) bool {
+	if !store.Locked() {
+		return true
+	}
+	passphrase := os.Getenv("ORUS_PASSPHRASE")
+	if passphrase == "" {
+		fmt.Fprintf(os.Stderr, "base chiffree : %s sans ORUS_PASSPHRASE\n", missing)
+		return true
+	}
+	if err := service.NewEncryptionService(store).Unlock(passphrase); err != nil {
+		fmt.Fprintf(os.Stderr, "deverrouillage impossible : %v\n", err)
+		return false
+	}
+	return true
+}
+
+// serveOPDS runs the OPDS catalog until interrupted.
+func serveOPDS(dbPath string, logger *slog.Logger, addr string) int {
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "base indisponible : %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	if !unlockFromEnv(store, "tags des fiches indisponibles") {
+		return 1
+	}
+
+	passwordPath := filepath.Join(filepath.Dir(dbPath), "opds-password")
+	password, err := httpserve.LoadOrCreatePassword(passwordPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	systemClock := clock.NewSystemClock()
+	catalog, err := opds.NewServer(
+		service.NewLibraryService(store, store, store, extractor.NewLocalFileExtractor(), logger, systemClock),
+		service.NewTrackerService(store, store, systemClock),
+		service.NewReadingSheetService(store, store, systemClock),
+		opds.Username, password, logger,
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("Catalogue OPDS sur http://%s/opds (adresse de cet ordinateur sur le reseau)\n", addr)
+	fmt.Printf("Utilisateur : %s, mot de passe : %s (dans %s)\n", opds.Username, password, passwordPath)
+	if err := catalog.ListenAndServe(ctx, addr); err != nil {
+		fmt.Fprintf(os.Stderr, "serveur arrete : %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// serveWeb runs the web reader until interrupted.
+func serveWeb(dbPath string, logger *slog.Logger, addr string) int {
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "base indisponible : %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	if !unlockFromEnv(store, "surlignages indisponibles") {
+		return 1
+	}
+
+	passwordPath := filepath.Join(filepath.Dir(dbPath), "web-password")
+	password, err := httpserve.LoadOrCreatePassword(passwordPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	systemClock := clock.NewSystemClock()
+	fileExtractor := extractor.NewLocalFileExtractor()
+	reader, err := webreader.NewServer(
+		service.NewLibraryService(store, store, store, fileExtractor, logger, systemClock),
+		service.NewTrackerService(store, store, systemClock),
+		service.NewAnnotationService(store, store, logger, systemClock),
+		fileExtractor,
+		webreader.Username, password, logger,
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("Lecteur web sur http://%s/ (adresse de cet ordinateur sur le reseau)\n", addr)
+	fmt.Printf("Utilisateur : %s, mot de passe : %s (dans %s)\n", webreader.Username, password, passwordPath)
+	if err := reader.ListenAndServe(ctx, addr); err != nil {
+		fmt.Fprintf(os.Stderr, "serveur arrete : %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/logfile"
+)
+
+// setupLogging logs from level on to stderr and to logs/orus.log in
+// dataDir, rotated by size. The logger becomes the default one, so that the
+// adapters and the log package write there too. Without a writable log file
+// Orus still starts, logging to stderr only, and file is nil.
+func setupLogging(dataDir, level string) (logger *slog.Logger, file *logfile.Writer, err error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, nil, fmt.Errorf("niveau de journal inconnu %q (debug, info, warn ou error)", level)
+	}
+	var out io.Writer = os.Stderr
+	file, fileErr := logfile.Open(filepath.Join(dataDir, "logs", "orus.log"), logfile.DefaultMaxSize, logfile.DefaultKeep)
+	if fileErr == nil {
+		out = io.MultiWriter(os.Stderr, file)
+	}
+	logger = slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: lvl}))
+	slog.SetDefault(logger)
+	if fileErr != nil {
+		logger.Warn("log file unavailable", "error", fileErr)
+	}
+	return logger, file, nil
+}
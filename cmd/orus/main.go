@@ -1,42 +1,198 @@
 package main
 
 import (
-	"log"
+	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"gioui.org/app"
+	"github.com/MiltonJ23/Orus/internal/adapters/calibre"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/eventbus"
 	"github.com/MiltonJ23/Orus/internal/adapters/extractor"
 	notifier "github.com/MiltonJ23/Orus/internal/adapters/notifier"
+	"github.com/MiltonJ23/Orus/internal/adapters/opds"
+	"github.com/MiltonJ23/Orus/internal/adapters/sharecard"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
 	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/adapters/syncbackend"
 	"github.com/MiltonJ23/Orus/internal/adapters/ui/views"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/port"
 	"github.com/MiltonJ23/Orus/internal/service"
 )
 
 // version is set at build time via -ldflags "-X main.version=..."
 var version = "dev"
 
+// repositories is what the services need from a storage adapter.
+type repositories interface {
+	port.BookRepository
+	port.SessionRepository
+	port.AnnotationRepository
+	port.ReadingSheetRepository
+	port.ReminderRepository
+	port.UnitOfWork
+}
+
 func main() {
-	log.Printf("Orus %s", version)
+	logLevel := flag.String("log-level", "info", "debug, info, warn ou error")
+	lang := flag.String("lang", "", "langue de l'interface (en, fr), retenue pour les lancements suivants")
+	demo := flag.Bool("demo", false, "bibliothèque d'exemple en mémoire, sans ouvrir orus.db")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
 	dbPath := filepath.Join(".", "orus.db")
-	store, err := sqlite.NewStorage(dbPath)
+	logger, logFile, err := setupLogging(filepath.Dir(dbPath), *logLevel)
 	if err != nil {
-		log.Fatalf("FATAL: failed to initialize storage: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if logFile != nil {
+		defer logFile.Close()
+	}
+	logger.Info("orus starting", "version", version, "log_level", *logLevel)
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(dbPath, logger, flag.Args()))
+	}
+	systemClock := clock.NewSystemClock()
+
+	// En démo, données d'exemple en mémoire : orus.db n'est ni ouverte ni
+	// modifiée, et ce qui dépend du fichier (chiffrement, sauvegardes,
+	// synchronisations) reste désactivé.
+	var store repositories
+	var disk *sqlite.Storage
+	if *demo {
+		store = memory.NewDemo(systemClock.Now())
+		logger.Info("demo mode, orus.db left untouched")
+	} else {
+		disk, err = sqlite.NewStorage(dbPath)
+		if err != nil {
+			logger.Error("storage unavailable", "path", dbPath, "error", err)
+			os.Exit(1)
+		}
+		defer disk.Close()
+		store = disk
 	}
-	defer store.Close()
 
 	fileExtractor := extractor.NewLocalFileExtractor()
-	logNotifier := notifier.NewLogNotifier()
+	logNotifier := notifier.NewLogNotifier(logger)
+
+	// Langue de l'interface et des exports, réglage conservé à côté de la base.
+	settingsPath := filepath.Join(filepath.Dir(dbPath), "settings.json")
+	language, err := i18n.LoadLanguage(settingsPath)
+	if err != nil {
+		logger.Warn("settings unavailable", "error", err)
+	}
+	if *lang != "" {
+		if l, ok := i18n.ParseLocale(*lang); ok {
+			language = l
+			if err := i18n.SaveLanguage(settingsPath, l); err != nil {
+				logger.Warn("language not saved", "error", err)
+			}
+		} else {
+			logger.Warn("unsupported language", "lang", *lang)
+		}
+	}
+	tr := i18n.New(language)
+
+	libService := service.NewLibraryService(store, store, store, fileExtractor, logger, systemClock)
+	// Livres téléchargés depuis les catalogues OPDS, à côté de la base.
+	libService.SetBooksDir(filepath.Join(filepath.Dir(dbPath), "books"))
+	trackerService := service.NewTrackerService(store, store, systemClock)
+	sheetService := service.NewReadingSheetService(store, store, systemClock)
+	reminderService := service.NewReminderService(store, store, logNotifier, logger, systemClock)
+	sharingService := service.NewSharingService(store, store, store, store, store, logger, systemClock)
+	reminderService.SetLocalizer(tr)
+	sharingService.SetLocalizer(tr)
+	// Suppressions et imports en une seule transaction.
+	libService.SetUnitOfWork(store)
+	sharingService.SetUnitOfWork(store)
+
+	// Événements du domaine : les services publient, l'UI et le coffre réagissent.
+	bus := eventbus.New(logger)
+	libService.SetEvents(bus)
+	trackerService.SetEvents(bus)
+	sheetService.SetEvents(bus)
+	reminderService.SetEvents(bus)
+	sharingService.SetEvents(bus)
 
-	libService := service.NewLibraryService(store, fileExtractor)
-	trackerService := service.NewTrackerService(store, store)
-	sheetService := service.NewReadingSheetService(store, store)
-	reminderService := service.NewReminderService(store, logNotifier)
-	sharingService := service.NewSharingService(store, store)
+	// Modèles d'export utilisateur, à côté de la base.
+	templateDir := filepath.Join(filepath.Dir(dbPath), "templates")
+	if err := os.MkdirAll(templateDir, 0o755); err != nil {
+		logger.Warn("template directory unavailable", "dir", templateDir, "error", err)
+	}
+	sharingService.SetTemplateDir(templateDir)
+
+	// Cartes PNG de partage, dessinées hors écran.
+	if cards, err := sharecard.NewRenderer(); err != nil {
+		logger.Warn("share cards unavailable", "error", err)
+	} else {
+		sharingService.SetCardRenderer(cards)
+	}
+	sharingService.SetContentReader(fileExtractor)
 
+	// Heures calmes et limitation des rappels, réglages conservés dans settings.json.
+	if err := reminderService.LoadPolicy(settingsPath); err != nil {
+		logger.Warn("reminder settings unavailable", "error", err)
+	}
 	go reminderService.StartScheduler()
 	defer reminderService.Stop()
 
+	var (
+		encryptionService *service.EncryptionService
+		vaultSync         *service.VaultSyncService
+		backupService     *service.BackupService
+		syncService       *service.SyncService
+	)
+	if disk != nil {
+		// Chiffrement optionnel : la base démarre verrouillée, l'UI demande la phrase.
+		encryptionService = service.NewEncryptionService(disk)
+		sharingService.SetEncryption(disk)
+
+		// Synchronisation du coffre Obsidian/Logseq, réglage conservé à côté de la base.
+		vaultSync = service.NewVaultSyncService(store, store, store, store, logger, systemClock)
+		if err := vaultSync.LoadConfig(filepath.Join(filepath.Dir(dbPath), "vault.json")); err != nil {
+			logger.Warn("vault config unavailable", "error", err)
+		}
+		vaultSync.SetLocalizer(tr)
+		// Comme les exports : rien de chiffré n'arrive en clair dans le coffre.
+		vaultSync.SetEncryption(disk)
+		bus.Subscribe(vaultSync.HandleEvent)
+		// Les notes en cours d'écriture sont terminées avant de fermer la base.
+		defer vaultSync.Wait()
+
+		// Instantanés quotidiens tournants, à côté de la base.
+		backupService = service.NewBackupService(disk, sqlite.BackupDir(dbPath), logger, systemClock)
+		go backupService.StartScheduler()
+		defer backupService.Stop()
+
+		// Synchronisation entre appareils par un dossier partagé (Syncthing…) ou un serveur WebDAV.
+		syncService = service.NewSyncService(store, store, store, store, store, logger, systemClock)
+		syncService.SetBackendOpener(syncbackend.Open)
+		syncService.SetBooksDir(filepath.Join(filepath.Dir(dbPath), "books"))
+		syncService.SetEvents(bus)
+		// Le journal porterait les notes en clair : pas de synchronisation chiffrée.
+		syncService.SetEncryption(disk)
+		if err := syncService.LoadState(filepath.Join(filepath.Dir(dbPath), "sync.json")); err != nil {
+			logger.Warn("sync state unavailable", "error", err)
+		}
+		go syncService.StartScheduler()
+		defer syncService.Stop()
+	}
+
+	// Diagnostic pour les rapports de bug : journal récent et environnement.
+	diagInfo := service.DiagnosticsInfo{Version: version, LogLevel: *logLevel}
+	if dataDir, err := filepath.Abs(filepath.Dir(dbPath)); err == nil {
+		diagInfo.DataDir = dataDir
+	}
+	var logs port.LogReader
+	if logFile != nil {
+		logs, diagInfo.LogFile = logFile, logFile.Path()
+	}
+	diagnostics := service.NewDiagnosticsService(logs, store, diagInfo, systemClock)
+
 	// fileExtractor implémente port.ContentReader (ReadBookText)
 	windowManager := views.NewWindowManager(
 		libService,
@@ -44,12 +200,23 @@ func main() {
 		sheetService,
 		reminderService,
 		sharingService,
+		vaultSync,
+		backupService,
+		encryptionService,
+		syncService,
 		fileExtractor,
+		func(dir string) (port.LibrarySource, error) { return calibre.Open(dir) },
+		opds.Open,
+		diagnostics,
+		tr,
+		func(l i18n.Locale) error { return i18n.SaveLanguage(settingsPath, l) },
 	)
+	bus.Subscribe(windowManager.HandleEvent)
 
 	go func() {
 		if err := windowManager.Run(); err != nil {
-			log.Fatalf("UI Engine crashed: %v", err)
+			logger.Error("ui stopped", "error", err)
+			os.Exit(1)
 		}
 		os.Exit(0)
 	}()
@@ -0,0 +1,243 @@
+// Package calibre reads a Calibre library folder (metadata.db plus one folder
+// per book) as a port.LibrarySource.
+package calibre
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "modernc.org/sqlite"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// ErrNotCalibreLibrary indicates a folder without a metadata.db file.
+var ErrNotCalibreLibrary = errors.New("not a calibre library")
+
+// coverMaxHeight bounds the stored thumbnails; Orus covers are drawn at most
+// a few hundred pixels high.
+const coverMaxHeight = 400
+
+var _ port.LibrarySource = (*Library)(nil)
+
+// Library is a Calibre library folder, opened read-only.
+type Library struct {
+	dir string
+}
+
+// Open checks that dir holds a Calibre library. The database itself is only
+// opened, read-only, while ListBooks runs.
+func Open(dir string) (*Library, error) {
+	if _, err := os.Stat(filepath.Join(dir, "metadata.db")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotCalibreLibrary, dir)
+	}
+	return &Library{dir: dir}, nil
+}
+
+// ListBooks returns every book of the library with its metadata, best
+// readable file and cover thumbnail.
+func (l *Library) ListBooks(ctx context.Context) ([]port.ExternalBook, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(l.dir, "metadata.db")+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open calibre database: %w", err)
+	}
+	defer db.Close()
+
+	type row struct {
+		book     port.ExternalBook
+		path     string
+		hasCover bool
+		files    map[string]string // format Calibre -> nom de fichier sans extension
+	}
+	var rows []*row
+	byID := make(map[int64]*row)
+
+	books, err := db.QueryContext(ctx, `SELECT id, title, path, has_cover, COALESCE(series_index, 1) FROM books ORDER BY sort, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calibre books: %w", err)
+	}
+	defer books.Close()
+	for books.Next() {
+		var (
+			id int64
+			r  = &row{files: make(map[string]string)}
+		)
+		if err := books.Scan(&id, &r.book.Title, &r.path, &r.hasCover, &r.book.SeriesIndex); err != nil {
+			return nil, fmt.Errorf("failed to scan calibre book: %w", err)
+		}
+		r.book.SourceID = fmt.Sprint(id)
+		rows = append(rows, r)
+		byID[id] = r
+	}
+	if err := books.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list calibre books: %w", err)
+	}
+
+	// Tables de liaison : chaque requête renvoie (book, valeur).
+	links := []struct {
+		query string
+		apply func(r *row, v string)
+	}{
+		{`SELECT l.book, a.name FROM books_authors_link l JOIN authors a ON a.id = l.author ORDER BY l.id`,
+			func(r *row, v string) { r.book.Authors = append(r.book.Authors, v) }},
+		{`SELECT l.book, s.name FROM books_series_link l JOIN series s ON s.id = l.series`,
+			func(r *row, v string) { r.book.Series = v }},
+		{`SELECT l.book, t.name FROM books_tags_link l JOIN tags t ON t.id = l.tag ORDER BY t.name`,
+			func(r *row, v string) { r.book.Tags = append(r.book.Tags, v) }},
+		{`SELECT l.book, CAST(r.rating AS TEXT) FROM books_ratings_link l JOIN ratings r ON r.id = l.rating`,
+			func(r *row, v string) { r.book.Rating = calibreRating(v) }},
+		{`SELECT book, text FROM comments`,
+			func(r *row, v string) { r.book.Comments = htmlToText(v) }},
+	}
+	for _, link := range links {
+		if err := eachLink(ctx, db, link.query, func(id int64, v string) {
+			if r, ok := byID[id]; ok {
+				link.apply(r, v)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := eachData(ctx, db, func(id int64, format, name string) {
+		if r, ok := byID[id]; ok {
+			r.files[strings.ToUpper(format)] = name
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	out := make([]port.ExternalBook, 0, len(rows))
+	for _, r := range rows {
+		bookDir := filepath.Join(l.dir, filepath.FromSlash(r.path))
+		r.book.FilePath, r.book.Format = bestFile(bookDir, r.files)
+		if r.hasCover {
+			r.book.Cover = thumbnail(filepath.Join(bookDir, "cover.jpg"))
+		}
+		out = append(out, r.book)
+	}
+	return out, nil
+}
+
+func eachLink(ctx context.Context, db *sql.DB, query string, fn func(id int64, v string)) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to read calibre metadata: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id int64
+			v  sql.NullString
+		)
+		if err := rows.Scan(&id, &v); err != nil {
+			return fmt.Errorf("failed to scan calibre metadata: %w", err)
+		}
+		fn(id, v.String)
+	}
+	return rows.Err()
+}
+
+func eachData(ctx context.Context, db *sql.DB, fn func(id int64, format, name string)) error {
+	rows, err := db.QueryContext(ctx, `SELECT book, format, name FROM data`)
+	if err != nil {
+		return fmt.Errorf("failed to read calibre formats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id           int64
+			format, name string
+		)
+		if err := rows.Scan(&id, &format, &name); err != nil {
+			return fmt.Errorf("failed to scan calibre format: %w", err)
+		}
+		fn(id, format, name)
+	}
+	return rows.Err()
+}
+
+// bestFile picks the EPUB, then the PDF, among the formats present on disk.
+func bestFile(bookDir string, files map[string]string) (string, domain.BookFormat) {
+	for _, f := range []domain.BookFormat{domain.FormatEPUB, domain.FormatPDF} {
+		name, ok := files[string(f)]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(bookDir, name+"."+strings.ToLower(string(f)))
+		if _, err := os.Stat(path); err == nil {
+			return path, f
+		}
+	}
+	return "", ""
+}
+
+// calibreRating converts Calibre's half-star scale (0-10) to 0-5 stars.
+func calibreRating(v string) int {
+	var n float64
+	if _, err := fmt.Sscan(v, &n); err != nil || n <= 0 {
+		return 0
+	}
+	return min(int(math.Round(n/2)), 5)
+}
+
+var (
+	htmlBlockEnd = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])>`)
+	htmlTag      = regexp.MustCompile(`<[^>]*>`)
+	blankLines   = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText flattens the HTML of Calibre comments to paragraphs of text.
+func htmlToText(s string) string {
+	s = htmlBlockEnd.ReplaceAllString(s, "\n")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// thumbnail returns a JPEG no taller than coverMaxHeight, or nil when the
+// cover cannot be read.
+func thumbnail(path string) []byte {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil
+	}
+	b := src.Bounds()
+	if b.Dy() > coverMaxHeight {
+		w := b.Dx() * coverMaxHeight / b.Dy()
+		dst := image.NewRGBA(image.Rect(0, 0, max(w, 1), coverMaxHeight))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+		src = dst
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: 80}); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
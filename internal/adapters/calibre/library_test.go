@@ -0,0 +1,150 @@
+package calibre_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/calibre"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	_ "modernc.org/sqlite"
+)
+
+// Sous-ensemble du schéma Calibre lu par l'adaptateur.
+const calibreSchema = `
+CREATE TABLE books (id INTEGER PRIMARY KEY, title TEXT, sort TEXT, path TEXT, has_cover BOOL DEFAULT 0, series_index REAL DEFAULT 1.0);
+CREATE TABLE authors (id INTEGER PRIMARY KEY, name TEXT);
+CREATE TABLE books_authors_link (id INTEGER PRIMARY KEY, book INTEGER, author INTEGER);
+CREATE TABLE series (id INTEGER PRIMARY KEY, name TEXT);
+CREATE TABLE books_series_link (id INTEGER PRIMARY KEY, book INTEGER, series INTEGER);
+CREATE TABLE tags (id INTEGER PRIMARY KEY, name TEXT);
+CREATE TABLE books_tags_link (id INTEGER PRIMARY KEY, book INTEGER, tag INTEGER);
+CREATE TABLE ratings (id INTEGER PRIMARY KEY, rating INTEGER);
+CREATE TABLE books_ratings_link (id INTEGER PRIMARY KEY, book INTEGER, rating INTEGER);
+CREATE TABLE comments (id INTEGER PRIMARY KEY, book INTEGER, text TEXT);
+CREATE TABLE data (id INTEGER PRIMARY KEY, book INTEGER, format TEXT, uncompressed_size INTEGER, name TEXT);
+
+INSERT INTO books VALUES (1, 'Dune', 'Dune', 'Frank Herbert/Dune (1)', 1, 1.0);
+INSERT INTO books VALUES (2, 'Good Omens', 'Good Omens', 'Terry Pratchett/Good Omens (2)', 0, 1.0);
+INSERT INTO books VALUES (3, 'Papier', 'Papier', 'Personne/Papier (3)', 0, 1.0);
+INSERT INTO authors VALUES (1, 'Frank Herbert'), (2, 'Terry Pratchett'), (3, 'Neil Gaiman'), (4, 'Personne');
+INSERT INTO books_authors_link VALUES (1, 1, 1), (2, 2, 2), (3, 2, 3), (4, 3, 4);
+INSERT INTO series VALUES (1, 'Dune');
+INSERT INTO books_series_link VALUES (1, 1, 1);
+INSERT INTO tags VALUES (1, 'sf'), (2, 'classique');
+INSERT INTO books_tags_link VALUES (1, 1, 1), (2, 1, 2);
+INSERT INTO ratings VALUES (1, 8), (2, 3);
+INSERT INTO books_ratings_link VALUES (1, 1, 1), (2, 2, 2);
+INSERT INTO comments VALUES (1, 1, '<div><p>Sur Arrakis,</p><p>l&#39;epice &amp; le desert.</p></div>');
+INSERT INTO data VALUES (1, 1, 'EPUB', 10, 'Dune - Frank Herbert'), (2, 1, 'PDF', 10, 'Dune - Frank Herbert');
+INSERT INTO data VALUES (3, 2, 'EPUB', 10, 'Good Omens - Terry Pratchett'), (4, 2, 'PDF', 10, 'Good Omens - Terry Pratchett');
+INSERT INTO data VALUES (5, 3, 'MOBI', 10, 'Papier - Personne');
+`
+
+func setupCalibreLibrary(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	db, err := sql.Open("sqlite", filepath.Join(dir, "metadata.db"))
+	if err != nil {
+		t.Fatalf("Could not create metadata.db: %v", err)
+	}
+	if _, err := db.Exec(calibreSchema); err != nil {
+		t.Fatalf("Could not create calibre schema: %v", err)
+	}
+	db.Close()
+
+	files := map[string][]byte{
+		"Frank Herbert/Dune (1)/Dune - Frank Herbert.epub":                []byte("epub"),
+		"Frank Herbert/Dune (1)/Dune - Frank Herbert.pdf":                 []byte("pdf"),
+		"Frank Herbert/Dune (1)/cover.jpg":                                testCover(t, 300, 900),
+		"Terry Pratchett/Good Omens (2)/Good Omens - Terry Pratchett.pdf": []byte("pdf"),
+		"Personne/Papier (3)/Papier - Personne.mobi":                      []byte("mobi"),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func testCover(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 120, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestOpen_NotCalibreLibrary(t *testing.T) {
+	if _, err := calibre.Open(t.TempDir()); !errors.Is(err, calibre.ErrNotCalibreLibrary) {
+		t.Errorf("expected ErrNotCalibreLibrary, got: %v", err)
+	}
+}
+
+func TestLibrary_ListBooks(t *testing.T) {
+	dir := setupCalibreLibrary(t)
+	lib, err := calibre.Open(dir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	books, err := lib.ListBooks(context.Background())
+	if err != nil {
+		t.Fatalf("ListBooks failed: %v", err)
+	}
+	if len(books) != 3 {
+		t.Fatalf("expected 3 books, got %d", len(books))
+	}
+
+	dune, omens, papier := books[0], books[1], books[2]
+	if dune.Title != "Dune" || dune.Series != "Dune" || dune.SeriesIndex != 1 || dune.Rating != 4 {
+		t.Errorf("unexpected Dune metadata: %+v", dune)
+	}
+	if dune.Format != domain.FormatEPUB || filepath.Base(dune.FilePath) != "Dune - Frank Herbert.epub" {
+		t.Errorf("expected the EPUB to be preferred, got %s %q", dune.Format, dune.FilePath)
+	}
+	if len(dune.Tags) != 2 || dune.Tags[0] != "classique" || dune.Tags[1] != "sf" {
+		t.Errorf("unexpected tags: %v", dune.Tags)
+	}
+	if want := "Sur Arrakis,\nl'epice & le desert."; dune.Comments != want {
+		t.Errorf("expected comments %q, got %q", want, dune.Comments)
+	}
+	cover, _, err := image.Decode(bytes.NewReader(dune.Cover))
+	if err != nil {
+		t.Fatalf("expected a decodable cover, got: %v", err)
+	}
+	if b := cover.Bounds(); b.Dy() != 400 || b.Dx() != 133 {
+		t.Errorf("expected a 133x400 thumbnail, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	// L'EPUB est déclaré mais absent du disque : on retombe sur le PDF.
+	if omens.Format != domain.FormatPDF || len(omens.Authors) != 2 || omens.Authors[1] != "Neil Gaiman" {
+		t.Errorf("unexpected Good Omens: %+v", omens)
+	}
+	if omens.Rating != 2 || omens.Cover != nil {
+		t.Errorf("expected rating 2 and no cover, got %d, %d bytes", omens.Rating, len(omens.Cover))
+	}
+
+	if papier.FilePath != "" || papier.Format != "" {
+		t.Errorf("expected no readable file for a MOBI-only book, got %q", papier.FilePath)
+	}
+}
@@ -0,0 +1,100 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+)
+
+var start = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func TestFakeClock_NowAndAdvance(t *testing.T) {
+	c := clock.NewFakeClock(start)
+	if !c.Now().Equal(start) {
+		t.Fatalf("expected %s, got %s", start, c.Now())
+	}
+	c.Advance(90 * time.Minute)
+	if want := start.Add(90 * time.Minute); !c.Now().Equal(want) {
+		t.Errorf("expected %s after Advance, got %s", want, c.Now())
+	}
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("expected Set to rewind to %s, got %s", start, c.Now())
+	}
+}
+
+func TestFakeClock_TickerFiresOnAdvance(t *testing.T) {
+	c := clock.NewFakeClock(start)
+	ticker := c.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	c.Advance(29 * time.Second)
+	select {
+	case tick := <-ticker.C():
+		t.Fatalf("unexpected tick at %s", tick)
+	default:
+	}
+
+	c.Advance(time.Second)
+	select {
+	case tick := <-ticker.C():
+		if want := start.Add(30 * time.Second); !tick.Equal(want) {
+			t.Errorf("expected tick at %s, got %s", want, tick)
+		}
+	default:
+		t.Fatal("expected a tick once the period elapsed")
+	}
+}
+
+func TestFakeClock_TickerDropsUnreadTicks(t *testing.T) {
+	c := clock.NewFakeClock(start)
+	ticker := c.NewTicker(time.Minute)
+
+	c.Advance(5 * time.Minute)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Fatal("expected unread ticks to be dropped like time.Ticker")
+	default:
+	}
+
+	ticker.Stop()
+	c.Advance(time.Minute)
+	select {
+	case <-ticker.C():
+		t.Fatal("expected no tick after Stop")
+	default:
+	}
+}
+
+func TestFakeClock_WaitForTickers(t *testing.T) {
+	c := clock.NewFakeClock(start)
+	done := make(chan struct{})
+	go func() {
+		c.WaitForTickers(1)
+		close(done)
+	}()
+
+	c.NewTicker(time.Second)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForTickers did not return after a ticker was created")
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	c := clock.NewSystemClock()
+	before := time.Now()
+	if c.Now().Before(before) {
+		t.Error("expected system clock to follow wall time")
+	}
+	ticker := c.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected real ticker to fire")
+	}
+}
@@ -0,0 +1,30 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.Clock = (*SystemClock)(nil)
+
+// SystemClock implements port.Clock with the wall clock and real tickers.
+type SystemClock struct{}
+
+// NewSystemClock creates a new SystemClock.
+func NewSystemClock() *SystemClock {
+	return &SystemClock{}
+}
+
+// Now returns the current local time.
+func (c *SystemClock) Now() time.Time { return time.Now() }
+
+// NewTicker wraps time.NewTicker.
+func (c *SystemClock) NewTicker(d time.Duration) port.Ticker {
+	return &systemTicker{t: time.NewTicker(d)}
+}
+
+type systemTicker struct{ t *time.Ticker }
+
+func (s *systemTicker) C() <-chan time.Time { return s.t.C }
+func (s *systemTicker) Stop()               { s.t.Stop() }
@@ -0,0 +1,110 @@
+package eventbus
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.EventPublisher = (*Bus)(nil)
+
+// Handler receives the events of a Bus. It runs on the goroutine of a
+// publisher and must not block: slow work belongs on its own goroutine.
+type Handler func(ctx context.Context, event domain.Event)
+
+// Bus delivers domain events to subscribers within the process.
+//
+// Every subscriber sees every event, in publication order and in the order
+// subscribers registered. An event published by a handler, or by another
+// goroutine while delivery is under way, is queued behind the current one
+// rather than delivered in the middle of it; the goroutine already
+// delivering hands it out before Publish returns to its own caller.
+type Bus struct {
+	logger *slog.Logger
+
+	mu       sync.Mutex // protège handlers, queue et draining
+	handlers []*subscription
+	queue    []pending
+	draining bool
+}
+
+type subscription struct {
+	handle Handler
+}
+
+type pending struct {
+	ctx   context.Context
+	event domain.Event
+}
+
+// New creates an empty Bus. A nil logger uses slog.Default().
+func New(logger *slog.Logger) *Bus {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Bus{logger: logger.With("component", "events")}
+}
+
+// Subscribe registers h for every event and returns the function removing
+// it. Events already queued may still reach h after removal.
+func (b *Bus) Subscribe(h Handler) (unsubscribe func()) {
+	s := &subscription{handle: h}
+	b.mu.Lock()
+	b.handlers = append(b.handlers, s)
+	b.mu.Unlock()
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, other := range b.handlers {
+			if other == s {
+				b.handlers = append(b.handlers[:i:i], b.handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Handle subscribes h to the events of type E only.
+func Handle[E domain.Event](b *Bus, h func(ctx context.Context, event E)) (unsubscribe func()) {
+	return b.Subscribe(func(ctx context.Context, event domain.Event) {
+		if e, ok := event.(E); ok {
+			h(ctx, e)
+		}
+	})
+}
+
+// Publish delivers event to the subscribers. A panicking handler is logged
+// and does not keep the others from the event.
+func (b *Bus) Publish(ctx context.Context, event domain.Event) {
+	b.mu.Lock()
+	b.queue = append(b.queue, pending{ctx: ctx, event: event})
+	if b.draining {
+		b.mu.Unlock()
+		return
+	}
+	b.draining = true
+	for len(b.queue) > 0 {
+		next := b.queue[0]
+		b.queue = b.queue[1:]
+		handlers := append([]*subscription(nil), b.handlers...)
+		b.mu.Unlock()
+		for _, s := range handlers {
+			b.deliver(s, next)
+		}
+		b.mu.Lock()
+	}
+	b.draining = false
+	b.mu.Unlock()
+}
+
+func (b *Bus) deliver(s *subscription, p pending) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("event handler panicked", "event", p.event.EventName(), "panic", r)
+		}
+	}()
+	s.handle(p.ctx, p.event)
+}
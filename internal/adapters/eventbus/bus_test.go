@@ -0,0 +1,155 @@
+package eventbus_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/eventbus"
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// recorder collects the events a subscriber receives, tagged with its name.
+type recorder struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (r *recorder) handler(name string) eventbus.Handler {
+	return func(_ context.Context, event domain.Event) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.got = append(r.got, name+":"+describe(event))
+	}
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.got)
+}
+
+func describe(event domain.Event) string {
+	if e, ok := event.(domain.PageTurned); ok {
+		return fmt.Sprintf("%s(%d)", e.EventName(), e.Page)
+	}
+	return event.EventName()
+}
+
+func TestBus_DeliversInOrderToEverySubscriber(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(nil)
+	rec := &recorder{}
+	bus.Subscribe(rec.handler("a"))
+	bus.Subscribe(rec.handler("b"))
+
+	bus.Publish(ctx, domain.BookImported{Book: &domain.Book{ID: "b1"}})
+	bus.Publish(ctx, domain.PageTurned{BookID: "b1", Page: 2})
+
+	want := []string{"a:book.imported", "b:book.imported", "a:page.turned(2)", "b:page.turned(2)"}
+	if got := rec.list(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestBus_NestedPublishIsQueued(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(nil)
+	rec := &recorder{}
+	bus.Subscribe(rec.handler("first"))
+	bus.Subscribe(func(ctx context.Context, event domain.Event) {
+		if turned, ok := event.(domain.PageTurned); ok && turned.Page == turned.TotalPages {
+			bus.Publish(ctx, domain.BookFinished{BookID: turned.BookID})
+		}
+	})
+	bus.Subscribe(rec.handler("last"))
+
+	bus.Publish(ctx, domain.PageTurned{BookID: "b1", Page: 10, TotalPages: 10})
+
+	// Le dernier abonné reçoit PageTurned avant BookFinished, comme le premier.
+	want := []string{"first:page.turned(10)", "last:page.turned(10)", "first:book.finished", "last:book.finished"}
+	if got := rec.list(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestBus_ConcurrentPublishersSameOrderForAll(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(nil)
+	a, b := &recorder{}, &recorder{}
+	bus.Subscribe(a.handler(""))
+	bus.Subscribe(b.handler(""))
+
+	const publishers, each = 8, 50
+	var wg sync.WaitGroup
+	for p := 0; p < publishers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				bus.Publish(ctx, domain.PageTurned{BookID: fmt.Sprint(p), Page: p*each + i})
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := a.list()
+	if len(got) != publishers*each {
+		t.Fatalf("expected %d events, got %d", publishers*each, len(got))
+	}
+	if !slices.Equal(got, b.list()) {
+		t.Error("expected both subscribers to see the events in the same order")
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(nil)
+	rec := &recorder{}
+	stop := bus.Subscribe(rec.handler("a"))
+	bus.Subscribe(rec.handler("b"))
+
+	bus.Publish(ctx, domain.BookDeleted{BookID: "b1"})
+	stop()
+	stop() // sans effet
+	bus.Publish(ctx, domain.BookDeleted{BookID: "b2"})
+
+	want := []string{"a:book.deleted", "b:book.deleted", "b:book.deleted"}
+	if got := rec.list(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestBus_PanickingHandlerIsIsolated(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(nil)
+	rec := &recorder{}
+	bus.Subscribe(func(context.Context, domain.Event) { panic("boom") })
+	bus.Subscribe(rec.handler("a"))
+
+	bus.Publish(ctx, domain.SheetUpdated{BookID: "b1"})
+	bus.Publish(ctx, domain.SheetUpdated{BookID: "b1"})
+
+	if got := rec.list(); len(got) != 2 {
+		t.Errorf("expected the other subscriber to receive both events, got %v", got)
+	}
+}
+
+func TestHandle_FiltersByType(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(nil)
+	var fired []string
+	eventbus.Handle(bus, func(_ context.Context, e domain.ReminderFired) {
+		fired = append(fired, e.Reminder.ID)
+	})
+
+	bus.Publish(ctx, domain.BookDeleted{BookID: "b1"})
+	bus.Publish(ctx, domain.ReminderFired{Reminder: &domain.Reminder{ID: "r1"}})
+	bus.Publish(ctx, domain.AnnotationChanged{AnnotationID: "a1"})
+
+	if !slices.Equal(fired, []string{"r1"}) {
+		t.Errorf("expected only the ReminderFired event, got %v", fired)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
@@ -236,11 +238,45 @@ func (e *LocalFileExtractor) extractEPUB(filePath string) (*domain.BookMetadata,
 		spineCount = 1
 	}
 
-	return &domain.BookMetadata{
+	meta := &domain.BookMetadata{
 		Title:      title,
 		Author:     author,
 		FilePath:   filePath,
 		Format:     domain.FormatEPUB,
 		TotalPages: spineCount,
-	}, nil
+		ISBN:       epubISBN(book.Opf.Metadata.Identifier),
+	}
+	if len(book.Opf.Metadata.Publisher) > 0 {
+		meta.Publisher = strings.TrimSpace(book.Opf.Metadata.Publisher[0])
+	}
+	for _, d := range book.Opf.Metadata.Date {
+		if y := yearPattern.FindString(d.Data); y != "" {
+			meta.Year, _ = strconv.Atoi(y)
+			break
+		}
+	}
+	return meta, nil
+}
+
+var (
+	isbnPattern = regexp.MustCompile(`^(97[89][- ]?)?\d{1,5}[- ]?\d+[- ]?\d+[- ]?[\dXx]$`)
+	yearPattern = regexp.MustCompile(`\b\d{4}\b`)
+)
+
+// epubISBN retourne le premier identifiant qui ressemble à un ISBN : schéma
+// opf:scheme="ISBN", préfixe urn:isbn: ou valeur de 10/13 chiffres.
+func epubISBN(ids []epub.Identifier) string {
+	for _, id := range ids {
+		v := strings.TrimSpace(id.Data)
+		if lower := strings.ToLower(v); strings.HasPrefix(lower, "urn:isbn:") {
+			v = v[len("urn:isbn:"):]
+		} else if !strings.EqualFold(id.Scheme, "isbn") && !isbnPattern.MatchString(v) {
+			continue
+		}
+		digits := strings.NewReplacer("-", "", " ", "").Replace(v)
+		if len(digits) == 10 || len(digits) == 13 {
+			return v
+		}
+	}
+	return ""
 }
@@ -52,6 +52,9 @@ func TestLocalFileExtractor_ExtractInfo(t *testing.T) {
 		if meta.FilePath != epubPath {
 			t.Errorf("expected file path %s, got %s", epubPath, meta.FilePath)
 		}
+		if meta.ISBN != "978-1-4842-2692-6" || meta.Publisher != "Apress, Berkeley, CA" {
+			t.Errorf("expected ISBN and publisher from the OPF, got %q / %q", meta.ISBN, meta.Publisher)
+		}
 	})
 
 	t.Run("Unsupported Format", func(t *testing.T) {
@@ -0,0 +1,528 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/httpserve"
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /api/openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPISpec)
+	})
+
+	s.mux.HandleFunc("GET /api/books", s.listBooks)
+	s.mux.HandleFunc("POST /api/books", s.importBook)
+	s.mux.HandleFunc("GET /api/books/status", s.bookStatus)
+	s.mux.HandleFunc("GET /api/books/{id}", s.getBook)
+	s.mux.HandleFunc("DELETE /api/books/{id}", s.deleteBook)
+	s.mux.HandleFunc("PUT /api/books/{id}/progress", s.updateProgress)
+	s.mux.HandleFunc("GET /api/books/{id}/reading-time", s.readingTime)
+	s.mux.HandleFunc("GET /api/sessions/recent", s.recentSessions)
+
+	s.mux.HandleFunc("GET /api/sheets", s.listSheets)
+	s.mux.HandleFunc("POST /api/sheets", s.createSheet)
+	s.mux.HandleFunc("GET /api/books/{id}/sheet", s.getSheet)
+	s.mux.HandleFunc("PATCH /api/sheets/{id}", s.updateSheet)
+	s.mux.HandleFunc("POST /api/sheets/{id}/quotes", s.addQuote)
+	s.mux.HandleFunc("DELETE /api/sheets/{id}", s.deleteSheet)
+
+	s.mux.HandleFunc("GET /api/reminders", s.listReminders)
+	s.mux.HandleFunc("POST /api/reminders", s.addReminder)
+	s.mux.HandleFunc("PATCH /api/reminders/{id}", s.updateReminder)
+	s.mux.HandleFunc("DELETE /api/reminders/{id}", s.deleteReminder)
+
+	s.mux.HandleFunc("GET /api/books/{id}/annotations", s.listAnnotations)
+	s.mux.HandleFunc("POST /api/books/{id}/annotations", s.addAnnotation)
+	s.mux.HandleFunc("GET /api/annotations", s.annotationsByType)
+	s.mux.HandleFunc("DELETE /api/annotations/{id}", s.deleteAnnotation)
+}
+
+// bookJSON is the API view of a book: the cover stays out, it would weigh
+// more than everything else.
+type bookJSON struct {
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Author      string            `json:"author"`
+	FilePath    string            `json:"file_path"`
+	Format      domain.BookFormat `json:"format"`
+	TotalPages  int               `json:"total_pages"`
+	Series      string            `json:"series,omitempty"`
+	SeriesIndex float64           `json:"series_index,omitempty"`
+	ISBN        string            `json:"isbn,omitempty"`
+	Publisher   string            `json:"publisher,omitempty"`
+	Year        int               `json:"year,omitempty"`
+	AddedAt     time.Time         `json:"added_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+func toBookJSON(b *domain.Book) bookJSON {
+	return bookJSON{
+		ID: b.ID, Title: b.Title, Author: b.Author, FilePath: b.FilePath, Format: b.Format, TotalPages: b.TotalPages,
+		Series: b.Series, SeriesIndex: b.SeriesIndex, ISBN: b.ISBN, Publisher: b.Publisher, Year: b.Year,
+		AddedAt: b.AddedAt, UpdatedAt: b.UpdatedAt,
+	}
+}
+
+// sessionJSON is the API view of a reading session.
+type sessionJSON struct {
+	ID              string    `json:"id"`
+	BookID          string    `json:"book_id"`
+	TotalPages      int       `json:"total_pages"`
+	CurrentPage     int       `json:"current_page"`
+	Completion      float64   `json:"completion"` // pourcentage
+	StartedAt       time.Time `json:"started_at"`
+	LastReadingTime time.Time `json:"last_reading_time"`
+}
+
+func toSessionJSON(s *domain.ReadingSession) sessionJSON {
+	return sessionJSON{
+		ID: s.SessionID, BookID: s.BookID, TotalPages: s.TotalPages, CurrentPage: s.CurrentPage,
+		Completion: s.CalculateCompletion(), StartedAt: s.StartedAt, LastReadingTime: s.LastReadingTime,
+	}
+}
+
+// orEmpty keeps empty lists as [] rather than null in the responses.
+func orEmpty[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+// --- Books and reading progress ---
+
+func (s *Server) listBooks(w http.ResponseWriter, r *http.Request) {
+	books, err := s.library.GetLibrary(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	out := make([]bookJSON, 0, len(books))
+	for _, b := range books {
+		out = append(out, toBookJSON(b))
+	}
+	httpserve.WriteJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getBook(w http.ResponseWriter, r *http.Request) {
+	book, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, toBookJSON(book))
+}
+
+func (s *Server) importBook(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Path string `json:"path"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if body.Path == "" {
+		httpserve.WriteError(w, http.StatusBadRequest, errors.New("path is required"))
+		return
+	}
+	book, err := s.library.ImportBook(r.Context(), body.Path)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusCreated, toBookJSON(book))
+}
+
+func (s *Server) deleteBook(w http.ResponseWriter, r *http.Request) {
+	if _, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	if err := s.library.DeleteBook(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) bookStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.tracker.BookCompletionStatus(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, status)
+}
+
+// updateProgress moves the book to the given page. Like the web reader, it
+// resumes the session it holds for the book rather than saving a new one per
+// call; a new session starts when there is none yet or when the body names
+// another one.
+func (s *Server) updateProgress(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Session string `json:"session"` // facultatif
+		Page    int    `json:"page"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if body.Page < 1 {
+		httpserve.Fail(w, s.logger, domain.ErrInvalidSessionPage)
+		return
+	}
+	bookID := r.PathValue("id")
+	s.mu.Lock()
+	ses := s.sessions[bookID]
+	s.mu.Unlock()
+	if ses == nil || (body.Session != "" && body.Session != ses.SessionID) {
+		var err error
+		if ses, err = s.tracker.OpenBook(r.Context(), bookID); err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+		s.mu.Lock()
+		s.sessions[bookID] = ses
+		s.mu.Unlock()
+	}
+	s.mu.Lock()
+	err := s.tracker.UpdateProgress(r.Context(), body.Page, ses)
+	out := toSessionJSON(ses)
+	s.mu.Unlock()
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) readingTime(w http.ResponseWriter, r *http.Request) {
+	if _, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	total, err := s.tracker.TotalReadingTime(r.Context(), r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, map[string]int64{"seconds": int64(total / time.Second)})
+}
+
+func (s *Server) recentSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := s.tracker.GetRecentSessions(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	out := make([]sessionJSON, 0, len(sessions))
+	for _, ses := range sessions {
+		out = append(out, toSessionJSON(ses))
+	}
+	httpserve.WriteJSON(w, http.StatusOK, out)
+}
+
+// --- Reading sheets ---
+
+func (s *Server) listSheets(w http.ResponseWriter, r *http.Request) {
+	sheets, err := s.sheets.ListSheets(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, orEmpty(sheets))
+}
+
+func (s *Server) getSheet(w http.ResponseWriter, r *http.Request) {
+	sheet, err := s.sheets.GetSheetForBook(r.Context(), r.PathValue("id"))
+	if err == nil && sheet == nil {
+		err = domain.ErrReadingSheetNotFound
+	}
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, sheet)
+}
+
+func (s *Server) createSheet(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BookID  string   `json:"book_id"`
+		Summary string   `json:"summary"`
+		Rating  int      `json:"rating"`
+		Quotes  []string `json:"quotes"`
+		Tags    []string `json:"tags"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if existing, err := s.sheets.GetSheetForBook(r.Context(), body.BookID); err == nil && existing != nil {
+		httpserve.WriteError(w, http.StatusConflict, fmt.Errorf("book %s already has a sheet", body.BookID))
+		return
+	}
+	sheet, err := s.sheets.CreateSheet(r.Context(), body.BookID, body.Summary, body.Rating, body.Quotes, body.Tags)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusCreated, sheet)
+}
+
+// updateSheet changes the summary and/or the rating; absent fields are kept.
+func (s *Server) updateSheet(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Summary *string `json:"summary"`
+		Rating  *int    `json:"rating"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	id := r.PathValue("id")
+	if body.Rating != nil {
+		if err := s.sheets.SetRating(r.Context(), id, *body.Rating); err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+	}
+	if body.Summary != nil {
+		if err := s.sheets.UpdateSummary(r.Context(), id, *body.Summary); err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+	}
+	s.writeSheet(w, r, id)
+}
+
+func (s *Server) addQuote(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Quote string `json:"quote"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if body.Quote == "" {
+		httpserve.WriteError(w, http.StatusBadRequest, errors.New("quote is required"))
+		return
+	}
+	id := r.PathValue("id")
+	if err := s.sheets.AddQuote(r.Context(), id, body.Quote); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	s.writeSheet(w, r, id)
+}
+
+// writeSheet answers with the sheet as stored after an update.
+func (s *Server) writeSheet(w http.ResponseWriter, r *http.Request, id string) {
+	sheets, err := s.sheets.ListSheets(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	for _, sheet := range sheets {
+		if sheet.ID == id {
+			httpserve.WriteJSON(w, http.StatusOK, sheet)
+			return
+		}
+	}
+	httpserve.Fail(w, s.logger, domain.ErrReadingSheetNotFound)
+}
+
+func (s *Server) deleteSheet(w http.ResponseWriter, r *http.Request) {
+	if err := s.sheets.DeleteSheet(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- Reminders ---
+
+func (s *Server) listReminders(w http.ResponseWriter, r *http.Request) {
+	reminders, err := s.reminders.ListReminders(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, orEmpty(reminders))
+}
+
+func (s *Server) findReminder(ctx context.Context, id string) (*domain.Reminder, error) {
+	reminders, err := s.reminders.ListReminders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rem := range reminders {
+		if rem.ID == id {
+			return rem, nil
+		}
+	}
+	return nil, domain.ErrReminderNotFound
+}
+
+func (s *Server) addReminder(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BookID    string                   `json:"book_id"`
+		Label     string                   `json:"label"`
+		Hour      int                      `json:"hour"`
+		Minute    int                      `json:"minute"`
+		Frequency domain.ReminderFrequency `json:"frequency"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	switch body.Frequency {
+	case "":
+		body.Frequency = domain.FrequencyDaily
+	case domain.FrequencyDaily, domain.FrequencyWeekly, domain.FrequencyWeekdays, domain.FrequencyOnce:
+	default:
+		httpserve.WriteError(w, http.StatusBadRequest, fmt.Errorf("unknown frequency %q", body.Frequency))
+		return
+	}
+	title := ""
+	if body.BookID != "" {
+		book, err := httpserve.FindBook(r.Context(), s.library, body.BookID)
+		if err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+		title = book.Title
+	}
+	rem, err := s.reminders.AddReminder(r.Context(), body.BookID, title, body.Label, body.Hour, body.Minute, body.Frequency)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusCreated, rem)
+}
+
+// updateReminder switches the reminder on or off and sets its "skip if read
+// today" option; absent fields are kept.
+func (s *Server) updateReminder(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Enabled         *bool `json:"enabled"`
+		SkipIfReadToday *bool `json:"skip_if_read_today"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	rem, err := s.findReminder(r.Context(), r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	if body.Enabled != nil && *body.Enabled != rem.Enabled {
+		if err := s.reminders.ToggleReminder(r.Context(), rem.ID); err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+	}
+	if body.SkipIfReadToday != nil {
+		if err := s.reminders.SetSkipIfReadToday(r.Context(), rem.ID, *body.SkipIfReadToday); err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+	}
+	if rem, err = s.findReminder(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, rem)
+}
+
+func (s *Server) deleteReminder(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.findReminder(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	if err := s.reminders.DeleteReminder(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- Annotations ---
+
+// listAnnotations returns the annotations of a book, or of one page with
+// ?page=N.
+func (s *Server) listAnnotations(w http.ResponseWriter, r *http.Request) {
+	if _, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	id := r.PathValue("id")
+	var annots []*domain.Annotation
+	var err error
+	if raw := r.URL.Query().Get("page"); raw != "" {
+		page, convErr := strconv.Atoi(raw)
+		if convErr != nil || page < 1 {
+			httpserve.Fail(w, s.logger, domain.ErrInvalidPageNumber)
+			return
+		}
+		annots, err = s.annots.GetAnnotationsByPage(r.Context(), id, page)
+	} else {
+		annots, err = s.annots.ListAnnotationsForBook(r.Context(), id)
+	}
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, orEmpty(annots))
+}
+
+func validAnnotationType(t domain.AnnotationType) bool {
+	return t == domain.AnnotationBookmark || t == domain.AnnotationHighlight
+}
+
+func (s *Server) addAnnotation(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Type domain.AnnotationType `json:"type"`
+		Page int                   `json:"page"`
+		Note string                `json:"note"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if !validAnnotationType(body.Type) {
+		httpserve.WriteError(w, http.StatusBadRequest, fmt.Errorf("unknown annotation type %q", body.Type))
+		return
+	}
+	annot, err := s.annots.AddAnnotationWithNote(r.Context(), r.PathValue("id"), body.Type, body.Page, body.Note)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusCreated, annot)
+}
+
+// annotationsByType returns every annotation of ?type=bookmark|highlight,
+// across the library.
+func (s *Server) annotationsByType(w http.ResponseWriter, r *http.Request) {
+	t := domain.AnnotationType(r.URL.Query().Get("type"))
+	if !validAnnotationType(t) {
+		httpserve.WriteError(w, http.StatusBadRequest, fmt.Errorf("unknown annotation type %q", t))
+		return
+	}
+	annots, err := s.annots.GetAnnotationsByType(r.Context(), t)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, orEmpty(annots))
+}
+
+func (s *Server) deleteAnnotation(w http.ResponseWriter, r *http.Request) {
+	if err := s.annots.DeleteAnnotation(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,87 @@
+// Package httpapi exposes the Orus services as a REST/JSON API for local
+// tools (dashboards, browser extensions). It only listens on loopback
+// addresses and every route but the OpenAPI description needs the token.
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/httpserve"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// DefaultAddr is where `orus serve` listens without an explicit address.
+const DefaultAddr = "127.0.0.1:7878"
+
+// maxBodySize bounds request bodies; the largest one is a reading sheet.
+const maxBodySize = 1 << 20
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+// Server routes the API requests to the services.
+type Server struct {
+	library   *service.LibraryService
+	tracker   *service.TrackerService
+	sheets    *service.ReadingSheetService
+	reminders *service.ReminderService
+	annots    *service.AnnotationService
+	token     string
+	mux       *http.ServeMux
+	logger    *slog.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*domain.ReadingSession // session reprise par livre, voir updateProgress
+}
+
+// NewServer creates an API server over the services. Requests must carry
+// token as "Authorization: Bearer <token>". A nil logger uses slog.Default().
+func NewServer(library *service.LibraryService, tracker *service.TrackerService, sheets *service.ReadingSheetService,
+	reminders *service.ReminderService, annots *service.AnnotationService, token string, logger *slog.Logger) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("empty API token")
+	}
+	s := &Server{library: library, tracker: tracker, sheets: sheets, reminders: reminders, annots: annots, token: token,
+		logger: httpserve.ComponentLogger(logger, "api"), sessions: make(map[string]*domain.ReadingSession)}
+	s.routes()
+	return s, nil
+}
+
+// ServeHTTP checks the token, then dispatches the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/openapi.json" && !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="orus"`)
+		httpserve.WriteError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1
+}
+
+// ListenAndServe serves the API on addr until ctx is cancelled. Only
+// loopback addresses are accepted: the API is not meant for the network.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("refusing to listen on %s: only loopback addresses are allowed", addr)
+	}
+	return httpserve.ListenAndServe(ctx, addr, s)
+}
@@ -0,0 +1,272 @@
+package httpapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/httpapi"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+type stubExtractor struct{}
+
+func (stubExtractor) ExtractInfo(_ context.Context, path string) (*domain.BookMetadata, error) {
+	title := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return &domain.BookMetadata{Title: title, Author: "Anonyme", FilePath: path, Format: domain.FormatEPUB, TotalPages: 300}, nil
+}
+
+var fakeNow = time.Date(2026, 3, 10, 21, 0, 0, 0, time.UTC)
+
+const testToken = "jeton-de-test"
+
+// apiClient sends authenticated requests to the API under test.
+type apiClient struct {
+	t   *testing.T
+	srv *httptest.Server
+}
+
+func newAPI(t *testing.T) (*apiClient, *memory.Storage) {
+	t.Helper()
+	store := memory.NewStorage()
+	clk := clock.NewFakeClock(fakeNow)
+	api, err := httpapi.NewServer(
+		service.NewLibraryService(store, store, store, stubExtractor{}, nil, clk),
+		service.NewTrackerService(store, store, clk),
+		service.NewReadingSheetService(store, store, clk),
+		service.NewReminderService(store, store, nil, nil, clk),
+		service.NewAnnotationService(store, store, nil, clk),
+		testToken, nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	return &apiClient{t: t, srv: srv}, store
+}
+
+// do sends body as JSON and decodes the response into out when not nil.
+func (c *apiClient) do(method, path string, body any, out any) int {
+	c.t.Helper()
+	var r io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		r = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(method, c.srv.URL+path, r)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("%s %s: undecodable response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+type bookResp struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	TotalPages int    `json:"total_pages"`
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	api, _ := newAPI(t)
+	for _, auth := range []string{"", "Bearer faux", testToken} {
+		req, _ := http.NewRequest(http.MethodGet, api.srv.URL+"/api/books", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %d", auth, resp.StatusCode)
+		}
+	}
+	resp, err := http.Get(api.srv.URL + "/api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the description without a token, got %d", resp.StatusCode)
+	}
+	if _, err := httpapi.NewServer(nil, nil, nil, nil, nil, "", nil); err == nil {
+		t.Error("expected an empty token to be refused")
+	}
+}
+
+func TestServer_BooksAndProgress(t *testing.T) {
+	api, store := newAPI(t)
+
+	var book bookResp
+	if code := api.do("POST", "/api/books", map[string]string{"path": "/livres/Dune.epub"}, &book); code != http.StatusCreated || book.Title != "Dune" {
+		t.Fatalf("expected the imported book, got %d %+v", code, book)
+	}
+	var books []bookResp
+	if code := api.do("GET", "/api/books", nil, &books); code != http.StatusOK || len(books) != 1 || books[0].ID != book.ID {
+		t.Fatalf("expected one book, got %d %+v", code, books)
+	}
+	if code := api.do("GET", "/api/books/absent", nil, nil); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown book, got %d", code)
+	}
+	if code := api.do("POST", "/api/books", map[string]any{"chemin": 1}, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown field, got %d", code)
+	}
+
+	var ses struct {
+		ID          string  `json:"id"`
+		BookID      string  `json:"book_id"`
+		CurrentPage int     `json:"current_page"`
+		Completion  float64 `json:"completion"`
+	}
+	if code := api.do("PUT", "/api/books/"+book.ID+"/progress", map[string]int{"page": 150}, &ses); code != http.StatusOK || ses.CurrentPage != 150 || ses.Completion != 50 {
+		t.Fatalf("expected page 150 at 50%%, got %d %+v", code, ses)
+	}
+	first := ses.ID
+	sessionCount := func() int {
+		sessions, _ := store.GetSessionByID(context.Background(), book.ID)
+		return len(sessions)
+	}
+	if api.do("PUT", "/api/books/"+book.ID+"/progress", map[string]int{"page": 151}, &ses); ses.ID != first || sessionCount() != 1 {
+		t.Errorf("expected the session %s resumed, got %s and %d sessions", first, ses.ID, sessionCount())
+	}
+	if api.do("PUT", "/api/books/"+book.ID+"/progress", map[string]any{"session": "autre", "page": 152}, &ses); ses.ID == first || ses.CurrentPage != 152 || sessionCount() != 2 {
+		t.Errorf("expected a new session for another session ID, got %+v and %d sessions", ses, sessionCount())
+	}
+	if code := api.do("PUT", "/api/books/"+book.ID+"/progress", map[string]int{"page": 0}, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for page 0, got %d", code)
+	}
+	var status map[string]string
+	if api.do("GET", "/api/books/status", nil, &status); status[book.ID] != "reading" {
+		t.Errorf("expected the book in progress, got %v", status)
+	}
+	var recent []map[string]any
+	if api.do("GET", "/api/sessions/recent", nil, &recent); len(recent) != 1 {
+		t.Errorf("expected one recent session, got %v", recent)
+	}
+
+	if code := api.do("DELETE", "/api/books/"+book.ID, nil, nil); code != http.StatusNoContent {
+		t.Errorf("expected the book deleted, got %d", code)
+	}
+	if books, _ := store.ListAll(context.Background()); len(books) != 0 {
+		t.Errorf("expected no book left, got %d", len(books))
+	}
+}
+
+func TestServer_SheetsRemindersAnnotations(t *testing.T) {
+	api, _ := newAPI(t)
+	var book bookResp
+	api.do("POST", "/api/books", map[string]string{"path": "/livres/Fondation.epub"}, &book)
+
+	var sheet domain.ReadingSheet
+	code := api.do("POST", "/api/sheets", map[string]any{"book_id": book.ID, "summary": "Psychohistoire", "rating": 4, "quotes": []string{"La violence est le dernier refuge de l'incompetence."}}, &sheet)
+	if code != http.StatusCreated || sheet.BookTitle != "Fondation" {
+		t.Fatalf("expected the created sheet, got %d %+v", code, sheet)
+	}
+	if code := api.do("POST", "/api/sheets", map[string]any{"book_id": book.ID}, nil); code != http.StatusConflict {
+		t.Errorf("expected 409 for a second sheet, got %d", code)
+	}
+	if code := api.do("PATCH", "/api/sheets/"+sheet.ID, map[string]any{"rating": 9}, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for rating 9, got %d", code)
+	}
+	if code := api.do("PATCH", "/api/sheets/"+sheet.ID, map[string]any{"summary": "Hari Seldon", "rating": 5}, &sheet); code != http.StatusOK || sheet.Summary != "Hari Seldon" || sheet.Rating != 5 {
+		t.Errorf("expected the updated sheet, got %d %+v", code, sheet)
+	}
+	if api.do("POST", "/api/sheets/"+sheet.ID+"/quotes", map[string]string{"quote": "Terminus"}, &sheet); len(sheet.Quotes) != 2 {
+		t.Errorf("expected two quotes, got %v", sheet.Quotes)
+	}
+	var got domain.ReadingSheet
+	if code := api.do("GET", "/api/books/"+book.ID+"/sheet", nil, &got); code != http.StatusOK || got.ID != sheet.ID {
+		t.Errorf("expected the book sheet, got %d %+v", code, got)
+	}
+
+	var rem domain.Reminder
+	if code := api.do("POST", "/api/reminders", map[string]any{"book_id": book.ID, "hour": 21, "minute": 30}, &rem); code != http.StatusCreated || rem.BookTitle != "Fondation" || rem.Frequency != domain.FrequencyDaily {
+		t.Fatalf("expected a daily reminder for the book, got %d %+v", code, rem)
+	}
+	if code := api.do("POST", "/api/reminders", map[string]any{"hour": 25}, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for hour 25, got %d", code)
+	}
+	if api.do("PATCH", "/api/reminders/"+rem.ID, map[string]bool{"enabled": false, "skip_if_read_today": true}, &rem); rem.Enabled || !rem.SkipIfReadToday {
+		t.Errorf("expected a disabled reminder skipping read days, got %+v", rem)
+	}
+	if code := api.do("DELETE", "/api/reminders/"+rem.ID, nil, nil); code != http.StatusNoContent {
+		t.Errorf("expected the reminder deleted, got %d", code)
+	}
+
+	var annot domain.Annotation
+	if code := api.do("POST", "/api/books/"+book.ID+"/annotations", map[string]any{"type": "highlight", "page": 12, "note": "Trantor"}, &annot); code != http.StatusCreated {
+		t.Fatalf("expected the highlight created, got %d", code)
+	}
+	if code := api.do("POST", "/api/books/"+book.ID+"/annotations", map[string]any{"type": "highlight", "page": 999}, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 past the last page, got %d", code)
+	}
+	api.do("POST", "/api/books/"+book.ID+"/annotations", map[string]any{"type": "bookmark", "page": 40}, nil)
+	var list []domain.Annotation
+	if api.do("GET", "/api/books/"+book.ID+"/annotations?page=12", nil, &list); len(list) != 1 || list[0].Note != "Trantor" {
+		t.Errorf("expected the page 12 highlight, got %+v", list)
+	}
+	if api.do("GET", "/api/annotations?type=bookmark", nil, &list); len(list) != 1 || list[0].PageNo != 40 {
+		t.Errorf("expected the bookmark, got %+v", list)
+	}
+	if code := api.do("DELETE", "/api/annotations/"+annot.ID, nil, nil); code != http.StatusNoContent {
+		t.Errorf("expected the highlight deleted, got %d", code)
+	}
+	if api.do("GET", "/api/books/"+book.ID+"/annotations", nil, &list); len(list) != 1 {
+		t.Errorf("expected one annotation left, got %+v", list)
+	}
+}
+
+// TestServer_OpenAPIMatchesRoutes checks that every documented operation is
+// routed: unknown IDs must reach a handler and get a JSON answer, not the
+// router's plain-text 404 or 405.
+func TestServer_OpenAPIMatchesRoutes(t *testing.T) {
+	api, _ := newAPI(t)
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if code := api.do("GET", "/api/openapi.json", nil, &spec); code != http.StatusOK || len(spec.Paths) == 0 {
+		t.Fatalf("expected the description, got %d", code)
+	}
+	operations := 0
+	for path, item := range spec.Paths {
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+			operations++
+			req, _ := http.NewRequest(strings.ToUpper(method), api.srv.URL+strings.ReplaceAll(path, "{id}", "absent"), strings.NewReader("{}"))
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusNoContent && ct != "application/json" {
+				t.Errorf("%s %s: not routed (%d, %s)", method, path, resp.StatusCode, ct)
+			}
+		}
+	}
+	if operations != 23 {
+		t.Errorf("expected 23 documented operations, got %d", operations)
+	}
+}
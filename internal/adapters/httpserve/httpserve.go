@@ -0,0 +1,168 @@
+// Package httpserve holds what the HTTP servers of Orus share: the REST API
+// (httpapi), the OPDS catalog (opds) and the web reader (webreader). It
+// covers authentication, the server lifetime, the stored secrets and the
+// JSON answers.
+package httpserve
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// ComponentLogger returns logger, or slog.Default() when nil, with the
+// component attribute of a server.
+func ComponentLogger(logger *slog.Logger, component string) *slog.Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return logger.With("component", component)
+}
+
+// BasicAuth returns a handler answering 401 to the requests that do not carry
+// the given basic auth credentials, and passing the others to next.
+func BasicAuth(username, password string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Orus", charset="UTF-8"`)
+			http.Error(w, "authentification requise", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ListenAndServe serves handler on addr until ctx is cancelled, then gives
+// the requests in flight five seconds to finish.
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	srv := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// LoadOrCreatePassword reads the password stored at path, or writes a new
+// one readable only by the user. It avoids look-alike characters: it is
+// typed on e-readers and tablets.
+func LoadOrCreatePassword(path string) (string, error) {
+	return loadOrCreate(path, "password", func(buf []byte) string {
+		const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+		for i, b := range buf {
+			buf[i] = alphabet[int(b)%len(alphabet)]
+		}
+		return string(buf)
+	}, 12)
+}
+
+// LoadOrCreateToken reads the token stored at path, or writes a new random
+// one readable only by the user.
+func LoadOrCreateToken(path string) (string, error) {
+	return loadOrCreate(path, "token", hex.EncodeToString, 32)
+}
+
+// loadOrCreate reads the secret stored at path, or writes the encoding of
+// size random bytes.
+func loadOrCreate(path, what string, encode func([]byte) string, size int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if secret := strings.TrimSpace(string(data)); secret != "" {
+			return secret, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	secret := encode(buf)
+	if err := os.WriteFile(path, []byte(secret+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return secret, nil
+}
+
+// WriteJSON answers with v encoded as JSON; a nil v leaves the body empty.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// WriteError answers with {"error": err}.
+func WriteError(w http.ResponseWriter, status int, err error) {
+	WriteJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// Fail answers with the status matching a service error. Unexpected errors,
+// answered 500, are logged.
+func Fail(w http.ResponseWriter, logger *slog.Logger, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrBookNotFound), errors.Is(err, domain.ErrReadingSheetNotFound),
+		errors.Is(err, domain.ErrReminderNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidBookTitle), errors.Is(err, domain.ErrInvalidBookId),
+		errors.Is(err, domain.ErrInvalidPageNumber), errors.Is(err, domain.ErrInvalidSessionPage),
+		errors.Is(err, domain.ErrInvalidRating), errors.Is(err, domain.ErrInvalidReminderTime):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrBookAlreadyExists):
+		status = http.StatusConflict
+	case errors.Is(err, port.ErrStoreLocked):
+		status = http.StatusLocked
+	}
+	if status == http.StatusInternalServerError {
+		logger.Error("request failed", "error", err)
+	}
+	WriteError(w, status, err)
+}
+
+// Decode reads a JSON body into v, answering 400 itself when it cannot.
+func Decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %w", err))
+		return false
+	}
+	return true
+}
+
+// FindBook looks a book up in the library, which has no lookup by ID.
+func FindBook(ctx context.Context, library *service.LibraryService, id string) (*domain.Book, error) {
+	books, err := library.GetLibrary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range books {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("book %s: %w", id, domain.ErrBookNotFound)
+}
@@ -0,0 +1,64 @@
+package httpserve_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/httpserve"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+func TestLoadOrCreateSecrets(t *testing.T) {
+	for name, load := range map[string]func(string) (string, error){
+		"password": httpserve.LoadOrCreatePassword,
+		"token":    httpserve.LoadOrCreateToken,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			first, err := load(path)
+			if err != nil || first == "" {
+				t.Fatalf("expected a new secret, got %q, %v", first, err)
+			}
+			if info, _ := os.Stat(path); info == nil || info.Mode().Perm() != 0600 {
+				t.Errorf("expected a file readable only by the user, got %v", info)
+			}
+			again, err := load(path)
+			if err != nil || again != first {
+				t.Errorf("expected the stored secret %q, got %q, %v", first, again, err)
+			}
+		})
+	}
+	password, _ := httpserve.LoadOrCreatePassword(filepath.Join(t.TempDir(), "password"))
+	if strings.ContainsAny(password, "01ilo") {
+		t.Errorf("expected no look-alike characters, got %q", password)
+	}
+}
+
+func TestFail(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	for err, want := range map[error]int{
+		fmt.Errorf("book 1: %w", domain.ErrBookNotFound): http.StatusNotFound,
+		domain.ErrInvalidRating:                          http.StatusBadRequest,
+		port.ErrStoreLocked:                              http.StatusLocked,
+		errors.New("disque plein"):                       http.StatusInternalServerError,
+	} {
+		rec := httptest.NewRecorder()
+		httpserve.Fail(rec, logger, err)
+		if rec.Code != want || !strings.Contains(rec.Body.String(), `"error"`) {
+			t.Errorf("%v: expected %d with a JSON error, got %d %s", err, want, rec.Code, rec.Body)
+		}
+	}
+	if got := logs.String(); strings.Count(got, "level=ERROR") != 1 || !strings.Contains(got, `error="disque plein"`) {
+		t.Errorf("expected only the unexpected error logged, got %q", got)
+	}
+}
@@ -0,0 +1,149 @@
+// Package logfile keeps the application log in the data directory, rotated
+// by size so that it never grows past a few megabytes.
+package logfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.LogReader = (*Writer)(nil)
+
+const (
+	// DefaultMaxSize is the size past which the log is rotated.
+	DefaultMaxSize = 1 << 20
+	// DefaultKeep is the number of rotated logs kept, orus.log.1 the newest.
+	DefaultKeep = 3
+)
+
+// Writer appends to a log file, renaming it to path.1 (and path.1 to
+// path.2, and so on) once it reaches maxSize. It is safe for concurrent use.
+type Writer struct {
+	path    string
+	maxSize int64
+	keep    int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open opens the log at path for appending, creating its directory.
+func Open(path string, maxSize int64, keep int) (*Writer, error) {
+	if maxSize <= 0 || keep < 1 {
+		return nil, errors.New("log rotation needs a positive size and at least one kept file")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	w := &Writer{path: path, maxSize: maxSize, keep: keep}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Path returns the path of the current log.
+func (w *Writer) Path() string { return w.path }
+
+func (w *Writer) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	w.file, w.size = f, info.Size()
+	return nil
+}
+
+// Write appends p, rotating the log first when p would not fit.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil && w.file == nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the kept logs by one, dropping the oldest, and starts an
+// empty log. When a rename fails the log goes on in the current file.
+func (w *Writer) rotate() error {
+	w.file.Close()
+	w.file = nil
+	var rotateErr error
+	for i := w.keep - 1; i >= 0 && rotateErr == nil; i-- {
+		from := w.path
+		if i > 0 {
+			from = w.rotated(i)
+		}
+		if err := os.Rename(from, w.rotated(i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			rotateErr = fmt.Errorf("failed to rotate log: %w", err)
+		}
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	return rotateErr
+}
+
+func (w *Writer) rotated(i int) string { return fmt.Sprintf("%s.%d", w.path, i) }
+
+// Tail returns the last lines logged within maxBytes, reaching into the
+// previous log right after a rotation.
+func (w *Writer) Tail(maxBytes int) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out []byte
+	for _, path := range []string{w.path, w.rotated(1)} {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read log: %w", err)
+		}
+		out = append(data, out...)
+		if len(out) >= maxBytes {
+			break
+		}
+	}
+	if len(out) > maxBytes {
+		out = out[len(out)-maxBytes:]
+		// La première ligne est coupée : on repart de la suivante.
+		if i := bytes.IndexByte(out, '\n'); i >= 0 {
+			out = out[i+1:]
+		}
+	}
+	return out, nil
+}
+
+// Close closes the log; later writes fail.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
@@ -0,0 +1,78 @@
+package logfile_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/logfile"
+)
+
+func TestWriter_RotatesAndKeepsRecentLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "orus.log")
+	w, err := logfile.Open(path, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 12; i++ {
+		if _, err := fmt.Fprintf(w, "ligne %02d du journal\n", i); err != nil { // 20 octets
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"orus.log", "orus.log.1", "orus.log.2"} {
+		info, err := os.Stat(filepath.Join(filepath.Dir(path), name))
+		if err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+		if info.Size() > 100 {
+			t.Errorf("%s is %d bytes, past the rotation size", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("only two rotated logs should be kept, got %v", err)
+	}
+	current, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(current), "ligne 10") {
+		t.Errorf("the current log should start after the last rotation, got %q", current)
+	}
+
+	// La fin du journal traverse la rotation et ne coupe pas de ligne.
+	tail, err := w.Tail(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tail) != "ligne 10 du journal\nligne 11 du journal\n" {
+		t.Errorf("unexpected tail %q", tail)
+	}
+	tail, _ = w.Tail(90)
+	if !strings.HasPrefix(string(tail), "ligne 08") || !strings.HasSuffix(string(tail), "ligne 11 du journal\n") {
+		t.Errorf("tail should reach into the rotated log, got %q", tail)
+	}
+}
+
+func TestWriter_AppendsToExistingLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orus.log")
+	if err := os.WriteFile(path, []byte("avant\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w, err := logfile.Open(path, logfile.DefaultMaxSize, logfile.DefaultKeep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(w, "apres")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("ferme\n")); err == nil {
+		t.Error("expected writes after Close to fail")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "avant\napres\n" {
+		t.Errorf("unexpected log %q", data)
+	}
+}
@@ -2,8 +2,7 @@ package notifier
 
 import (
 	"fmt"
-	"log"
-	"time"
+	"log/slog"
 
 	"github.com/MiltonJ23/Orus/internal/port"
 )
@@ -14,16 +13,22 @@ import (
 var _ port.Notifier = (*LogNotifier)(nil)
 
 // LogNotifier sends notifications via standard logging output.
-type LogNotifier struct{}
+type LogNotifier struct {
+	logger *slog.Logger
+}
 
-// NewLogNotifier creates a new LogNotifier.
-func NewLogNotifier() *LogNotifier {
-	return &LogNotifier{}
+// NewLogNotifier creates a new LogNotifier. A nil logger uses slog.Default().
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogNotifier{logger: logger.With("component", "notifier")}
 }
 
-// Notify prints a notification to the console with box-drawing characters.
+// Notify logs a notification and prints it to the console with box-drawing
+// characters.
 func (n *LogNotifier) Notify(title, message string) error {
-	log.Printf("[NOTIFICATION] %s — %s (à %s)", title, message, time.Now().Format("15:04"))
+	n.logger.Info("notification", "title", title, "message", message)
 	fmt.Printf("\n╔══════════════════════════════╗\n║  %s\n║  %s\n╚══════════════════════════════╝\n\n", title, message)
 	return nil
 }
@@ -2,7 +2,8 @@ package notifier_test
 
 import (
 	"bytes"
-	"log"
+	"io"
+	"log/slog"
 	"os"
 	"strings"
 	"testing"
@@ -10,14 +11,15 @@ import (
 	"github.com/MiltonJ23/Orus/internal/adapters/notifier"
 )
 
+func newLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, nil))
+}
+
 func TestLogNotifier_Notify(t *testing.T) {
 	t.Run("Basic Notification", func(t *testing.T) {
-		// Capture log output
 		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr) // Restore default output
 
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(newLogger(&buf))
 		err := notif.Notify("Test Title", "Test Message")
 
 		if err != nil {
@@ -26,23 +28,17 @@ func TestLogNotifier_Notify(t *testing.T) {
 
 		// Verify log output contains the notification
 		output := buf.String()
-		if !strings.Contains(output, "Test Title") {
-			t.Errorf("expected log to contain 'Test Title', got: %s", output)
-		}
-		if !strings.Contains(output, "Test Message") {
-			t.Errorf("expected log to contain 'Test Message', got: %s", output)
-		}
-		if !strings.Contains(output, "[NOTIFICATION]") {
-			t.Errorf("expected log to contain '[NOTIFICATION]', got: %s", output)
+		for _, attr := range []string{"msg=notification", "component=notifier", `title="Test Title"`, `message="Test Message"`} {
+			if !strings.Contains(output, attr) {
+				t.Errorf("expected log to contain %s, got: %s", attr, output)
+			}
 		}
 	})
 
 	t.Run("Empty Title and Message", func(t *testing.T) {
 		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr)
 
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(newLogger(&buf))
 		err := notif.Notify("", "")
 
 		if err != nil {
@@ -51,17 +47,15 @@ func TestLogNotifier_Notify(t *testing.T) {
 
 		// Should still log something
 		output := buf.String()
-		if !strings.Contains(output, "[NOTIFICATION]") {
-			t.Errorf("expected log to contain '[NOTIFICATION]', got: %s", output)
+		if !strings.Contains(output, "msg=notification") {
+			t.Errorf("expected log to contain 'msg=notification', got: %s", output)
 		}
 	})
 
 	t.Run("Special Characters in Notification", func(t *testing.T) {
 		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr)
 
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(newLogger(&buf))
 		title := "Special: Test & Demo"
 		message := "Message with \"quotes\" and 'apostrophes' and \nnewlines"
 
@@ -78,10 +72,8 @@ func TestLogNotifier_Notify(t *testing.T) {
 
 	t.Run("Long Title and Message", func(t *testing.T) {
 		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr)
 
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(newLogger(&buf))
 		longTitle := strings.Repeat("A very long title ", 20)
 		longMessage := strings.Repeat("A very long message with lots of text ", 50)
 
@@ -91,17 +83,15 @@ func TestLogNotifier_Notify(t *testing.T) {
 		}
 
 		output := buf.String()
-		if !strings.Contains(output, "[NOTIFICATION]") {
+		if !strings.Contains(output, "msg=notification") {
 			t.Errorf("expected log to contain notification marker")
 		}
 	})
 
 	t.Run("Multiple Sequential Notifications", func(t *testing.T) {
 		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr)
 
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(newLogger(&buf))
 
 		for i := 1; i <= 5; i++ {
 			err := notif.Notify("Title", "Message")
@@ -111,7 +101,7 @@ func TestLogNotifier_Notify(t *testing.T) {
 		}
 
 		output := buf.String()
-		count := strings.Count(output, "[NOTIFICATION]")
+		count := strings.Count(output, "msg=notification")
 		if count != 5 {
 			t.Errorf("expected 5 notifications in log, got %d", count)
 		}
@@ -119,10 +109,8 @@ func TestLogNotifier_Notify(t *testing.T) {
 
 	t.Run("Unicode Characters in Notification", func(t *testing.T) {
 		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr)
 
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(newLogger(&buf))
 		title := "📖 Time to Read!"
 		message := "Bonjour! It's time to read your favorite book 🎉"
 
@@ -140,25 +128,22 @@ func TestLogNotifier_Notify(t *testing.T) {
 
 func TestNewLogNotifier(t *testing.T) {
 	t.Run("Create New Notifier", func(t *testing.T) {
-		notif := notifier.NewLogNotifier()
+		notif := notifier.NewLogNotifier(nil)
 		if notif == nil {
 			t.Error("expected non-nil notifier")
 		}
 	})
 
 	t.Run("Multiple Instances", func(t *testing.T) {
-		notif1 := notifier.NewLogNotifier()
-		notif2 := notifier.NewLogNotifier()
+		var buf bytes.Buffer
+		notif1 := notifier.NewLogNotifier(newLogger(&buf))
+		notif2 := notifier.NewLogNotifier(newLogger(&buf))
 
 		if notif1 == nil || notif2 == nil {
 			t.Error("expected both notifiers to be non-nil")
 		}
 
 		// Both should work independently
-		var buf bytes.Buffer
-		log.SetOutput(&buf)
-		defer log.SetOutput(os.Stderr)
-
 		notif1.Notify("From 1", "Message 1")
 		notif2.Notify("From 2", "Message 2")
 
@@ -178,7 +163,7 @@ func TestLogNotifier_StdoutOutput(t *testing.T) {
 	}
 	os.Stdout = w
 
-	notif := notifier.NewLogNotifier()
+	notif := notifier.NewLogNotifier(newLogger(io.Discard))
 	notif.Notify("Stdout Title", "Stdout Message")
 
 	w.Close()
@@ -202,12 +187,9 @@ func TestLogNotifier_StdoutOutput(t *testing.T) {
 
 func TestLogNotifier_AlwaysReturnsNil(t *testing.T) {
 	// LogNotifier.Notify should always return nil (no failure path)
-	notif := notifier.NewLogNotifier()
-
 	// Silence log output during this test
 	var buf bytes.Buffer
-	log.SetOutput(&buf)
-	defer log.SetOutput(os.Stderr)
+	notif := notifier.NewLogNotifier(newLogger(&buf))
 
 	testCases := []struct{ title, message string }{
 		{"", ""},
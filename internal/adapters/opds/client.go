@@ -0,0 +1,350 @@
+package opds
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.CatalogClient = (*Client)(nil)
+
+// maxFeedSize bounds the feeds and OpenSearch descriptions read by the client.
+const maxFeedSize = 16 << 20
+
+// Client reads a remote OPDS 1.x catalog (Calibre content server, COPS,
+// Project Gutenberg, or another Orus). The credentials are only sent to the
+// host of the catalog, never to the sites its links point to.
+type Client struct {
+	root     *url.URL
+	username string
+	password string
+	client   *http.Client
+
+	mu        sync.Mutex
+	templates map[string]string // modèles de recherche par URL de description OpenSearch
+}
+
+// NewClient creates a client for the catalog whose first feed is at rawURL.
+func NewClient(rawURL, username, password string) (*Client, error) {
+	root, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog URL: %w", err)
+	}
+	if (root.Scheme != "http" && root.Scheme != "https") || root.Host == "" {
+		return nil, fmt.Errorf("invalid catalog URL %q: expected http or https", rawURL)
+	}
+	return &Client{root: root, username: username, password: password,
+		client: &http.Client{Timeout: 10 * time.Minute}, templates: map[string]string{}}, nil
+}
+
+// Open is the port.CatalogOpener of OPDS catalogs.
+func Open(c port.Catalog) (port.CatalogClient, error) {
+	return NewClient(c.URL, c.Username, c.Password)
+}
+
+// SetHTTPClient replaces the HTTP client, for proxies or custom certificates.
+func (c *Client) SetHTTPClient(client *http.Client) { c.client = client }
+
+func (c *Client) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/atom+xml, application/xml;q=0.9, */*;q=0.5")
+	if c.username != "" && req.URL.Host == c.root.Host {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OPDS GET %s: %w", target, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("OPDS GET %s: authentication refused (%s)", target, resp.Status)
+		}
+		return nil, fmt.Errorf("OPDS GET %s: %s", target, resp.Status)
+	}
+	return resp, nil
+}
+
+// Root returns the first page of the catalog.
+func (c *Client) Root(ctx context.Context) (*port.CatalogFeed, error) {
+	return c.Fetch(ctx, c.root.String())
+}
+
+// Fetch downloads and parses the Atom feed at target. Relative links are
+// resolved against the final URL, after redirects.
+func (c *Client) Fetch(ctx context.Context, target string) (*port.CatalogFeed, error) {
+	resp, err := c.get(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var f atomInFeed
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxFeedSize)).Decode(&f); err != nil {
+		return nil, fmt.Errorf("OPDS feed %s: %w", target, err)
+	}
+	base := resp.Request.URL
+	feed := &port.CatalogFeed{URL: base.String(), Title: strings.TrimSpace(f.Title)}
+	for _, l := range f.Links {
+		switch l.Rel {
+		case "next":
+			feed.Next = resolve(base, l.Href)
+		case "previous", "prev":
+			feed.Previous = resolve(base, l.Href)
+		case "search":
+			if feed.Search == "" {
+				feed.Search = c.searchTemplate(ctx, base, l)
+			}
+		}
+	}
+	for _, e := range f.Entries {
+		feed.Entries = append(feed.Entries, e.toEntry(base))
+	}
+	return feed, nil
+}
+
+// Search fetches the results of query from template.
+func (c *Client) Search(ctx context.Context, template, query string) (*port.CatalogFeed, error) {
+	if template == "" {
+		return nil, fmt.Errorf("catalog cannot be searched")
+	}
+	terms := strings.ReplaceAll(url.QueryEscape(strings.TrimSpace(query)), "+", "%20")
+	return c.Fetch(ctx, expandTemplate(template, terms))
+}
+
+// Download opens the file at target.
+func (c *Client) Download(ctx context.Context, target string) (io.ReadCloser, string, error) {
+	resp, err := c.get(ctx, target)
+	if err != nil {
+		return nil, "", err
+	}
+	name := ""
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		name = params["filename"]
+	}
+	return resp.Body, name, nil
+}
+
+// searchTemplate returns the search template of a search link: the link
+// itself when it is already a template, else the Atom URL of its OpenSearch
+// description. It returns "" when the description cannot be read.
+func (c *Client) searchTemplate(ctx context.Context, base *url.URL, l atomInLink) string {
+	href := resolve(base, l.Href)
+	if strings.Contains(l.Href, "{searchTerms}") {
+		return unbrace(href)
+	}
+	if !strings.Contains(l.Type, "opensearchdescription") {
+		return ""
+	}
+	c.mu.Lock()
+	template, ok := c.templates[href]
+	c.mu.Unlock()
+	if ok {
+		return template
+	}
+	if resp, err := c.get(ctx, href); err == nil {
+		var desc openSearchIn
+		if xml.NewDecoder(io.LimitReader(resp.Body, maxFeedSize)).Decode(&desc) == nil {
+			for _, u := range desc.URLs {
+				if strings.Contains(u.Type, "atom") && strings.Contains(u.Template, "{searchTerms}") {
+					template = unbrace(resolve(resp.Request.URL, u.Template))
+					break
+				}
+			}
+		}
+		resp.Body.Close()
+	}
+	c.mu.Lock()
+	c.templates[href] = template
+	c.mu.Unlock()
+	return template
+}
+
+// unbrace restores the template braces escaped by URL resolution.
+func unbrace(template string) string {
+	return strings.NewReplacer("%7B", "{", "%7D", "}", "%7b", "{", "%7d", "}").Replace(template)
+}
+
+var optionalParam = regexp.MustCompile(`\{[^}]*\?\}`)
+
+// expandTemplate fills an OpenSearch template: searchTerms with terms, the
+// optional parameters with nothing.
+func expandTemplate(template, terms string) string {
+	return optionalParam.ReplaceAllString(strings.ReplaceAll(template, "{searchTerms}", terms), "")
+}
+
+func resolve(base *url.URL, href string) string {
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// --- Atom parsing ---
+
+type atomInFeed struct {
+	Title   string        `xml:"http://www.w3.org/2005/Atom title"`
+	Links   []atomInLink  `xml:"http://www.w3.org/2005/Atom link"`
+	Entries []atomInEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type atomInLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomInText struct {
+	Type  string `xml:"type,attr"`
+	Inner string `xml:",innerxml"`
+}
+
+type atomInEntry struct {
+	ID         string       `xml:"http://www.w3.org/2005/Atom id"`
+	Title      string       `xml:"http://www.w3.org/2005/Atom title"`
+	Authors    []string     `xml:"http://www.w3.org/2005/Atom author>name"`
+	Summary    atomInText   `xml:"http://www.w3.org/2005/Atom summary"`
+	Content    atomInText   `xml:"http://www.w3.org/2005/Atom content"`
+	Categories []atomInTerm `xml:"http://www.w3.org/2005/Atom category"`
+	Links      []atomInLink `xml:"http://www.w3.org/2005/Atom link"`
+	Publisher  []string     `xml:"http://purl.org/dc/terms/ publisher"`
+	Publisher1 []string     `xml:"http://purl.org/dc/elements/1.1/ publisher"`
+	Issued     []string     `xml:"http://purl.org/dc/terms/ issued"`
+	Date       []string     `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Identifier []string     `xml:"http://purl.org/dc/terms/ identifier"`
+	Ident1     []string     `xml:"http://purl.org/dc/elements/1.1/ identifier"`
+}
+
+type atomInTerm struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type openSearchIn struct {
+	URLs []struct {
+		Type     string `xml:"type,attr"`
+		Template string `xml:"template,attr"`
+	} `xml:"Url"`
+}
+
+var (
+	markup     = regexp.MustCompile(`<[^>]*>`)
+	blankLines = regexp.MustCompile(`\n\s*\n+`)
+	isbnURN    = regexp.MustCompile(`(?i)^urn:isbn:([0-9xX-]+)$`)
+	yearPrefix = regexp.MustCompile(`^\d{4}`)
+)
+
+// text returns the plain text of an Atom text construct.
+func (t atomInText) text() string {
+	s := t.Inner
+	if t.Type == "html" {
+		// Le HTML est échappé une fois de plus que le XHTML.
+		s = html.UnescapeString(s)
+	}
+	if t.Type == "html" || t.Type == "xhtml" || strings.Contains(s, "<") {
+		s = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n", "</p>", "\n\n", "</div>", "\n").Replace(s)
+		s = markup.ReplaceAllString(s, "")
+	}
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n"))
+}
+
+func first(values ...[]string) string {
+	for _, v := range values {
+		for _, s := range v {
+			if s = strings.TrimSpace(s); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// formatOf maps the media type of an acquisition link to the formats Orus opens.
+func formatOf(mediaType string) domain.BookFormat {
+	mt, _, _ := mime.ParseMediaType(mediaType)
+	switch mt {
+	case "application/epub+zip":
+		return domain.FormatEPUB
+	case "application/pdf":
+		return domain.FormatPDF
+	}
+	return ""
+}
+
+func (e atomInEntry) toEntry(base *url.URL) port.CatalogEntry {
+	out := port.CatalogEntry{
+		ID:        strings.TrimSpace(e.ID),
+		Title:     strings.TrimSpace(e.Title),
+		Summary:   e.Summary.text(),
+		Publisher: first(e.Publisher, e.Publisher1),
+	}
+	if out.Summary == "" {
+		out.Summary = e.Content.text()
+	}
+	for _, a := range e.Authors {
+		if a = strings.TrimSpace(a); a != "" {
+			out.Authors = append(out.Authors, a)
+		}
+	}
+	for _, c := range e.Categories {
+		tag := strings.TrimSpace(c.Label)
+		if tag == "" {
+			tag = strings.TrimSpace(c.Term)
+		}
+		if tag != "" && !strings.Contains(tag, "://") {
+			out.Tags = append(out.Tags, tag)
+		}
+	}
+	if y := yearPrefix.FindString(first(e.Issued, e.Date)); y != "" {
+		out.Year, _ = strconv.Atoi(y)
+	}
+	for _, id := range append(append(append([]string{}, e.Identifier...), e.Ident1...), e.ID) {
+		if m := isbnURN.FindStringSubmatch(strings.TrimSpace(id)); m != nil {
+			out.ISBN = strings.ReplaceAll(m[1], "-", "")
+			break
+		}
+	}
+
+	var thumbnail string
+	for _, l := range e.Links {
+		href := resolve(base, l.Href)
+		switch {
+		case strings.HasPrefix(l.Rel, relAcquisition):
+			// acquisition, acquisition/open-access... ; les achats et
+			// emprunts ne mènent pas directement au fichier.
+			if l.Rel == relAcquisition || l.Rel == relAcquisition+"/open-access" {
+				out.Files = append(out.Files, port.CatalogFile{URL: href, Type: l.Type, Format: formatOf(l.Type)})
+			}
+		case l.Rel == relImage || l.Rel == "http://opds-spec.org/cover" || l.Rel == "x-stanza-cover-image":
+			out.Cover = href
+		case l.Rel == relThumbnail || l.Rel == "http://opds-spec.org/thumbnail" || l.Rel == "x-stanza-cover-image-thumbnail":
+			thumbnail = href
+		case out.Link == "" && strings.Contains(l.Type, "atom+xml") && l.Rel != "alternate" && l.Rel != "related":
+			out.Link = href
+		}
+	}
+	if out.Cover == "" {
+		out.Cover = thumbnail
+	}
+	if len(out.Files) > 0 {
+		out.Link = ""
+	}
+	return out
+}
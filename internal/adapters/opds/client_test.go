@@ -0,0 +1,183 @@
+package opds_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/opds"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// calibreFeed looks like a page of the Calibre content server: relative
+// links, XHTML summaries, Dublin Core elements and an OpenSearch description.
+const calibreFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/terms/" xmlns:dcel="http://purl.org/dc/elements/1.1/">
+  <id>urn:calibre:main</id>
+  <title>Bibliotheque de Paul</title>
+  <link rel="search" type="application/opensearchdescription+xml" href="../opensearch.xml"/>
+  <link rel="next" type="application/atom+xml;profile=opds-catalog;kind=acquisition" href="books?offset=25"/>
+  <entry>
+    <title>Par auteur</title>
+    <id>calibre:authors</id>
+    <link rel="subsection" type="application/atom+xml;profile=opds-catalog;kind=navigation" href="authors"/>
+  </entry>
+  <entry>
+    <title>Les Miserables</title>
+    <id>urn:uuid:42</id>
+    <author><name>Victor Hugo</name></author>
+    <dcel:publisher>Lacroix</dcel:publisher>
+    <dc:issued>1862-04-03</dc:issued>
+    <dc:identifier>urn:isbn:978-2-07-040850-4</dc:identifier>
+    <category term="Roman" label="Roman"/>
+    <content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Jean Valjean &amp; Cosette.</p><p>Tome 1.</p></div></content>
+    <link rel="http://opds-spec.org/acquisition" type="application/epub+zip" href="/get/epub/42"/>
+    <link rel="http://opds-spec.org/acquisition/buy" type="application/pdf" href="https://store.example/42"/>
+    <link rel="http://opds-spec.org/acquisition" type="application/x-mobipocket-ebook" href="/get/mobi/42"/>
+    <link rel="http://opds-spec.org/image/thumbnail" type="image/jpeg" href="/thumb/42"/>
+    <link rel="http://opds-spec.org/image" type="image/jpeg" href="/cover/42"/>
+  </entry>
+</feed>`
+
+const calibreOpenSearch = `<?xml version="1.0" encoding="UTF-8"?>
+<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/">
+  <Url type="text/html" template="/browse/search?q={searchTerms}"/>
+  <Url type="application/atom+xml" template="/opds/search/{searchTerms}?start={startPage?}"/>
+</OpenSearchDescription>`
+
+func TestClient_ParsesCalibreFeeds(t *testing.T) {
+	var searched, authHosts []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/opds/books", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, calibreFeed)
+	})
+	mux.HandleFunc("/opensearch.xml", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, calibreOpenSearch)
+	})
+	mux.HandleFunc("/opds/search/{terms}", func(w http.ResponseWriter, r *http.Request) {
+		searched = append(searched, r.PathValue("terms"))
+		io.WriteString(w, `<feed xmlns="http://www.w3.org/2005/Atom"><title>Recherche</title></feed>`)
+	})
+	mux.HandleFunc("/get/epub/42", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="Les Miserables - Victor Hugo.epub"`)
+		io.WriteString(w, "epub")
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "paul" || pass != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="calibre"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		authHosts = append(authHosts, r.Host)
+		mux.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	if c, _ := opds.NewClient(srv.URL+"/opds/books", "paul", "faux"); c != nil {
+		if _, err := c.Root(ctx); err == nil || !strings.Contains(err.Error(), "authentication") {
+			t.Errorf("expected an authentication error, got %v", err)
+		}
+	}
+	client, err := opds.Open(port.Catalog{URL: srv.URL + "/opds/books", Username: "paul", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed, err := client.Root(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed.Title != "Bibliotheque de Paul" || feed.Next != srv.URL+"/opds/books?offset=25" || len(feed.Entries) != 2 {
+		t.Fatalf("unexpected feed %+v", feed)
+	}
+	if nav := feed.Entries[0]; nav.Link != srv.URL+"/opds/authors" || len(nav.Files) != 0 {
+		t.Errorf("unexpected navigation entry %+v", nav)
+	}
+	book := feed.Entries[1]
+	if book.Link != "" || book.Publisher != "Lacroix" || book.Year != 1862 || book.ISBN != "9782070408504" ||
+		len(book.Authors) != 1 || book.Authors[0] != "Victor Hugo" || len(book.Tags) != 1 || book.Cover != srv.URL+"/cover/42" {
+		t.Errorf("unexpected book entry %+v", book)
+	}
+	if book.Summary != "Jean Valjean & Cosette.\n\nTome 1." {
+		t.Errorf("unexpected summary %q", book.Summary)
+	}
+	if len(book.Files) != 2 || book.Files[0].Format != domain.FormatEPUB || book.Files[1].Format != "" {
+		t.Errorf("expected the EPUB and MOBI downloads only, got %+v", book.Files)
+	}
+
+	if _, err := client.Search(ctx, feed.Search, "victor hugo"); err != nil || len(searched) != 1 || searched[0] != "victor hugo" {
+		t.Errorf("expected a search through the Atom template %q, got %v %v", feed.Search, searched, err)
+	}
+	body, name, err := client.Download(ctx, book.Files[0].URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "epub" || name != "Les Miserables - Victor Hugo.epub" {
+		t.Errorf("unexpected download %q %q", data, name)
+	}
+}
+
+func TestClient_CredentialsStayOnCatalogHost(t *testing.T) {
+	var leaked bool
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, leaked = r.BasicAuth()
+		io.WriteString(w, "livre")
+	}))
+	defer other.Close()
+	catalog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `<feed xmlns="http://www.w3.org/2005/Atom"><title>Miroir</title><entry><title>Libre</title>
+<link rel="http://opds-spec.org/acquisition/open-access" type="application/epub+zip" href="`+other.URL+`/libre.epub"/></entry></feed>`)
+	}))
+	defer catalog.Close()
+
+	client, _ := opds.NewClient(catalog.URL, "moi", "secret")
+	feed, err := client.Root(context.Background())
+	if err != nil || len(feed.Entries) != 1 || len(feed.Entries[0].Files) != 1 || feed.Search != "" {
+		t.Fatalf("unexpected feed %+v, %v", feed, err)
+	}
+	body, _, err := client.Download(context.Background(), feed.Entries[0].Files[0].URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+	if leaked {
+		t.Error("expected the credentials to stay on the catalog host")
+	}
+}
+
+// The client reads the catalogs of another Orus.
+func TestClient_BrowsesOrusServer(t *testing.T) {
+	lib := newLibrary(t)
+	lib.addBook("Dune", "Frank Herbert", "contenu de dune", fakeNow)
+	lib.addBook("Fondation", "Isaac Asimov", "x", fakeNow)
+
+	client, _ := opds.NewClient(lib.srv.URL+"/opds", opds.Username, "liseuse")
+	ctx := context.Background()
+	root, err := client.Root(ctx)
+	if err != nil || len(root.Entries) != 5 || root.Search == "" {
+		t.Fatalf("unexpected root %+v, %v", root, err)
+	}
+	authors, err := client.Fetch(ctx, root.Entries[2].Link)
+	if err != nil || len(authors.Entries) != 2 {
+		t.Fatalf("unexpected authors feed %+v, %v", authors, err)
+	}
+	found, err := client.Search(ctx, root.Search, "dune")
+	if err != nil || len(found.Entries) != 1 || found.Entries[0].Files[0].Format != domain.FormatEPUB {
+		t.Fatalf("unexpected search results %+v, %v", found, err)
+	}
+	body, name, err := client.Download(ctx, found.Entries[0].Files[0].URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "contenu de dune" || name != "Dune.epub" {
+		t.Errorf("unexpected download %q %q", data, name)
+	}
+}
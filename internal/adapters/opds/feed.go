@@ -0,0 +1,166 @@
+package opds
+
+import (
+	"encoding/xml"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// OPDS 1.2 media types and link relations.
+const (
+	typeNavigation  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	typeAcquisition = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	typeOpenSearch  = "application/opensearchdescription+xml"
+
+	relAcquisition = "http://opds-spec.org/acquisition"
+	relImage       = "http://opds-spec.org/image"
+	relThumbnail   = "http://opds-spec.org/image/thumbnail"
+	relSubsection  = "subsection"
+)
+
+type feed struct {
+	XMLName xml.Name  `xml:"feed"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	DC      string    `xml:"xmlns:dc,attr"`
+	OPDS    string    `xml:"xmlns:opds,attr"`
+	OS      string    `xml:"xmlns:opensearch,attr"`
+	ID      string    `xml:"id"`
+	Title   string    `xml:"title"`
+	Updated time.Time `xml:"updated"`
+	Author  *person   `xml:"author,omitempty"`
+	Total   int       `xml:"opensearch:totalResults,omitempty"`
+	Links   []link    `xml:"link"`
+	Entries []entry   `xml:"entry"`
+}
+
+type person struct {
+	Name string `xml:"name"`
+}
+
+type link struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type category struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type content struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+type entry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Updated    time.Time  `xml:"updated"`
+	Authors    []person   `xml:"author,omitempty"`
+	Publisher  string     `xml:"dc:publisher,omitempty"`
+	Issued     string     `xml:"dc:issued,omitempty"`
+	Identifier string     `xml:"dc:identifier,omitempty"`
+	Categories []category `xml:"category,omitempty"`
+	Content    *content   `xml:"content,omitempty"`
+	Links      []link     `xml:"link"`
+}
+
+func newFeed(id, title string, updated time.Time) *feed {
+	return &feed{
+		Xmlns: "http://www.w3.org/2005/Atom",
+		DC:    "http://purl.org/dc/terms/",
+		OPDS:  "http://opds-spec.org/2010/catalog",
+		OS:    "http://a9.com/-/spec/opensearch/1.1/",
+		ID:    id, Title: title, Updated: updated.UTC(),
+		Author: &person{Name: "Orus"},
+	}
+}
+
+// navEntry links to a sub-feed.
+func navEntry(id, title, summary, href, kind string, updated time.Time) entry {
+	return entry{
+		ID: id, Title: title, Updated: updated.UTC(),
+		Content: &content{Type: "text", Text: summary},
+		Links:   []link{{Rel: relSubsection, Href: href, Type: kind}},
+	}
+}
+
+// mimeType returns the media type of the book file, from its extension or
+// else from Book.Format.
+func mimeType(b *domain.Book) string {
+	switch strings.ToLower(filepath.Ext(b.FilePath)) {
+	case ".epub":
+		return "application/epub+zip"
+	case ".pdf":
+		return "application/pdf"
+	case ".mobi":
+		return "application/x-mobipocket-ebook"
+	}
+	switch b.Format {
+	case domain.FormatEPUB:
+		return "application/epub+zip"
+	case domain.FormatPDF:
+		return "application/pdf"
+	case domain.FormatMOBI:
+		return "application/x-mobipocket-ebook"
+	}
+	return "application/octet-stream"
+}
+
+// bookEntry describes a book with its download and cover links.
+func bookEntry(b *domain.Book, tags []string, status string) entry {
+	e := entry{
+		ID:        "urn:uuid:" + b.ID,
+		Title:     b.Title,
+		Updated:   b.UpdatedAt.UTC(),
+		Publisher: b.Publisher,
+	}
+	if e.Updated.IsZero() {
+		e.Updated = b.AddedAt.UTC()
+	}
+	if b.ISBN != "" {
+		e.Identifier = "urn:isbn:" + b.ISBN
+	}
+	if b.Author != "" {
+		e.Authors = []person{{Name: b.Author}}
+	}
+	if b.Year > 0 {
+		e.Issued = strconv.Itoa(b.Year)
+	}
+	for _, t := range tags {
+		e.Categories = append(e.Categories, category{Term: t, Label: t})
+	}
+	var summary []string
+	if b.Series != "" {
+		series := b.Series
+		if b.SeriesIndex > 0 {
+			series += " #" + strconv.FormatFloat(b.SeriesIndex, 'f', -1, 64)
+		}
+		summary = append(summary, series)
+	}
+	if b.TotalPages > 0 {
+		summary = append(summary, strconv.Itoa(b.TotalPages)+" pages")
+	}
+	if label := statusLabels[status]; label != "" {
+		summary = append(summary, label)
+	}
+	if len(summary) > 0 {
+		e.Content = &content{Type: "text", Text: strings.Join(summary, " · ")}
+	}
+	base := "/opds/books/" + b.ID
+	e.Links = []link{{Rel: relAcquisition, Href: base + "/file", Type: mimeType(b)}}
+	if len(b.CoverImage) > 0 {
+		e.Links = append(e.Links,
+			link{Rel: relImage, Href: base + "/cover", Type: http.DetectContentType(b.CoverImage)},
+			link{Rel: relThumbnail, Href: base + "/thumbnail", Type: "image/jpeg"},
+		)
+	}
+	return e
+}
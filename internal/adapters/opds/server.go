@@ -0,0 +1,502 @@
+// Package opds serves the library as an OPDS 1.2 catalog, so that e-readers
+// and phone apps on the local network can browse and download the books.
+// Every request needs HTTP basic authentication.
+package opds
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/draw"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/httpserve"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// DefaultAddr is where `orus opds` listens without an explicit address: every
+// interface, so that the other devices of the network can reach it.
+const DefaultAddr = ":7879"
+
+// Username is the basic auth user of the catalog.
+const Username = "orus"
+
+const (
+	pageSize        = 50  // entrées par page des flux d'acquisition
+	recentCount     = 25  // livres du flux « Derniers ajouts »
+	thumbnailHeight = 200 // hauteur des vignettes, en pixels
+)
+
+// statusLabels names the reading statuses of TrackerService.BookCompletionStatus.
+var statusLabels = map[string]string{
+	"unread":  "Non commence",
+	"reading": "En cours",
+	"done":    "Termine",
+}
+
+// Server answers the OPDS requests from the services.
+type Server struct {
+	library *service.LibraryService
+	tracker *service.TrackerService
+	sheets  *service.ReadingSheetService
+	handler http.Handler // mux derrière l'authentification
+	logger  *slog.Logger
+
+	mu     sync.Mutex
+	thumbs map[string]coverThumb // vignettes JPEG, par livre
+}
+
+// coverThumb is a scaled cover, valid while the book keeps the same cover.
+type coverThumb struct {
+	sum  uint32 // CRC-32 de la couverture d'origine
+	jpeg []byte // nil quand la couverture ne se décode pas
+}
+
+// NewServer creates an OPDS server over the services, protected by the given
+// basic auth credentials. A nil logger uses slog.Default().
+func NewServer(library *service.LibraryService, tracker *service.TrackerService, sheets *service.ReadingSheetService,
+	username, password string, logger *slog.Logger) (*Server, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("OPDS credentials are required")
+	}
+	s := &Server{library: library, tracker: tracker, sheets: sheets, logger: httpserve.ComponentLogger(logger, "opds"),
+		thumbs: map[string]coverThumb{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /opds", s.root)
+	mux.HandleFunc("GET /opds/all", s.all)
+	mux.HandleFunc("GET /opds/recent", s.recent)
+	mux.HandleFunc("GET /opds/authors", s.authors)
+	mux.HandleFunc("GET /opds/authors/{name}", s.byAuthor)
+	mux.HandleFunc("GET /opds/tags", s.tags)
+	mux.HandleFunc("GET /opds/tags/{tag}", s.byTag)
+	mux.HandleFunc("GET /opds/status", s.statuses)
+	mux.HandleFunc("GET /opds/status/{status}", s.byStatus)
+	mux.HandleFunc("GET /opds/search", s.search)
+	mux.HandleFunc("GET /opds/opensearch.xml", s.openSearch)
+	mux.HandleFunc("GET /opds/books/{id}/file", s.file)
+	mux.HandleFunc("GET /opds/books/{id}/cover", s.cover)
+	mux.HandleFunc("GET /opds/books/{id}/thumbnail", s.thumbnail)
+	mux.Handle("GET /{$}", http.RedirectHandler("/opds", http.StatusFound))
+	s.handler = httpserve.BasicAuth(username, password, mux)
+	return s, nil
+}
+
+// ServeHTTP checks the credentials, then dispatches the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the catalog on addr until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	return httpserve.ListenAndServe(ctx, addr, s)
+}
+
+// catalog is the library as read for one request.
+type catalog struct {
+	books  []*domain.Book      // triés par titre
+	tags   map[string][]string // par ID de livre
+	status map[string]string   // par ID de livre : unread, reading, done
+	byID   map[string]*domain.Book
+}
+
+func (s *Server) load(ctx context.Context) (*catalog, error) {
+	books, err := s.library.GetLibrary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(books, func(i, j int) bool { return strings.ToLower(books[i].Title) < strings.ToLower(books[j].Title) })
+	c := &catalog{books: books, tags: map[string][]string{}, byID: map[string]*domain.Book{}}
+	for _, b := range books {
+		c.byID[b.ID] = b
+	}
+	if c.status, err = s.tracker.BookCompletionStatus(ctx); err != nil {
+		return nil, err
+	}
+	sheets, err := s.sheets.ListSheets(ctx)
+	switch {
+	case errors.Is(err, port.ErrStoreLocked):
+		// Base chiffrée verrouillée : le catalogue reste navigable, sans tags.
+	case err != nil:
+		return nil, err
+	}
+	for _, sh := range sheets {
+		c.tags[sh.BookID] = sh.Tags
+	}
+	return c, nil
+}
+
+// updated is the date of the most recently changed book.
+func (c *catalog) updated() time.Time {
+	var latest time.Time
+	for _, b := range c.books {
+		if b.UpdatedAt.After(latest) {
+			latest = b.UpdatedAt
+		}
+	}
+	return latest
+}
+
+func (s *Server) loadOrFail(w http.ResponseWriter, r *http.Request) *catalog {
+	c, err := s.load(r.Context())
+	if err != nil {
+		s.logger.Error("catalog unavailable", "error", err)
+		http.Error(w, "catalogue indisponible", http.StatusInternalServerError)
+		return nil
+	}
+	return c
+}
+
+func (s *Server) writeFeed(w http.ResponseWriter, kind string, f *feed) {
+	f.Links = append([]link{
+		{Rel: "start", Href: "/opds", Type: typeNavigation, Title: "Orus"},
+		{Rel: "search", Href: "/opds/opensearch.xml", Type: typeOpenSearch},
+	}, f.Links...)
+	w.Header().Set("Content-Type", kind+";charset=utf-8")
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		s.logger.Warn("feed not written", "error", err)
+	}
+}
+
+// --- Navigation feeds ---
+
+func (s *Server) root(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	now := c.updated()
+	f := newFeed("urn:orus:root", "Bibliotheque Orus", now)
+	f.Links = []link{{Rel: "self", Href: "/opds", Type: typeNavigation}}
+	f.Entries = []entry{
+		navEntry("urn:orus:all", "Tous les livres", fmt.Sprintf("%d livre(s)", len(c.books)), "/opds/all", typeAcquisition, now),
+		navEntry("urn:orus:recent", "Derniers ajouts", "Les livres importes le plus recemment", "/opds/recent", typeAcquisition, now),
+		navEntry("urn:orus:authors", "Par auteur", "Livres classes par auteur", "/opds/authors", typeNavigation, now),
+		navEntry("urn:orus:tags", "Par tag", "Tags des fiches de lecture", "/opds/tags", typeNavigation, now),
+		navEntry("urn:orus:status", "Par statut", "Non commences, en cours, termines", "/opds/status", typeNavigation, now),
+	}
+	s.writeFeed(w, typeNavigation, f)
+}
+
+// groupFeed writes a navigation feed with one entry per group, sorted by name.
+func (s *Server) groupFeed(w http.ResponseWriter, c *catalog, id, title, self string, groups map[string]int, href func(string) string) {
+	f := newFeed(id, title, c.updated())
+	f.Links = []link{{Rel: "self", Href: self, Type: typeNavigation}, {Rel: "up", Href: "/opds", Type: typeNavigation}}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	for _, name := range names {
+		f.Entries = append(f.Entries, navEntry(id+":"+url.PathEscape(name), name,
+			fmt.Sprintf("%d livre(s)", groups[name]), href(name), typeAcquisition, c.updated()))
+	}
+	s.writeFeed(w, typeNavigation, f)
+}
+
+const unknownAuthor = "Auteur inconnu"
+
+func authorOf(b *domain.Book) string {
+	if a := strings.TrimSpace(b.Author); a != "" {
+		return a
+	}
+	return unknownAuthor
+}
+
+func (s *Server) authors(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	groups := map[string]int{}
+	for _, b := range c.books {
+		groups[authorOf(b)]++
+	}
+	s.groupFeed(w, c, "urn:orus:authors", "Par auteur", "/opds/authors", groups,
+		func(name string) string { return "/opds/authors/" + url.PathEscape(name) })
+}
+
+func (s *Server) tags(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	groups := map[string]int{}
+	for _, b := range c.books {
+		for _, t := range c.tags[b.ID] {
+			groups[t]++
+		}
+	}
+	s.groupFeed(w, c, "urn:orus:tags", "Par tag", "/opds/tags", groups,
+		func(name string) string { return "/opds/tags/" + url.PathEscape(name) })
+}
+
+func (s *Server) statuses(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	f := newFeed("urn:orus:status", "Par statut", c.updated())
+	f.Links = []link{{Rel: "self", Href: "/opds/status", Type: typeNavigation}, {Rel: "up", Href: "/opds", Type: typeNavigation}}
+	counts := map[string]int{}
+	for _, b := range c.books {
+		counts[c.status[b.ID]]++
+	}
+	for _, st := range []string{"reading", "unread", "done"} {
+		f.Entries = append(f.Entries, navEntry("urn:orus:status:"+st, statusLabels[st],
+			fmt.Sprintf("%d livre(s)", counts[st]), "/opds/status/"+st, typeAcquisition, c.updated()))
+	}
+	s.writeFeed(w, typeNavigation, f)
+}
+
+// --- Acquisition feeds ---
+
+// acquisitionFeed writes one page of books, with the links to the other
+// pages. The page number comes from ?page=, starting at 1.
+func (s *Server) acquisitionFeed(w http.ResponseWriter, r *http.Request, c *catalog, id, title string, books []*domain.Book) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	page = max(page, 1)
+	pageURL := func(p int) string {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(p))
+		return r.URL.Path + "?" + q.Encode()
+	}
+	f := newFeed(id, title, c.updated())
+	f.Total = len(books)
+	self := r.URL.RequestURI()
+	f.Links = []link{{Rel: "self", Href: self, Type: typeAcquisition}, {Rel: "up", Href: "/opds", Type: typeNavigation}}
+	start := min((page-1)*pageSize, len(books))
+	end := min(start+pageSize, len(books))
+	if page > 1 {
+		f.Links = append(f.Links, link{Rel: "previous", Href: pageURL(page - 1), Type: typeAcquisition})
+	}
+	if end < len(books) {
+		f.Links = append(f.Links, link{Rel: "next", Href: pageURL(page + 1), Type: typeAcquisition})
+	}
+	for _, b := range books[start:end] {
+		f.Entries = append(f.Entries, bookEntry(b, c.tags[b.ID], c.status[b.ID]))
+	}
+	s.writeFeed(w, typeAcquisition, f)
+}
+
+func (c *catalog) filter(keep func(*domain.Book) bool) []*domain.Book {
+	var out []*domain.Book
+	for _, b := range c.books {
+		if keep(b) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (s *Server) all(w http.ResponseWriter, r *http.Request) {
+	if c := s.loadOrFail(w, r); c != nil {
+		s.acquisitionFeed(w, r, c, "urn:orus:all", "Tous les livres", c.books)
+	}
+}
+
+func (s *Server) recent(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	books := append([]*domain.Book(nil), c.books...)
+	sort.SliceStable(books, func(i, j int) bool { return books[i].AddedAt.After(books[j].AddedAt) })
+	s.acquisitionFeed(w, r, c, "urn:orus:recent", "Derniers ajouts", books[:min(recentCount, len(books))])
+}
+
+func (s *Server) byAuthor(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	name := r.PathValue("name")
+	books := c.filter(func(b *domain.Book) bool { return authorOf(b) == name })
+	s.acquisitionFeed(w, r, c, "urn:orus:authors:"+url.PathEscape(name), name, books)
+}
+
+func (s *Server) byTag(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	tag := r.PathValue("tag")
+	books := c.filter(func(b *domain.Book) bool {
+		for _, t := range c.tags[b.ID] {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	})
+	s.acquisitionFeed(w, r, c, "urn:orus:tags:"+url.PathEscape(tag), tag, books)
+}
+
+func (s *Server) byStatus(w http.ResponseWriter, r *http.Request) {
+	status := r.PathValue("status")
+	if statusLabels[status] == "" {
+		http.NotFound(w, r)
+		return
+	}
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	books := c.filter(func(b *domain.Book) bool { return c.status[b.ID] == status })
+	s.acquisitionFeed(w, r, c, "urn:orus:status:"+status, statusLabels[status], books)
+}
+
+// search matches every word of ?q= against the title, author, series,
+// ISBN and tags of the books, ignoring case.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return
+	}
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	words := strings.Fields(strings.ToLower(q))
+	books := c.filter(func(b *domain.Book) bool {
+		text := strings.ToLower(strings.Join(append([]string{b.Title, b.Author, b.Series, b.ISBN}, c.tags[b.ID]...), " "))
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				return false
+			}
+		}
+		return len(words) > 0
+	})
+	s.acquisitionFeed(w, r, c, "urn:orus:search:"+url.QueryEscape(q), "Recherche : "+q, books)
+}
+
+func (s *Server) openSearch(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", typeOpenSearch+";charset=utf-8")
+	fmt.Fprintf(w, `%s<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/">
+  <ShortName>Orus</ShortName>
+  <Description>Recherche dans la bibliotheque Orus</Description>
+  <InputEncoding>UTF-8</InputEncoding>
+  <OutputEncoding>UTF-8</OutputEncoding>
+  <Url type="%s" template="/opds/search?q={searchTerms}"/>
+</OpenSearchDescription>
+`, xml.Header, typeAcquisition)
+}
+
+// --- Files and covers ---
+
+func (s *Server) book(w http.ResponseWriter, r *http.Request) *domain.Book {
+	c := s.loadOrFail(w, r)
+	if c == nil {
+		return nil
+	}
+	b := c.byID[r.PathValue("id")]
+	if b == nil {
+		http.NotFound(w, r)
+	}
+	return b
+}
+
+// file streams the book from Book.FilePath, with range requests for the
+// readers that resume interrupted downloads.
+func (s *Server) file(w http.ResponseWriter, r *http.Request) {
+	b := s.book(w, r)
+	if b == nil {
+		return
+	}
+	f, err := os.Open(b.FilePath)
+	if err != nil {
+		s.logger.Warn("book file unavailable", "book_id", b.ID, "path", b.FilePath, "error", err)
+		http.Error(w, "fichier introuvable sur cet ordinateur", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.Error(w, "fichier illisible", http.StatusInternalServerError)
+		return
+	}
+	name := filepath.Base(b.FilePath)
+	w.Header().Set("Content-Type", mimeType(b))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(name)))
+	http.ServeContent(w, r, name, info.ModTime(), f)
+}
+
+func (s *Server) cover(w http.ResponseWriter, r *http.Request) {
+	b := s.book(w, r)
+	if b == nil {
+		return
+	}
+	if len(b.CoverImage) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(b.CoverImage))
+	http.ServeContent(w, r, "", b.UpdatedAt, bytes.NewReader(b.CoverImage))
+}
+
+func (s *Server) thumbnail(w http.ResponseWriter, r *http.Request) {
+	b := s.book(w, r)
+	if b == nil {
+		return
+	}
+	s.mu.Lock()
+	cached, ok := s.thumbs[b.ID]
+	s.mu.Unlock()
+	// Une couverture modifiée remplace la vignette du livre. UpdatedAt ne
+	// suffit pas : le stockage SQLite ne le conserve pas pour les livres.
+	sum := crc32.ChecksumIEEE(b.CoverImage)
+	if !ok || cached.sum != sum {
+		cached = coverThumb{sum: sum, jpeg: scaleCover(b.CoverImage)}
+		s.mu.Lock()
+		s.thumbs[b.ID] = cached
+		s.mu.Unlock()
+	}
+	thumb := cached.jpeg
+	if thumb == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	http.ServeContent(w, r, "", b.UpdatedAt, bytes.NewReader(thumb))
+}
+
+// scaleCover returns a JPEG no taller than thumbnailHeight, or nil when the
+// cover cannot be decoded.
+func scaleCover(cover []byte) []byte {
+	if len(cover) == 0 {
+		return nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(cover))
+	if err != nil {
+		return nil
+	}
+	b := src.Bounds()
+	if b.Dy() > thumbnailHeight {
+		w := b.Dx() * thumbnailHeight / b.Dy()
+		dst := image.NewRGBA(image.Rect(0, 0, max(w, 1), thumbnailHeight))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+		src = dst
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: 80}); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,303 @@
+package opds_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/opds"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+var fakeNow = time.Date(2026, 3, 10, 21, 0, 0, 0, time.UTC)
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomFeed struct {
+	Title   string     `xml:"title"`
+	Total   int        `xml:"totalResults"`
+	Links   []atomLink `xml:"link"`
+	Entries []struct {
+		Title      string `xml:"title"`
+		Author     string `xml:"author>name"`
+		Content    string `xml:"content"`
+		Categories []struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+		Links []atomLink `xml:"link"`
+	} `xml:"entry"`
+}
+
+func (f *atomFeed) link(rel string) string {
+	for _, l := range f.Links {
+		if l.Rel == rel {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+type library struct {
+	t     *testing.T
+	store *sqlite.Storage
+	srv   *httptest.Server
+	dir   string
+}
+
+func newLibrary(t *testing.T) *library {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := sqlite.NewStorage(filepath.Join(dir, "orus.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	clk := clock.NewFakeClock(fakeNow)
+	server, err := opds.NewServer(
+		service.NewLibraryService(store, store, store, nil, nil, clk),
+		service.NewTrackerService(store, store, clk),
+		service.NewReadingSheetService(store, store, clk),
+		opds.Username, "liseuse", nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	return &library{t: t, store: store, srv: srv, dir: dir}
+}
+
+// addBook stores a book whose file holds content.
+func (l *library) addBook(title, author, content string, added time.Time) *domain.Book {
+	l.t.Helper()
+	path := filepath.Join(l.dir, title+".epub")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		l.t.Fatal(err)
+	}
+	b, err := domain.NewBook(title, author, path, domain.FormatEPUB, 200, added)
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	if err := l.store.Save(context.Background(), b); err != nil {
+		l.t.Fatal(err)
+	}
+	return b
+}
+
+func (l *library) get(path string, header map[string]string) *http.Response {
+	l.t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, l.srv.URL+path, nil)
+	req.SetBasicAuth(opds.Username, "liseuse")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	return resp
+}
+
+func (l *library) feed(path string) *atomFeed {
+	l.t.Helper()
+	resp := l.get(path, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(resp.Header.Get("Content-Type"), "profile=opds-catalog") {
+		l.t.Fatalf("GET %s: %d %s", path, resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	var f atomFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&f); err != nil {
+		l.t.Fatalf("GET %s: invalid Atom: %v", path, err)
+	}
+	return &f
+}
+
+func TestOPDS_RequiresCredentials(t *testing.T) {
+	lib := newLibrary(t)
+	for _, pass := range []string{"", "faux"} {
+		req, _ := http.NewRequest(http.MethodGet, lib.srv.URL+"/opds", nil)
+		if pass != "" {
+			req.SetBasicAuth(opds.Username, pass)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(resp.Header.Get("WWW-Authenticate"), "Basic") {
+			t.Errorf("password %q: expected a basic auth challenge, got %d", pass, resp.StatusCode)
+		}
+	}
+	if _, err := opds.NewServer(nil, nil, nil, opds.Username, "", nil); err == nil {
+		t.Error("expected an empty password to be refused")
+	}
+}
+
+func TestOPDS_NavigationAndAcquisition(t *testing.T) {
+	ctx := context.Background()
+	lib := newLibrary(t)
+	dune := lib.addBook("Dune", "Frank Herbert", "contenu de dune", fakeNow)
+	lib.addBook("Les Enfants de Dune", "Frank Herbert", "suite", fakeNow.Add(time.Hour))
+	lib.addBook("Fondation", "Isaac Asimov", "psychohistoire", fakeNow.Add(2*time.Hour))
+	sheet, _ := domain.NewReadingSheet(dune.ID, dune.Title, "", 5, nil, []string{"science-fiction", "classique"}, fakeNow)
+	_ = lib.store.SaveSheet(ctx, sheet)
+	ses, _ := domain.NewSession(dune.ID, 200, 50, fakeNow)
+	_ = lib.store.SaveSession(ctx, ses)
+
+	root := lib.feed("/opds")
+	if len(root.Entries) != 5 || root.link("search") != "/opds/opensearch.xml" || root.link("start") != "/opds" {
+		t.Fatalf("unexpected root feed %+v", root)
+	}
+
+	authors := lib.feed("/opds/authors")
+	if len(authors.Entries) != 2 || authors.Entries[0].Title != "Frank Herbert" || authors.Entries[0].Content != "2 livre(s)" {
+		t.Fatalf("unexpected authors feed %+v", authors.Entries)
+	}
+	herbert := lib.feed(authors.Entries[0].Links[0].Href)
+	if len(herbert.Entries) != 2 || herbert.Entries[0].Title != "Dune" || herbert.Entries[0].Author != "Frank Herbert" {
+		t.Fatalf("unexpected author feed %+v", herbert.Entries)
+	}
+	acq := herbert.Entries[0].Links[0]
+	if acq.Rel != "http://opds-spec.org/acquisition" || acq.Type != "application/epub+zip" {
+		t.Fatalf("unexpected acquisition link %+v", acq)
+	}
+
+	resp := lib.get(acq.Href, nil)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "contenu de dune" || !strings.Contains(resp.Header.Get("Content-Disposition"), "Dune.epub") {
+		t.Errorf("expected the book file, got %q (%s)", body, resp.Header.Get("Content-Disposition"))
+	}
+	resp = lib.get(acq.Href, map[string]string{"Range": "bytes=0-6"})
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent || string(body) != "contenu" {
+		t.Errorf("expected a partial download, got %d %q", resp.StatusCode, body)
+	}
+
+	tags := lib.feed("/opds/tags")
+	if len(tags.Entries) != 2 || tags.Entries[0].Title != "classique" {
+		t.Fatalf("unexpected tags feed %+v", tags.Entries)
+	}
+	if sf := lib.feed("/opds/tags/science-fiction"); len(sf.Entries) != 1 || len(sf.Entries[0].Categories) != 2 {
+		t.Errorf("expected Dune with its tags, got %+v", sf.Entries)
+	}
+
+	if reading := lib.feed("/opds/status/reading"); len(reading.Entries) != 1 || !strings.Contains(reading.Entries[0].Content, "En cours") {
+		t.Errorf("expected Dune in progress, got %+v", reading.Entries)
+	}
+	if unread := lib.feed("/opds/status/unread"); len(unread.Entries) != 2 {
+		t.Errorf("expected two unread books, got %d", len(unread.Entries))
+	}
+	if recent := lib.feed("/opds/recent"); recent.Entries[0].Title != "Fondation" {
+		t.Errorf("expected the latest import first, got %q", recent.Entries[0].Title)
+	}
+	if resp := lib.get("/opds/books/absent/file", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown book, got %d", resp.StatusCode)
+	}
+}
+
+func TestOPDS_Search(t *testing.T) {
+	lib := newLibrary(t)
+	lib.addBook("Dune", "Frank Herbert", "x", fakeNow)
+	lib.addBook("Fondation", "Isaac Asimov", "x", fakeNow)
+
+	resp := lib.get("/opds/opensearch.xml", nil)
+	desc, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(desc), `template="/opds/search?q={searchTerms}"`) {
+		t.Fatalf("unexpected OpenSearch description %s", desc)
+	}
+	if f := lib.feed("/opds/search?q=asimov+FOND"); len(f.Entries) != 1 || f.Entries[0].Title != "Fondation" || f.Total != 1 {
+		t.Errorf("expected Fondation, got %+v", f.Entries)
+	}
+	if f := lib.feed("/opds/search?q="); len(f.Entries) != 0 {
+		t.Errorf("expected no result for an empty query, got %d", len(f.Entries))
+	}
+}
+
+func TestOPDS_Pagination(t *testing.T) {
+	lib := newLibrary(t)
+	for i := 0; i < 60; i++ {
+		lib.addBook(fmt.Sprintf("Livre %02d", i), "Auteur", "x", fakeNow)
+	}
+	first := lib.feed("/opds/all")
+	if len(first.Entries) != 50 || first.Total != 60 || first.link("next") == "" || first.link("previous") != "" {
+		t.Fatalf("unexpected first page: %d entries, links %+v", len(first.Entries), first.Links)
+	}
+	second := lib.feed(first.link("next"))
+	if len(second.Entries) != 10 || second.Entries[9].Title != "Livre 59" || second.link("next") != "" {
+		t.Errorf("unexpected second page: %d entries", len(second.Entries))
+	}
+}
+
+func TestOPDS_Covers(t *testing.T) {
+	lib := newLibrary(t)
+	img := image.NewRGBA(image.Rect(0, 0, 300, 600))
+	for y := 0; y < 600; y++ {
+		for x := 0; x < 300; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 120, B: 40, A: 255})
+		}
+	}
+	var cover bytes.Buffer
+	_ = png.Encode(&cover, img)
+	b := lib.addBook("Dune", "Frank Herbert", "x", fakeNow)
+	b.CoverImage = cover.Bytes()
+	_ = lib.store.Save(context.Background(), b)
+	lib.addBook("Sans couverture", "Anonyme", "x", fakeNow)
+
+	all := lib.feed("/opds/all")
+	var thumbURL string
+	for _, l := range all.Entries[0].Links {
+		if l.Rel == "http://opds-spec.org/image/thumbnail" {
+			thumbURL = l.Href
+		}
+	}
+	if thumbURL == "" || len(all.Entries[1].Links) != 1 {
+		t.Fatalf("expected cover links only on the book with a cover, got %+v", all.Entries)
+	}
+
+	resp := lib.get(thumbURL, nil)
+	defer resp.Body.Close()
+	thumb, err := jpeg.Decode(resp.Body)
+	if err != nil || thumb.Bounds().Dy() != 200 || thumb.Bounds().Dx() != 100 {
+		t.Fatalf("expected a 100x200 JPEG thumbnail, got %v, %v", thumb, err)
+	}
+
+	// Une nouvelle couverture remplace la vignette en cache.
+	wide := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	cover.Reset()
+	_ = png.Encode(&cover, wide)
+	b.CoverImage = cover.Bytes()
+	_ = lib.store.Save(context.Background(), b)
+	again := lib.get(thumbURL, nil)
+	defer again.Body.Close()
+	if thumb, err := jpeg.Decode(again.Body); err != nil || thumb.Bounds().Dx() != 400 {
+		t.Fatalf("expected the 400x200 thumbnail of the new cover, got %v, %v", thumb.Bounds(), err)
+	}
+	full := lib.get(strings.Replace(thumbURL, "thumbnail", "cover", 1), nil)
+	full.Body.Close()
+	if full.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("expected the original PNG cover, got %s", full.Header.Get("Content-Type"))
+	}
+}
@@ -0,0 +1,333 @@
+// Package sharecard draws the PNG share cards (quotes, finished books)
+// offscreen, in pure Go: no window nor GPU is needed, so cards can be
+// rendered from tests and background goroutines alike.
+package sharecard
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// Cards use the 1200x630 size social networks expect for link previews.
+const (
+	Width  = 1200
+	Height = 630
+
+	margin = 80
+)
+
+var _ port.CardRenderer = (*Renderer)(nil)
+
+// Renderer draws share cards with the Go fonts and the Mecha-Egyptian palette.
+type Renderer struct {
+	regular, bold, italic *opentype.Font
+	mu                    sync.Mutex // protège faces, les font.Face n'étant pas concurrentes
+	faces                 map[faceKey]font.Face
+}
+
+type faceKey struct {
+	f    *opentype.Font
+	size float64
+}
+
+// NewRenderer parses the embedded Go fonts.
+func NewRenderer() (*Renderer, error) {
+	r := &Renderer{faces: make(map[faceKey]font.Face)}
+	for _, f := range []struct {
+		dst *(*opentype.Font)
+		ttf []byte
+	}{{&r.regular, goregular.TTF}, {&r.bold, gobold.TTF}, {&r.italic, goitalic.TTF}} {
+		parsed, err := opentype.Parse(f.ttf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse font: %w", err)
+		}
+		*f.dst = parsed
+	}
+	return r, nil
+}
+
+// RenderQuoteCard draws a quote with its book and page reference. Long
+// quotes are set smaller, then cut with an ellipsis.
+func (r *Renderer) RenderQuoteCard(card port.QuoteCard) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	img := r.canvas()
+
+	// Guillemet décoratif en fond.
+	r.text(img, r.bold, 220, margin-10, 230, "“", theme.WithAlpha(theme.ColorSandGold, 70))
+
+	textWidth := Width - 2*margin
+	var lines []string
+	size := 0.0
+	for _, size = range []float64{46, 40, 34, 28} {
+		lines = r.wrap(r.face(r.italic, size), card.Quote, textWidth)
+		if float64(len(lines))*size*1.3 <= 300 {
+			break
+		}
+	}
+	maxLines := int(300 / (size * 1.3))
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = r.ellipsis(r.face(r.italic, size), lines[maxLines-1]+"…", textWidth)
+	}
+	y := 170 + int(size)
+	for _, line := range lines {
+		r.text(img, r.italic, size, margin, y, line, theme.ColorGlassWhite)
+		y += int(size * 1.3)
+	}
+
+	fillRect(img, image.Rect(margin, 500, margin+64, 504), theme.ColorSandGold)
+	source := r.ellipsis(r.face(r.bold, 28), card.BookTitle, Width-2*margin-160)
+	r.text(img, r.bold, 28, margin, 548, source, theme.ColorSandGold)
+	var ref []string
+	if card.Author != "" {
+		ref = append(ref, card.Author)
+	}
+	if card.Page > 0 {
+		ref = append(ref, "p. "+strconv.Itoa(card.Page))
+	}
+	r.text(img, r.regular, 22, margin, 584, strings.Join(ref, " · "), theme.WithAlpha(theme.ColorGlassWhite, 170))
+
+	r.signature(img)
+	return encodePNG(img)
+}
+
+// RenderFinishedCard draws the "book finished" card: title, rating and up to
+// four statistics tiles.
+func (r *Renderer) RenderFinishedCard(card port.FinishedCard) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	img := r.canvas()
+
+	r.spaced(img, r.bold, 22, margin, 110, "LIVRE TERMINÉ", 4, theme.ColorSandGold)
+
+	titleLines := r.wrap(r.face(r.bold, 52), card.BookTitle, Width-2*margin)
+	if len(titleLines) > 2 {
+		titleLines = titleLines[:2]
+		titleLines[1] = r.ellipsis(r.face(r.bold, 52), titleLines[1]+"…", Width-2*margin)
+	}
+	y := 180
+	for _, line := range titleLines {
+		r.text(img, r.bold, 52, margin, y, line, theme.ColorGlassWhite)
+		y += 62
+	}
+	if card.Author != "" {
+		r.text(img, r.regular, 28, margin, y, card.Author, theme.WithAlpha(theme.ColorGlassWhite, 180))
+	}
+	if card.Rating > 0 {
+		for i := 0; i < 5; i++ {
+			c := theme.ColorSandGold
+			if i >= card.Rating {
+				c = theme.WithAlpha(theme.ColorSandGold, 60)
+			}
+			fillStar(img, float32(Width-margin-5*44+i*44+20), 104, 18, c)
+		}
+	}
+
+	type tile struct{ value, label string }
+	var tiles []tile
+	if card.Pages > 0 {
+		tiles = append(tiles, tile{strconv.Itoa(card.Pages), "pages"})
+	}
+	if card.Sessions > 0 {
+		tiles = append(tiles, tile{strconv.Itoa(card.Sessions), plural(card.Sessions, "séance", "séances")})
+	}
+	if m := int(card.ReadingTime.Minutes()); m > 0 {
+		value := strconv.Itoa(m) + " min"
+		if m >= 60 {
+			value = fmt.Sprintf("%dh%02d", m/60, m%60)
+		}
+		tiles = append(tiles, tile{value, "de lecture"})
+	}
+	if card.Days > 0 {
+		tiles = append(tiles, tile{strconv.Itoa(card.Days), plural(card.Days, "jour", "jours")})
+	}
+	if len(tiles) > 0 {
+		gap := 24
+		w := (Width - 2*margin - gap*(len(tiles)-1)) / len(tiles)
+		for i, t := range tiles {
+			x := margin + i*(w+gap)
+			fillRoundedRect(img, image.Rect(x, 360, x+w, 500), 16, theme.ColorCyberCyan)
+			fillRect(img, image.Rect(x, 376, x+4, 484), theme.ColorSandGold)
+			r.text(img, r.bold, 44, x+28, 432, t.value, theme.ColorSandGold)
+			r.text(img, r.regular, 22, x+28, 470, t.label, theme.WithAlpha(theme.ColorGlassWhite, 200))
+		}
+	}
+
+	if !card.FinishedAt.IsZero() {
+		r.text(img, r.regular, 22, margin, 574, "Terminé le "+card.FinishedAt.Format("02/01/2006"),
+			theme.WithAlpha(theme.ColorGlassWhite, 170))
+	}
+	r.signature(img)
+	return encodePNG(img)
+}
+
+// canvas returns the card background: the void colour, a gold band on the
+// left edge and a thin frame.
+func (r *Renderer) canvas() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fillRect(img, img.Bounds(), theme.ColorVoidDark)
+	fillRect(img, image.Rect(0, 0, 12, Height), theme.ColorSandGold)
+	fillRect(img, image.Rect(32, 32, Width-32, 34), theme.ColorCyberCyan)
+	fillRect(img, image.Rect(32, Height-34, Width-32, Height-32), theme.ColorCyberCyan)
+	return img
+}
+
+// signature writes the app name in the bottom-right corner.
+func (r *Renderer) signature(img *image.RGBA) {
+	const label = "ORUS"
+	w := r.spacedWidth(r.face(r.bold, 24), label, 6)
+	r.spaced(img, r.bold, 24, Width-margin-w, 584, label, 6, theme.ColorSandGold)
+}
+
+func (r *Renderer) face(f *opentype.Font, size float64) font.Face {
+	key := faceKey{f, size}
+	if face, ok := r.faces[key]; ok {
+		return face
+	}
+	// Sans hinting, le rendu ne dépend que des contours : les golden files
+	// restent stables.
+	face, _ := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	r.faces[key] = face
+	return face
+}
+
+// text draws s with its baseline at (x, y).
+func (r *Renderer) text(img draw.Image, f *opentype.Font, size float64, x, y int, s string, c color.NRGBA) {
+	d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: r.face(f, size), Dot: fixed.P(x, y)}
+	d.DrawString(s)
+}
+
+// spaced draws s with extra tracking between letters.
+func (r *Renderer) spaced(img draw.Image, f *opentype.Font, size float64, x, y int, s string, tracking int, c color.NRGBA) {
+	d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: r.face(f, size), Dot: fixed.P(x, y)}
+	for _, ch := range s {
+		d.DrawString(string(ch))
+		d.Dot.X += fixed.I(tracking)
+	}
+}
+
+func (r *Renderer) spacedWidth(face font.Face, s string, tracking int) int {
+	return font.MeasureString(face, s).Ceil() + tracking*(utf8.RuneCountInString(s)-1)
+}
+
+// wrap breaks s into lines at most width pixels wide. Words longer than a
+// line are kept whole.
+func (r *Renderer) wrap(face font.Face, s string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ellipsis shortens s until it fits in width, ending it with "…". Callers
+// append "…" themselves to mark a line cut by the wrapping.
+func (r *Renderer) ellipsis(face font.Face, s string, width int) string {
+	if font.MeasureString(face, s).Ceil() <= width && !strings.HasSuffix(s, "…") {
+		return s
+	}
+	runes := []rune(strings.TrimSuffix(s, "…"))
+	for len(runes) > 0 {
+		candidate := strings.TrimRight(string(runes), " ,;:") + "…"
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+		runes = runes[:len(runes)-1]
+	}
+	return "…"
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+func fillRect(img draw.Image, rect image.Rectangle, c color.NRGBA) {
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+// fillRoundedRect fills rect with corners of radius rad.
+func fillRoundedRect(img draw.Image, rect image.Rectangle, rad float32, c color.NRGBA) {
+	z := vector.NewRasterizer(Width, Height)
+	x0, y0, x1, y1 := float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Max.X), float32(rect.Max.Y)
+	z.MoveTo(x0+rad, y0)
+	z.LineTo(x1-rad, y0)
+	z.QuadTo(x1, y0, x1, y0+rad)
+	z.LineTo(x1, y1-rad)
+	z.QuadTo(x1, y1, x1-rad, y1)
+	z.LineTo(x0+rad, y1)
+	z.QuadTo(x0, y1, x0, y1-rad)
+	z.LineTo(x0, y0+rad)
+	z.QuadTo(x0, y0, x0+rad, y0)
+	z.ClosePath()
+	z.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{})
+}
+
+// fillStar fills a five-pointed star centred on (cx, cy). The Go fonts have
+// no star glyph, so rating stars are drawn as polygons.
+func fillStar(img draw.Image, cx, cy, radius float32, c color.NRGBA) {
+	z := vector.NewRasterizer(Width, Height)
+	for i := 0; i < 10; i++ {
+		rad := radius
+		if i%2 == 1 {
+			rad = radius * 0.45
+		}
+		angle := -math.Pi/2 + float64(i)*math.Pi/5
+		x := cx + rad*float32(math.Cos(angle))
+		y := cy + rad*float32(math.Sin(angle))
+		if i == 0 {
+			z.MoveTo(x, y)
+		} else {
+			z.LineTo(x, y)
+		}
+	}
+	z.ClosePath()
+	z.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{})
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+package sharecard_test
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/sharecard"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden PNG files in testdata/golden")
+
+func TestRenderer_GoldenCards(t *testing.T) {
+	r, err := sharecard.NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		render func() ([]byte, error)
+	}{
+		{"quote", func() ([]byte, error) {
+			return r.RenderQuoteCard(port.QuoteCard{
+				Quote:     "Je ne connaîtrai pas la peur, car la peur tue l'esprit. La peur est la petite mort qui conduit à l'oblitération totale.",
+				BookTitle: "Dune", Author: "Frank Herbert", Page: 42,
+			})
+		}},
+		{"quote_long", func() ([]byte, error) {
+			long := ""
+			for i := 0; i < 30; i++ {
+				long += "Une phrase qui ne finit jamais vraiment, "
+			}
+			return r.RenderQuoteCard(port.QuoteCard{Quote: long, BookTitle: "Un titre"})
+		}},
+		{"finished", func() ([]byte, error) {
+			return r.RenderFinishedCard(port.FinishedCard{
+				BookTitle: "Le Seigneur des Anneaux : La Communauté de l'Anneau", Author: "J. R. R. Tolkien",
+				Pages: 576, Sessions: 14, ReadingTime: 9*time.Hour + 25*time.Minute, Days: 12, Rating: 4,
+				FinishedAt: time.Date(2026, 3, 10, 21, 0, 0, 0, time.UTC),
+			})
+		}},
+		{"finished_minimal", func() ([]byte, error) {
+			return r.RenderFinishedCard(port.FinishedCard{BookTitle: "Notes", Pages: 12})
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.render()
+			if err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			golden := filepath.Join("testdata", "golden", tc.name+".png")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, data, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("expected a valid PNG, got: %v", err)
+			}
+			if b := got.Bounds(); b.Dx() != sharecard.Width || b.Dy() != sharecard.Height {
+				t.Fatalf("expected %dx%d, got %dx%d", sharecard.Width, sharecard.Height, b.Dx(), b.Dy())
+			}
+			raw, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("missing golden file (run with -update): %v", err)
+			}
+			want, _ := png.Decode(bytes.NewReader(raw))
+			if n := diffPixels(got, want); n > sharecard.Width*sharecard.Height/1000 {
+				t.Errorf("%d pixels differ from %s", n, golden)
+			}
+		})
+	}
+}
+
+// diffPixels counts the pixels whose channels differ by more than a few
+// levels, so that floating-point rounding across platforms is tolerated.
+func diffPixels(a, b image.Image) int {
+	n := 0
+	for y := 0; y < sharecard.Height; y++ {
+		for x := 0; x < sharecard.Width; x++ {
+			r1, g1, b1, _ := a.At(x, y).RGBA()
+			r2, g2, b2, _ := b.At(x, y).RGBA()
+			if absDiff(r1, r2) > 8<<8 || absDiff(g1, g2) > 8<<8 || absDiff(b1, b2) > 8<<8 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.AnnotationRepository = (*Storage)(nil)
+
+func (s *Storage) SaveAnnotation(ctx context.Context, annotation *domain.Annotation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.bookExists(annotation.BookID) {
+		return fmt.Errorf("failed to save annotation: %w", domain.ErrBookNotFound)
+	}
+	for _, a := range s.annotations {
+		if a.ID == annotation.ID {
+			return fmt.Errorf("failed to save annotation: %w", ErrDuplicateID)
+		}
+	}
+	c := *annotation
+	s.annotations = append(s.annotations, &c)
+	return nil
+}
+
+// GetAnnotationByPage returns the annotations of a page of a book.
+func (s *Storage) GetAnnotationByPage(ctx context.Context, pageNo int, bookId string) ([]*domain.Annotation, error) {
+	return s.listAnnotations(ctx, func(a *domain.Annotation) bool { return a.PageNo == pageNo && a.BookID == bookId })
+}
+
+// GetAnnotationByType returns the annotations of a type, across books.
+func (s *Storage) GetAnnotationByType(ctx context.Context, annotationType string) ([]*domain.Annotation, error) {
+	return s.listAnnotations(ctx, func(a *domain.Annotation) bool { return string(a.AnnotationType) == annotationType })
+}
+
+func (s *Storage) DeleteAnnotation(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.annotations = remove(s.annotations, func(a *domain.Annotation) bool { return a.ID == id })
+	return nil
+}
+
+// ListAllAnnotationOfABook returns all of the annotations of a book.
+func (s *Storage) ListAllAnnotationOfABook(ctx context.Context, bookID string) ([]*domain.Annotation, error) {
+	return s.listAnnotations(ctx, func(a *domain.Annotation) bool { return a.BookID == bookID })
+}
+
+// listAnnotations copies the annotations matching keep, in order of addition.
+func (s *Storage) listAnnotations(ctx context.Context, keep func(*domain.Annotation) bool) ([]*domain.Annotation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*domain.Annotation
+	for _, a := range s.annotations {
+		if keep(a) {
+			c := *a
+			out = append(out, &c)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.BookRepository = (*Storage)(nil)
+
+// Save adds book, or updates the stored one like the SQLite upsert: the
+// author, format, page count and date of addition of an existing book are
+// kept, and an empty cover never erases the stored one.
+func (s *Storage) Save(ctx context.Context, book *domain.Book) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.bookIndex(book.ID)
+	if i < 0 {
+		s.books = append(s.books, cloneBook(book))
+		return nil
+	}
+	stored := s.books[i]
+	stored.Title, stored.FilePath = book.Title, book.FilePath
+	stored.Series, stored.SeriesIndex = book.Series, book.SeriesIndex
+	stored.ISBN, stored.Publisher, stored.Year = book.ISBN, book.Publisher, book.Year
+	if len(book.CoverImage) > 0 {
+		stored.CoverImage = append([]byte(nil), book.CoverImage...)
+	}
+	return nil
+}
+
+func (s *Storage) GetByID(ctx context.Context, id string) (*domain.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.bookIndex(id)
+	if i < 0 {
+		return nil, domain.ErrBookNotFound
+	}
+	return cloneBook(s.books[i]), nil
+}
+
+func (s *Storage) ListAll(ctx context.Context) ([]*domain.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var books []*domain.Book
+	for _, b := range s.books {
+		books = append(books, cloneBook(b))
+	}
+	return books, nil
+}
+
+// Delete removes a book with its sessions, annotations and reading sheets,
+// like the ON DELETE CASCADE of the SQLite schema. Reminders are kept.
+func (s *Storage) Delete(ctx context.Context, bookId string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.books = remove(s.books, func(b *domain.Book) bool { return b.ID == bookId })
+	s.sessions = remove(s.sessions, func(r *domain.ReadingSession) bool { return r.BookID == bookId })
+	s.annotations = remove(s.annotations, func(a *domain.Annotation) bool { return a.BookID == bookId })
+	s.sheets = remove(s.sheets, func(sh *domain.ReadingSheet) bool { return sh.BookID == bookId })
+	return nil
+}
+
+// bookIndex returns the position of bookID in s.books, or -1. The caller
+// holds mu.
+func (s *Storage) bookIndex(bookID string) int {
+	for i, b := range s.books {
+		if b.ID == bookID {
+			return i
+		}
+	}
+	return -1
+}
+
+// cloneBook copies what the books table keeps: UpdatedAt is not stored and an
+// empty cover reads back as nil.
+func cloneBook(b *domain.Book) *domain.Book {
+	c := *b
+	c.UpdatedAt = time.Time{}
+	c.CoverImage = nil
+	if len(b.CoverImage) > 0 {
+		c.CoverImage = append([]byte(nil), b.CoverImage...)
+	}
+	return &c
+}
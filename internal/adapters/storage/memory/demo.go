@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// demoBook describes a book of the demo library and how far it was read.
+type demoBook struct {
+	title, author string
+	format        domain.BookFormat
+	pages, year   int
+	series        string
+	seriesIndex   float64
+	addedDaysAgo  int
+	// readDays lists, oldest first, how many days ago each session took place
+	// and the page it ended on; nil leaves the book unread.
+	readDays [][2]int
+}
+
+var demoBooks = []demoBook{
+	{title: "Le Comte de Monte-Cristo", author: "Alexandre Dumas", format: domain.FormatEPUB, pages: 1312, year: 1844, addedDaysAgo: 40,
+		readDays: [][2]int{{13, 402}, {11, 468}, {9, 530}, {8, 601}, {6, 655}, {4, 720}, {3, 781}, {1, 874}}},
+	{title: "Madame Bovary", author: "Gustave Flaubert", format: domain.FormatEPUB, pages: 478, year: 1857, addedDaysAgo: 60,
+		readDays: [][2]int{{26, 120}, {22, 260}, {18, 390}, {12, 478}}},
+	{title: "Vingt mille lieues sous les mers", author: "Jules Verne", format: domain.FormatEPUB, pages: 560, year: 1870,
+		series: "Voyages extraordinaires", seriesIndex: 6, addedDaysAgo: 20, readDays: [][2]int{{7, 95}, {5, 160}, {2, 212}}},
+	{title: "Germinal", author: "Emile Zola", format: domain.FormatPDF, pages: 592, year: 1885, addedDaysAgo: 90,
+		readDays: [][2]int{{45, 200}, {38, 410}, {31, 592}}},
+	{title: "Candide", author: "Voltaire", format: domain.FormatPDF, pages: 160, year: 1759, addedDaysAgo: 3,
+		readDays: [][2]int{{0, 40}}},
+	{title: "Les Miserables", author: "Victor Hugo", format: domain.FormatEPUB, pages: 1900, year: 1862, addedDaysAgo: 1},
+	{title: "Le Tour du monde en quatre-vingts jours", author: "Jules Verne", format: domain.FormatEPUB, pages: 320, year: 1872,
+		series: "Voyages extraordinaires", seriesIndex: 11, addedDaysAgo: 1},
+}
+
+// NewDemo returns a Storage holding a small library of public domain books,
+// read over the weeks before now, for screenshots and first steps. The books
+// have no file on disk: they can be browsed but not opened.
+func NewDemo(now time.Time) *Storage {
+	s := NewStorage()
+	if err := s.seedDemo(context.Background(), now); err != nil {
+		// Les données sont fixes : une erreur est un bogue de ce fichier.
+		panic(fmt.Sprintf("memory: invalid demo data: %v", err))
+	}
+	return s
+}
+
+func (s *Storage) seedDemo(ctx context.Context, now time.Time) error {
+	day := func(daysAgo, hour, minute int) time.Time {
+		d := now.AddDate(0, 0, -daysAgo)
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, now.Location())
+	}
+	books := make(map[string]*domain.Book)
+	for _, db := range demoBooks {
+		book, err := domain.NewBook(db.title, db.author, "demo/"+db.title, db.format, db.pages, day(db.addedDaysAgo, 18, 0))
+		if err != nil {
+			return err
+		}
+		book.Series, book.SeriesIndex, book.Year = db.series, db.seriesIndex, db.year
+		if err := s.Save(ctx, book); err != nil {
+			return err
+		}
+		books[db.title] = book
+		for _, read := range db.readDays {
+			// Une demi-heure de lecture le soir, jamais dans le futur.
+			end := day(read[0], 21, 40)
+			if end.After(now) {
+				end = now
+			}
+			ses, err := domain.NewSession(book.ID, db.pages, read[1], end.Add(-35*time.Minute))
+			if err != nil {
+				return err
+			}
+			ses.LastReadingTime = end
+			if err := s.SaveSession(ctx, ses); err != nil {
+				return err
+			}
+		}
+	}
+
+	sheets := []struct {
+		title, summary string
+		rating         int
+		quotes, tags   []string
+		daysAgo        int
+	}{
+		{"Madame Bovary", "Emma s'ennuie a Yonville et cherche dans les romans, puis dans ses amants, la vie qu'elle avait revee.", 4,
+			[]string{"Elle souhaitait a la fois mourir et habiter Paris.", "Il ne faut pas toucher aux idoles : la dorure en reste aux mains."},
+			[]string{"classique", "realisme"}, 12},
+		{"Germinal", "La greve des mineurs de Montsou, racontee depuis le fond de la fosse du Voreux.", 5,
+			[]string{"Des hommes poussaient, une armee noire, vengeresse, qui germait lentement dans les sillons."},
+			[]string{"classique", "naturalisme"}, 30},
+		{"Le Comte de Monte-Cristo", "Trahi le jour de ses fiancailles, Edmond Dantes s'evade du chateau d'If.", 0,
+			[]string{"Attendre et esperer."}, []string{"aventure"}, 2},
+	}
+	for _, sh := range sheets {
+		book := books[sh.title]
+		sheet, err := domain.NewReadingSheet(book.ID, book.Title, sh.summary, sh.rating, sh.quotes, sh.tags, day(sh.daysAgo, 22, 15))
+		if err != nil {
+			return err
+		}
+		if err := s.SaveSheet(ctx, sheet); err != nil {
+			return err
+		}
+	}
+
+	annotations := []struct {
+		title string
+		kind  domain.AnnotationType
+		page  int
+		note  string
+	}{
+		{"Le Comte de Monte-Cristo", domain.AnnotationHighlight, 212, "L'abbe Faria et le tresor"},
+		{"Le Comte de Monte-Cristo", domain.AnnotationBookmark, 874, ""},
+		{"Madame Bovary", domain.AnnotationHighlight, 96, "Le bal a la Vaubyessard"},
+		{"Vingt mille lieues sous les mers", domain.AnnotationHighlight, 180, "Mobilis in mobili"},
+	}
+	for i, a := range annotations {
+		annot, err := domain.NewAnnotation(books[a.title].ID, a.kind, a.page, day(10-i, 21, 20))
+		if err != nil {
+			return err
+		}
+		annot.Note = a.note
+		if err := s.SaveAnnotation(ctx, annot); err != nil {
+			return err
+		}
+	}
+
+	evening, err := domain.NewReminder("", "", "Lecture du soir", 21, 30, domain.FrequencyDaily, now)
+	if err != nil {
+		return err
+	}
+	evening.SkipIfReadToday = true
+	monteCristo := books["Le Comte de Monte-Cristo"]
+	weekend, err := domain.NewReminder(monteCristo.ID, monteCristo.Title, "Finir Monte-Cristo", 10, 0, domain.FrequencyWeekly, now)
+	if err != nil {
+		return err
+	}
+	for _, r := range []*domain.Reminder{evening, weekend} {
+		if err := s.SaveReminder(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/storagetest"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+func TestStorage_Contract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store { return memory.NewStorage() })
+}
+
+func TestNewDemo(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC)
+	store := memory.NewDemo(now)
+
+	books, err := store.ListAll(ctx)
+	if err != nil || len(books) < 5 {
+		t.Fatalf("expected a seeded library, got %d books, %v", len(books), err)
+	}
+	tracker := service.NewTrackerService(store, store, clock.NewFakeClock(now))
+	status, err := tracker.BookCompletionStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]int{}
+	for _, s := range status {
+		counts[s]++
+	}
+	if counts["done"] == 0 || counts["reading"] == 0 || counts["unread"] == 0 {
+		t.Errorf("expected finished, started and unread books, got %v", counts)
+	}
+	if recent, _ := store.ListSessionsSince(ctx, now.AddDate(0, 0, -7)); len(recent) == 0 {
+		t.Error("expected sessions during the last week")
+	}
+	all, _ := store.ListSessionsSince(ctx, time.Time{})
+	for _, ses := range all {
+		if ses.LastReadingTime.After(now) {
+			t.Errorf("session %s read in the future: %s", ses.SessionID, ses.LastReadingTime)
+		}
+	}
+	if sheets, _ := store.ListAllSheets(ctx); len(sheets) == 0 {
+		t.Error("expected reading sheets")
+	}
+	if reminders, _ := store.ListEnabledReminders(ctx); len(reminders) == 0 {
+		t.Error("expected enabled reminders")
+	}
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.ReadingSheetRepository = (*Storage)(nil)
+
+// SaveSheet ajoute une fiche de lecture
+func (s *Storage) SaveSheet(ctx context.Context, sheet *domain.ReadingSheet) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.bookExists(sheet.BookID) {
+		return fmt.Errorf("failed to save reading sheet: %w", domain.ErrBookNotFound)
+	}
+	if s.sheetIndex(sheet.ID) >= 0 {
+		return fmt.Errorf("failed to save reading sheet: %w", ErrDuplicateID)
+	}
+	s.sheets = append(s.sheets, cloneSheet(sheet))
+	return nil
+}
+
+// GetSheetByID récupère une fiche par son ID
+func (s *Storage) GetSheetByID(ctx context.Context, id string) (*domain.ReadingSheet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.sheetIndex(id)
+	if i < 0 {
+		return nil, domain.ErrReadingSheetNotFound
+	}
+	return cloneSheet(s.sheets[i]), nil
+}
+
+// GetSheetByBookID récupère la première fiche enregistrée pour un livre
+func (s *Storage) GetSheetByBookID(ctx context.Context, bookID string) (*domain.ReadingSheet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sh := range s.sheets {
+		if sh.BookID == bookID {
+			return cloneSheet(sh), nil
+		}
+	}
+	return nil, domain.ErrReadingSheetNotFound
+}
+
+// ListAllSheets retourne toutes les fiches, les plus récemment modifiées d'abord
+func (s *Storage) ListAllSheets(ctx context.Context) ([]*domain.ReadingSheet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sheets []*domain.ReadingSheet
+	for _, sh := range s.sheets {
+		sheets = append(sheets, cloneSheet(sh))
+	}
+	sort.SliceStable(sheets, func(i, j int) bool { return sheets[i].UpdatedAt.After(sheets[j].UpdatedAt) })
+	return sheets, nil
+}
+
+// UpdateSheet met à jour le contenu d'une fiche existante. Comme en SQLite, le
+// livre et son titre ne changent pas, UpdatedAt est repris de sheet et une
+// fiche absente est ignorée.
+func (s *Storage) UpdateSheet(ctx context.Context, sheet *domain.ReadingSheet) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.sheetIndex(sheet.ID)
+	if i < 0 {
+		return nil
+	}
+	stored := s.sheets[i]
+	stored.Summary, stored.Rating = sheet.Summary, sheet.Rating
+	stored.Quotes, stored.Tags = cloneStrings(sheet.Quotes), cloneStrings(sheet.Tags)
+	stored.UpdatedAt = sheet.UpdatedAt
+	return nil
+}
+
+// DeleteSheet supprime une fiche de lecture
+func (s *Storage) DeleteSheet(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sheets = remove(s.sheets, func(sh *domain.ReadingSheet) bool { return sh.ID == id })
+	return nil
+}
+
+// sheetIndex returns the position of id in s.sheets, or -1. The caller holds mu.
+func (s *Storage) sheetIndex(id string) int {
+	for i, sh := range s.sheets {
+		if sh.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func cloneSheet(sheet *domain.ReadingSheet) *domain.ReadingSheet {
+	c := *sheet
+	c.Quotes, c.Tags = cloneStrings(sheet.Quotes), cloneStrings(sheet.Tags)
+	return &c
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.ReminderRepository = (*Storage)(nil)
+
+func (s *Storage) SaveReminder(ctx context.Context, r *domain.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.reminderIndex(r.ID) >= 0 {
+		return fmt.Errorf("failed to save reminder: %w", ErrDuplicateID)
+	}
+	c := *r
+	s.reminders = append(s.reminders, &c)
+	return nil
+}
+
+func (s *Storage) GetReminderByID(ctx context.Context, id string) (*domain.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.reminderIndex(id)
+	if i < 0 {
+		return nil, domain.ErrReminderNotFound
+	}
+	c := *s.reminders[i]
+	return &c, nil
+}
+
+// ListAllReminders returns every reminder by time of day.
+func (s *Storage) ListAllReminders(ctx context.Context) ([]*domain.Reminder, error) {
+	reminders, err := s.listReminders(ctx, false)
+	sort.SliceStable(reminders, func(i, j int) bool {
+		a, b := reminders[i], reminders[j]
+		return a.Hour < b.Hour || (a.Hour == b.Hour && a.Minute < b.Minute)
+	})
+	return reminders, err
+}
+
+// ListEnabledReminders returns the enabled reminders, the next to ring first.
+func (s *Storage) ListEnabledReminders(ctx context.Context) ([]*domain.Reminder, error) {
+	reminders, err := s.listReminders(ctx, true)
+	sort.SliceStable(reminders, func(i, j int) bool { return reminders[i].NextRing.Before(reminders[j].NextRing) })
+	return reminders, err
+}
+
+// UpdateReminder updates the schedule and label of a stored reminder; its
+// book and creation date do not change, and an absent reminder is ignored.
+func (s *Storage) UpdateReminder(ctx context.Context, r *domain.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.reminderIndex(r.ID)
+	if i < 0 {
+		return nil
+	}
+	stored := s.reminders[i]
+	stored.Label, stored.Hour, stored.Minute, stored.Frequency = r.Label, r.Hour, r.Minute, r.Frequency
+	stored.Enabled, stored.NextRing, stored.SkipIfReadToday = r.Enabled, r.NextRing, r.SkipIfReadToday
+	return nil
+}
+
+func (s *Storage) DeleteReminder(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reminders = remove(s.reminders, func(r *domain.Reminder) bool { return r.ID == id })
+	return nil
+}
+
+// --- helpers ---
+
+func (s *Storage) listReminders(ctx context.Context, enabledOnly bool) ([]*domain.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*domain.Reminder
+	for _, r := range s.reminders {
+		if enabledOnly && !r.Enabled {
+			continue
+		}
+		c := *r
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+// reminderIndex returns the position of id in s.reminders, or -1. The caller
+// holds mu.
+func (s *Storage) reminderIndex(id string) int {
+	for i, r := range s.reminders {
+		if r.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.SessionRepository = (*Storage)(nil)
+
+// SaveSession stores session, replacing the one with the same ID.
+func (s *Storage) SaveSession(ctx context.Context, session *domain.ReadingSession) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if session.SessionID == "" {
+		return fmt.Errorf("session id is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.bookExists(session.BookID) {
+		return fmt.Errorf("failed to save session: %w", domain.ErrBookNotFound)
+	}
+	// Comme INSERT OR REPLACE : la session remplacée passe en fin de liste.
+	s.sessions = remove(s.sessions, func(r *domain.ReadingSession) bool { return r.SessionID == session.SessionID })
+	c := *session
+	s.sessions = append(s.sessions, &c)
+	return nil
+}
+
+// GetSessionByID returns all sessions for a book, with TotalPages from the book.
+func (s *Storage) GetSessionByID(ctx context.Context, bookID string) ([]*domain.ReadingSession, error) {
+	return s.listSessions(ctx, func(r *domain.ReadingSession) bool { return r.BookID == bookID })
+}
+
+// GetLastReadingSession returns the most recent session, nil when the book has none.
+func (s *Storage) GetLastReadingSession(ctx context.Context, bookId string) (*domain.ReadingSession, error) {
+	sessions, err := s.listSessions(ctx, func(r *domain.ReadingSession) bool { return r.BookID == bookId })
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	mostRecentFirst(sessions)
+	return sessions[0], nil
+}
+
+// ListSessionsSince returns all sessions read at or after since, most recent first.
+func (s *Storage) ListSessionsSince(ctx context.Context, since time.Time) ([]*domain.ReadingSession, error) {
+	sessions, err := s.listSessions(ctx, func(r *domain.ReadingSession) bool { return !r.LastReadingTime.Before(since) })
+	if err != nil {
+		return nil, err
+	}
+	mostRecentFirst(sessions)
+	return sessions, nil
+}
+
+// listSessions copies the sessions matching keep, in order of storage.
+func (s *Storage) listSessions(ctx context.Context, keep func(*domain.ReadingSession) bool) ([]*domain.ReadingSession, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*domain.ReadingSession
+	for _, r := range s.sessions {
+		if !keep(r) {
+			continue
+		}
+		c := *r
+		c.TotalPages = 0
+		if i := s.bookIndex(r.BookID); i >= 0 {
+			c.TotalPages = s.books[i].TotalPages
+		}
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func mostRecentFirst(sessions []*domain.ReadingSession) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastReadingTime.After(sessions[j].LastReadingTime)
+	})
+}
@@ -0,0 +1,62 @@
+// Package memory keeps the repositories in memory, with the semantics of the
+// SQLite storage: same not-found errors, same ordering, same cascades. It
+// backs the demo mode and the tests that need a real repository.
+package memory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// ErrDuplicateID indicates that an entry with the same ID is already stored,
+// where SQLite would report a primary key violation.
+var ErrDuplicateID = errors.New("an entry with this ID already exists")
+
+// Storage provides in-memory persistence for all repository interfaces. Its
+// content is lost when the process exits. Like SQLite, every method fails
+// once its context is cancelled, and reads return copies.
+type Storage struct {
+	mu          sync.RWMutex
+	books       []*domain.Book // dans l'ordre d'ajout, comme les rowid SQLite
+	sessions    []*domain.ReadingSession
+	annotations []*domain.Annotation
+	sheets      []*domain.ReadingSheet
+	reminders   []*domain.Reminder
+}
+
+// NewStorage returns an empty Storage.
+func NewStorage() *Storage {
+	return &Storage{}
+}
+
+// Close is a no-op, for parity with the SQLite storage.
+func (s *Storage) Close() error { return nil }
+
+// bookExists reports whether bookID is stored, the foreign key every session,
+// annotation and sheet must satisfy. The caller holds mu.
+func (s *Storage) bookExists(bookID string) bool {
+	return s.bookIndex(bookID) >= 0
+}
+
+// remove returns items without those matching drop, keeping the order.
+func remove[T any](items []T, drop func(T) bool) []T {
+	out := items[:0]
+	for _, item := range items {
+		if !drop(item) {
+			out = append(out, item)
+		}
+	}
+	clear(items[len(out):])
+	return out
+}
+
+// cloneStrings copies a list read back from a "||" or "," joined column:
+// SQLite returns nil for an empty one.
+func cloneStrings(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	return append([]string(nil), list...)
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.UnitOfWork = (*Storage)(nil)
+
+// WithTx runs fn on a copy of the store and keeps the copy only when fn
+// returns nil. The store stays locked meanwhile, so other callers wait for
+// the end of the unit of work as they would for a SQLite writer.
+func (s *Storage) WithTx(ctx context.Context, fn func(repos port.Repositories) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	work := &Storage{
+		books:       cloneAll(s.books, cloneBook),
+		sessions:    cloneAll(s.sessions, shallow[domain.ReadingSession]),
+		annotations: cloneAll(s.annotations, shallow[domain.Annotation]),
+		sheets:      cloneAll(s.sheets, cloneSheet),
+		reminders:   cloneAll(s.reminders, shallow[domain.Reminder]),
+	}
+	if err := fn(work.repositories()); err != nil {
+		return err
+	}
+	// Comme un COMMIT sur un contexte annulé : rien n'est gardé.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.books, s.sessions, s.annotations, s.sheets, s.reminders = work.books, work.sessions, work.annotations, work.sheets, work.reminders
+	return nil
+}
+
+func (s *Storage) repositories() port.Repositories {
+	return port.Repositories{Books: s, Sessions: s, Annotations: s, Sheets: s, Reminders: s}
+}
+
+func cloneAll[T any](items []*T, clone func(*T) *T) []*T {
+	out := make([]*T, len(items))
+	for i, item := range items {
+		out[i] = clone(item)
+	}
+	return out
+}
+
+func shallow[T any](item *T) *T {
+	c := *item
+	return &c
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -11,15 +12,25 @@ import (
 
 var _ port.AnnotationRepository = (*Storage)(nil)
 
+// annotationColumns lists the columns read by scanAnnotation, in order.
+const annotationColumns = `id, book_id, annotation_type, page_number, created_at, note`
+
 func (s *Storage) SaveAnnotation(ctx context.Context, annotation *domain.Annotation) error {
 	// let's manage the context lifecycle
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
+
+	note, err := s.crypt.seal(annotation.Note, "annotations", "note", annotation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt annotation note: %w", err)
+	}
 
 	// now let's build the query
-	query := `INSERT INTO annotations (id,book_id,annotation_type,page_number,created_at) VALUES (?,?,?,?,?);`
+	query := `INSERT INTO annotations (id,book_id,annotation_type,page_number,created_at,note) VALUES (?,?,?,?,?,?);`
 
-	_, queryExecutionError := s.db.ExecContext(ctx, query, annotation.ID, annotation.BookID, annotation.AnnotationType, annotation.PageNo, annotation.CreatedAt)
+	_, queryExecutionError := s.conn.ExecContext(ctx, query, annotation.ID, annotation.BookID, annotation.AnnotationType, annotation.PageNo, annotation.CreatedAt, note)
 	if queryExecutionError != nil {
 		return fmt.Errorf("an error occured while inserting annotation into database: %v", queryExecutionError)
 	}
@@ -31,11 +42,13 @@ func (s *Storage) GetAnnotationByPage(ctx context.Context, pageNo int, bookId st
 	// we manage the context lifecycle
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
 	// let's build the query
-	query := `SELECT * FROM annotations WHERE page_number = ? AND book_id=? ORDER BY page_number ASC;`
+	query := `SELECT ` + annotationColumns + ` FROM annotations WHERE page_number = ? AND book_id=? ORDER BY page_number ASC;`
 
-	rows, fetchingError := s.db.QueryContext(ctx, query, pageNo, bookId)
+	rows, fetchingError := s.conn.QueryContext(ctx, query, pageNo, bookId)
 	if fetchingError != nil {
 		return nil, fmt.Errorf("an error occured while querying annotations table: %v", fetchingError)
 	}
@@ -44,15 +57,11 @@ func (s *Storage) GetAnnotationByPage(ctx context.Context, pageNo int, bookId st
 	var annotations []*domain.Annotation
 
 	for rows.Next() {
-		var annot domain.Annotation
-		var formatStr string
-
-		scanningError := rows.Scan(&annot.ID, &annot.BookID, &formatStr, &annot.PageNo, &annot.CreatedAt)
+		annot, scanningError := s.scanAnnotation(rows)
 		if scanningError != nil {
 			return nil, fmt.Errorf("an error occured while scanning annotations table: %v", scanningError)
 		}
-		annot.AnnotationType = domain.AnnotationType(formatStr)
-		annotations = append(annotations, &annot)
+		annotations = append(annotations, annot)
 	}
 	streamIterationError := rows.Err()
 	if streamIterationError != nil {
@@ -67,11 +76,13 @@ func (s *Storage) GetAnnotationByType(ctx context.Context, annotationType string
 	// let's manage the context lifecycle
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
 	// let's build the query
-	query := `SELECT * FROM annotations WHERE annotation_type = ?;`
+	query := `SELECT ` + annotationColumns + ` FROM annotations WHERE annotation_type = ?;`
 
-	rows, fetchingError := s.db.QueryContext(ctx, query, annotationType)
+	rows, fetchingError := s.conn.QueryContext(ctx, query, annotationType)
 	if fetchingError != nil {
 		return nil, fmt.Errorf("an error occured while querying annotations table: %v", fetchingError)
 	}
@@ -80,17 +91,11 @@ func (s *Storage) GetAnnotationByType(ctx context.Context, annotationType string
 	var annotations []*domain.Annotation
 
 	for rows.Next() {
-		var annot domain.Annotation
-
-		var formatStr string
-
-		scanningError := rows.Scan(&annot.ID, &annot.BookID, &formatStr, &annot.PageNo, &annot.CreatedAt)
+		annot, scanningError := s.scanAnnotation(rows)
 		if scanningError != nil {
-
 			return nil, fmt.Errorf("an error occured while scanning annotations table: %v", scanningError)
 		}
-		annot.AnnotationType = domain.AnnotationType(formatStr)
-		annotations = append(annotations, &annot)
+		annotations = append(annotations, annot)
 	}
 	streamIterationError := rows.Err()
 	if streamIterationError != nil {
@@ -108,7 +113,7 @@ func (s *Storage) DeleteAnnotation(ctx context.Context, id string) error {
 	// we build the query
 	query := `DELETE  FROM annotations WHERE id=?`
 
-	_, queryExecutionError := s.db.ExecContext(ctx, query, id)
+	_, queryExecutionError := s.conn.ExecContext(ctx, query, id)
 	if queryExecutionError != nil {
 		return fmt.Errorf("an error occured while deleting annotation: %v", queryExecutionError)
 	}
@@ -120,10 +125,12 @@ func (s *Storage) ListAllAnnotationOfABook(ctx context.Context, book_id string)
 	// let's manage the context lifecycle
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
-	query := `SELECT * FROM annotations WHERE book_id=?;`
+	query := `SELECT ` + annotationColumns + ` FROM annotations WHERE book_id=?;`
 
-	rows, fetchingError := s.db.QueryContext(ctx, query, book_id)
+	rows, fetchingError := s.conn.QueryContext(ctx, query, book_id)
 	if fetchingError != nil {
 		return nil, fmt.Errorf("an error occured while querying annotations table: %v", fetchingError)
 	}
@@ -132,15 +139,11 @@ func (s *Storage) ListAllAnnotationOfABook(ctx context.Context, book_id string)
 	var annotations []*domain.Annotation
 
 	for rows.Next() {
-		var annot domain.Annotation
-		var formatStr string
-
-		scanningError := rows.Scan(&annot.ID, &annot.BookID, &formatStr, &annot.PageNo, &annot.CreatedAt)
+		annot, scanningError := s.scanAnnotation(rows)
 		if scanningError != nil {
 			return nil, fmt.Errorf("an error occured while scanning annotations rows pointer: %v", scanningError)
 		}
-		annot.AnnotationType = domain.AnnotationType(formatStr)
-		annotations = append(annotations, &annot)
+		annotations = append(annotations, annot)
 	}
 	streamIterationError := rows.Err()
 	if streamIterationError != nil {
@@ -150,3 +153,21 @@ func (s *Storage) ListAllAnnotationOfABook(ctx context.Context, book_id string)
 	return annotations, nil
 
 }
+
+// scanAnnotation reads one row selected with annotationColumns, decrypting
+// its note. The caller holds crypt.mu.
+func (s *Storage) scanAnnotation(rows *sql.Rows) (*domain.Annotation, error) {
+	var annot domain.Annotation
+	var formatStr string
+	var note sql.NullString
+	if err := rows.Scan(&annot.ID, &annot.BookID, &formatStr, &annot.PageNo, &annot.CreatedAt, &note); err != nil {
+		return nil, err
+	}
+	annot.AnnotationType = domain.AnnotationType(formatStr)
+	text, err := s.crypt.open(note.String, "annotations", "note", annot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt note: %w", err)
+	}
+	annot.Note = text
+	return &annot, nil
+}
@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.DatabaseSnapshotter = (*Storage)(nil)
+
+// ErrCorruptDatabase indicates that a database file failed PRAGMA integrity_check.
+var ErrCorruptDatabase = errors.New("database failed integrity check")
+
+// BackupDir returns the folder holding the snapshots of the database at
+// dbPath: the pre-migration copies written by NewStorage and the rotating
+// snapshots of the backup service.
+func BackupDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+// Snapshot copies the live database to destPath with VACUUM INTO, which reads
+// a consistent state without blocking other connections. The copy is written
+// next to destPath and renamed once complete.
+func (s *Storage) Snapshot(ctx context.Context, destPath string) error {
+	return snapshot(ctx, s.db, destPath)
+}
+
+func snapshot(ctx context.Context, db *sql.DB, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create backup folder: %w", err)
+	}
+	tmp := destPath + ".tmp"
+	os.Remove(tmp) // VACUUM INTO refuse un fichier existant
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?;", tmp); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+	if err := os.Rename(tmp, destPath); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to finalize snapshot: %w", err)
+	}
+	return nil
+}
+
+// backupBeforeMigration snapshots an existing database that is about to be
+// migrated, as orus-pre-migration-v<version>-<time>.db in BackupDir.
+func backupBeforeMigration(db *sql.DB, dbPath string) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version;").Scan(&version); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if version >= len(migrations) {
+		return nil
+	}
+	name := fmt.Sprintf("orus-pre-migration-v%d-%s.db", version, time.Now().Format("20060102-150405"))
+	return snapshot(context.Background(), db, filepath.Join(BackupDir(dbPath), name))
+}
+
+// CheckIntegrity opens the database at path read-only and runs
+// PRAGMA integrity_check. Damaged files and files that are not SQLite
+// databases are reported with ErrCorruptDatabase.
+func CheckIntegrity(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("PRAGMA integrity_check;")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptDatabase, err)
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("%w: %v", ErrCorruptDatabase, err)
+		}
+		problems = append(problems, line)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptDatabase, err)
+	}
+	if len(problems) == 1 && problems[0] == "ok" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrCorruptDatabase, strings.Join(problems, "; "))
+}
+
+// Restore replaces the database at dbPath with the snapshot at snapshotPath,
+// once the snapshot passes CheckIntegrity. The replaced database, if any, is
+// kept as dbPath + ".pre-restore" and its path returned. The database must
+// not be open: Restore is meant to run before NewStorage.
+func Restore(snapshotPath, dbPath string) (string, error) {
+	if err := CheckIntegrity(snapshotPath); err != nil {
+		return "", fmt.Errorf("snapshot rejected: %w", err)
+	}
+	tmp := dbPath + ".restore"
+	if err := copyFile(snapshotPath, tmp); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to copy snapshot: %w", err)
+	}
+
+	previous := ""
+	if _, err := os.Stat(dbPath); err == nil {
+		previous = dbPath + ".pre-restore"
+		if err := os.Rename(dbPath, previous); err != nil {
+			os.Remove(tmp)
+			return "", fmt.Errorf("failed to set current database aside: %w", err)
+		}
+	}
+	// Un journal laissé par l'ancienne base serait rejoué sur la nouvelle.
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		if _, err := os.Stat(dbPath + suffix); err == nil {
+			if previous != "" {
+				os.Rename(dbPath+suffix, previous+suffix)
+			} else {
+				os.Remove(dbPath + suffix)
+			}
+		}
+	}
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return previous, fmt.Errorf("failed to install snapshot: %w", err)
+	}
+	return previous, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -19,10 +19,21 @@ func (s *Storage) Save(ctx context.Context, book *domain.Book) error {
 	defer cancel()
 
 	// first, let's build the query || the query is a kind of UPSERT
-	query := `INSERT INTO books (id, title, author, file_path, format, total_pages, added_at) VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET title=excluded.title, file_path=excluded.file_path`
+	query := `INSERT INTO books (id, title, author, file_path, format, total_pages, added_at, series, series_index, cover, isbn, publisher, year)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	          ON CONFLICT(id) DO UPDATE SET title=excluded.title, file_path=excluded.file_path,
+	          series=excluded.series, series_index=excluded.series_index, cover=COALESCE(excluded.cover, cover),
+	          isbn=excluded.isbn, publisher=excluded.publisher, year=excluded.year`
+
+	// an empty cover is stored as NULL so that it never erases an existing one
+	var cover any
+	if len(book.CoverImage) > 0 {
+		cover = book.CoverImage
+	}
 
 	// then, let's execute the query
-	_, queryExecutionerr := s.db.ExecContext(ctx, query, book.ID, book.Title, book.Author, book.FilePath, book.Format, book.TotalPages, book.AddedAt)
+	_, queryExecutionerr := s.conn.ExecContext(ctx, query, book.ID, book.Title, book.Author, book.FilePath, book.Format, book.TotalPages, book.AddedAt,
+		book.Series, book.SeriesIndex, cover, book.ISBN, book.Publisher, book.Year)
 	return queryExecutionerr
 
 }
@@ -34,16 +45,11 @@ func (s *Storage) GetByID(ctx context.Context, id string) (*domain.Book, error)
 	defer cancel()
 
 	// let's build the query
-	query := `SELECT * FROM books WHERE id=?`
-
-	row := s.db.QueryRowContext(ctx, query, id)
+	query := `SELECT ` + bookColumns + ` FROM books WHERE id=?`
 
-	var (
-		b         domain.Book
-		formatStr string
-	)
+	row := s.conn.QueryRowContext(ctx, query, id)
 
-	copyingDataFromRowError := row.Scan(&b.ID, &b.Title, &b.Author, &b.FilePath, &formatStr, &b.TotalPages, &b.AddedAt)
+	b, copyingDataFromRowError := scanBook(row)
 	if copyingDataFromRowError != nil {
 		// maybe because the row was empty
 		if errors.Is(copyingDataFromRowError, sql.ErrNoRows) {
@@ -51,8 +57,7 @@ func (s *Storage) GetByID(ctx context.Context, id string) (*domain.Book, error)
 		}
 		return nil, fmt.Errorf("unable to scan the data from database row, %v", copyingDataFromRowError)
 	}
-	b.Format = domain.BookFormat(formatStr)
-	return &b, nil
+	return b, nil
 }
 
 func (s *Storage) ListAll(ctx context.Context) ([]*domain.Book, error) {
@@ -64,9 +69,9 @@ func (s *Storage) ListAll(ctx context.Context) ([]*domain.Book, error) {
 		bookList []*domain.Book
 	)
 	// now let's build the query to fetch the entries
-	query := `SELECT * FROM books`
+	query := `SELECT ` + bookColumns + ` FROM books`
 
-	rows, fetchingError := s.db.QueryContext(ctx, query)
+	rows, fetchingError := s.conn.QueryContext(ctx, query)
 	if fetchingError != nil {
 		return nil, fmt.Errorf("unable to fetch all books, %v", fetchingError)
 	}
@@ -74,14 +79,11 @@ func (s *Storage) ListAll(ctx context.Context) ([]*domain.Book, error) {
 
 	for rows.Next() {
 		// we create a new book instance to store the data of the current row
-		var b domain.Book
-		var formatStr string
-		scanningRowError := rows.Scan(&b.ID, &b.Title, &b.Author, &b.FilePath, &formatStr, &b.TotalPages, &b.AddedAt)
+		b, scanningRowError := scanBook(rows)
 		if scanningRowError != nil {
 			return nil, fmt.Errorf("unable to scan the data from database row, %v", scanningRowError)
 		}
-		b.Format = domain.BookFormat(formatStr)
-		bookList = append(bookList, &b)
+		bookList = append(bookList, b)
 	}
 	streamIterationError := rows.Err()
 	if streamIterationError != nil {
@@ -99,10 +101,37 @@ func (s *Storage) Delete(ctx context.Context, bookId string) error {
 	// now let's build the query to delete the entry
 	query := `DELETE FROM books WHERE id=?`
 
-	_, queryExecutionError := s.db.ExecContext(ctx, query, bookId)
+	_, queryExecutionError := s.conn.ExecContext(ctx, query, bookId)
 	if queryExecutionError != nil {
 		return fmt.Errorf("unable to delete book, %v", queryExecutionError)
 	}
 
 	return nil
 }
+
+// bookColumns lists the columns read by scanBook, in order. Columns are named
+// explicitly because migrations append new ones to the table.
+const bookColumns = `id, title, author, file_path, format, total_pages, added_at, series, series_index, cover, isbn, publisher, year`
+
+func scanBook(row rowScanner) (*domain.Book, error) {
+	var (
+		b           domain.Book
+		formatStr   string
+		series      sql.NullString
+		seriesIndex sql.NullFloat64
+		isbn        sql.NullString
+		publisher   sql.NullString
+		year        sql.NullInt64
+	)
+	if err := row.Scan(&b.ID, &b.Title, &b.Author, &b.FilePath, &formatStr, &b.TotalPages, &b.AddedAt,
+		&series, &seriesIndex, &b.CoverImage, &isbn, &publisher, &year); err != nil {
+		return nil, err
+	}
+	b.ISBN = isbn.String
+	b.Publisher = publisher.String
+	b.Year = int(year.Int64)
+	b.Format = domain.BookFormat(formatStr)
+	b.Series = series.String
+	b.SeriesIndex = seriesIndex.Float64
+	return &b, nil
+}
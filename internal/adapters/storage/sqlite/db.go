@@ -4,23 +4,31 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 
 	_ "modernc.org/sqlite"
 )
 
 // Storage provides SQLite-backed persistence for all repository interfaces.
 type Storage struct {
-	db *sql.DB
+	db    *sql.DB
+	conn  querier // db, ou la transaction d'une unité de travail
+	crypt *columnCipher
 }
 
 // NewStorage opens a SQLite database at dbPath, enables foreign keys, and
-// creates all required tables. The caller must call Close() when finished.
+// creates all required tables. An existing database is copied to BackupDir
+// before pending migrations run. Transactions take the write lock when they
+// begin, and a writer waits up to 5 seconds for another one to finish. The
+// caller must call Close() when finished.
 func NewStorage(dbPath string) (*Storage, error) {
 	if dbPath == "" {
 		return nil, errors.New("dbPath cannot be empty")
 	}
+	info, statErr := os.Stat(dbPath)
+	existing := statErr == nil && info.Size() > 0
 
-	db, dbReadingError := sql.Open("sqlite", "file:"+dbPath+"?_pragma=foreign_keys(1)")
+	db, dbReadingError := sql.Open("sqlite", "file:"+dbPath+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate")
 	if dbReadingError != nil {
 		return nil, fmt.Errorf("failed to connect to sqlite database: %s", dbReadingError.Error())
 	}
@@ -36,7 +44,24 @@ func NewStorage(dbPath string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to create tables in sqlite database: %s", creatingTablesError.Error())
 	}
 
-	return &Storage{db: db}, nil
+	if existing {
+		if err := backupBeforeMigration(db, dbPath); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("refusing to migrate without a backup: %w", err)
+		}
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate sqlite database: %w", err)
+	}
+
+	s := &Storage{db: db, conn: db, crypt: &columnCipher{}}
+	if err := s.loadEncryption(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
 }
 
 func createTables(db *sql.DB) error {
@@ -99,6 +124,60 @@ func createTables(db *sql.DB) error {
 	return queryExecutionError
 }
 
+// migrations upgrade the base schema created by createTables. Each entry runs
+// exactly once, in order; PRAGMA user_version records how many have been
+// applied, so databases created by older versions catch up on open.
+var migrations = []string{
+	// 1: per-reminder "don't ring if I already read today"
+	`ALTER TABLE reminders ADD COLUMN skip_if_read_today INTEGER DEFAULT 0;`,
+	// 2: session start, so durations survive a restart
+	`ALTER TABLE sessions ADD COLUMN started_at DATETIME;`,
+	// 3: series and cover thumbnail, filled by external library imports
+	`ALTER TABLE books ADD COLUMN series TEXT DEFAULT '';
+	 ALTER TABLE books ADD COLUMN series_index REAL DEFAULT 0;
+	 ALTER TABLE books ADD COLUMN cover BLOB;`,
+	// 4: bibliographic metadata for citation exports
+	`ALTER TABLE books ADD COLUMN isbn TEXT DEFAULT '';
+	 ALTER TABLE books ADD COLUMN publisher TEXT DEFAULT '';
+	 ALTER TABLE books ADD COLUMN year INTEGER DEFAULT 0;`,
+	// 5: free-text note on bookmarks and highlights
+	`ALTER TABLE annotations ADD COLUMN note TEXT DEFAULT '';`,
+	// 6: opt-in encryption of sensitive columns, at most one row
+	`CREATE TABLE encryption (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		kdf TEXT NOT NULL,      -- argon2id
+		params TEXT NOT NULL,   -- m=65536,t=3,p=4
+		salt BLOB NOT NULL,
+		verifier TEXT NOT NULL  -- "orus" chiffré, pour reconnaître la bonne phrase
+	);`,
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version;").Scan(&version); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		// PRAGMA does not accept bound parameters.
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d;", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: set version: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d: commit: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
 // Close closes the underlying database connection.
 func (s *Storage) Close() error {
 	if s == nil || s.db == nil {
@@ -0,0 +1,322 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.EncryptedStore = (*Storage)(nil)
+
+// errCorruptValue indicates a sealed value that cannot be decoded or
+// authenticated with the loaded key.
+var errCorruptValue = errors.New("corrupt encrypted value")
+
+// sealedPrefix marks encrypted values; anything else is read as plain text,
+// so a column may mix both while encryption is switched on or off.
+const sealedPrefix = "enc1:"
+
+// verifierAAD binds the verifier to its role, so that no column value can
+// stand in for it.
+const verifierAAD = "encryption.verifier"
+
+// sealedColumns lists the columns encrypted when encryption is enabled.
+var sealedColumns = []struct{ table, column string }{
+	{"reading_sheets", "summary"},
+	{"reading_sheets", "quotes"},
+	{"annotations", "note"},
+}
+
+// kdfParams are the Argon2id costs, stored with the salt so they can be
+// raised later without breaking existing databases.
+type kdfParams struct {
+	Memory  uint32 // en Kio
+	Time    uint32
+	Threads uint8
+}
+
+// defaultKDF follows the second recommendation of RFC 9106 (64 MiB, 3 passes).
+var defaultKDF = kdfParams{Memory: 64 * 1024, Time: 3, Threads: 4}
+
+func (p kdfParams) String() string {
+	return fmt.Sprintf("m=%d,t=%d,p=%d", p.Memory, p.Time, p.Threads)
+}
+
+func parseKDFParams(s string) (kdfParams, error) {
+	var p kdfParams
+	if _, err := fmt.Sscanf(s, "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, fmt.Errorf("invalid key derivation parameters %q: %w", s, err)
+	}
+	return p, nil
+}
+
+// deriveAEAD turns a passphrase into an AES-256-GCM cipher.
+func deriveAEAD(passphrase string, salt []byte, p kdfParams) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), salt, p.Time, p.Memory, p.Threads, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// columnCipher holds the encryption state of a Storage. Repository methods
+// that read or write sealed columns hold mu for reading during their whole
+// query, so that a passphrase change never interleaves with them.
+type columnCipher struct {
+	mu      sync.RWMutex
+	enabled bool
+	aead    cipher.AEAD // nil tant que la base est verrouillée
+}
+
+// seal encrypts value for the given row and column. The caller holds mu.
+func (c *columnCipher) seal(value, table, column, id string) (string, error) {
+	if !c.enabled {
+		return value, nil
+	}
+	if c.aead == nil {
+		return "", port.ErrStoreLocked
+	}
+	return sealWith(c.aead, value, columnAAD(table, column, id)), nil
+}
+
+// open decrypts a value read from the given row and column. The caller holds mu.
+func (c *columnCipher) open(value, table, column, id string) (string, error) {
+	return openWith(c.aead, value, columnAAD(table, column, id))
+}
+
+// columnAAD ties a sealed value to its row and column, so that values cannot
+// be swapped between rows without failing authentication.
+func columnAAD(table, column, id string) string {
+	return table + "." + column + ":" + id
+}
+
+// sealWith encrypts value with a random nonce. Empty values stay empty: they
+// hold nothing to hide.
+func sealWith(aead cipher.AEAD, value, aad string) string {
+	if aead == nil || value == "" {
+		return value
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err) // crypto/rand ne renvoie pas d'erreur sur les plateformes supportées
+	}
+	return sealedPrefix + base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(value), []byte(aad)))
+}
+
+func openWith(aead cipher.AEAD, value, aad string) (string, error) {
+	if !strings.HasPrefix(value, sealedPrefix) {
+		return value, nil
+	}
+	if aead == nil {
+		return "", port.ErrStoreLocked
+	}
+	raw, err := base64.StdEncoding.DecodeString(value[len(sealedPrefix):])
+	if err != nil || len(raw) < aead.NonceSize() {
+		return "", errCorruptValue
+	}
+	plain, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], []byte(aad))
+	if err != nil {
+		return "", errCorruptValue
+	}
+	return string(plain), nil
+}
+
+// loadEncryption reads whether encryption is enabled; the store then starts
+// locked until Unlock.
+func (s *Storage) loadEncryption() error {
+	var n int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM encryption;").Scan(&n); err != nil {
+		return fmt.Errorf("read encryption settings: %w", err)
+	}
+	s.crypt.enabled = n > 0
+	return nil
+}
+
+// EncryptionEnabled reports whether sensitive columns are encrypted.
+func (s *Storage) EncryptionEnabled() bool {
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
+	return s.crypt.enabled
+}
+
+// Locked reports whether encryption is enabled and the key is not loaded.
+func (s *Storage) Locked() bool {
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
+	return s.crypt.enabled && s.crypt.aead == nil
+}
+
+// Unlock derives the key from passphrase and checks it against the stored
+// verifier. It returns port.ErrWrongPassphrase on mismatch.
+func (s *Storage) Unlock(passphrase string) error {
+	aead, err := s.checkPassphrase(context.Background(), passphrase)
+	if err != nil {
+		return err
+	}
+	s.crypt.mu.Lock()
+	defer s.crypt.mu.Unlock()
+	s.crypt.aead = aead
+	return nil
+}
+
+// checkPassphrase returns the cipher derived from passphrase if it opens the
+// stored verifier.
+func (s *Storage) checkPassphrase(ctx context.Context, passphrase string) (cipher.AEAD, error) {
+	var params, verifier string
+	var salt []byte
+	err := s.db.QueryRowContext(ctx, "SELECT params, salt, verifier FROM encryption WHERE id = 1;").Scan(&params, &salt, &verifier)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("encryption is not enabled")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read encryption settings: %w", err)
+	}
+	p, err := parseKDFParams(params)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := deriveAEAD(passphrase, salt, p)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := openWith(aead, verifier, verifierAAD); err != nil {
+		return nil, port.ErrWrongPassphrase
+	}
+	return aead, nil
+}
+
+// EnableEncryption encrypts the sensitive columns with a key derived from
+// passphrase, then compacts the file so that no free page keeps the clear
+// text. The store is unlocked afterwards. Snapshots taken before, in
+// BackupDir or next to the database, still hold the clear text.
+func (s *Storage) EnableEncryption(ctx context.Context, passphrase string) error {
+	s.crypt.mu.Lock()
+	defer s.crypt.mu.Unlock()
+	if s.crypt.enabled {
+		return errors.New("encryption is already enabled")
+	}
+	return s.rekey(ctx, nil, passphrase)
+}
+
+// ChangePassphrase re-encrypts every sensitive value with a key derived from
+// next, in a single transaction, then compacts the file.
+func (s *Storage) ChangePassphrase(ctx context.Context, current, next string) error {
+	s.crypt.mu.Lock()
+	defer s.crypt.mu.Unlock()
+	old, err := s.checkPassphrase(ctx, current)
+	if err != nil {
+		return err
+	}
+	return s.rekey(ctx, old, next)
+}
+
+// DisableEncryption stores every sensitive value in clear again.
+func (s *Storage) DisableEncryption(ctx context.Context, passphrase string) error {
+	s.crypt.mu.Lock()
+	defer s.crypt.mu.Unlock()
+	old, err := s.checkPassphrase(ctx, passphrase)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := reencrypt(ctx, tx, old, nil); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM encryption;"); err != nil {
+		return fmt.Errorf("failed to clear encryption settings: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit decryption: %w", err)
+	}
+	s.crypt.enabled, s.crypt.aead = false, nil
+	return nil
+}
+
+// rekey replaces the key by one derived from passphrase with a fresh salt,
+// re-encrypting the values sealed with old (nil when they are in clear).
+// The caller holds crypt.mu.
+func (s *Storage) rekey(ctx context.Context, old cipher.AEAD, passphrase string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	next, err := deriveAEAD(passphrase, salt, defaultKDF)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := reencrypt(ctx, tx, old, next); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO encryption (id, kdf, params, salt, verifier) VALUES (1, 'argon2id', ?, ?, ?);`,
+		defaultKDF.String(), salt, sealWith(next, "orus", verifierAAD))
+	if err != nil {
+		return fmt.Errorf("failed to save encryption settings: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit re-encryption: %w", err)
+	}
+	s.crypt.enabled, s.crypt.aead = true, next
+	// Les anciennes valeurs restent dans les pages libérées jusqu'au VACUUM.
+	if _, err := s.db.ExecContext(ctx, "VACUUM;"); err != nil {
+		return fmt.Errorf("failed to compact the database: %w", err)
+	}
+	return nil
+}
+
+// reencrypt opens every non-empty sealed column with from and seals it again
+// with to; a nil cipher stands for clear text.
+func reencrypt(ctx context.Context, tx *sql.Tx, from, to cipher.AEAD) error {
+	for _, col := range sealedColumns {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT id, %s FROM %s WHERE %s != '';", col.column, col.table, col.column))
+		if err != nil {
+			return fmt.Errorf("failed to read %s.%s: %w", col.table, col.column, err)
+		}
+		values := map[string]string{}
+		for rows.Next() {
+			var id, value string
+			if err := rows.Scan(&id, &value); err != nil {
+				rows.Close()
+				return err
+			}
+			values[id] = value
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		update := fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?;", col.table, col.column)
+		for id, value := range values {
+			aad := columnAAD(col.table, col.column, id)
+			plain, err := openWith(from, value, aad)
+			if err != nil {
+				return fmt.Errorf("%s.%s of %s: %w", col.table, col.column, id, err)
+			}
+			if _, err := tx.ExecContext(ctx, update, sealWith(to, plain, aad), id); err != nil {
+				return fmt.Errorf("failed to update %s.%s: %w", col.table, col.column, err)
+			}
+		}
+	}
+	return nil
+}
@@ -18,15 +18,20 @@ var _ port.ReadingSheetRepository = (*Storage)(nil)
 func (s *Storage) SaveSheet(ctx context.Context, sheet *domain.ReadingSheet) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
-	quotesStr := strings.Join(sheet.Quotes, "||")
+	summary, quotesStr, err := s.sealSheet(sheet)
+	if err != nil {
+		return err
+	}
 	tagsStr := strings.Join(sheet.Tags, ",")
 
 	query := `INSERT INTO reading_sheets (id, book_id, book_title, summary, quotes, rating, tags, created_at, updated_at)
 	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := s.db.ExecContext(ctx, query,
-		sheet.ID, sheet.BookID, sheet.BookTitle, sheet.Summary,
+	_, err = s.conn.ExecContext(ctx, query,
+		sheet.ID, sheet.BookID, sheet.BookTitle, summary,
 		quotesStr, sheet.Rating, tagsStr, sheet.CreatedAt, sheet.UpdatedAt,
 	)
 	if err != nil {
@@ -39,10 +44,12 @@ func (s *Storage) SaveSheet(ctx context.Context, sheet *domain.ReadingSheet) err
 func (s *Storage) GetSheetByID(ctx context.Context, id string) (*domain.ReadingSheet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
 	query := `SELECT id, book_id, book_title, summary, quotes, rating, tags, created_at, updated_at FROM reading_sheets WHERE id = ?`
-	row := s.db.QueryRowContext(ctx, query, id)
-	sheet, err := scanSheet(row)
+	row := s.conn.QueryRowContext(ctx, query, id)
+	sheet, err := s.scanSheet(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrReadingSheetNotFound
@@ -56,10 +63,12 @@ func (s *Storage) GetSheetByID(ctx context.Context, id string) (*domain.ReadingS
 func (s *Storage) GetSheetByBookID(ctx context.Context, bookID string) (*domain.ReadingSheet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
 	query := `SELECT id, book_id, book_title, summary, quotes, rating, tags, created_at, updated_at FROM reading_sheets WHERE book_id = ? LIMIT 1`
-	row := s.db.QueryRowContext(ctx, query, bookID)
-	sheet, err := scanSheet(row)
+	row := s.conn.QueryRowContext(ctx, query, bookID)
+	sheet, err := s.scanSheet(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrReadingSheetNotFound
@@ -73,9 +82,11 @@ func (s *Storage) GetSheetByBookID(ctx context.Context, bookID string) (*domain.
 func (s *Storage) ListAllSheets(ctx context.Context) ([]*domain.ReadingSheet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
 
 	query := `SELECT id, book_id, book_title, summary, quotes, rating, tags, created_at, updated_at FROM reading_sheets ORDER BY updated_at DESC`
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.conn.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list reading sheets: %w", err)
 	}
@@ -83,7 +94,7 @@ func (s *Storage) ListAllSheets(ctx context.Context) ([]*domain.ReadingSheet, er
 
 	var sheets []*domain.ReadingSheet
 	for rows.Next() {
-		sheet, err := scanSheetRows(rows)
+		sheet, err := s.scanSheet(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan reading sheet: %w", err)
 		}
@@ -92,17 +103,22 @@ func (s *Storage) ListAllSheets(ctx context.Context) ([]*domain.ReadingSheet, er
 	return sheets, rows.Err()
 }
 
-// UpdateSheet met à jour une fiche existante
+// UpdateSheet met à jour une fiche existante, datée de sheet.UpdatedAt
 func (s *Storage) UpdateSheet(ctx context.Context, sheet *domain.ReadingSheet) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	sheet.UpdatedAt = time.Now()
-	quotesStr := strings.Join(sheet.Quotes, "||")
+	s.crypt.mu.RLock()
+	defer s.crypt.mu.RUnlock()
+
+	summary, quotesStr, err := s.sealSheet(sheet)
+	if err != nil {
+		return err
+	}
 	tagsStr := strings.Join(sheet.Tags, ",")
 
 	query := `UPDATE reading_sheets SET summary=?, quotes=?, rating=?, tags=?, updated_at=? WHERE id=?`
-	_, err := s.db.ExecContext(ctx, query, sheet.Summary, quotesStr, sheet.Rating, tagsStr, sheet.UpdatedAt, sheet.ID)
+	_, err = s.conn.ExecContext(ctx, query, summary, quotesStr, sheet.Rating, tagsStr, sheet.UpdatedAt, sheet.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update reading sheet: %w", err)
 	}
@@ -114,7 +130,7 @@ func (s *Storage) DeleteSheet(ctx context.Context, id string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	_, err := s.db.ExecContext(ctx, `DELETE FROM reading_sheets WHERE id=?`, id)
+	_, err := s.conn.ExecContext(ctx, `DELETE FROM reading_sheets WHERE id=?`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete reading sheet: %w", err)
 	}
@@ -127,29 +143,33 @@ type rowScanner interface {
 	Scan(dest ...any) error
 }
 
-func scanSheet(row rowScanner) (*domain.ReadingSheet, error) {
-	var sheet domain.ReadingSheet
-	var quotesStr, tagsStr string
-	err := row.Scan(&sheet.ID, &sheet.BookID, &sheet.BookTitle, &sheet.Summary, &quotesStr, &sheet.Rating, &tagsStr, &sheet.CreatedAt, &sheet.UpdatedAt)
-	if err != nil {
-		return nil, err
+// sealSheet returns the summary and joined quotes as stored, encrypted when
+// encryption is enabled. The caller holds crypt.mu.
+func (s *Storage) sealSheet(sheet *domain.ReadingSheet) (summary, quotes string, err error) {
+	if summary, err = s.crypt.seal(sheet.Summary, "reading_sheets", "summary", sheet.ID); err != nil {
+		return "", "", fmt.Errorf("failed to encrypt summary: %w", err)
 	}
-	if quotesStr != "" {
-		sheet.Quotes = strings.Split(quotesStr, "||")
-	}
-	if tagsStr != "" {
-		sheet.Tags = strings.Split(tagsStr, ",")
+	if quotes, err = s.crypt.seal(strings.Join(sheet.Quotes, "||"), "reading_sheets", "quotes", sheet.ID); err != nil {
+		return "", "", fmt.Errorf("failed to encrypt quotes: %w", err)
 	}
-	return &sheet, nil
+	return summary, quotes, nil
 }
 
-func scanSheetRows(rows *sql.Rows) (*domain.ReadingSheet, error) {
+// scanSheet reads one reading_sheets row, decrypting its sensitive columns.
+// The caller holds crypt.mu.
+func (s *Storage) scanSheet(row rowScanner) (*domain.ReadingSheet, error) {
 	var sheet domain.ReadingSheet
 	var quotesStr, tagsStr string
-	err := rows.Scan(&sheet.ID, &sheet.BookID, &sheet.BookTitle, &sheet.Summary, &quotesStr, &sheet.Rating, &tagsStr, &sheet.CreatedAt, &sheet.UpdatedAt)
+	err := row.Scan(&sheet.ID, &sheet.BookID, &sheet.BookTitle, &sheet.Summary, &quotesStr, &sheet.Rating, &tagsStr, &sheet.CreatedAt, &sheet.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
+	if sheet.Summary, err = s.crypt.open(sheet.Summary, "reading_sheets", "summary", sheet.ID); err != nil {
+		return nil, fmt.Errorf("failed to decrypt summary: %w", err)
+	}
+	if quotesStr, err = s.crypt.open(quotesStr, "reading_sheets", "quotes", sheet.ID); err != nil {
+		return nil, fmt.Errorf("failed to decrypt quotes: %w", err)
+	}
 	if quotesStr != "" {
 		sheet.Quotes = strings.Split(quotesStr, "||")
 	}
@@ -17,12 +17,12 @@ func (s *Storage) SaveReminder(ctx context.Context, r *domain.Reminder) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	query := `INSERT INTO reminders (id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := s.db.ExecContext(ctx, query,
+	query := `INSERT INTO reminders (id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at, skip_if_read_today)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := s.conn.ExecContext(ctx, query,
 		r.ID, r.BookID, r.BookTitle, r.Label,
 		r.Hour, r.Minute, string(r.Frequency),
-		r.Enabled, r.NextRing, r.CreatedAt,
+		r.Enabled, r.NextRing, r.CreatedAt, r.SkipIfReadToday,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save reminder: %w", err)
@@ -34,8 +34,8 @@ func (s *Storage) GetReminderByID(ctx context.Context, id string) (*domain.Remin
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	query := `SELECT id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at FROM reminders WHERE id=?`
-	row := s.db.QueryRowContext(ctx, query, id)
+	query := `SELECT id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at, skip_if_read_today FROM reminders WHERE id=?`
+	row := s.conn.QueryRowContext(ctx, query, id)
 	r, err := scanReminder(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -50,22 +50,22 @@ func (s *Storage) ListAllReminders(ctx context.Context) ([]*domain.Reminder, err
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	return s.queryReminders(ctx, `SELECT id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at FROM reminders ORDER BY hour, minute`)
+	return s.queryReminders(ctx, `SELECT id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at, skip_if_read_today FROM reminders ORDER BY hour, minute`)
 }
 
 func (s *Storage) ListEnabledReminders(ctx context.Context) ([]*domain.Reminder, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	return s.queryReminders(ctx, `SELECT id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at FROM reminders WHERE enabled=1 ORDER BY next_ring ASC`)
+	return s.queryReminders(ctx, `SELECT id, book_id, book_title, label, hour, minute, frequency, enabled, next_ring, created_at, skip_if_read_today FROM reminders WHERE enabled=1 ORDER BY next_ring ASC`)
 }
 
 func (s *Storage) UpdateReminder(ctx context.Context, r *domain.Reminder) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	query := `UPDATE reminders SET label=?, hour=?, minute=?, frequency=?, enabled=?, next_ring=? WHERE id=?`
-	_, err := s.db.ExecContext(ctx, query, r.Label, r.Hour, r.Minute, string(r.Frequency), r.Enabled, r.NextRing, r.ID)
+	query := `UPDATE reminders SET label=?, hour=?, minute=?, frequency=?, enabled=?, next_ring=?, skip_if_read_today=? WHERE id=?`
+	_, err := s.conn.ExecContext(ctx, query, r.Label, r.Hour, r.Minute, string(r.Frequency), r.Enabled, r.NextRing, r.SkipIfReadToday, r.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update reminder: %w", err)
 	}
@@ -76,7 +76,7 @@ func (s *Storage) DeleteReminder(ctx context.Context, id string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	_, err := s.db.ExecContext(ctx, `DELETE FROM reminders WHERE id=?`, id)
+	_, err := s.conn.ExecContext(ctx, `DELETE FROM reminders WHERE id=?`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete reminder: %w", err)
 	}
@@ -86,7 +86,7 @@ func (s *Storage) DeleteReminder(ctx context.Context, id string) error {
 // --- helpers ---
 
 func (s *Storage) queryReminders(ctx context.Context, query string) ([]*domain.Reminder, error) {
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.conn.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query reminders: %w", err)
 	}
@@ -96,7 +96,7 @@ func (s *Storage) queryReminders(ctx context.Context, query string) ([]*domain.R
 	for rows.Next() {
 		var r domain.Reminder
 		var freqStr string
-		err := rows.Scan(&r.ID, &r.BookID, &r.BookTitle, &r.Label, &r.Hour, &r.Minute, &freqStr, &r.Enabled, &r.NextRing, &r.CreatedAt)
+		err := rows.Scan(&r.ID, &r.BookID, &r.BookTitle, &r.Label, &r.Hour, &r.Minute, &freqStr, &r.Enabled, &r.NextRing, &r.CreatedAt, &r.SkipIfReadToday)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan reminder: %w", err)
 		}
@@ -109,7 +109,7 @@ func (s *Storage) queryReminders(ctx context.Context, query string) ([]*domain.R
 func scanReminder(row rowScanner) (*domain.Reminder, error) {
 	var r domain.Reminder
 	var freqStr string
-	err := row.Scan(&r.ID, &r.BookID, &r.BookTitle, &r.Label, &r.Hour, &r.Minute, &freqStr, &r.Enabled, &r.NextRing, &r.CreatedAt)
+	err := row.Scan(&r.ID, &r.BookID, &r.BookTitle, &r.Label, &r.Hour, &r.Minute, &freqStr, &r.Enabled, &r.NextRing, &r.CreatedAt, &r.SkipIfReadToday)
 	if err != nil {
 		return nil, err
 	}
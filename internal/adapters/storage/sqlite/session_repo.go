@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -17,10 +18,10 @@ func (s *Storage) SaveSession(ctx context.Context, session *domain.ReadingSessio
 	if session.SessionID == "" {
 		return fmt.Errorf("session id is required")
 	}
-	_, err := s.db.ExecContext(ctx,
-		`INSERT OR REPLACE INTO sessions (session_id, book_id, current_page, last_read_time)
-		 VALUES (?, ?, ?, ?)`,
-		session.SessionID, session.BookID, session.CurrentPage, session.LastReadingTime)
+	_, err := s.conn.ExecContext(ctx,
+		`INSERT OR REPLACE INTO sessions (session_id, book_id, current_page, started_at, last_read_time)
+		 VALUES (?, ?, ?, ?, ?)`,
+		session.SessionID, session.BookID, session.CurrentPage, nullTime(session.StartedAt), session.LastReadingTime)
 	return err
 }
 
@@ -28,8 +29,8 @@ func (s *Storage) SaveSession(ctx context.Context, session *domain.ReadingSessio
 func (s *Storage) GetSessionByID(ctx context.Context, bookID string) ([]*domain.ReadingSession, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT s.session_id, s.book_id, s.current_page, s.last_read_time,
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT s.session_id, s.book_id, s.current_page, s.started_at, s.last_read_time,
 		       COALESCE(b.total_pages, 0)
 		FROM sessions s
 		LEFT JOIN books b ON b.id = s.book_id
@@ -41,10 +42,12 @@ func (s *Storage) GetSessionByID(ctx context.Context, bookID string) ([]*domain.
 	var out []*domain.ReadingSession
 	for rows.Next() {
 		ses := &domain.ReadingSession{}
+		var startedAt sql.NullTime
 		if err := rows.Scan(&ses.SessionID, &ses.BookID, &ses.CurrentPage,
-			&ses.LastReadingTime, &ses.TotalPages); err != nil {
+			&startedAt, &ses.LastReadingTime, &ses.TotalPages); err != nil {
 			return nil, err
 		}
+		ses.StartedAt = startedAt.Time
 		out = append(out, ses)
 	}
 	return out, rows.Err()
@@ -54,8 +57,8 @@ func (s *Storage) GetSessionByID(ctx context.Context, bookID string) ([]*domain.
 func (s *Storage) GetLastReadingSession(ctx context.Context, bookId string) (*domain.ReadingSession, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT s.session_id, s.book_id, s.current_page, s.last_read_time,
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT s.session_id, s.book_id, s.current_page, s.started_at, s.last_read_time,
 		       COALESCE(b.total_pages, 0)
 		FROM sessions s
 		LEFT JOIN books b ON b.id = s.book_id
@@ -67,11 +70,48 @@ func (s *Storage) GetLastReadingSession(ctx context.Context, bookId string) (*do
 	defer rows.Close()
 	if rows.Next() {
 		var ses domain.ReadingSession
+		var startedAt sql.NullTime
 		if err := rows.Scan(&ses.SessionID, &ses.BookID, &ses.CurrentPage,
-			&ses.LastReadingTime, &ses.TotalPages); err != nil {
+			&startedAt, &ses.LastReadingTime, &ses.TotalPages); err != nil {
 			return nil, err
 		}
+		ses.StartedAt = startedAt.Time
 		return &ses, nil
 	}
 	return nil, nil
 }
+
+// ListSessionsSince returns all sessions read at or after since, most recent first.
+func (s *Storage) ListSessionsSince(ctx context.Context, since time.Time) ([]*domain.ReadingSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT s.session_id, s.book_id, s.current_page, s.started_at, s.last_read_time,
+		       COALESCE(b.total_pages, 0)
+		FROM sessions s
+		LEFT JOIN books b ON b.id = s.book_id
+		WHERE s.last_read_time >= ?
+		ORDER BY s.last_read_time DESC`, since)
+	if err != nil {
+		return nil, fmt.Errorf("ListSessionsSince: %w", err)
+	}
+	defer rows.Close()
+	var out []*domain.ReadingSession
+	for rows.Next() {
+		ses := &domain.ReadingSession{}
+		var startedAt sql.NullTime
+		if err := rows.Scan(&ses.SessionID, &ses.BookID, &ses.CurrentPage,
+			&startedAt, &ses.LastReadingTime, &ses.TotalPages); err != nil {
+			return nil, err
+		}
+		ses.StartedAt = startedAt.Time
+		out = append(out, ses)
+	}
+	return out, rows.Err()
+}
+
+// nullTime stores the zero time as NULL so legacy rows and unset values scan
+// back as the zero time.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
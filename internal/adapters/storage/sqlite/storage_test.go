@@ -1,14 +1,20 @@
 package sqlite_test
 
 import (
+	"bytes"
 	"context"
-	_ "database/sql"
+	"database/sql"
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/storagetest"
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
 	_ "modernc.org/sqlite" // Use the same driver as main code
 )
 
@@ -36,6 +42,17 @@ func setupTestDB(t *testing.T) (*sqlite.Storage, func()) {
 	return store, cleanup
 }
 
+func TestStorage_Contract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		store, err := sqlite.NewStorage(filepath.Join(t.TempDir(), "orus.db"))
+		if err != nil {
+			t.Fatalf("Could not init storage: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
 // --- BOOK REPO TESTS ---
 
 func TestBookRepository_Lifecycle(t *testing.T) {
@@ -45,7 +62,7 @@ func TestBookRepository_Lifecycle(t *testing.T) {
 	ctx := context.Background()
 
 	// 1. Create & Save
-	book, _ := domain.NewBook("Dune", "Frank Herbert", "/path/dune.pdf", domain.FormatPDF, 800)
+	book, _ := domain.NewBook("Dune", "Frank Herbert", "/path/dune.pdf", domain.FormatPDF, 800, time.Now())
 	// Manually set ID for deterministic testing if needed, or rely on NewBook's UUID
 	if err := store.Save(ctx, book); err != nil {
 		t.Fatalf("Failed to save book: %v", err)
@@ -77,7 +94,7 @@ func TestBookRepository_Lifecycle(t *testing.T) {
 	}
 
 	// 4. ListAll
-	book2, _ := domain.NewBook("Hyperion", "Dan Simmons", "/path/hyp.epub", domain.FormatEPUB, 400)
+	book2, _ := domain.NewBook("Hyperion", "Dan Simmons", "/path/hyp.epub", domain.FormatEPUB, 400, time.Now())
 	store.Save(ctx, book2)
 
 	all, err := store.ListAll(ctx)
@@ -117,7 +134,7 @@ func TestAnnotationRepository(t *testing.T) {
 	ctx := context.Background()
 
 	// Prerequisite: Create a book (Constraint Check)
-	book, _ := domain.NewBook("Notes Book", "Me", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Notes Book", "Me", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// 1. Save Annotation
@@ -203,7 +220,7 @@ func TestSessionRepository(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200)
+	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200, time.Now())
 	store.Save(ctx, book)
 
 	session := &domain.ReadingSession{
@@ -236,7 +253,7 @@ func TestSessionRepository_GetLastReadingSession(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200)
+	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200, time.Now())
 	store.Save(ctx, book)
 
 	// Save multiple sessions at different times
@@ -281,7 +298,7 @@ func TestSessionRepository_GetLastReadingSession_NoSessions(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200)
+	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200, time.Now())
 	store.Save(ctx, book)
 
 	// No sessions saved
@@ -299,7 +316,7 @@ func TestSessionRepository_SaveWithoutSessionID(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200)
+	book, _ := domain.NewBook("Session Book", "Reader", "path", "epub", 200, time.Now())
 	store.Save(ctx, book)
 
 	// Try to save session without session ID
@@ -324,11 +341,11 @@ func TestReminderRepository_Lifecycle(t *testing.T) {
 	ctx := context.Background()
 
 	// Create prerequisite book
-	book, _ := domain.NewBook("Reminder Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Reminder Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// 1. Create and Save Reminder
-	reminder, err := domain.NewReminder(book.ID, book.Title, "Read 30 minutes", 20, 30, domain.FrequencyDaily)
+	reminder, err := domain.NewReminder(book.ID, book.Title, "Read 30 minutes", 20, 30, domain.FrequencyDaily, time.Now())
 	if err != nil {
 		t.Fatalf("Failed to create reminder: %v", err)
 	}
@@ -396,13 +413,13 @@ func TestReminderRepository_ListEnabledReminders(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// Create multiple reminders with different enabled states
-	r1, _ := domain.NewReminder(book.ID, book.Title, "Morning", 8, 0, domain.FrequencyDaily)
-	r2, _ := domain.NewReminder(book.ID, book.Title, "Evening", 20, 0, domain.FrequencyDaily)
-	r3, _ := domain.NewReminder(book.ID, book.Title, "Disabled", 12, 0, domain.FrequencyWeekly)
+	r1, _ := domain.NewReminder(book.ID, book.Title, "Morning", 8, 0, domain.FrequencyDaily, time.Now())
+	r2, _ := domain.NewReminder(book.ID, book.Title, "Evening", 20, 0, domain.FrequencyDaily, time.Now())
+	r3, _ := domain.NewReminder(book.ID, book.Title, "Disabled", 12, 0, domain.FrequencyWeekly, time.Now())
 	r3.Enabled = false
 
 	store.SaveReminder(ctx, r1)
@@ -431,7 +448,7 @@ func TestReminderRepository_GlobalReminder(t *testing.T) {
 	ctx := context.Background()
 
 	// Create global reminder (no book association)
-	reminder, err := domain.NewReminder("", "", "General Reading Time", 18, 0, domain.FrequencyWeekdays)
+	reminder, err := domain.NewReminder("", "", "General Reading Time", 18, 0, domain.FrequencyWeekdays, time.Now())
 	if err != nil {
 		t.Fatalf("Failed to create global reminder: %v", err)
 	}
@@ -460,13 +477,13 @@ func TestReadingSheetRepository_Lifecycle(t *testing.T) {
 	ctx := context.Background()
 
 	// Create prerequisite book
-	book, _ := domain.NewBook("Test Book", "Test Author", "path", domain.FormatEPUB, 300)
+	book, _ := domain.NewBook("Test Book", "Test Author", "path", domain.FormatEPUB, 300, time.Now())
 	store.Save(ctx, book)
 
 	// 1. Create and Save Sheet
 	quotes := []string{"Quote 1", "Quote 2"}
 	tags := []string{"fiction", "adventure"}
-	sheet, err := domain.NewReadingSheet(book.ID, book.Title, "Great book!", 5, quotes, tags)
+	sheet, err := domain.NewReadingSheet(book.ID, book.Title, "Great book!", 5, quotes, tags, time.Now())
 	if err != nil {
 		t.Fatalf("Failed to create reading sheet: %v", err)
 	}
@@ -546,11 +563,11 @@ func TestReadingSheetRepository_EmptyQuotesAndTags(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// Create sheet with empty quotes and tags
-	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Summary", 3, nil, nil)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Summary", 3, nil, nil, time.Now())
 	if err := store.SaveSheet(ctx, sheet); err != nil {
 		t.Fatalf("Failed to save sheet with empty quotes/tags: %v", err)
 	}
@@ -575,7 +592,7 @@ func TestReadingSheetRepository_SpecialCharactersInQuotes(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// Quotes with special characters (avoiding "||" which is the storage separator)
@@ -584,7 +601,7 @@ func TestReadingSheetRepository_SpecialCharactersInQuotes(t *testing.T) {
 		"Quote with \"double quotes\" and 'single quotes'",
 		"Quote with special chars: @#$%^&*()",
 	}
-	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Test", 4, quotes, []string{"tag1", "tag2"})
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Test", 4, quotes, []string{"tag1", "tag2"}, time.Now())
 	if err := store.SaveSheet(ctx, sheet); err != nil {
 		t.Fatalf("Failed to save sheet: %v", err)
 	}
@@ -610,7 +627,7 @@ func TestReadingSheetRepository_SeparatorInQuotes(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// Test that the separator "||" causes issues (known limitation)
@@ -618,7 +635,7 @@ func TestReadingSheetRepository_SeparatorInQuotes(t *testing.T) {
 	quotes := []string{
 		"Quote with || separator inside",
 	}
-	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Test", 4, quotes, []string{"tag1"})
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Test", 4, quotes, []string{"tag1"}, time.Now())
 	if err := store.SaveSheet(ctx, sheet); err != nil {
 		t.Fatalf("Failed to save sheet: %v", err)
 	}
@@ -664,7 +681,7 @@ func TestStorage_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	book, _ := domain.NewBook("Fast Book", "Me", "path", "pdf", 10)
+	book, _ := domain.NewBook("Fast Book", "Me", "path", "pdf", 10, time.Now())
 
 	err := store.Save(ctx, book)
 	if err == nil {
@@ -712,7 +729,7 @@ func TestAnnotationRepository_MultipleAnnotationsOnPage(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Multi Annotation Book", "Author", "path", domain.FormatPDF, 50)
+	book, _ := domain.NewBook("Multi Annotation Book", "Author", "path", domain.FormatPDF, 50, time.Now())
 	store.Save(ctx, book)
 
 	// Save multiple annotations on the same page
@@ -743,7 +760,7 @@ func TestAnnotationRepository_GetByPage_Empty(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Empty Annotation Book", "Author", "path", domain.FormatPDF, 50)
+	book, _ := domain.NewBook("Empty Annotation Book", "Author", "path", domain.FormatPDF, 50, time.Now())
 	store.Save(ctx, book)
 
 	// No annotations saved — expect empty slice, not error
@@ -776,7 +793,7 @@ func TestAnnotationRepository_BookmarkType(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Bookmark Book", "Author", "path", domain.FormatEPUB, 200)
+	book, _ := domain.NewBook("Bookmark Book", "Author", "path", domain.FormatEPUB, 200, time.Now())
 	store.Save(ctx, book)
 
 	// Save a bookmark annotation
@@ -807,12 +824,41 @@ func TestAnnotationRepository_BookmarkType(t *testing.T) {
 	}
 }
 
+func TestAnnotationRepository_NoteRoundTrip(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	book, _ := domain.NewBook("Noted Book", "Author", "path", domain.FormatEPUB, 200, time.Now())
+	store.Save(ctx, book)
+
+	hl := &domain.Annotation{
+		ID:             "hl-note",
+		BookID:         book.ID,
+		AnnotationType: domain.AnnotationHighlight,
+		PageNo:         7,
+		Note:           "À relire, « vraiment »",
+		CreatedAt:      time.Now(),
+	}
+	if err := store.SaveAnnotation(ctx, hl); err != nil {
+		t.Fatalf("Failed to save highlight: %v", err)
+	}
+
+	all, err := store.ListAllAnnotationOfABook(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("ListAllAnnotationOfABook failed: %v", err)
+	}
+	if len(all) != 1 || all[0].Note != hl.Note {
+		t.Errorf("Expected note %q, got %+v", hl.Note, all)
+	}
+}
+
 func TestAnnotationRepository_MixedTypes(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Mixed Annotations Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Mixed Annotations Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	annotations := []*domain.Annotation{
@@ -848,7 +894,7 @@ func TestSessionRepository_TotalPagesFromJoin(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Pages Book", "Author", "path", domain.FormatPDF, 350)
+	book, _ := domain.NewBook("Pages Book", "Author", "path", domain.FormatPDF, 350, time.Now())
 	store.Save(ctx, book)
 
 	session := &domain.ReadingSession{
@@ -890,7 +936,7 @@ func TestSessionRepository_Upsert(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Upsert Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Upsert Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// Save initial session
@@ -929,7 +975,7 @@ func TestReminderRepository_FrequencyOnce(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	reminder, err := domain.NewReminder("", "", "One time reading", 10, 0, domain.FrequencyOnce)
+	reminder, err := domain.NewReminder("", "", "One time reading", 10, 0, domain.FrequencyOnce, time.Now())
 	if err != nil {
 		t.Fatalf("Failed to create once reminder: %v", err)
 	}
@@ -977,7 +1023,7 @@ func TestReminderRepository_WeeklyFrequency(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	reminder, err := domain.NewReminder("", "", "Weekly reading", 9, 0, domain.FrequencyWeekly)
+	reminder, err := domain.NewReminder("", "", "Weekly reading", 9, 0, domain.FrequencyWeekly, time.Now())
 	if err != nil {
 		t.Fatalf("Failed to create weekly reminder: %v", err)
 	}
@@ -1019,10 +1065,10 @@ func TestReadingSheetRepository_MultipleSheets(t *testing.T) {
 	// Create multiple books and sheets
 	for i := 1; i <= 3; i++ {
 		title := "Book " + string(rune('0'+i))
-		book, _ := domain.NewBook(title, "Author", "path/"+title, domain.FormatPDF, 100)
+		book, _ := domain.NewBook(title, "Author", "path/"+title, domain.FormatPDF, 100, time.Now())
 		store.Save(ctx, book)
 
-		sheet, _ := domain.NewReadingSheet(book.ID, title, "Summary for "+title, i, nil, nil)
+		sheet, _ := domain.NewReadingSheet(book.ID, title, "Summary for "+title, i, nil, nil, time.Now())
 		if err := store.SaveSheet(ctx, sheet); err != nil {
 			t.Fatalf("Failed to save sheet %d: %v", i, err)
 		}
@@ -1082,7 +1128,7 @@ func TestBookRepository_CascadeDeleteAnnotations(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	book, _ := domain.NewBook("Cascade Book", "Author", "path", domain.FormatPDF, 100)
+	book, _ := domain.NewBook("Cascade Book", "Author", "path", domain.FormatPDF, 100, time.Now())
 	store.Save(ctx, book)
 
 	// Save annotations linked to the book
@@ -1109,3 +1155,412 @@ func TestBookRepository_CascadeDeleteAnnotations(t *testing.T) {
 		t.Errorf("Expected cascade delete to remove annotations, got %d", len(remaining))
 	}
 }
+
+func TestSessionRepository_ListSessionsSince(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	book1, _ := domain.NewBook("Book One", "A", "p1", domain.FormatPDF, 100, time.Now())
+	book2, _ := domain.NewBook("Book Two", "B", "p2", domain.FormatPDF, 100, time.Now())
+	store.Save(ctx, book1)
+	store.Save(ctx, book2)
+
+	now := time.Now()
+	store.SaveSession(ctx, &domain.ReadingSession{SessionID: "old", BookID: book1.ID, CurrentPage: 1, LastReadingTime: now.Add(-48 * time.Hour)})
+	store.SaveSession(ctx, &domain.ReadingSession{SessionID: "recent-1", BookID: book1.ID, CurrentPage: 5, LastReadingTime: now.Add(-2 * time.Hour)})
+	store.SaveSession(ctx, &domain.ReadingSession{SessionID: "recent-2", BookID: book2.ID, CurrentPage: 9, LastReadingTime: now.Add(-time.Hour)})
+
+	sessions, err := store.ListSessionsSince(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("ListSessionsSince failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 recent sessions, got %d", len(sessions))
+	}
+	if sessions[0].SessionID != "recent-2" {
+		t.Errorf("Expected most recent first, got %s", sessions[0].SessionID)
+	}
+	if sessions[0].TotalPages != 100 {
+		t.Errorf("Expected TotalPages joined from books, got %d", sessions[0].TotalPages)
+	}
+}
+
+func TestReminderRepository_SkipIfReadToday(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	r, _ := domain.NewReminder("", "", "Read", 21, 0, domain.FrequencyDaily, time.Now())
+	r.SkipIfReadToday = true
+	if err := store.SaveReminder(ctx, r); err != nil {
+		t.Fatalf("SaveReminder failed: %v", err)
+	}
+	fetched, _ := store.GetReminderByID(ctx, r.ID)
+	if !fetched.SkipIfReadToday {
+		t.Error("Expected SkipIfReadToday to round-trip on save")
+	}
+
+	fetched.SkipIfReadToday = false
+	if err := store.UpdateReminder(ctx, fetched); err != nil {
+		t.Fatalf("UpdateReminder failed: %v", err)
+	}
+	updated, _ := store.GetReminderByID(ctx, r.ID)
+	if updated.SkipIfReadToday {
+		t.Error("Expected SkipIfReadToday to be cleared on update")
+	}
+}
+
+func TestNewStorage_MigratesLegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+
+	// A database created before migrations existed: reminders has no
+	// skip_if_read_today column and user_version is 0.
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open legacy db: %v", err)
+	}
+	_, err = legacy.Exec(`CREATE TABLE reminders (
+		id TEXT PRIMARY KEY, book_id TEXT DEFAULT '', book_title TEXT DEFAULT '',
+		label TEXT NOT NULL, hour INTEGER NOT NULL, minute INTEGER NOT NULL,
+		frequency TEXT NOT NULL, enabled INTEGER DEFAULT 1, next_ring DATETIME, created_at DATETIME);
+		INSERT INTO reminders (id, label, hour, minute, frequency, next_ring, created_at)
+		VALUES ('legacy', 'Old reminder', 8, 0, 'daily', '2026-01-01 08:00:00', '2026-01-01 08:00:00');`)
+	legacy.Close()
+	if err != nil {
+		t.Fatalf("create legacy schema: %v", err)
+	}
+
+	for i := 0; i < 2; i++ { // the second open must not re-apply migrations
+		store, err := sqlite.NewStorage(dbPath)
+		if err != nil {
+			t.Fatalf("open #%d: %v", i+1, err)
+		}
+		r, err := store.GetReminderByID(context.Background(), "legacy")
+		if err != nil {
+			t.Fatalf("open #%d: expected legacy reminder, got %v", i+1, err)
+		}
+		if r.SkipIfReadToday {
+			t.Errorf("open #%d: expected migrated column to default to false", i+1)
+		}
+		store.Close()
+	}
+
+	// Only the first open had migrations to run, hence a single copy.
+	copies, _ := filepath.Glob(filepath.Join(sqlite.BackupDir(dbPath), "orus-pre-migration-v0-*.db"))
+	if len(copies) != 1 {
+		t.Fatalf("Expected one pre-migration backup, got %v", copies)
+	}
+	if err := sqlite.CheckIntegrity(copies[0]); err != nil {
+		t.Errorf("Expected a sound pre-migration backup, got %v", err)
+	}
+	backup, _ := sql.Open("sqlite", copies[0])
+	defer backup.Close()
+	var version int
+	backup.QueryRow("PRAGMA user_version;").Scan(&version)
+	if version != 0 {
+		t.Errorf("Expected the backup to keep the legacy schema, got version %d", version)
+	}
+}
+
+func TestStorage_SnapshotAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "orus.db")
+	ctx := context.Background()
+
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	if entries, _ := os.ReadDir(sqlite.BackupDir(dbPath)); len(entries) != 0 {
+		t.Errorf("Expected no pre-migration backup for a new database, got %d file(s)", len(entries))
+	}
+	book, _ := domain.NewBook("Saved", "A", "p", domain.FormatPDF, 10, time.Now())
+	store.Save(ctx, book)
+
+	snapshot := filepath.Join(sqlite.BackupDir(dbPath), "orus-20260310-210000.db")
+	if err := store.Snapshot(ctx, snapshot); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if _, err := os.Stat(snapshot + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected the temporary snapshot to be renamed")
+	}
+	lost, _ := domain.NewBook("Lost", "A", "p", domain.FormatPDF, 10, time.Now())
+	store.Save(ctx, lost)
+	store.Close()
+
+	corrupt := filepath.Join(dir, "corrupt.db")
+	os.WriteFile(corrupt, []byte("definitely not a database, just some text"), 0o600)
+	if _, err := sqlite.Restore(corrupt, dbPath); !errors.Is(err, sqlite.ErrCorruptDatabase) {
+		t.Fatalf("Expected ErrCorruptDatabase, got %v", err)
+	}
+
+	previous, err := sqlite.Restore(snapshot, dbPath)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if previous != dbPath+".pre-restore" {
+		t.Errorf("Expected the replaced database to be kept, got %q", previous)
+	}
+
+	restored, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer restored.Close()
+	books, _ := restored.ListAll(ctx)
+	if len(books) != 1 || books[0].Title != "Saved" {
+		t.Errorf("Expected the snapshot content, got %v", books)
+	}
+}
+
+func TestSessionRepository_StartedAtRoundTrip(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	book, _ := domain.NewBook("Timed", "A", "p", domain.FormatPDF, 100, time.Now())
+	store.Save(ctx, book)
+
+	start := time.Now().Add(-30 * time.Minute)
+	ses, _ := domain.NewSession(book.ID, 100, 1, start)
+	ses.UpdatePosition(10, start.Add(30*time.Minute))
+	if err := store.SaveSession(ctx, ses); err != nil {
+		t.Fatalf("SaveSession failed: %v", err)
+	}
+
+	last, err := store.GetLastReadingSession(ctx, book.ID)
+	if err != nil || last == nil {
+		t.Fatalf("GetLastReadingSession failed: %v", err)
+	}
+	if last.Duration().Round(time.Second) != 30*time.Minute {
+		t.Errorf("Expected 30m duration after reload, got %s", last.Duration())
+	}
+}
+
+func TestBookRepository_SeriesAndCoverRoundTrip(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	book, _ := domain.NewBook("Dune", "Frank Herbert", "/path/dune.epub", domain.FormatEPUB, 800, time.Now())
+	book.Series = "Dune"
+	book.SeriesIndex = 1.5
+	book.CoverImage = []byte{0xFF, 0xD8, 0xFF}
+	if err := store.Save(ctx, book); err != nil {
+		t.Fatalf("Failed to save book: %v", err)
+	}
+
+	// Un upsert sans couverture ne doit pas effacer celle déjà stockée.
+	book.CoverImage = nil
+	book.Title = "Dune (édition 2)"
+	if err := store.Save(ctx, book); err != nil {
+		t.Fatalf("Failed to update book: %v", err)
+	}
+
+	fetched, err := store.GetByID(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("Failed to get book: %v", err)
+	}
+	if fetched.Series != "Dune" || fetched.SeriesIndex != 1.5 {
+		t.Errorf("Expected series Dune #1.5, got %q #%v", fetched.Series, fetched.SeriesIndex)
+	}
+	if len(fetched.CoverImage) != 3 {
+		t.Errorf("Expected the cover to be kept, got %d bytes", len(fetched.CoverImage))
+	}
+}
+
+func TestBookRepository_PublicationMetadataRoundTrip(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	book, _ := domain.NewBook("Network Programming with Go", "Jan Newmarch", "/path/go.epub", domain.FormatEPUB, 20, time.Now())
+	book.ISBN = "978-1-4842-2692-6"
+	book.Publisher = "Apress"
+	book.Year = 2017
+	if err := store.Save(ctx, book); err != nil {
+		t.Fatalf("Failed to save book: %v", err)
+	}
+
+	books, err := store.ListAll(ctx)
+	if err != nil || len(books) != 1 {
+		t.Fatalf("Failed to list books: %v", err)
+	}
+	if got := books[0]; got.ISBN != book.ISBN || got.Publisher != "Apress" || got.Year != 2017 {
+		t.Errorf("Expected ISBN, publisher and year to round-trip, got %q %q %d", got.ISBN, got.Publisher, got.Year)
+	}
+}
+
+// --- ENCRYPTION TESTS ---
+
+func TestStorage_EncryptionLifecycle(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "orus.db")
+	ctx := context.Background()
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	book, _ := domain.NewBook("Secret", "A", "p", domain.FormatEPUB, 10, time.Now())
+	store.Save(ctx, book)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Mes pensées intimes", 4, []string{"Une citation", "Une autre"}, []string{"perso"}, time.Now())
+	store.SaveSheet(ctx, sheet)
+	store.SaveAnnotation(ctx, &domain.Annotation{ID: "n1", BookID: book.ID, AnnotationType: domain.AnnotationHighlight, PageNo: 3, Note: "note privée", CreatedAt: time.Now()})
+
+	// rawColumns reads the stored values without going through the repositories.
+	rawColumns := func() string {
+		raw, _ := sql.Open("sqlite", dbPath)
+		defer raw.Close()
+		var summary, quotes, note string
+		raw.QueryRow("SELECT summary, quotes FROM reading_sheets").Scan(&summary, &quotes)
+		raw.QueryRow("SELECT note FROM annotations").Scan(&note)
+		return summary + "|" + quotes + "|" + note
+	}
+
+	if store.EncryptionEnabled() || store.Locked() {
+		t.Fatal("Expected encryption to be off by default")
+	}
+	if err := store.EnableEncryption(ctx, "correct horse"); err != nil {
+		t.Fatalf("EnableEncryption failed: %v", err)
+	}
+	if raw := rawColumns(); strings.Contains(raw, "pensées") || strings.Contains(raw, "citation") || strings.Contains(raw, "privée") {
+		t.Errorf("Expected sensitive columns to be encrypted, got %q", raw)
+	}
+	store.Close()
+
+	store, err = sqlite.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer func() { store.Close() }()
+	if !store.Locked() {
+		t.Fatal("Expected an encrypted database to open locked")
+	}
+	if _, err := store.GetSheetByID(ctx, sheet.ID); !errors.Is(err, port.ErrStoreLocked) {
+		t.Errorf("Expected ErrStoreLocked on read, got %v", err)
+	}
+	if err := store.UpdateSheet(ctx, sheet); !errors.Is(err, port.ErrStoreLocked) {
+		t.Errorf("Expected ErrStoreLocked on write, got %v", err)
+	}
+	if books, err := store.ListAll(ctx); err != nil || len(books) != 1 {
+		t.Errorf("Expected books to stay readable while locked, got %v, %v", books, err)
+	}
+	if err := store.Unlock("wrong horse"); !errors.Is(err, port.ErrWrongPassphrase) {
+		t.Errorf("Expected ErrWrongPassphrase, got %v", err)
+	}
+	if err := store.Unlock("correct horse"); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	fetched, err := store.GetSheetByID(ctx, sheet.ID)
+	if err != nil || fetched.Summary != sheet.Summary || len(fetched.Quotes) != 2 || fetched.Tags[0] != "perso" {
+		t.Fatalf("Expected the decrypted sheet, got %+v, %v", fetched, err)
+	}
+
+	if err := store.ChangePassphrase(ctx, "correct horse", "battery staple"); err != nil {
+		t.Fatalf("ChangePassphrase failed: %v", err)
+	}
+	if notes, _ := store.ListAllAnnotationOfABook(ctx, book.ID); len(notes) != 1 || notes[0].Note != "note privée" {
+		t.Errorf("Expected the note to survive re-encryption, got %+v", notes)
+	}
+	if err := store.DisableEncryption(ctx, "correct horse"); !errors.Is(err, port.ErrWrongPassphrase) {
+		t.Errorf("Expected the old passphrase to be rejected, got %v", err)
+	}
+	if err := store.DisableEncryption(ctx, "battery staple"); err != nil {
+		t.Fatalf("DisableEncryption failed: %v", err)
+	}
+	if raw := rawColumns(); raw != "Mes pensées intimes|Une citation||Une autre|note privée" {
+		t.Errorf("Expected clear text after disabling, got %q", raw)
+	}
+}
+
+func TestStorage_EncryptionLeavesNoFreePages(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "orus.db")
+	ctx := context.Background()
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	defer store.Close()
+
+	// Un long résumé occupe des pages de débordement, libérées au rechiffrement.
+	book, _ := domain.NewBook("Secret", "A", "p", domain.FormatEPUB, 10, time.Now())
+	store.Save(ctx, book)
+	summary := strings.Repeat("TOPSECRET pensées intimes. ", 400)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, summary, 4, nil, nil, time.Now())
+	store.SaveSheet(ctx, sheet)
+
+	if err := store.EnableEncryption(ctx, "correct horse"); err != nil {
+		t.Fatalf("EnableEncryption failed: %v", err)
+	}
+	if file, _ := os.ReadFile(dbPath); bytes.Contains(file, []byte("TOPSECRET")) {
+		t.Error("Expected no clear text left in the database file")
+	}
+}
+
+func TestStorage_WithTxWritersWait(t *testing.T) {
+	store, err := sqlite.NewStorage(filepath.Join(t.TempDir(), "orus.db"))
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	book, _ := domain.NewBook("Dune", "A", "p", domain.FormatEPUB, 10, time.Now())
+	other, _ := domain.NewBook("Solaris", "A", "q", domain.FormatEPUB, 10, time.Now())
+	done := make(chan error, 1)
+	err = store.WithTx(ctx, func(repos port.Repositories) error {
+		if err := repos.Books.Save(ctx, book); err != nil {
+			return err
+		}
+		// Un autre écrivain attend le COMMIT au lieu d'échouer sur SQLITE_BUSY.
+		go func() { done <- store.Save(ctx, other) }()
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Expected the concurrent write to wait for the commit, got %v", err)
+	}
+	if books, _ := store.ListAll(ctx); len(books) != 2 {
+		t.Errorf("Expected both books, got %d", len(books))
+	}
+}
+
+func TestStorage_WithTxEncrypts(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "orus.db")
+	store, err := sqlite.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	if err := store.EnableEncryption(ctx, "correct horse"); err != nil {
+		t.Fatalf("EnableEncryption failed: %v", err)
+	}
+
+	book, _ := domain.NewBook("Secret", "A", "p", domain.FormatEPUB, 10, time.Now())
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Mes pensées intimes", 4, nil, nil, time.Now())
+	err = store.WithTx(ctx, func(repos port.Repositories) error {
+		if err := repos.Books.Save(ctx, book); err != nil {
+			return err
+		}
+		return repos.Sheets.SaveSheet(ctx, sheet)
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	raw, _ := sql.Open("sqlite", dbPath)
+	defer raw.Close()
+	var summary string
+	raw.QueryRow("SELECT summary FROM reading_sheets").Scan(&summary)
+	if strings.Contains(summary, "pensées") {
+		t.Errorf("Expected the summary written in the transaction to be encrypted, got %q", summary)
+	}
+	if fetched, err := store.GetSheetByID(ctx, sheet.ID); err != nil || fetched.Summary != sheet.Summary {
+		t.Errorf("Expected the decrypted sheet, got %+v, %v", fetched, err)
+	}
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.UnitOfWork = (*Storage)(nil)
+
+// querier runs the statements of the repositories: the database itself, or
+// the transaction of a unit of work.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// WithTx runs fn in a BEGIN IMMEDIATE transaction, committed when fn returns
+// nil and rolled back otherwise. The repositories handed to fn share the
+// encryption state of s. Other writers wait for the commit, 5 seconds at
+// most, so fn should stay short.
+func (s *Storage) WithTx(ctx context.Context, fn func(repos port.Repositories) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		// Erreur ou panique de fn : rien de ce qu'elle a écrit ne reste.
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	bound := &Storage{db: s.db, conn: tx, crypt: s.crypt}
+	if err := fn(bound.repositories()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+func (s *Storage) repositories() port.Repositories {
+	return port.Repositories{Books: s, Sessions: s, Annotations: s, Sheets: s, Reminders: s}
+}
@@ -0,0 +1,635 @@
+// Package storagetest holds the contract every implementation of the port
+// repositories must honour. The SQLite and in-memory storages both run it, so
+// that a service tested against one behaves the same on the other.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// Store is the set of repositories under test, with the unit of work that
+// groups their writes.
+type Store interface {
+	port.BookRepository
+	port.SessionRepository
+	port.AnnotationRepository
+	port.ReadingSheetRepository
+	port.ReminderRepository
+	port.UnitOfWork
+}
+
+// Run runs the contract against the stores returned by open, one fresh and
+// empty store per subtest.
+func Run(t *testing.T, open func(t *testing.T) Store) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s Store)
+	}{
+		{"BookLifecycle", testBookLifecycle},
+		{"BookUpsertKeepsImportFields", testBookUpsert},
+		{"BookDeleteCascades", testBookDeleteCascades},
+		{"SessionOrdering", testSessionOrdering},
+		{"SessionNeedsBook", testSessionNeedsBook},
+		{"AnnotationQueries", testAnnotationQueries},
+		{"AnnotationNeedsBook", testAnnotationNeedsBook},
+		{"SheetLifecycle", testSheetLifecycle},
+		{"SheetOrdering", testSheetOrdering},
+		{"ReminderLifecycle", testReminderLifecycle},
+		{"ReminderOrdering", testReminderOrdering},
+		{"DuplicateIDs", testDuplicateIDs},
+		{"ReturnsCopies", testReturnsCopies},
+		{"CancelledContext", testCancelledContext},
+		{"UnitOfWorkCommits", testUnitOfWorkCommits},
+		{"UnitOfWorkRollsBack", testUnitOfWorkRollsBack},
+		{"UnitOfWorkPanics", testUnitOfWorkPanics},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { tt.run(t, open(t)) })
+	}
+}
+
+var now = time.Date(2026, time.March, 10, 21, 0, 0, 0, time.UTC)
+
+func newBook(t *testing.T, s Store, title string) *domain.Book {
+	t.Helper()
+	book, err := domain.NewBook(title, "Auteur", "/livres/"+title+".epub", domain.FormatEPUB, 300, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(context.Background(), book); err != nil {
+		t.Fatalf("Save(%q): %v", title, err)
+	}
+	return book
+}
+
+func bookTitles(books []*domain.Book) []string {
+	titles := make([]string, len(books))
+	for i, b := range books {
+		titles[i] = b.Title
+	}
+	return titles
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testBookLifecycle(t *testing.T, s Store) {
+	ctx := context.Background()
+	if books, err := s.ListAll(ctx); err != nil || len(books) != 0 {
+		t.Fatalf("expected an empty library, got %d books, %v", len(books), err)
+	}
+	if _, err := s.GetByID(ctx, "absent"); !errors.Is(err, domain.ErrBookNotFound) {
+		t.Errorf("expected ErrBookNotFound, got %v", err)
+	}
+	if err := s.Delete(ctx, "absent"); err != nil {
+		t.Errorf("expected deleting an absent book to succeed, got %v", err)
+	}
+
+	dune := newBook(t, s, "Dune")
+	dune.Series, dune.SeriesIndex, dune.ISBN, dune.Publisher, dune.Year = "Dune", 1, "9780441013593", "Ace", 1965
+	dune.CoverImage = []byte{0xff, 0xd8}
+	if err := s.Save(ctx, dune); err != nil {
+		t.Fatal(err)
+	}
+	newBook(t, s, "Solaris")
+	newBook(t, s, "Akira")
+
+	got, err := s.GetByID(ctx, dune.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Title != "Dune" || got.Author != "Auteur" || got.FilePath != dune.FilePath || got.Format != domain.FormatEPUB ||
+		got.TotalPages != 300 || !got.AddedAt.Equal(now) || got.Series != "Dune" || got.SeriesIndex != 1 ||
+		got.ISBN != dune.ISBN || got.Publisher != "Ace" || got.Year != 1965 || string(got.CoverImage) != "\xff\xd8" {
+		t.Errorf("book not stored as saved: %+v", got)
+	}
+
+	// Les livres sont listés dans l'ordre d'ajout, même après une mise à jour.
+	books, _ := s.ListAll(ctx)
+	if titles := bookTitles(books); !equal(titles, []string{"Dune", "Solaris", "Akira"}) {
+		t.Errorf("expected the order of addition, got %v", titles)
+	}
+
+	if err := s.Delete(ctx, dune.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetByID(ctx, dune.ID); !errors.Is(err, domain.ErrBookNotFound) {
+		t.Errorf("expected ErrBookNotFound after Delete, got %v", err)
+	}
+}
+
+func testBookUpsert(t *testing.T, s Store) {
+	ctx := context.Background()
+	book := newBook(t, s, "Dune")
+	book.CoverImage = []byte("cover")
+	_ = s.Save(ctx, book)
+
+	// Saving again updates the file and metadata, but not what the import
+	// decided: author, format, page count and date of addition.
+	edited := *book
+	edited.Title, edited.FilePath, edited.Publisher = "Dune (poche)", "/ailleurs/dune.epub", "Pocket"
+	edited.Author, edited.Format, edited.TotalPages, edited.AddedAt = "Autre", domain.FormatPDF, 10, now.Add(time.Hour)
+	edited.CoverImage = nil
+	if err := s.Save(ctx, &edited); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.GetByID(ctx, book.ID)
+	if got.Title != "Dune (poche)" || got.FilePath != "/ailleurs/dune.epub" || got.Publisher != "Pocket" {
+		t.Errorf("expected title, path and publisher updated, got %+v", got)
+	}
+	if got.Author != "Auteur" || got.Format != domain.FormatEPUB || got.TotalPages != 300 || !got.AddedAt.Equal(now) {
+		t.Errorf("expected author, format, pages and date kept, got %+v", got)
+	}
+	if string(got.CoverImage) != "cover" {
+		t.Errorf("expected an empty cover not to erase the stored one, got %q", got.CoverImage)
+	}
+	if books, _ := s.ListAll(ctx); len(books) != 1 {
+		t.Errorf("expected a single book after the upsert, got %d", len(books))
+	}
+}
+
+func testBookDeleteCascades(t *testing.T, s Store) {
+	ctx := context.Background()
+	book, other := newBook(t, s, "Dune"), newBook(t, s, "Solaris")
+	for _, b := range []*domain.Book{book, other} {
+		ses, _ := domain.NewSession(b.ID, b.TotalPages, 12, now)
+		annot, _ := domain.NewAnnotation(b.ID, domain.AnnotationHighlight, 12, now)
+		sheet, _ := domain.NewReadingSheet(b.ID, b.Title, "Resume", 4, nil, nil, now)
+		if err := s.SaveSession(ctx, ses); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SaveAnnotation(ctx, annot); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SaveSheet(ctx, sheet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reminder, _ := domain.NewReminder(book.ID, book.Title, "Lire", 21, 0, domain.FrequencyDaily, now)
+	_ = s.SaveReminder(ctx, reminder)
+
+	if err := s.Delete(ctx, book.ID); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, _ := s.GetSessionByID(ctx, book.ID); len(sessions) != 0 {
+		t.Errorf("expected the sessions of the book deleted, got %d", len(sessions))
+	}
+	if annots, _ := s.ListAllAnnotationOfABook(ctx, book.ID); len(annots) != 0 {
+		t.Errorf("expected the annotations of the book deleted, got %d", len(annots))
+	}
+	if _, err := s.GetSheetByBookID(ctx, book.ID); !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		t.Errorf("expected the sheet of the book deleted, got %v", err)
+	}
+	if _, err := s.GetReminderByID(ctx, reminder.ID); err != nil {
+		t.Errorf("expected reminders to outlive their book, got %v", err)
+	}
+	if sessions, _ := s.GetSessionByID(ctx, other.ID); len(sessions) != 1 {
+		t.Errorf("expected the other book untouched, got %d sessions", len(sessions))
+	}
+	if sheets, _ := s.ListAllSheets(ctx); len(sheets) != 1 {
+		t.Errorf("expected the other sheet untouched, got %d", len(sheets))
+	}
+}
+
+func testSessionOrdering(t *testing.T, s Store) {
+	ctx := context.Background()
+	book, other := newBook(t, s, "Dune"), newBook(t, s, "Solaris")
+
+	if last, err := s.GetLastReadingSession(ctx, book.ID); err != nil || last != nil {
+		t.Fatalf("expected no session yet, got %+v, %v", last, err)
+	}
+	if err := s.SaveSession(ctx, &domain.ReadingSession{BookID: book.ID}); err == nil {
+		t.Error("expected a session without ID to be refused")
+	}
+
+	save := func(id, bookID string, page int, at time.Time) *domain.ReadingSession {
+		ses := &domain.ReadingSession{SessionID: id, BookID: bookID, CurrentPage: page, StartedAt: at.Add(-time.Hour), LastReadingTime: at}
+		if err := s.SaveSession(ctx, ses); err != nil {
+			t.Fatalf("SaveSession(%s): %v", id, err)
+		}
+		return ses
+	}
+	save("old", book.ID, 10, now.Add(-48*time.Hour))
+	recent := save("recent", book.ID, 80, now.Add(-time.Hour))
+	save("middle", book.ID, 40, now.Add(-24*time.Hour))
+	save("other", other.ID, 5, now.Add(-30*time.Minute))
+	legacy := &domain.ReadingSession{SessionID: "legacy", BookID: other.ID, CurrentPage: 2, LastReadingTime: now.Add(-72 * time.Hour)}
+	_ = s.SaveSession(ctx, legacy)
+
+	last, err := s.GetLastReadingSession(ctx, book.ID)
+	if err != nil || last == nil || last.SessionID != "recent" {
+		t.Fatalf("expected the most recent session, got %+v, %v", last, err)
+	}
+	if last.TotalPages != 300 || last.CurrentPage != 80 || !last.StartedAt.Equal(recent.StartedAt) || !last.LastReadingTime.Equal(recent.LastReadingTime) {
+		t.Errorf("session not stored as saved, with the pages of its book: %+v", last)
+	}
+	if sessions, _ := s.GetSessionByID(ctx, other.ID); len(sessions) != 2 {
+		t.Errorf("expected 2 sessions for the other book, got %d", len(sessions))
+	}
+	byID, _ := s.GetSessionByID(ctx, other.ID)
+	for _, ses := range byID {
+		if ses.SessionID == "legacy" && !ses.StartedAt.IsZero() {
+			t.Errorf("expected an unset start to stay zero, got %s", ses.StartedAt)
+		}
+	}
+
+	since, _ := s.ListSessionsSince(ctx, now.Add(-24*time.Hour))
+	var ids []string
+	for _, ses := range since {
+		ids = append(ids, ses.SessionID)
+	}
+	if !equal(ids, []string{"other", "recent", "middle"}) {
+		t.Errorf("expected the sessions since yesterday, most recent first, got %v", ids)
+	}
+
+	// Enregistrer de nouveau une session la remplace.
+	recent.CurrentPage = 120
+	_ = s.SaveSession(ctx, recent)
+	if sessions, _ := s.GetSessionByID(ctx, book.ID); len(sessions) != 3 {
+		t.Errorf("expected the session replaced, got %d sessions", len(sessions))
+	}
+	if last, _ := s.GetLastReadingSession(ctx, book.ID); last.CurrentPage != 120 {
+		t.Errorf("expected page 120 after saving again, got %d", last.CurrentPage)
+	}
+	if sessions, err := s.GetSessionByID(ctx, "absent"); err != nil || len(sessions) != 0 {
+		t.Errorf("expected no session for an unknown book, got %d, %v", len(sessions), err)
+	}
+}
+
+func testSessionNeedsBook(t *testing.T, s Store) {
+	ses, _ := domain.NewSession("absent", 100, 1, now)
+	if err := s.SaveSession(context.Background(), ses); err == nil {
+		t.Error("expected a session of an unknown book to be refused")
+	}
+}
+
+func testAnnotationQueries(t *testing.T, s Store) {
+	ctx := context.Background()
+	book, other := newBook(t, s, "Dune"), newBook(t, s, "Solaris")
+	add := func(bookID string, kind domain.AnnotationType, page int, note string) *domain.Annotation {
+		a, _ := domain.NewAnnotation(bookID, kind, page, now)
+		a.Note = note
+		if err := s.SaveAnnotation(ctx, a); err != nil {
+			t.Fatalf("SaveAnnotation: %v", err)
+		}
+		return a
+	}
+	first := add(book.ID, domain.AnnotationHighlight, 42, "La peur tue l'esprit")
+	add(book.ID, domain.AnnotationBookmark, 42, "")
+	add(book.ID, domain.AnnotationHighlight, 7, "")
+	add(other.ID, domain.AnnotationHighlight, 42, "")
+
+	onPage, _ := s.GetAnnotationByPage(ctx, 42, book.ID)
+	if len(onPage) != 2 || onPage[0].ID != first.ID {
+		t.Fatalf("expected the 2 annotations of page 42 in order of addition, got %d", len(onPage))
+	}
+	if got := onPage[0]; got.Note != "La peur tue l'esprit" || got.AnnotationType != domain.AnnotationHighlight ||
+		got.PageNo != 42 || got.BookID != book.ID || !got.CreatedAt.Equal(now) {
+		t.Errorf("annotation not stored as saved: %+v", got)
+	}
+	if all, _ := s.ListAllAnnotationOfABook(ctx, book.ID); len(all) != 3 {
+		t.Errorf("expected 3 annotations for the book, got %d", len(all))
+	}
+	if highlights, _ := s.GetAnnotationByType(ctx, string(domain.AnnotationHighlight)); len(highlights) != 3 {
+		t.Errorf("expected 3 highlights across books, got %d", len(highlights))
+	}
+	if none, err := s.GetAnnotationByPage(ctx, 1, book.ID); err != nil || len(none) != 0 {
+		t.Errorf("expected no annotation on page 1, got %d, %v", len(none), err)
+	}
+
+	if err := s.DeleteAnnotation(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteAnnotation(ctx, "absent"); err != nil {
+		t.Errorf("expected deleting an absent annotation to succeed, got %v", err)
+	}
+	if onPage, _ := s.GetAnnotationByPage(ctx, 42, book.ID); len(onPage) != 1 {
+		t.Errorf("expected 1 annotation left on page 42, got %d", len(onPage))
+	}
+}
+
+func testAnnotationNeedsBook(t *testing.T, s Store) {
+	a, _ := domain.NewAnnotation("absent", domain.AnnotationBookmark, 1, now)
+	if err := s.SaveAnnotation(context.Background(), a); err == nil {
+		t.Error("expected an annotation of an unknown book to be refused")
+	}
+}
+
+func testSheetLifecycle(t *testing.T, s Store) {
+	ctx := context.Background()
+	book := newBook(t, s, "Dune")
+	if _, err := s.GetSheetByID(ctx, "absent"); !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		t.Errorf("expected ErrReadingSheetNotFound, got %v", err)
+	}
+	if _, err := s.GetSheetByBookID(ctx, book.ID); !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		t.Errorf("expected ErrReadingSheetNotFound for a book without sheet, got %v", err)
+	}
+
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Sur Arrakis", 4, []string{"La peur tue l'esprit"}, []string{"sf", "classique"}, now)
+	if err := s.SaveSheet(ctx, sheet); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetSheetByBookID(ctx, book.ID)
+	if err != nil || got.ID != sheet.ID {
+		t.Fatalf("expected the sheet of the book, got %+v, %v", got, err)
+	}
+	if got.BookTitle != "Dune" || got.Summary != "Sur Arrakis" || got.Rating != 4 || !equal(got.Quotes, sheet.Quotes) ||
+		!equal(got.Tags, sheet.Tags) || !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+		t.Errorf("sheet not stored as saved: %+v", got)
+	}
+
+	// UpdateSheet ne touche ni au livre ni au titre, et garde la date fournie.
+	later := now.Add(time.Hour)
+	got.Summary, got.Rating, got.Quotes, got.Tags, got.UpdatedAt = "Relu", 5, nil, nil, later
+	got.BookID, got.BookTitle = "autre", "Autre"
+	if err := s.UpdateSheet(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := s.GetSheetByID(ctx, sheet.ID)
+	if updated.Summary != "Relu" || updated.Rating != 5 || updated.Quotes != nil || updated.Tags != nil {
+		t.Errorf("expected the sheet updated, with empty quotes and tags read as nil, got %+v", updated)
+	}
+	if updated.BookID != book.ID || updated.BookTitle != "Dune" {
+		t.Errorf("expected book and title kept, got %q, %q", updated.BookID, updated.BookTitle)
+	}
+	if !updated.UpdatedAt.Equal(later) || !got.UpdatedAt.Equal(later) {
+		t.Errorf("expected UpdateSheet to store the given UpdatedAt %s, got %s (caller has %s)", later, updated.UpdatedAt, got.UpdatedAt)
+	}
+	if err := s.UpdateSheet(ctx, &domain.ReadingSheet{ID: "absent"}); err != nil {
+		t.Errorf("expected updating an absent sheet to be a no-op, got %v", err)
+	}
+
+	if err := s.DeleteSheet(ctx, sheet.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteSheet(ctx, "absent"); err != nil {
+		t.Errorf("expected deleting an absent sheet to succeed, got %v", err)
+	}
+	if _, err := s.GetSheetByID(ctx, sheet.ID); !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		t.Errorf("expected ErrReadingSheetNotFound after DeleteSheet, got %v", err)
+	}
+
+	orphan, _ := domain.NewReadingSheet("absent", "Absent", "", 0, nil, nil, now)
+	if err := s.SaveSheet(ctx, orphan); err == nil {
+		t.Error("expected a sheet of an unknown book to be refused")
+	}
+}
+
+func testSheetOrdering(t *testing.T, s Store) {
+	ctx := context.Background()
+	for i, title := range []string{"Ancien", "Recent", "Milieu"} {
+		book := newBook(t, s, title)
+		at := []time.Time{now.Add(-48 * time.Hour), now, now.Add(-24 * time.Hour)}[i]
+		sheet, _ := domain.NewReadingSheet(book.ID, title, "", 0, nil, nil, at)
+		if err := s.SaveSheet(ctx, sheet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sheets, _ := s.ListAllSheets(ctx)
+	var titles []string
+	for _, sh := range sheets {
+		titles = append(titles, sh.BookTitle)
+	}
+	if !equal(titles, []string{"Recent", "Milieu", "Ancien"}) {
+		t.Errorf("expected the sheets most recently updated first, got %v", titles)
+	}
+}
+
+func testReminderLifecycle(t *testing.T, s Store) {
+	ctx := context.Background()
+	if _, err := s.GetReminderByID(ctx, "absent"); !errors.Is(err, domain.ErrReminderNotFound) {
+		t.Errorf("expected ErrReminderNotFound, got %v", err)
+	}
+	// Les rappels globaux n'ont pas de livre : aucune contrainte sur BookID.
+	r, _ := domain.NewReminder("", "", "Lire un peu", 21, 30, domain.FrequencyWeekdays, now)
+	r.SkipIfReadToday = true
+	if err := s.SaveReminder(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetReminderByID(ctx, r.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Label != "Lire un peu" || got.Hour != 21 || got.Minute != 30 || got.Frequency != domain.FrequencyWeekdays ||
+		!got.Enabled || !got.NextRing.Equal(r.NextRing) || !got.CreatedAt.Equal(now) || !got.SkipIfReadToday {
+		t.Errorf("reminder not stored as saved: %+v", got)
+	}
+
+	got.Label, got.Enabled, got.Frequency, got.SkipIfReadToday = "Lire", false, domain.FrequencyOnce, false
+	got.BookID, got.BookTitle = "autre", "Autre"
+	if err := s.UpdateReminder(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := s.GetReminderByID(ctx, r.ID)
+	if updated.Label != "Lire" || updated.Enabled || updated.Frequency != domain.FrequencyOnce || updated.SkipIfReadToday {
+		t.Errorf("expected the reminder updated, got %+v", updated)
+	}
+	if updated.BookID != "" || updated.BookTitle != "" {
+		t.Errorf("expected UpdateReminder to keep the book, got %q, %q", updated.BookID, updated.BookTitle)
+	}
+	if err := s.UpdateReminder(ctx, &domain.Reminder{ID: "absent"}); err != nil {
+		t.Errorf("expected updating an absent reminder to be a no-op, got %v", err)
+	}
+
+	if err := s.DeleteReminder(ctx, r.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteReminder(ctx, "absent"); err != nil {
+		t.Errorf("expected deleting an absent reminder to succeed, got %v", err)
+	}
+	if all, err := s.ListAllReminders(ctx); err != nil || len(all) != 0 {
+		t.Errorf("expected no reminder left, got %d, %v", len(all), err)
+	}
+}
+
+func testReminderOrdering(t *testing.T, s Store) {
+	ctx := context.Background()
+	add := func(label string, hour, minute int, enabled bool) {
+		r, _ := domain.NewReminder("", "", label, hour, minute, domain.FrequencyDaily, now)
+		r.Enabled = enabled
+		if err := s.SaveReminder(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// À 21h, le rappel de 22h sonne avant ceux de 7h et 9h le lendemain.
+	add("soir", 22, 0, true)
+	add("matin", 9, 15, true)
+	add("aube", 7, 0, true)
+	add("midi", 12, 0, false)
+
+	labels := func(rs []*domain.Reminder) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.Label)
+		}
+		return out
+	}
+	all, _ := s.ListAllReminders(ctx)
+	if got := labels(all); !equal(got, []string{"aube", "matin", "midi", "soir"}) {
+		t.Errorf("expected all reminders by time of day, got %v", got)
+	}
+	enabled, _ := s.ListEnabledReminders(ctx)
+	if got := labels(enabled); !equal(got, []string{"soir", "aube", "matin"}) {
+		t.Errorf("expected the enabled reminders by next ring, got %v", got)
+	}
+}
+
+func testDuplicateIDs(t *testing.T, s Store) {
+	ctx := context.Background()
+	book := newBook(t, s, "Dune")
+	annot, _ := domain.NewAnnotation(book.ID, domain.AnnotationBookmark, 1, now)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "", 0, nil, nil, now)
+	reminder, _ := domain.NewReminder("", "", "Lire", 8, 0, domain.FrequencyDaily, now)
+	for name, save := range map[string]func() error{
+		"annotation": func() error { return s.SaveAnnotation(ctx, annot) },
+		"sheet":      func() error { return s.SaveSheet(ctx, sheet) },
+		"reminder":   func() error { return s.SaveReminder(ctx, reminder) },
+	} {
+		if err := save(); err != nil {
+			t.Fatalf("first %s save: %v", name, err)
+		}
+		if err := save(); err == nil {
+			t.Errorf("expected a second %s with the same ID to be refused", name)
+		}
+	}
+}
+
+func testReturnsCopies(t *testing.T, s Store) {
+	ctx := context.Background()
+	book := newBook(t, s, "Dune")
+	book.Title = "Modifie sans Save"
+	got, _ := s.GetByID(ctx, book.ID)
+	got.Title = "Modifie aussi"
+	if again, _ := s.GetByID(ctx, book.ID); again.Title != "Dune" {
+		t.Errorf("expected the stored book unaffected by callers, got %q", again.Title)
+	}
+
+	sheet, _ := domain.NewReadingSheet(book.ID, "Dune", "", 0, []string{"une"}, nil, now)
+	_ = s.SaveSheet(ctx, sheet)
+	sheet.Quotes[0] = "changee"
+	stored, _ := s.GetSheetByID(ctx, sheet.ID)
+	stored.Quotes = append(stored.Quotes, "deux")
+	if again, _ := s.GetSheetByID(ctx, sheet.ID); !equal(again.Quotes, []string{"une"}) {
+		t.Errorf("expected the stored quotes unaffected by callers, got %v", again.Quotes)
+	}
+}
+
+func testCancelledContext(t *testing.T, s Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	book, _ := domain.NewBook("Dune", "Auteur", "/livres/dune.epub", domain.FormatEPUB, 10, now)
+	if err := s.Save(ctx, book); err == nil {
+		t.Error("expected Save to fail with a cancelled context")
+	}
+	if _, err := s.ListAll(ctx); err == nil {
+		t.Error("expected ListAll to fail with a cancelled context")
+	}
+}
+
+func testUnitOfWorkCommits(t *testing.T, s Store) {
+	ctx := context.Background()
+	book, _ := domain.NewBook("Dune", "Auteur", "/livres/dune.epub", domain.FormatEPUB, 300, now)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Resume", 4, nil, nil, now)
+	err := s.WithTx(ctx, func(repos port.Repositories) error {
+		if err := repos.Books.Save(ctx, book); err != nil {
+			return err
+		}
+		// Les écritures de l'unité de travail lui sont visibles avant le COMMIT.
+		if _, err := repos.Books.GetByID(ctx, book.ID); err != nil {
+			return err
+		}
+		return repos.Sheets.SaveSheet(ctx, sheet)
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if _, err := s.GetByID(ctx, book.ID); err != nil {
+		t.Errorf("expected the book committed, got %v", err)
+	}
+	if _, err := s.GetSheetByBookID(ctx, book.ID); err != nil {
+		t.Errorf("expected the sheet committed, got %v", err)
+	}
+}
+
+func testUnitOfWorkRollsBack(t *testing.T, s Store) {
+	ctx := context.Background()
+	kept := newBook(t, s, "Solaris")
+	reminder, _ := domain.NewReminder(kept.ID, kept.Title, "Lire", 21, 0, domain.FrequencyDaily, now)
+	if err := s.SaveReminder(ctx, reminder); err != nil {
+		t.Fatal(err)
+	}
+	book, _ := domain.NewBook("Dune", "Auteur", "/livres/dune.epub", domain.FormatEPUB, 300, now)
+	errStop := errors.New("stop")
+
+	err := s.WithTx(ctx, func(repos port.Repositories) error {
+		if err := repos.Books.Save(ctx, book); err != nil {
+			return err
+		}
+		ses, _ := domain.NewSession(book.ID, book.TotalPages, 40, now)
+		if err := repos.Sessions.SaveSession(ctx, ses); err != nil {
+			return err
+		}
+		if err := repos.Reminders.DeleteReminder(ctx, reminder.ID); err != nil {
+			return err
+		}
+		if err := repos.Books.Delete(ctx, kept.ID); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	if _, err := s.GetByID(ctx, book.ID); !errors.Is(err, domain.ErrBookNotFound) {
+		t.Errorf("expected the new book rolled back, got %v", err)
+	}
+	if sessions, _ := s.GetSessionByID(ctx, book.ID); len(sessions) != 0 {
+		t.Errorf("expected the session rolled back, got %d", len(sessions))
+	}
+	if _, err := s.GetByID(ctx, kept.ID); err != nil {
+		t.Errorf("expected the deleted book restored, got %v", err)
+	}
+	if _, err := s.GetReminderByID(ctx, reminder.ID); err != nil {
+		t.Errorf("expected the deleted reminder restored, got %v", err)
+	}
+}
+
+func testUnitOfWorkPanics(t *testing.T, s Store) {
+	ctx := context.Background()
+	book, _ := domain.NewBook("Dune", "Auteur", "/livres/dune.epub", domain.FormatEPUB, 300, now)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic of fn to reach the caller")
+			}
+		}()
+		_ = s.WithTx(ctx, func(repos port.Repositories) error {
+			if err := repos.Books.Save(ctx, book); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+	if _, err := s.GetByID(ctx, book.ID); !errors.Is(err, domain.ErrBookNotFound) {
+		t.Errorf("expected the book rolled back, got %v", err)
+	}
+	// La panique n'a laissé ni verrou ni transaction ouverte.
+	if err := s.Save(ctx, book); err != nil {
+		t.Errorf("expected the store usable after a panic, got %v", err)
+	}
+}
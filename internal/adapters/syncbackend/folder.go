@@ -0,0 +1,123 @@
+package syncbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.SyncBackend = (*Folder)(nil)
+
+// Folder is a SyncBackend over a local folder, typically kept in step with
+// the other devices by Syncthing, Nextcloud or Dropbox. ETags are derived
+// from the modification time and size, like most HTTP servers do.
+type Folder struct {
+	root string
+	mu   sync.Mutex // rend atomiques la vérification d'ETag et l'écriture
+}
+
+// NewFolder creates a Folder backend rooted at dir, created if needed.
+func NewFolder(dir string) (*Folder, error) {
+	if dir == "" {
+		return nil, errors.New("empty sync folder")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create sync folder: %w", err)
+	}
+	return &Folder{root: dir}, nil
+}
+
+func (f *Folder) path(name string) string {
+	return filepath.Join(f.root, filepath.FromSlash(path.Clean("/"+name)))
+}
+
+func fileETag(info fs.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+// List returns the files directly inside dir.
+func (f *Folder) List(_ context.Context, dir string) ([]port.SyncObject, error) {
+	entries, err := os.ReadDir(f.path(dir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync folder: %w", err)
+	}
+	var out []port.SyncObject
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, port.SyncObject{Name: path.Join(dir, e.Name()), ETag: fileETag(info), Size: info.Size()})
+	}
+	return out, nil
+}
+
+// Get reads name unless its ETag is still ifNoneMatch.
+func (f *Folder) Get(_ context.Context, name, ifNoneMatch string) ([]byte, string, error) {
+	p := f.path(name)
+	info, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", port.ErrSyncObjectNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	etag := fileETag(info)
+	if ifNoneMatch != "" && ifNoneMatch == etag {
+		return nil, etag, port.ErrSyncNotModified
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, etag, nil
+}
+
+// Put writes name through a temporary file renamed into place, so that the
+// sync tool never uploads a half-written file.
+func (f *Folder) Put(_ context.Context, name string, data []byte, ifMatch string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.path(name)
+	info, err := os.Stat(p)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if ifMatch != "" {
+			return "", port.ErrSyncETagMismatch
+		}
+	case err != nil:
+		return "", err
+	case ifMatch == "" || ifMatch != fileETag(info):
+		return "", port.ErrSyncETagMismatch
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	info, err = os.Stat(p)
+	if err != nil {
+		return "", err
+	}
+	return fileETag(info), nil
+}
@@ -0,0 +1,22 @@
+// Package syncbackend implements port.SyncBackend over a local folder and
+// over WebDAV.
+package syncbackend
+
+import (
+	"fmt"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.SyncBackendOpener = Open
+
+// Open returns the backend described by target.
+func Open(target port.SyncTarget) (port.SyncBackend, error) {
+	switch target.Kind {
+	case port.SyncTargetFolder, "":
+		return NewFolder(target.Location)
+	case port.SyncTargetWebDAV:
+		return NewWebDAV(target.Location, target.Username, target.Password)
+	}
+	return nil, fmt.Errorf("unknown sync target %q", target.Kind)
+}
@@ -0,0 +1,261 @@
+package syncbackend_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/adapters/syncbackend"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// davStandIn is a minimal in-memory WebDAV server: PROPFIND depth 1, GET,
+// HEAD, conditional PUT and MKCOL, behind basic authentication.
+type davStandIn struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	etags map[string]string
+	dirs  map[string]bool
+	seq   int
+}
+
+func newDAVServer(t *testing.T) (*davStandIn, string) {
+	t.Helper()
+	dav := &davStandIn{files: map[string][]byte{}, etags: map[string]string{}, dirs: map[string]bool{"/dav/": true}}
+	srv := httptest.NewServer(dav)
+	t.Cleanup(srv.Close)
+	return dav, srv.URL + "/dav"
+}
+
+func (d *davStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != "lecteur" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := r.URL.Path
+	switch r.Method {
+	case "PROPFIND":
+		dir := strings.TrimSuffix(p, "/") + "/"
+		if !d.dirs[dir] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var b strings.Builder
+		b.WriteString(`<?xml version="1.0"?><d:multistatus xmlns:d="DAV:">`)
+		fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, dir)
+		for name, data := range d.files {
+			if path.Dir(name)+"/" == dir {
+				fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:getetag>%s</d:getetag><d:getcontentlength>%d</d:getcontentlength><d:resourcetype/></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`,
+					strings.ReplaceAll(name, " ", "%20"), d.etags[name], len(data))
+			}
+		}
+		for sub := range d.dirs {
+			if sub != dir && path.Dir(strings.TrimSuffix(sub, "/"))+"/" == dir {
+				fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, sub)
+			}
+		}
+		b.WriteString(`</d:multistatus>`)
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, b.String())
+	case http.MethodGet, http.MethodHead:
+		data, ok := d.files[p]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", d.etags[p])
+		if r.Header.Get("If-None-Match") == d.etags[p] {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodPut:
+		if !d.dirs[path.Dir(p)+"/"] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		current, exists := d.etags[p]
+		if m := r.Header.Get("If-Match"); m != "" && m != current {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		d.seq++
+		d.files[p], d.etags[p] = data, fmt.Sprintf(`"v%d"`, d.seq)
+		w.Header().Set("ETag", d.etags[p])
+		w.WriteHeader(http.StatusCreated)
+	case "MKCOL":
+		dir := strings.TrimSuffix(p, "/") + "/"
+		if d.dirs[dir] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		d.dirs[dir] = true
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// testBackendContract checks the behaviour every SyncBackend must share.
+func testBackendContract(t *testing.T, b port.SyncBackend) {
+	ctx := context.Background()
+	if objs, err := b.List(ctx, "books"); err != nil || len(objs) != 0 {
+		t.Fatalf("expected an empty missing dir, got %v, %v", objs, err)
+	}
+	if _, _, err := b.Get(ctx, "orus-sync-a.jsonl", ""); !errors.Is(err, port.ErrSyncObjectNotFound) {
+		t.Errorf("expected ErrSyncObjectNotFound, got %v", err)
+	}
+
+	etag, err := b.Put(ctx, "orus-sync-a.jsonl", []byte("1\n"), "")
+	if err != nil || etag == "" {
+		t.Fatalf("expected a created file with an ETag, got %q, %v", etag, err)
+	}
+	if _, err := b.Put(ctx, "orus-sync-a.jsonl", []byte("x\n"), ""); !errors.Is(err, port.ErrSyncETagMismatch) {
+		t.Errorf("expected creating an existing file to fail, got %v", err)
+	}
+	time.Sleep(2 * time.Millisecond) // ETag du dossier : date de modification
+	next, err := b.Put(ctx, "orus-sync-a.jsonl", []byte("1\n2\n"), etag)
+	if err != nil || next == etag {
+		t.Fatalf("expected a new ETag, got %q, %v", next, err)
+	}
+	if _, err := b.Put(ctx, "orus-sync-a.jsonl", []byte("lost\n"), etag); !errors.Is(err, port.ErrSyncETagMismatch) {
+		t.Errorf("expected a stale ETag to be refused, got %v", err)
+	}
+
+	data, got, err := b.Get(ctx, "orus-sync-a.jsonl", "")
+	if err != nil || string(data) != "1\n2\n" || got != next {
+		t.Errorf("expected the last write, got %q %q, %v", data, got, err)
+	}
+	if _, _, err := b.Get(ctx, "orus-sync-a.jsonl", next); !errors.Is(err, port.ErrSyncNotModified) {
+		t.Errorf("expected ErrSyncNotModified, got %v", err)
+	}
+
+	if _, err := b.Put(ctx, "books/id 1.epub", []byte("epub"), ""); err != nil {
+		t.Fatalf("expected the parent dir to be created, got %v", err)
+	}
+	root, _ := b.List(ctx, "")
+	books, _ := b.List(ctx, "books")
+	if len(root) != 1 || root[0].Name != "orus-sync-a.jsonl" || root[0].ETag != next || root[0].Size != 4 {
+		t.Errorf("unexpected root listing %+v", root)
+	}
+	if len(books) != 1 || books[0].Name != "books/id 1.epub" {
+		t.Errorf("unexpected books listing %+v", books)
+	}
+}
+
+func TestFolder_Contract(t *testing.T) {
+	b, err := syncbackend.NewFolder(filepath.Join(t.TempDir(), "Nextcloud"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBackendContract(t, b)
+}
+
+func TestWebDAV_Contract(t *testing.T) {
+	_, url := newDAVServer(t)
+	b, err := syncbackend.NewWebDAV(url, "lecteur", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBackendContract(t, b)
+}
+
+func TestWebDAV_Errors(t *testing.T) {
+	_, url := newDAVServer(t)
+	if _, err := syncbackend.NewWebDAV("ftp://exemple.org/dav", "", ""); err == nil {
+		t.Error("expected a non-HTTP URL to be refused")
+	}
+	b, _ := syncbackend.NewWebDAV(url, "lecteur", "faux")
+	if _, err := b.List(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Errorf("expected access denied, got %v", err)
+	}
+	if _, err := syncbackend.Open(port.SyncTarget{Kind: "ftp"}); err == nil {
+		t.Error("expected an unknown target kind to be refused")
+	}
+}
+
+// TestWebDAV_TwoDevices syncs two SQLite libraries through the stand-in.
+func TestWebDAV_TwoDevices(t *testing.T) {
+	ctx := context.Background()
+	dav, url := newDAVServer(t)
+	target := port.SyncTarget{Kind: port.SyncTargetWebDAV, Location: url, Username: "lecteur", Password: "secret"}
+	now := time.Date(2026, 3, 10, 21, 0, 0, 0, time.UTC)
+
+	type device struct {
+		store *sqlite.Storage
+		sync  *service.SyncService
+	}
+	newDevice := func() device {
+		store, err := sqlite.NewStorage(filepath.Join(t.TempDir(), "orus.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { store.Close() })
+		svc := service.NewSyncService(store, store, store, store, store, nil, clock.NewFakeClock(now))
+		svc.SetBackendOpener(syncbackend.Open)
+		if err := svc.SetTarget(target); err != nil {
+			t.Fatal(err)
+		}
+		return device{store, svc}
+	}
+	desktop, laptop := newDevice(), newDevice()
+
+	book, _ := domain.NewBook("Dune", "Frank Herbert", "/livres/dune.epub", domain.FormatEPUB, 600, now)
+	_ = desktop.store.Save(ctx, book)
+	sheet, _ := domain.NewReadingSheet(book.ID, "Dune", "Arrakis", 4, []string{"La peur tue l'esprit"}, nil, now)
+	_ = desktop.store.SaveSheet(ctx, sheet)
+	ses, _ := domain.NewSession(book.ID, 600, 42, now)
+	_ = desktop.store.SaveSession(ctx, ses)
+
+	for i, d := range []device{desktop, laptop, desktop, laptop} {
+		if _, err := d.sync.Sync(ctx); err != nil {
+			t.Fatalf("sync %d failed: %v", i, err)
+		}
+	}
+	got, err := laptop.store.GetSheetByBookID(ctx, book.ID)
+	if err != nil || got.Summary != "Arrakis" || len(got.Quotes) != 1 {
+		t.Fatalf("expected the sheet on the laptop, got %+v, %v", got, err)
+	}
+	if last, err := laptop.store.GetLastReadingSession(ctx, book.ID); err != nil || last.CurrentPage != 42 {
+		t.Errorf("expected the reading position on the laptop, got %+v, %v", last, err)
+	}
+
+	var names []string
+	for name := range dav.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) != 1 {
+		t.Errorf("expected only the desktop log on the server, got %v", names)
+	}
+
+	// Les journaux inchangés ne sont pas retéléchargés.
+	for _, d := range []device{desktop, laptop} {
+		if r, err := d.sync.Sync(ctx); err != nil || r.Exported+r.Applied != 0 {
+			t.Errorf("expected a quiet sync, got %+v, %v", r, err)
+		}
+	}
+}
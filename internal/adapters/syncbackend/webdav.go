@@ -0,0 +1,241 @@
+package syncbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var _ port.SyncBackend = (*WebDAV)(nil)
+
+// propfindBody asks only for the properties List needs.
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:getetag/><d:getcontentlength/><d:resourcetype/></d:prop></d:propfind>`
+
+// WebDAV is a SyncBackend over a WebDAV collection (Nextcloud, ownCloud,
+// Apache mod_dav, rclone serve webdav...). Writes use If-Match and
+// If-None-Match, so a device never overwrites a file changed behind its back.
+type WebDAV struct {
+	base     *url.URL // se termine toujours par "/"
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewWebDAV creates a WebDAV backend for the collection at rawURL. The
+// credentials are sent with HTTP basic authentication when username is set.
+func NewWebDAV(rawURL, username, password string) (*WebDAV, error) {
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebDAV URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid WebDAV URL %q: expected http or https", rawURL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return &WebDAV{base: base, username: username, password: password, client: &http.Client{Timeout: 2 * time.Minute}}, nil
+}
+
+// SetHTTPClient replaces the HTTP client, for proxies or custom certificates.
+func (w *WebDAV) SetHTTPClient(client *http.Client) { w.client = client }
+
+func (w *WebDAV) url(name string) string {
+	u := *w.base
+	u.Path += strings.TrimPrefix(path.Clean("/"+name), "/")
+	return u.String()
+}
+
+func (w *WebDAV) do(ctx context.Context, method, target string, body []byte, header map[string]string) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, r)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	if w.username != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("WebDAV %s: %w", method, err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		return nil, fmt.Errorf("WebDAV %s: access denied (%s)", method, resp.Status)
+	}
+	return resp, nil
+}
+
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ETag         string `xml:"getetag"`
+				Length       string `xml:"getcontentlength"`
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+			Status string `xml:"status"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// List runs a depth-1 PROPFIND on dir.
+func (w *WebDAV) List(ctx context.Context, dir string) ([]port.SyncObject, error) {
+	target := w.url(dir)
+	if !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+	resp, err := w.do(ctx, "PROPFIND", target, []byte(propfindBody), map[string]string{
+		"Depth": "1", "Content-Type": "application/xml; charset=utf-8",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("WebDAV PROPFIND %s: %s", dir, resp.Status)
+	}
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("WebDAV PROPFIND %s: %w", dir, err)
+	}
+	var out []port.SyncObject
+	for _, r := range ms.Responses {
+		href, err := url.PathUnescape(r.Href)
+		if err != nil {
+			continue
+		}
+		if u, err := url.Parse(href); err == nil && u.Path != "" {
+			href = u.Path // certains serveurs renvoient des URL absolues
+		}
+		if strings.HasSuffix(href, "/") {
+			continue // le dossier lui-même ou un sous-dossier
+		}
+		for _, ps := range r.Propstat {
+			if !strings.Contains(ps.Status, " 200 ") || ps.Prop.ResourceType.Collection != nil {
+				continue
+			}
+			size, _ := strconv.ParseInt(ps.Prop.Length, 10, 64)
+			out = append(out, port.SyncObject{Name: path.Join(dir, path.Base(href)), ETag: ps.Prop.ETag, Size: size})
+		}
+	}
+	return out, nil
+}
+
+// Get downloads name with a conditional GET.
+func (w *WebDAV) Get(ctx context.Context, name, ifNoneMatch string) ([]byte, string, error) {
+	header := map[string]string{}
+	if ifNoneMatch != "" {
+		header["If-None-Match"] = ifNoneMatch
+	}
+	resp, err := w.do(ctx, http.MethodGet, w.url(name), nil, header)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("WebDAV GET %s: %w", name, err)
+		}
+		return data, resp.Header.Get("ETag"), nil
+	case http.StatusNotModified:
+		return nil, ifNoneMatch, port.ErrSyncNotModified
+	case http.StatusNotFound:
+		return nil, "", port.ErrSyncObjectNotFound
+	}
+	return nil, "", fmt.Errorf("WebDAV GET %s: %s", name, resp.Status)
+}
+
+// Put uploads name with If-Match, or If-None-Match: * for a new file. Missing
+// parent collections are created with MKCOL.
+func (w *WebDAV) Put(ctx context.Context, name string, data []byte, ifMatch string) (string, error) {
+	header := map[string]string{"Content-Type": "application/octet-stream"}
+	if ifMatch != "" {
+		header["If-Match"] = ifMatch
+	} else {
+		header["If-None-Match"] = "*"
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := w.do(ctx, http.MethodPut, w.url(name), data, header)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusPreconditionFailed:
+			return "", port.ErrSyncETagMismatch
+		case resp.StatusCode == http.StatusConflict && attempt == 0:
+			// RFC 4918 : 409 quand la collection parente n'existe pas.
+			if err := w.mkcolAll(ctx, path.Dir(path.Clean("/"+name))); err != nil {
+				return "", err
+			}
+			continue
+		case resp.StatusCode < 200 || resp.StatusCode > 299:
+			return "", fmt.Errorf("WebDAV PUT %s: %s", name, resp.Status)
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			return etag, nil
+		}
+		return w.head(ctx, name)
+	}
+}
+
+// head reads the ETag of name, for the servers that omit it in PUT responses.
+func (w *WebDAV) head(ctx context.Context, name string) (string, error) {
+	resp, err := w.do(ctx, http.MethodHead, w.url(name), nil, nil)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("WebDAV HEAD %s: %s", name, resp.Status)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// mkcolAll creates dir and its missing parents below the base collection.
+func (w *WebDAV) mkcolAll(ctx context.Context, dir string) error {
+	dir = strings.Trim(dir, "/")
+	if dir == "" || dir == "." {
+		return nil
+	}
+	parts := strings.Split(dir, "/")
+	for i := range parts {
+		target := w.url(strings.Join(parts[:i+1], "/")) + "/"
+		resp, err := w.do(ctx, "MKCOL", target, nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		// 405 : la collection existe déjà.
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("WebDAV MKCOL %s: %s", dir, resp.Status)
+		}
+	}
+	return nil
+}
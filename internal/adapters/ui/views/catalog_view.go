@@ -0,0 +1,405 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"log/slog"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// catalogViewState is the catalog browser: a remote OPDS catalog (Calibre
+// server, public domain library, another Orus) browsed page by page.
+type catalogViewState struct {
+	url      widget.Editor
+	username widget.Editor
+	password widget.Editor
+	search   widget.Editor
+	connect  widget.Clickable
+	find     widget.Clickable
+	back     widget.Clickable
+	prev     widget.Clickable
+	next     widget.Clickable
+	list     widget.List
+	btns     []widget.Clickable
+
+	client  port.CatalogClient
+	feed    *port.CatalogFeed
+	history []*port.CatalogFeed // pages précédentes, pour « Retour »
+	busy    bool                // page en cours de chargement
+	errMsg  string
+	// État des téléchargements par entrée : en cours, ajouté ou erreur.
+	downloads map[string]string
+}
+
+func newCatalogViewState() catalogViewState {
+	return catalogViewState{
+		url:       widget.Editor{SingleLine: true, Submit: true},
+		username:  widget.Editor{SingleLine: true, Submit: true},
+		password:  newPassphraseEditor(),
+		search:    widget.Editor{SingleLine: true, Submit: true},
+		list:      widget.List{List: layout.List{Axis: layout.Vertical}},
+		downloads: map[string]string{},
+	}
+}
+
+func catalogEntryKey(e port.CatalogEntry) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Title + "\x00" + strings.Join(e.Authors, ",")
+}
+
+// connectCatalog opens the catalog typed in the form and shows its first page.
+func (wm *WindowManager) connectCatalog() {
+	c := &wm.catalog
+	if c.busy || wm.openCatalog == nil {
+		return
+	}
+	client, err := wm.openCatalog(port.Catalog{
+		URL:      strings.TrimSpace(c.url.Text()),
+		Username: strings.TrimSpace(c.username.Text()),
+		Password: c.password.Text(),
+	})
+	if err != nil {
+		c.errMsg = wm.tr.T("catalog.invalid_url")
+		return
+	}
+	c.client, c.history = client, nil
+	wm.browseCatalog(false, func(ctx context.Context) (*port.CatalogFeed, error) { return client.Root(ctx) })
+}
+
+// browseCatalog loads a page on a goroutine. With push, the current page is
+// kept for the back button.
+func (wm *WindowManager) browseCatalog(push bool, load func(ctx context.Context) (*port.CatalogFeed, error)) {
+	c := &wm.catalog
+	if c.busy {
+		return
+	}
+	c.busy, c.errMsg = true, ""
+	go func() {
+		feed, err := load(context.Background())
+		wm.uiChan <- func() {
+			c.busy = false
+			if err != nil {
+				slog.Warn("catalog unavailable", "component", "ui", "error", err)
+				c.errMsg = wm.tr.T("common.error", err)
+			} else {
+				if push && c.feed != nil {
+					c.history = append(c.history, c.feed)
+				}
+				c.feed = feed
+				c.list.Position = layout.Position{}
+			}
+			wm.window.Invalidate()
+		}
+	}()
+}
+
+func (wm *WindowManager) searchCatalog() {
+	c := &wm.catalog
+	query := strings.TrimSpace(c.search.Text())
+	if c.client == nil || c.feed == nil || c.feed.Search == "" || query == "" {
+		return
+	}
+	client, template := c.client, c.feed.Search
+	wm.browseCatalog(true, func(ctx context.Context) (*port.CatalogFeed, error) { return client.Search(ctx, template, query) })
+}
+
+// downloadCatalogEntry imports a catalog book on a goroutine.
+func (wm *WindowManager) downloadCatalogEntry(entry port.CatalogEntry) {
+	c := &wm.catalog
+	key := catalogEntryKey(entry)
+	if c.client == nil || wm.libSvc == nil || c.downloads[key] == wm.tr.T("catalog.downloading") {
+		return
+	}
+	c.downloads[key] = wm.tr.T("catalog.downloading")
+	client := c.client
+	go func() {
+		book, err := wm.libSvc.ImportFromCatalog(context.Background(), client, entry)
+		wm.uiChan <- func() {
+			switch {
+			case errors.Is(err, service.ErrBookAlreadyExists):
+				c.downloads[key] = wm.tr.T("catalog.already_there")
+			case errors.Is(err, service.ErrNoReadableFile):
+				c.downloads[key] = wm.tr.T("catalog.no_file")
+			case err != nil:
+				slog.Error("catalog download failed", "component", "ui", "title", entry.Title, "error", err)
+				c.downloads[key] = wm.tr.T("common.error", err)
+			default:
+				c.downloads[key] = wm.tr.T("catalog.added")
+				wm.booksLoaded, wm.bookStatusLoaded, wm.dashboardLoaded = false, false, false
+				slog.Info("catalog book added", "component", "ui", "book_id", book.ID)
+			}
+			wm.window.Invalidate()
+		}
+	}()
+}
+
+// ==========================================================
+// CATALOG VIEW
+// ==========================================================
+
+func (wm *WindowManager) drawCatalogView(gtx layout.Context) layout.Dimensions {
+	c := &wm.catalog
+	for _, ed := range []*widget.Editor{&c.url, &c.username, &c.password, &c.search} {
+		for {
+			e, ok := ed.Update(gtx)
+			if !ok {
+				break
+			}
+			if _, ok := e.(widget.SubmitEvent); ok {
+				if ed == &c.search {
+					wm.searchCatalog()
+				} else {
+					wm.connectCatalog()
+				}
+			}
+		}
+	}
+	if c.connect.Clicked(gtx) {
+		wm.connectCatalog()
+	}
+	if c.find.Clicked(gtx) {
+		wm.searchCatalog()
+	}
+	if c.back.Clicked(gtx) && len(c.history) > 0 && !c.busy {
+		c.feed, c.history = c.history[len(c.history)-1], c.history[:len(c.history)-1]
+		c.list.Position = layout.Position{}
+	}
+	if c.feed != nil && c.client != nil {
+		client := c.client
+		if c.next.Clicked(gtx) && c.feed.Next != "" {
+			next := c.feed.Next
+			wm.browseCatalog(true, func(ctx context.Context) (*port.CatalogFeed, error) { return client.Fetch(ctx, next) })
+		}
+		if c.prev.Clicked(gtx) && c.feed.Previous != "" {
+			prev := c.feed.Previous
+			wm.browseCatalog(true, func(ctx context.Context) (*port.CatalogFeed, error) { return client.Fetch(ctx, prev) })
+		}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.H5(wm.theme, wm.tr.T("catalog.title"))
+			lbl.Font.Weight = font.Bold
+			return layout.Inset{Bottom: 8}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Label(wm.theme, 14, wm.tr.T("catalog.subtitle"))
+			lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 155)
+			return layout.Inset{Bottom: 16}.Layout(gtx, lbl.Layout)
+		}),
+		// Connexion
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.End}.Layout(gtx,
+				layout.Flexed(3, func(gtx layout.Context) layout.Dimensions {
+					return wm.drawLabeledField(gtx, wm.tr.T("catalog.url"), &c.url, "http://192.168.1.20:8080/opds")
+				}),
+				layout.Rigid(layout.Spacer{Width: 10}.Layout),
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return wm.drawLabeledField(gtx, wm.tr.T("common.username"), &c.username, wm.tr.T("common.optional"))
+				}),
+				layout.Rigid(layout.Spacer{Width: 10}.Layout),
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return wm.drawLabeledField(gtx, wm.tr.T("common.password"), &c.password, "")
+				}),
+				layout.Rigid(layout.Spacer{Width: 10}.Layout),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return wm.drawPillButton(gtx, wm.tr.T("catalog.connect"), &c.connect, theme.ColorSandGold)
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: 16}.Layout),
+		// Navigation et recherche
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if c.feed == nil {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Bottom: 12}.Layout(gtx, wm.drawCatalogToolbar)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			msg, col := c.errMsg, color.NRGBA{R: 180, G: 40, B: 40, A: 255}
+			if c.busy {
+				msg, col = wm.tr.T("common.loading"), theme.ColorCyberCyan
+			}
+			if msg == "" {
+				return layout.Dimensions{}
+			}
+			lbl := material.Label(wm.theme, 13, msg)
+			lbl.Color = col
+			return layout.Inset{Bottom: 12}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			if c.feed == nil {
+				return layout.Dimensions{}
+			}
+			entries := c.feed.Entries
+			if len(entries) == 0 {
+				lbl := material.Label(wm.theme, 15, wm.tr.T("catalog.empty"))
+				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 130)
+				return layout.Center.Layout(gtx, lbl.Layout)
+			}
+			if len(c.btns) < len(entries) {
+				c.btns = make([]widget.Clickable, len(entries))
+			}
+			return material.List(wm.theme, &c.list).Layout(gtx, len(entries), func(gtx layout.Context, i int) layout.Dimensions {
+				return layout.Inset{Bottom: 12, Right: 12}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return wm.drawCatalogEntry(gtx, entries[i], &c.btns[i])
+				})
+			})
+		}),
+	)
+}
+
+func (wm *WindowManager) drawCatalogToolbar(gtx layout.Context) layout.Dimensions {
+	c := &wm.catalog
+	var children []layout.FlexChild
+	if len(c.history) > 0 {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawPillButton(gtx, wm.tr.T("catalog.back"), &c.back, theme.ColorCyberCyan)
+			})
+		}))
+	}
+	children = append(children, layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+		lbl := material.Label(wm.theme, 16, c.feed.Title)
+		lbl.Font.Weight = font.Bold
+		lbl.MaxLines = 1
+		return lbl.Layout(gtx)
+	}))
+	if c.feed.Search != "" {
+		children = append(children,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Max.X = gtx.Dp(260)
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return wm.drawLabeledField(gtx, "", &c.search, wm.tr.T("catalog.search_hint"))
+			}),
+			layout.Rigid(layout.Spacer{Width: 10}.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return wm.drawPillButton(gtx, wm.tr.T("catalog.search"), &c.find, theme.ColorCyberCyan)
+			}),
+		)
+	}
+	if c.feed.Previous != "" {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawPillButton(gtx, wm.tr.T("catalog.previous"), &c.prev, theme.ColorCyberCyan)
+			})
+		}))
+	}
+	if c.feed.Next != "" {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawPillButton(gtx, wm.tr.T("catalog.next"), &c.next, theme.ColorCyberCyan)
+			})
+		}))
+	}
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx, children...)
+}
+
+// drawCatalogEntry draws a sub-catalog with an open button, or a book with
+// its download button and status.
+func (wm *WindowManager) drawCatalogEntry(gtx layout.Context, entry port.CatalogEntry, btn *widget.Clickable) layout.Dimensions {
+	c := &wm.catalog
+	if btn.Clicked(gtx) {
+		if entry.Link != "" && c.client != nil {
+			client, link := c.client, entry.Link
+			wm.browseCatalog(true, func(ctx context.Context) (*port.CatalogFeed, error) { return client.Fetch(ctx, link) })
+		} else if entry.Link == "" {
+			wm.downloadCatalogEntry(entry)
+		}
+	}
+
+	var details []string
+	if len(entry.Authors) > 0 {
+		details = append(details, strings.Join(entry.Authors, ", "))
+	}
+	if entry.Year > 0 {
+		details = append(details, fmt.Sprint(entry.Year))
+	}
+	var formats []string
+	for _, f := range entry.Files {
+		if f.Format != "" {
+			formats = append(formats, string(f.Format))
+		}
+	}
+	if len(formats) > 0 {
+		details = append(details, strings.Join(formats, "/"))
+	}
+	summary := entry.Summary
+	if r := []rune(summary); len(r) > 220 {
+		summary = string(r[:220]) + "..."
+	}
+	status := c.downloads[catalogEntryKey(entry)]
+
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			cl := clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, 10).Push(gtx.Ops)
+			paint.Fill(gtx.Ops, theme.WithAlpha(theme.ColorCyberCyan, 7))
+			cl.Pop()
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: 14, Left: 20, Right: 20, Bottom: 14}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								lbl := material.Label(wm.theme, 15, entry.Title)
+								lbl.Font.Weight = font.Bold
+								return lbl.Layout(gtx)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if len(details) == 0 {
+									return layout.Dimensions{}
+								}
+								lbl := material.Label(wm.theme, 12, strings.Join(details, " · "))
+								lbl.Color = theme.ColorCyberCyan
+								return layout.Inset{Top: 3}.Layout(gtx, lbl.Layout)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if summary == "" {
+									return layout.Dimensions{}
+								}
+								lbl := material.Label(wm.theme, 13, summary)
+								lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
+								lbl.MaxLines = 3
+								return layout.Inset{Top: 5}.Layout(gtx, lbl.Layout)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if status == "" {
+									return layout.Dimensions{}
+								}
+								lbl := material.Label(wm.theme, 12, status)
+								lbl.Color = theme.ColorSandGold
+								lbl.Font.Weight = font.SemiBold
+								return layout.Inset{Top: 5}.Layout(gtx, lbl.Layout)
+							}),
+						)
+					}),
+					layout.Rigid(layout.Spacer{Width: 20}.Layout),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if entry.Link != "" {
+							return wm.drawPillButton(gtx, wm.tr.T("catalog.open"), btn, theme.ColorCyberCyan)
+						}
+						return wm.drawPillButton(gtx, wm.tr.T("catalog.download"), btn, theme.ColorSandGold)
+					}),
+				)
+			})
+		}),
+	)
+}
@@ -0,0 +1,340 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// passphraseMode is what the passphrase dialog does on submit.
+type passphraseMode int
+
+const (
+	passphraseUnlock passphraseMode = iota
+	passphraseEnable
+	passphraseChange
+	passphraseDisable
+)
+
+// passphraseDialog asks for the encryption passphrase. In unlock mode it
+// replaces the home screen until the database is unlocked; the other modes
+// are drawn over the home screen and can be dismissed.
+type passphraseDialog struct {
+	open    bool
+	mode    passphraseMode
+	busy    bool // dérivation de clé et rechiffrement en cours
+	errMsg  string
+	current widget.Editor
+	next    widget.Editor
+	confirm widget.Editor
+	submit  widget.Clickable
+	cancel  widget.Clickable
+}
+
+func newPassphraseEditor() widget.Editor {
+	return widget.Editor{SingleLine: true, Submit: true, Mask: '•'}
+}
+
+// openPassphraseDialog shows the dialog with empty fields. It must run on the
+// UI thread.
+func (wm *WindowManager) openPassphraseDialog(mode passphraseMode) {
+	d := &wm.passphrase
+	d.open, d.mode, d.busy, d.errMsg = true, mode, false, ""
+	d.current, d.next, d.confirm = newPassphraseEditor(), newPassphraseEditor(), newPassphraseEditor()
+	wm.window.Invalidate()
+}
+
+// fields returns the editors of the current mode with the keys of their labels.
+func (d *passphraseDialog) fields() ([]*widget.Editor, []string) {
+	switch d.mode {
+	case passphraseEnable:
+		return []*widget.Editor{&d.next, &d.confirm}, []string{"encryption.passphrase", "encryption.confirmation"}
+	case passphraseChange:
+		return []*widget.Editor{&d.current, &d.next, &d.confirm},
+			[]string{"encryption.current", "encryption.new", "encryption.confirmation"}
+	default:
+		return []*widget.Editor{&d.current}, []string{"encryption.passphrase"}
+	}
+}
+
+// texts returns the key prefix of the title, description and button of the
+// current mode, completed with ".title", ".desc" and ".button".
+func (d *passphraseDialog) texts() string {
+	switch d.mode {
+	case passphraseEnable:
+		return "encryption.enable"
+	case passphraseChange:
+		return "encryption.change"
+	case passphraseDisable:
+		return "encryption.disable"
+	default:
+		return "encryption.unlock"
+	}
+}
+
+// submitPassphrase validates the fields, then runs the slow key derivation
+// on a goroutine and reports back through uiChan.
+func (wm *WindowManager) submitPassphrase() {
+	d := &wm.passphrase
+	if d.busy || wm.encryptionSvc == nil {
+		return
+	}
+	if d.mode == passphraseEnable || d.mode == passphraseChange {
+		if d.next.Text() != d.confirm.Text() {
+			d.errMsg = wm.tr.T("encryption.mismatch")
+			return
+		}
+	}
+	mode, current, next := d.mode, d.current.Text(), d.next.Text()
+	d.busy, d.errMsg = true, ""
+	svc := wm.encryptionSvc
+	go func() {
+		ctx := context.Background()
+		var err error
+		switch mode {
+		case passphraseUnlock:
+			err = svc.Unlock(current)
+		case passphraseEnable:
+			err = svc.Enable(ctx, next)
+		case passphraseChange:
+			err = svc.ChangePassphrase(ctx, current, next)
+		case passphraseDisable:
+			err = svc.Disable(ctx, current)
+		}
+		wm.uiChan <- func() {
+			d.busy = false
+			if err != nil {
+				d.errMsg = passphraseError(wm.tr, err)
+				wm.window.Invalidate()
+				return
+			}
+			d.open = false
+			switch mode {
+			case passphraseUnlock:
+				wm.state = StateHome
+			case passphraseEnable:
+				wm.sharing.statusMsg = wm.tr.T("encryption.enabled")
+			case passphraseChange:
+				wm.sharing.statusMsg = wm.tr.T("encryption.changed")
+			case passphraseDisable:
+				wm.sharing.statusMsg = wm.tr.T("encryption.disabled")
+			}
+			// Les fiches chargées verrouillées doivent être relues en clair.
+			wm.sheetsLoaded = false
+			wm.window.Invalidate()
+		}
+	}()
+}
+
+func passphraseError(tr *i18n.Localizer, err error) string {
+	switch {
+	case errors.Is(err, port.ErrWrongPassphrase):
+		return tr.T("encryption.wrong")
+	case errors.Is(err, service.ErrWeakPassphrase):
+		return tr.T("encryption.weak")
+	default:
+		return tr.T("common.error", err)
+	}
+}
+
+// layoutUnlockScreen is shown after the splash screen while the database is
+// locked.
+func (wm *WindowManager) layoutUnlockScreen(gtx layout.Context) layout.Dimensions {
+	paint.Fill(gtx.Ops, theme.ColorGlassWhite)
+	if !wm.passphrase.open {
+		wm.openPassphraseDialog(passphraseUnlock)
+	}
+	wm.drawPassphraseDialog(gtx)
+	wm.drawMacControls(gtx)
+	return layout.Dimensions{Size: gtx.Constraints.Max}
+}
+
+func (wm *WindowManager) drawPassphraseDialog(gtx layout.Context) {
+	d := &wm.passphrase
+	editors, labels := d.fields()
+	for _, ed := range editors {
+		for {
+			e, ok := ed.Update(gtx)
+			if !ok {
+				break
+			}
+			if _, ok := e.(widget.SubmitEvent); ok {
+				wm.submitPassphrase()
+			}
+		}
+	}
+	if d.submit.Clicked(gtx) {
+		wm.submitPassphrase()
+	}
+	if d.cancel.Clicked(gtx) && d.mode != passphraseUnlock && !d.busy {
+		d.open = false
+		return
+	}
+
+	W, H := gtx.Constraints.Max.X, gtx.Constraints.Max.Y
+	if d.mode != passphraseUnlock {
+		cl := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+		paint.Fill(gtx.Ops, color.NRGBA{R: 8, G: 6, B: 22, A: 160})
+		cl.Pop()
+	}
+	cardW := 460
+	cardH := 190 + 62*len(editors)
+	off := op.Offset(image.Pt((W-cardW)/2, (H-cardH)/2)).Push(gtx.Ops)
+	defer off.Pop()
+	cl := clip.UniformRRect(image.Rectangle{Max: image.Pt(cardW, cardH)}, 18).Push(gtx.Ops)
+	paint.Fill(gtx.Ops, color.NRGBA{R: 253, G: 251, B: 246, A: 255})
+	cl.Pop()
+
+	texts := d.texts()
+	title, desc, button := wm.tr.T(texts+".title"), wm.tr.T(texts+".desc"), wm.tr.T(texts+".button")
+	gtx.Constraints = layout.Exact(image.Pt(cardW, cardH))
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Label(wm.theme, 18, title)
+			lbl.Font.Weight = font.Bold
+			return layout.Inset{Bottom: 6}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Label(wm.theme, 13, desc)
+			lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
+			return layout.Inset{Bottom: 14}.Layout(gtx, lbl.Layout)
+		}),
+	}
+	for i := range editors {
+		ed, label := editors[i], labels[i]
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawLabeledField(gtx, wm.tr.T(label), ed, "")
+			})
+		}))
+	}
+	children = append(children,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			msg, col := d.errMsg, color.NRGBA{R: 180, G: 40, B: 40, A: 255}
+			if d.busy {
+				msg, col = wm.tr.T("encryption.deriving"), theme.ColorCyberCyan
+			}
+			lbl := material.Label(wm.theme, 13, msg)
+			lbl.Color = col
+			return layout.Inset{Bottom: 10}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions { return layout.Dimensions{} }),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					if d.mode == passphraseUnlock {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Right: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return wm.drawPillButton(gtx, wm.tr.T("common.cancel"), &d.cancel, theme.ColorCyberCyan)
+					})
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return wm.drawPillButton(gtx, button, &d.submit, theme.ColorSandGold)
+				}),
+			)
+		}),
+	)
+	layout.UniformInset(28).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// encryptionAction switches the encryption of sheets and notes on, changes
+// its passphrase, or switches it off with the secondary button.
+func (wm *WindowManager) encryptionAction() shareAction {
+	action := shareAction{
+		title:  wm.tr.T("encryption.card"),
+		desc:   wm.tr.T("encryption.card_desc"),
+		button: wm.tr.T("encryption.card_enable"),
+		run:    func() { wm.uiChan <- func() { wm.openPassphraseDialog(passphraseEnable) } },
+	}
+	if wm.encryptionSvc == nil {
+		action.run = func() { wm.sharing.statusMsg = wm.tr.T("common.service_unavailable") }
+		return action
+	}
+	if wm.encryptionSvc.Enabled() {
+		action.desc = wm.tr.T("encryption.card_active")
+		action.button = wm.tr.T("encryption.card_change")
+		action.run = func() { wm.uiChan <- func() { wm.openPassphraseDialog(passphraseChange) } }
+		action.secondary = wm.tr.T("encryption.disable.button")
+		action.onSecondary = func() { wm.openPassphraseDialog(passphraseDisable) }
+	}
+	return action
+}
+
+// oldBackupsAction replaces the snapshots taken before encryption, which hold
+// the sheets and notes in clear, by a single encrypted one.
+func (wm *WindowManager) oldBackupsAction() shareAction {
+	action := shareAction{
+		title:   wm.tr.T("encryption.old_backups"),
+		desc:    wm.tr.T("encryption.old_backups_off"),
+		button:  wm.tr.T("encryption.old_backups_button"),
+		pending: wm.tr.T("share.backing_up"),
+		run:     func() { wm.sharing.statusMsg = wm.tr.T("encryption.old_backups_off") },
+	}
+	if wm.backupSvc == nil || wm.encryptionSvc == nil {
+		action.run = func() { wm.sharing.statusMsg = wm.tr.T("common.service_unavailable") }
+		return action
+	}
+	if !wm.encryptionSvc.Enabled() {
+		return action
+	}
+	action.desc = wm.tr.T("encryption.old_backups_desc", wm.backupSvc.Dir())
+	action.run = func() {
+		defer wm.window.Invalidate()
+		backup, removed, err := wm.backupSvc.ReplaceAll(context.Background())
+		if err != nil {
+			wm.sharing.statusMsg = wm.tr.T("common.error", err)
+			return
+		}
+		wm.sharing.statusMsg = wm.tr.N("encryption.old_backups_done", removed, backup.Path)
+	}
+	return action
+}
+
+// decryptExportsAction toggles whether exports include the encrypted fields.
+// Off by default: exports leave summaries, quotes and notes out.
+func (wm *WindowManager) decryptExportsAction() shareAction {
+	desc := wm.tr.T("encryption.exports_off")
+	button := wm.tr.T("encryption.exports_include")
+	if wm.sharing.decryptExports {
+		desc = wm.tr.T("encryption.exports_on")
+		button = wm.tr.T("encryption.exports_omit")
+	}
+	return shareAction{
+		title:  wm.tr.T("encryption.exports"),
+		desc:   desc,
+		button: button,
+		run: func() {
+			wm.uiChan <- func() {
+				wm.sharing.decryptExports = !wm.sharing.decryptExports
+				wm.window.Invalidate()
+			}
+		},
+	}
+}
+
+// exportContext returns the context of an export, allowed to decrypt the
+// sealed fields only when the user asked for it.
+func (wm *WindowManager) exportContext() context.Context {
+	ctx := context.Background()
+	if wm.sharing.decryptExports {
+		ctx = service.WithDecryption(ctx)
+	}
+	return ctx
+}
@@ -0,0 +1,82 @@
+package views
+
+import (
+	"context"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// HandleEvent is the subscriber of the window to the domain events. It runs on
+// the goroutine of the publisher and only hands the matching UI change over to
+// the render loop.
+func (wm *WindowManager) HandleEvent(_ context.Context, event domain.Event) {
+	switch e := event.(type) {
+	case domain.BookImported:
+		wm.post(func() {
+			wm.booksLoaded = false
+			wm.dashboardLoaded = false
+			wm.metricsLoaded = false
+			wm.bookStatusLoaded = false
+		})
+	case domain.BookDeleted:
+		// La fiche et les rappels du livre sont partis avec lui.
+		wm.post(func() {
+			wm.booksLoaded = false
+			wm.dashboardLoaded = false
+			wm.metricsLoaded = false
+			wm.bookStatusLoaded = false
+			wm.sheetsLoaded = false
+			wm.remindersLoaded = false
+		})
+	case domain.PageTurned:
+		wm.post(func() {
+			wm.dashboardLoaded = false
+			wm.metricsLoaded = false
+			wm.bookStatusLoaded = false
+		})
+	case domain.BookFinished:
+		wm.post(func() { wm.celebrate(e) })
+	case domain.SheetUpdated:
+		wm.post(func() { wm.sheetsLoaded = false })
+	case domain.ReminderFired:
+		wm.post(func() { wm.activeReminder = e.Reminder })
+	}
+}
+
+// post queues fn for the render loop without ever blocking the publisher:
+// when uiChan is full, a goroutine waits for room.
+func (wm *WindowManager) post(fn func()) {
+	select {
+	case wm.uiChan <- fn:
+	default:
+		go func() { wm.uiChan <- fn }()
+	}
+	wm.window.Invalidate()
+}
+
+// celebrate opens the achievement modal for a finished book, unless another
+// one is showing or the user already dismissed this book's.
+func (wm *WindowManager) celebrate(e domain.BookFinished) {
+	if wm.achievementBook != nil || wm.dismissedAchievements[e.BookID] {
+		return
+	}
+	book := wm.readerBook
+	if book == nil || book.ID != e.BookID {
+		book = nil
+		for _, b := range wm.books {
+			if b.ID == e.BookID {
+				book = b
+				break
+			}
+		}
+	}
+	if book == nil {
+		return
+	}
+	wm.achievementBook = book
+	wm.achievementStatus = ""
+	wm.achievementReadMin = int(e.Duration.Minutes())
+	wm.confettiStart = time.Now()
+	wm.confettiActive = true
+}
@@ -8,7 +8,8 @@ import (
 
 // pickMultipleFiles opens a native OS file dialog allowing multiple PDF/EPUB selection.
 // Returns the list of absolute paths chosen by the user (empty if cancelled).
-func pickMultipleFiles() []string {
+// booksName names the PDF/EPUB filter.
+func pickMultipleFiles(title, booksName string) []string {
 	var rawOut []byte
 	var err error
 
@@ -17,7 +18,7 @@ func pickMultipleFiles() []string {
 		// No "of type" restriction — UTI codes are unreliable across macOS versions.
 		// We accept any file and let the extractor reject unsupported formats.
 		script := `set output to ""
-set theFiles to choose file with prompt "Importer des livres (PDF, EPUB)" with multiple selections allowed
+set theFiles to choose file with prompt "` + title + ` (PDF, EPUB)" with multiple selections allowed
 repeat with f in theFiles
 	set output to output & POSIX path of f & linefeed
 end repeat
@@ -27,18 +28,18 @@ return output`
 	case "linux":
 		rawOut, err = exec.Command("zenity",
 			"--file-selection", "--multiple", "--separator=\n",
-			"--file-filter=Livres (*.pdf *.epub)|*.pdf *.epub",
-			"--title=Importer des livres").Output()
+			"--file-filter="+booksName+" (*.pdf *.epub)|*.pdf *.epub",
+			"--title="+title).Output()
 		if err != nil {
 			rawOut, err = exec.Command("kdialog",
 				"--getopenfilename", ".", "*.pdf *.epub",
-				"--title", "Importer des livres", "--multiple").Output()
+				"--title", title, "--multiple").Output()
 		}
 
 	case "windows":
 		ps := `Add-Type -AssemblyName System.Windows.Forms; ` +
 			`$d = New-Object System.Windows.Forms.OpenFileDialog; ` +
-			`$d.Filter="Livres (*.pdf;*.epub)|*.pdf;*.epub"; ` +
+			`$d.Filter="` + booksName + ` (*.pdf;*.epub)|*.pdf;*.epub"; ` +
 			`$d.Multiselect=$true; $d.ShowDialog()|Out-Null; ` +
 			`$d.FileNames -join "\n"`
 		rawOut, err = exec.Command("powershell", "-NoProfile", "-Command", ps).Output()
@@ -56,11 +57,11 @@ return output`
 	return paths
 }
 
-// openFolderDialog opens a native folder picker dialog.
-func openFolderDialog(defaultDir string) (string, error) {
+// openFolderDialog opens a native folder picker dialog titled title.
+func openFolderDialog(title, defaultDir string) (string, error) {
 	switch runtime.GOOS {
 	case "darwin":
-		script := `POSIX path of (choose folder with prompt "Choisir le dossier de destination")`
+		script := `POSIX path of (choose folder with prompt "` + title + `")`
 		out, err := exec.Command("osascript", "-e", script).Output()
 		if err == nil {
 			if r := strings.TrimSpace(string(out)); r != "" {
@@ -69,7 +70,7 @@ func openFolderDialog(defaultDir string) (string, error) {
 		}
 	case "linux":
 		for _, args := range [][]string{
-			{"zenity", "--file-selection", "--directory", "--title=Choisir le dossier"},
+			{"zenity", "--file-selection", "--directory", "--title=" + title},
 			{"kdialog", "--getexistingdirectory", defaultDir},
 		} {
 			out, err := exec.Command(args[0], args[1:]...).Output()
@@ -80,7 +81,7 @@ func openFolderDialog(defaultDir string) (string, error) {
 			}
 		}
 	default:
-		ps := `(New-Object -ComObject Shell.Application).BrowseForFolder(0,'Destination',0).Self.Path`
+		ps := `(New-Object -ComObject Shell.Application).BrowseForFolder(0,'` + title + `',0).Self.Path`
 		out, err := exec.Command("powershell", "-NoProfile", "-Command", ps).Output()
 		if err == nil {
 			if r := strings.TrimSpace(string(out)); r != "" {
@@ -90,3 +91,38 @@ func openFolderDialog(defaultDir string) (string, error) {
 	}
 	return defaultDir, nil
 }
+
+// pickFile opens a native OS file dialog for a single file matching pattern
+// (e.g. "*.ics"). Returns "" if cancelled or if no dialog tool is available.
+func pickFile(title, filterName, pattern string) string {
+	var rawOut []byte
+	var err error
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := `POSIX path of (choose file with prompt "` + title + `")`
+		rawOut, err = exec.Command("osascript", "-e", script).Output()
+
+	case "linux":
+		rawOut, err = exec.Command("zenity", "--file-selection",
+			"--file-filter="+filterName+"|"+pattern,
+			"--title="+title).Output()
+		if err != nil {
+			rawOut, err = exec.Command("kdialog",
+				"--getopenfilename", ".", pattern, "--title", title).Output()
+		}
+
+	case "windows":
+		winPattern := strings.ReplaceAll(pattern, " ", ";")
+		ps := `Add-Type -AssemblyName System.Windows.Forms; ` +
+			`$d = New-Object System.Windows.Forms.OpenFileDialog; ` +
+			`$d.Filter="` + filterName + `|` + winPattern + `"; ` +
+			`$d.ShowDialog()|Out-Null; $d.FileName`
+		rawOut, err = exec.Command("powershell", "-NoProfile", "-Command", ps).Output()
+	}
+
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(rawOut))
+}
@@ -2,10 +2,9 @@ package views
 
 import (
 	"context"
-	"fmt"
 	"image"
 	"image/color"
-	"log"
+	"log/slog"
 	"math"
 	"time"
 
@@ -53,13 +52,13 @@ const (
 
 func (wm *WindowManager) loadBooks() {
 	if wm.libSvc == nil {
-		log.Println("[Library] LibraryService est nil.")
+		slog.Warn("library service missing", "component", "ui")
 		wm.booksLoaded = true
 		return
 	}
 	books, err := wm.libSvc.GetLibrary(context.Background())
 	if err != nil {
-		log.Printf("[Library] Erreur : %v", err)
+		slog.Error("library unavailable", "component", "ui", "error", err)
 	} else {
 		wm.books = books
 	}
@@ -73,12 +72,12 @@ func (wm *WindowManager) drawBooksGrid(gtx layout.Context) layout.Dimensions {
 		wm.loadBooks()
 	}
 
-	tabLabel := "Tous les livres"
+	tabLabel := wm.tr.T("library.all")
 	switch wm.activeTab {
 	case 2:
-		tabLabel = "À lire"
+		tabLabel = wm.tr.T("library.unread")
 	case 3:
-		tabLabel = "Terminés"
+		tabLabel = wm.tr.T("library.done")
 	}
 
 	filtered := wm.filterBooksByTab(wm.books)
@@ -125,10 +124,10 @@ func (wm *WindowManager) drawBooksGrid(gtx layout.Context) layout.Dimensions {
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						if wm.importBtn.Clicked(gtx) {
-							wm.importStatusMsg = "Ouverture du sélecteur..."
+							wm.importStatusMsg = wm.tr.T("library.opening_picker")
 							go wm.importBooksFromPicker()
 						}
-						return wm.drawPillButton(gtx, "+ Importer", &wm.importBtn, theme.ColorSandGold)
+						return wm.drawPillButton(gtx, wm.tr.T("library.import"), &wm.importBtn, theme.ColorSandGold)
 					}),
 				)
 			})
@@ -145,13 +144,13 @@ func (wm *WindowManager) drawBooksGrid(gtx layout.Context) layout.Dimensions {
 		// Book grid — Flexed so scroll gets full remaining height
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 			if len(filtered) == 0 {
-				msg := "Aucun livre. Importez votre premier livre !"
+				msg := wm.tr.T("library.empty")
 				if wm.searchQuery != "" {
-					msg = fmt.Sprintf("Aucun résultat pour « %s ».", wm.searchQuery)
+					msg = wm.tr.T("library.no_match", wm.searchQuery)
 				} else if wm.activeTab == 2 {
-					msg = "Aucun livre marqué à lire."
+					msg = wm.tr.T("library.empty_unread")
 				} else if wm.activeTab == 3 {
-					msg = "Aucun livre terminé pour l'instant."
+					msg = wm.tr.T("library.empty_done")
 				}
 				return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 					lbl := material.Label(wm.theme, 16, msg)
@@ -281,7 +280,7 @@ func (wm *WindowManager) drawBookCard(gtx layout.Context, bk *domain.Book, origI
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 							a := bk.Author
 							if a == "" || a == "Unknown" || a == "Unknown Author" {
-								a = "Auteur inconnu"
+								a = wm.tr.T("common.unknown_author")
 							}
 							lbl := material.Label(wm.theme, 11, a)
 							lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 120)
@@ -297,7 +296,7 @@ func (wm *WindowManager) drawBookCard(gtx layout.Context, bk *domain.Book, origI
 								wm.openBookInReader(bk)
 							}
 							return layout.Inset{Bottom: unit.Dp(6)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								return wm.drawGlowCTA(gtx, "▶  Lire", &wm.bookOpenBtns[origIdx], coverCol)
+								return wm.drawGlowCTA(gtx, wm.tr.T("library.read"), &wm.bookOpenBtns[origIdx], coverCol)
 							})
 						}),
 					)
@@ -536,7 +535,7 @@ func (wm *WindowManager) drawBookActionOverlay(gtx layout.Context, imap map[stri
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					a := bk.Author
 					if a == "" || a == "Unknown" || a == "Unknown Author" {
-						a = "Auteur inconnu"
+						a = wm.tr.T("common.unknown_author")
 					}
 					lbl := material.Label(wm.theme, 13, a)
 					lbl.Color = theme.WithAlpha(theme.ColorPureBlack, actionAlpha/2)
@@ -552,7 +551,7 @@ func (wm *WindowManager) drawBookActionOverlay(gtx layout.Context, imap map[stri
 						wm.openBookInReader(bk)
 					}
 					return layout.Inset{Bottom: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						return wm.overlayActionBtnIcon(gtx, "Lire ce livre", "read", &wm.overlayReadBtns[origIdx], coverCol, 255)
+						return wm.overlayActionBtnIcon(gtx, wm.tr.T("library.read_book"), "read", &wm.overlayReadBtns[origIdx], coverCol, 255)
 					})
 				}),
 				// Archive
@@ -569,7 +568,7 @@ func (wm *WindowManager) drawBookActionOverlay(gtx layout.Context, imap map[stri
 					}
 					archiveCol := color.NRGBA{R: 200, G: 120, B: 10, A: 255}
 					return layout.Inset{Bottom: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						return wm.overlayActionBtnIcon(gtx, "Archiver / Retirer", "archive", &wm.bookArchiveBtns[origIdx], archiveCol, 255)
+						return wm.overlayActionBtnIcon(gtx, wm.tr.T("library.archive"), "archive", &wm.bookArchiveBtns[origIdx], archiveCol, 255)
 					})
 				}),
 				// Delete
@@ -587,7 +586,7 @@ func (wm *WindowManager) drawBookActionOverlay(gtx layout.Context, imap map[stri
 					}
 					deleteCol := color.NRGBA{R: 180, G: 40, B: 40, A: 255}
 					return layout.Inset{Bottom: unit.Dp(22)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						return wm.overlayActionBtnIcon(gtx, "Supprimer définitivement", "delete", &wm.bookDeleteBtns[origIdx], deleteCol, 255)
+						return wm.overlayActionBtnIcon(gtx, wm.tr.T("library.delete"), "delete", &wm.bookDeleteBtns[origIdx], deleteCol, 255)
 					})
 				}),
 				// Cancel
@@ -595,7 +594,7 @@ func (wm *WindowManager) drawBookActionOverlay(gtx layout.Context, imap map[stri
 					if wm.bookMenuCloseBtn.Clicked(gtx) {
 						wm.closeBookCardMenu()
 					}
-					lbl := material.Label(wm.theme, 12, "Annuler  ·  Échap")
+					lbl := material.Label(wm.theme, 12, wm.tr.T("library.menu_dismiss"))
 					lbl.Color = theme.ColorCyberCyan
 					return lbl.Layout(gtx)
 				}),
@@ -2,10 +2,9 @@ package views
 
 import (
 	"context"
-	"fmt"
 	"image"
 	"image/color"
-	"log"
+	"log/slog"
 	"math"
 	"strings"
 	"time"
@@ -38,8 +37,10 @@ var readerBgPresets = []color.NRGBA{
 	{R: 200, G: 220, B: 240, A: 255}, // 8 – ciel pâle
 }
 
+// readerBgLabels sont les clés des noms de fonds, dans l'ordre de readerBgColors.
 var readerBgLabels = []string{
-	"Clair", "Nuit", "XMB", "Bleu", "Forêt", "Violet", "Brun", "Crème", "Ciel",
+	"reader.bg.light", "reader.bg.night", "reader.bg.xmb", "reader.bg.blue", "reader.bg.forest",
+	"reader.bg.violet", "reader.bg.brown", "reader.bg.cream", "reader.bg.sky",
 }
 
 // ── Main entry point ──────────────────────────────────────────────────────────
@@ -50,16 +51,15 @@ func (wm *WindowManager) drawReaderView(gtx layout.Context) layout.Dimensions {
 		book := wm.readerBook
 		go func() {
 			if wm.contentReader == nil {
-				wm.readerContent = []string{"Lecteur non disponible."}
+				wm.readerContent = []string{wm.tr.T("reader.unavailable")}
 				wm.readerLoading = false
 				wm.window.Invalidate()
 				return
 			}
 			chunks, err := wm.contentReader.ReadBookText(context.Background(), book.FilePath)
 			if err != nil {
-				log.Printf("[Reader] Erreur lecture : %v", err)
-				wm.readerContent = []string{fmt.Sprintf(
-					"Impossible de lire ce fichier.\n\nErreur : %v\n\nFormats supportés : PDF, EPUB.", err)}
+				slog.Error("book text unavailable", "component", "ui", "book_id", book.ID, "path", book.FilePath, "error", err)
+				wm.readerContent = []string{wm.tr.T("reader.read_failed", err)}
 			} else {
 				wm.readerContent = chunks
 			}
@@ -237,12 +237,12 @@ func (wm *WindowManager) drawReaderTopBar(gtx layout.Context) layout.Dimensions
 				if wm.closeReaderBtn.Clicked(gtx) {
 					wm.closeReader()
 				}
-				return wm.readerPillBtn(gtx, "← Retour", &wm.closeReaderBtn, theme.ColorCyberCyan)
+				return wm.readerPillBtn(gtx, wm.tr.T("common.back"), &wm.closeReaderBtn, theme.ColorCyberCyan)
 			}),
 			layout.Rigid(layout.Spacer{Width: unit.Dp(16)}.Layout),
 			// Title
 			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-				title := "Lecture"
+				title := wm.tr.T("reader.title")
 				if wm.readerBook != nil {
 					title = wm.readerBook.Title
 				}
@@ -302,14 +302,14 @@ func (wm *WindowManager) drawReaderContent(gtx layout.Context) layout.Dimensions
 	textCol := wm.readerTextColor()
 	if wm.readerLoading {
 		return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			lbl := material.Label(wm.theme, 16, "Chargement…")
+			lbl := material.Label(wm.theme, 16, wm.tr.T("common.loading"))
 			lbl.Color = theme.WithAlpha(textCol, 150)
 			return lbl.Layout(gtx)
 		})
 	}
 	if len(wm.readerContent) == 0 {
 		return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			lbl := material.Label(wm.theme, 15, "Aucun contenu à afficher.")
+			lbl := material.Label(wm.theme, 15, wm.tr.T("reader.empty"))
 			lbl.Color = theme.WithAlpha(textCol, 120)
 			return lbl.Layout(gtx)
 		})
@@ -468,7 +468,7 @@ func (wm *WindowManager) drawReaderBottomBar(gtx layout.Context) layout.Dimensio
 					wm.readerPage--
 					wm.saveReaderProgress()
 				}
-				return wm.navPill(gtx, "← Précédent", &wm.readerPrevBtn, disabled, textCol)
+				return wm.navPill(gtx, wm.tr.T("reader.previous"), &wm.readerPrevBtn, disabled, textCol)
 			}),
 
 			// Center: progress bar + label
@@ -484,8 +484,7 @@ func (wm *WindowManager) drawReaderBottomBar(gtx layout.Context) layout.Dimensio
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						lbl := material.Label(wm.theme, 11,
-							fmt.Sprintf("%d / %d  ·  ← → pour naviguer  ·  ESC pour quitter",
-								wm.readerPage+1, total))
+							wm.tr.T("reader.page_hint", wm.readerPage+1, total))
 						lbl.Color = theme.WithAlpha(textCol, 90)
 						lbl.Alignment = text.Middle
 						return lbl.Layout(gtx)
@@ -500,7 +499,7 @@ func (wm *WindowManager) drawReaderBottomBar(gtx layout.Context) layout.Dimensio
 					wm.readerPage++
 					wm.saveReaderProgress()
 				}
-				return wm.navPill(gtx, "Suivant →", &wm.readerNextBtn, disabled, textCol)
+				return wm.navPill(gtx, wm.tr.T("reader.next"), &wm.readerNextBtn, disabled, textCol)
 			}),
 		)
 	})
@@ -620,7 +619,7 @@ func (wm *WindowManager) drawReaderBgPanel(gtx layout.Context) layout.Dimensions
 								h.Pop()
 							}
 							// Label below swatch
-							lbl := material.Label(wm.theme, 9, readerBgLabels[idx])
+							lbl := material.Label(wm.theme, 9, wm.tr.T(readerBgLabels[idx]))
 							lbl.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 200}
 							lbl.Alignment = text.Middle
 							ts := op.Offset(image.Pt(0, bh+3)).Push(gtx.Ops)
@@ -782,7 +781,6 @@ func (wm *WindowManager) closeReader() {
 
 func (wm *WindowManager) openBookInReader(book *domain.Book) {
 	wm.readerActive = true
-	wm.readerOpenedAt = time.Now()
 	wm.readerBook = book
 	wm.readerContent = nil
 	wm.readerPage = 0
@@ -794,7 +792,7 @@ func (wm *WindowManager) openBookInReader(book *domain.Book) {
 		go func() {
 			session, err := wm.trackSvc.OpenBook(context.Background(), book.ID)
 			if err != nil {
-				log.Printf("[Reader] OpenBook: %v", err)
+				slog.Error("reading session not opened", "component", "ui", "book_id", book.ID, "error", err)
 				return
 			}
 			wm.readerSession = session
@@ -1,11 +1,13 @@
 package views
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"image"
 	"image/color"
-	"log"
+	"log/slog"
+	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
@@ -24,6 +26,8 @@ import (
 
 	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/service"
 )
 
 // sheetFormState holds all editors and sub-view navigation state.
@@ -81,7 +85,7 @@ func (wm *WindowManager) drawSheetListPage(gtx layout.Context) layout.Dimensions
 			return layout.Inset{Bottom: unit.Dp(24)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
 					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-						lbl := material.H5(wm.theme, "Fiches de lecture")
+						lbl := material.H5(wm.theme, wm.tr.T("sheets.title"))
 						lbl.Font.Weight = font.Bold
 						return lbl.Layout(gtx)
 					}),
@@ -95,7 +99,7 @@ func (wm *WindowManager) drawSheetListPage(gtx layout.Context) layout.Dimensions
 							wm.sheetForm.ratingEditor.SetText("")
 							wm.sheetForm.pendingRating = 0
 						}
-						return wm.drawPillButton(gtx, "+ Nouvelle fiche", &wm.sheetForm.submitBtn, theme.ColorCyberCyan)
+						return wm.drawPillButton(gtx, wm.tr.T("sheets.new"), &wm.sheetForm.submitBtn, theme.ColorCyberCyan)
 					}),
 				)
 			})
@@ -117,13 +121,13 @@ func (wm *WindowManager) drawSheetListPage(gtx layout.Context) layout.Dimensions
 							return layout.Dimensions{Size: image.Point{X: gtx.Constraints.Max.X, Y: 60}}
 						}),
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							lbl := material.Label(wm.theme, 15, "Aucune fiche de lecture")
+							lbl := material.Label(wm.theme, 15, wm.tr.T("sheets.empty"))
 							lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 100)
 							lbl.Alignment = 2 // center
 							return layout.Inset{Top: unit.Dp(12)}.Layout(gtx, lbl.Layout)
 						}),
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							lbl := material.Label(wm.theme, 13, "Créez votre première fiche en cliquant sur "+"Nouvelle fiche")
+							lbl := material.Label(wm.theme, 13, wm.tr.T("sheets.empty_hint"))
 							lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 70)
 							lbl.Alignment = 2
 							return layout.Inset{Top: unit.Dp(6)}.Layout(gtx, lbl.Layout)
@@ -141,6 +145,7 @@ func (wm *WindowManager) drawSheetListPage(gtx layout.Context) layout.Dimensions
 					}
 					if wm.sheetDetailBtns[origIdx].Clicked(gtx) {
 						wm.activeSheetDetail = s
+						wm.shareQuoteIdx = 0
 					}
 					_ = i
 					return layout.Inset{Bottom: unit.Dp(14)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
@@ -211,7 +216,7 @@ func (wm *WindowManager) drawSheetCard(gtx layout.Context, s *domain.ReadingShee
 								return layout.Inset{Bottom: unit.Dp(4)}.Layout(gtx, lbl.Layout)
 							}),
 							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								summary := "Aucun résumé."
+								summary := wm.tr.T("sheets.no_summary")
 								if s.Summary != "" {
 									summary = s.Summary
 									if len([]rune(summary)) > 70 {
@@ -250,7 +255,7 @@ func (wm *WindowManager) drawSheetCard(gtx layout.Context, s *domain.ReadingShee
 								gtxA := gtx
 								gtxA.Constraints = layout.Exact(image.Pt(aw, ah))
 								layout.Center.Layout(gtxA, func(gtx layout.Context) layout.Dimensions {
-									lbl := material.Label(wm.theme, 12, "Ouvrir  →")
+									lbl := material.Label(wm.theme, 12, wm.tr.T("sheets.open"))
 									lbl.Color = accentCol
 									lbl.Font.Weight = font.SemiBold
 									return lbl.Layout(gtx)
@@ -279,12 +284,12 @@ func (wm *WindowManager) drawSheetCreatePage(gtx layout.Context) layout.Dimensio
 						if wm.sheetForm.cancelBtn.Clicked(gtx) {
 							wm.sheetForm.showForm = false
 						}
-						return wm.drawPillButton(gtx, "← Retour", &wm.sheetForm.cancelBtn,
+						return wm.drawPillButton(gtx, wm.tr.T("common.back"), &wm.sheetForm.cancelBtn,
 							theme.WithAlpha(theme.ColorCyberCyan, 180))
 					}),
 					layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
 					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-						lbl := material.H5(wm.theme, "Nouvelle fiche de lecture")
+						lbl := material.H5(wm.theme, wm.tr.T("sheets.form_title"))
 						lbl.Font.Weight = font.Bold
 						return lbl.Layout(gtx)
 					}),
@@ -297,7 +302,7 @@ func (wm *WindowManager) drawSheetCreatePage(gtx layout.Context) layout.Dimensio
 			return layout.Inset{Bottom: unit.Dp(24)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						lbl := material.Label(wm.theme, 11, "CHOISIR UN LIVRE")
+						lbl := material.Label(wm.theme, 11, wm.tr.T("sheets.pick_book"))
 						lbl.Font.Weight = font.Bold
 						lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 110)
 						return layout.Inset{Bottom: unit.Dp(12)}.Layout(gtx, lbl.Layout)
@@ -312,16 +317,16 @@ func (wm *WindowManager) drawSheetCreatePage(gtx layout.Context) layout.Dimensio
 		// Section: Résumé
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Inset{Bottom: unit.Dp(18)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return wm.drawSheetFieldArea(gtx, "RÉSUMÉ", &wm.sheetForm.summaryEditor,
-					"Écrivez un résumé de ce que vous avez retenu…", 120)
+				return wm.drawSheetFieldArea(gtx, wm.tr.T("sheets.summary_field"), &wm.sheetForm.summaryEditor,
+					wm.tr.T("sheets.summary_hint"), 120)
 			})
 		}),
 
 		// Section: Citation
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Inset{Bottom: unit.Dp(18)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return wm.drawSheetFieldArea(gtx, "CITATION CLÉ", &wm.sheetForm.quoteEditor,
-					"La phrase qui vous a le plus marqué…", 72)
+				return wm.drawSheetFieldArea(gtx, wm.tr.T("sheets.quote_field"), &wm.sheetForm.quoteEditor,
+					wm.tr.T("sheets.quote_hint"), 72)
 			})
 		}),
 
@@ -331,8 +336,8 @@ func (wm *WindowManager) drawSheetCreatePage(gtx layout.Context) layout.Dimensio
 				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Start}.Layout(gtx,
 					layout.Flexed(3, func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Right: unit.Dp(16)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							return wm.drawSheetFieldArea(gtx, "TAGS (séparés par virgule)",
-								&wm.sheetForm.tagsEditor, "roman, histoire, philosophie…", 48)
+							return wm.drawSheetFieldArea(gtx, wm.tr.T("sheets.tags_field"),
+								&wm.sheetForm.tagsEditor, wm.tr.T("sheets.tags_hint"), 48)
 						})
 					}),
 					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
@@ -347,7 +352,7 @@ func (wm *WindowManager) drawSheetCreatePage(gtx layout.Context) layout.Dimensio
 			if wm.sheetForm.submitBtn.Clicked(gtx) {
 				wm.submitNewSheet()
 			}
-			return wm.drawGlowCTA(gtx, "✓  Enregistrer la fiche", &wm.sheetForm.submitBtn, theme.ColorCyberCyan)
+			return wm.drawGlowCTA(gtx, wm.tr.T("sheets.save"), &wm.sheetForm.submitBtn, theme.ColorCyberCyan)
 		}),
 	)
 }
@@ -356,7 +361,7 @@ func (wm *WindowManager) drawSheetCreatePage(gtx layout.Context) layout.Dimensio
 // Single row, horizontally scrollable, gold glow on selection.
 func (wm *WindowManager) drawBookPickerGrid(gtx layout.Context) layout.Dimensions {
 	if len(wm.books) == 0 {
-		lbl := material.Label(wm.theme, 13, "Aucun livre importé.")
+		lbl := material.Label(wm.theme, 13, wm.tr.T("sheets.no_books"))
 		lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 120)
 		return lbl.Layout(gtx)
 	}
@@ -375,7 +380,7 @@ func (wm *WindowManager) drawBookPickerGrid(gtx layout.Context) layout.Dimension
 				gtxSB.Constraints.Max.Y = h
 				return layout.Inset{Left: unit.Dp(14), Right: unit.Dp(14), Top: unit.Dp(8), Bottom: unit.Dp(8)}.Layout(gtxSB,
 					func(gtx layout.Context) layout.Dimensions {
-						e := material.Editor(wm.theme, &wm.sheetPickerSearch, "🔍  Rechercher un livre…")
+						e := material.Editor(wm.theme, &wm.sheetPickerSearch, wm.tr.T("sheets.search_book"))
 						e.Color = theme.ColorPureBlack
 						e.HintColor = theme.WithAlpha(theme.ColorPureBlack, 100)
 						return e.Layout(gtx)
@@ -393,7 +398,7 @@ func (wm *WindowManager) drawBookPickerGrid(gtx layout.Context) layout.Dimension
 				}
 			}
 			if len(filtered) == 0 {
-				lbl := material.Label(wm.theme, 12, "Aucun résultat.")
+				lbl := material.Label(wm.theme, 12, wm.tr.T("common.no_result"))
 				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 100)
 				return lbl.Layout(gtx)
 			}
@@ -531,7 +536,7 @@ func (wm *WindowManager) drawBookPickerGrid(gtx layout.Context) layout.Dimension
 func (wm *WindowManager) drawStarRatingPicker(gtx layout.Context) layout.Dimensions {
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			lbl := material.Label(wm.theme, unit.Sp(11), "NOTE")
+			lbl := material.Label(wm.theme, unit.Sp(11), wm.tr.T("sheets.rating_field"))
 			lbl.Font.Weight = font.Bold
 			lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 110)
 			return layout.Inset{Bottom: unit.Dp(10)}.Layout(gtx, lbl.Layout)
@@ -643,7 +648,7 @@ func (wm *WindowManager) drawSheetDetailPage(gtx layout.Context) layout.Dimensio
 								if wm.closeSheetDetailBtn.Clicked(gtx) {
 									wm.activeSheetDetail = nil
 								}
-								return wm.drawPillButton(gtx, "← Retour", &wm.closeSheetDetailBtn, accentCol)
+								return wm.drawPillButton(gtx, wm.tr.T("common.back"), &wm.closeSheetDetailBtn, accentCol)
 							}),
 						)
 					})
@@ -737,7 +742,7 @@ func (wm *WindowManager) drawSheetDetailPage(gtx layout.Context) layout.Dimensio
 									return layout.Dimensions{}
 								}
 								return layout.Inset{Bottom: unit.Dp(52)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-									return wm.drawEditorialSection(gtx, "Résumé", accentCol, func(gtx layout.Context) layout.Dimensions {
+									return wm.drawEditorialSection(gtx, wm.tr.T("sheets.summary"), accentCol, func(gtx layout.Context) layout.Dimensions {
 										lbl := material.Label(wm.theme, unit.Sp(16), s.Summary)
 										lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 200)
 										lbl.LineHeight = unit.Sp(26)
@@ -752,23 +757,36 @@ func (wm *WindowManager) drawSheetDetailPage(gtx layout.Context) layout.Dimensio
 									return layout.Dimensions{}
 								}
 								return layout.Inset{Bottom: unit.Dp(52)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-									return wm.drawEditorialSection(gtx, "Citations", accentCol, func(gtx layout.Context) layout.Dimensions {
+									return wm.drawEditorialSection(gtx, wm.tr.T("sheets.quotes"), accentCol, func(gtx layout.Context) layout.Dimensions {
 										var rows []layout.FlexChild
-										for _, q := range s.Quotes {
-											quote := q
+										for len(wm.sheetQuoteBtns) < len(s.Quotes) {
+											wm.sheetQuoteBtns = append(wm.sheetQuoteBtns, widget.Clickable{})
+										}
+										for i, q := range s.Quotes {
+											quote, idx := q, i
 											rows = append(rows, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+												// Cliquer une citation la choisit pour la carte image.
+												if wm.sheetQuoteBtns[idx].Clicked(gtx) {
+													wm.shareQuoteIdx = idx
+												}
 												return layout.Inset{Bottom: unit.Dp(24)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-													w := gtx.Constraints.Max.X
-													strip := clip.UniformRRect(image.Rectangle{Max: image.Pt(3, 52)}, 2).Push(gtx.Ops)
-													paint.Fill(gtx.Ops, theme.WithAlpha(accentCol, 160))
-													strip.Pop()
-													return layout.Inset{Left: unit.Dp(18)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-														gtx.Constraints.Max.X = w - 18
-														lbl := material.Label(wm.theme, unit.Sp(16), "« "+quote+" »")
-														lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 165)
-														lbl.LineHeight = unit.Sp(26)
-														lbl.Font.Style = font.Italic
-														return lbl.Layout(gtx)
+													return wm.sheetQuoteBtns[idx].Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+														w := gtx.Constraints.Max.X
+														stripAlpha := uint8(160)
+														if idx == wm.shareQuoteIdx {
+															stripAlpha = 255
+														}
+														strip := clip.UniformRRect(image.Rectangle{Max: image.Pt(3, 52)}, 2).Push(gtx.Ops)
+														paint.Fill(gtx.Ops, theme.WithAlpha(accentCol, stripAlpha))
+														strip.Pop()
+														return layout.Inset{Left: unit.Dp(18)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+															gtx.Constraints.Max.X = w - 18
+															lbl := material.Label(wm.theme, unit.Sp(16), wm.tr.T("common.quoted", quote))
+															lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 165)
+															lbl.LineHeight = unit.Sp(26)
+															lbl.Font.Style = font.Italic
+															return lbl.Layout(gtx)
+														})
 													})
 												})
 											}))
@@ -784,7 +802,7 @@ func (wm *WindowManager) drawSheetDetailPage(gtx layout.Context) layout.Dimensio
 									return layout.Dimensions{}
 								}
 								return layout.Inset{Bottom: unit.Dp(52)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-									return wm.drawEditorialSection(gtx, "Thèmes", accentCol, func(gtx layout.Context) layout.Dimensions {
+									return wm.drawEditorialSection(gtx, wm.tr.T("sheets.themes"), accentCol, func(gtx layout.Context) layout.Dimensions {
 										var chips []layout.FlexChild
 										for _, tag := range s.Tags {
 											t := tag
@@ -944,7 +962,7 @@ func (wm *WindowManager) drawSolidPillBtn(gtx layout.Context, label string, btn
 
 func (wm *WindowManager) drawSheetFilterBar(gtx layout.Context) layout.Dimensions {
 	titles := uniqueBookTitles(wm.sheets)
-	all := append([]string{"Tous"}, titles...)
+	all := append([]string{wm.tr.T("sheets.filter_all")}, titles...)
 	for len(wm.sheetFilterBtns) < len(all) {
 		wm.sheetFilterBtns = append(wm.sheetFilterBtns, widget.Clickable{})
 	}
@@ -993,25 +1011,25 @@ func (wm *WindowManager) drawSheetFilterBar(gtx layout.Context) layout.Dimension
 // =============================================================================
 
 func (wm *WindowManager) drawSheetSocialShare(gtx layout.Context, s *domain.ReadingSheet) layout.Dimensions {
-	text := buildShareText(s)
+	text := buildShareText(wm.tr, s)
 	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			if wm.shareCopyBtn.Clicked(gtx) {
 				if err := copyToClipboard(text); err != nil {
-					wm.shareSheetStatus = "Erreur : " + err.Error()
+					wm.shareSheetStatus = wm.tr.T("common.error", err)
 				} else {
-					wm.shareSheetStatus = "Copié dans le presse-papier !"
+					wm.shareSheetStatus = wm.tr.T("share.copied")
 				}
 			}
 			return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return wm.drawPillButton(gtx, "Copier", &wm.shareCopyBtn,
+				return wm.drawPillButton(gtx, wm.tr.T("common.copy"), &wm.shareCopyBtn,
 					color.NRGBA{R: 80, G: 80, B: 80, A: 255})
 			})
 		}),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			if wm.shareTwitterBtn.Clicked(gtx) {
 				openURL("https://twitter.com/intent/tweet?text=" + urlEncode(text))
-				wm.shareSheetStatus = "Ouverture de Twitter / X…"
+				wm.shareSheetStatus = wm.tr.T("share.opening", "Twitter / X")
 			}
 			return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 				return wm.drawPillButton(gtx, "Twitter / X", &wm.shareTwitterBtn,
@@ -1022,14 +1040,79 @@ func (wm *WindowManager) drawSheetSocialShare(gtx layout.Context, s *domain.Read
 			if wm.shareSheetBtn.Clicked(gtx) {
 				openURL("https://www.linkedin.com/sharing/share-offsite/?url=" +
 					urlEncode("https://orus.app") + "&summary=" + urlEncode(text))
-				wm.shareSheetStatus = "Ouverture de LinkedIn…"
+				wm.shareSheetStatus = wm.tr.T("share.opening", "LinkedIn")
+			}
+			return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawPillButton(gtx, "LinkedIn", &wm.shareSheetBtn,
+					color.NRGBA{R: 10, G: 102, B: 194, A: 255})
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if wm.shareCardBtn.Clicked(gtx) {
+				wm.shareQuoteCard(s, false)
+			}
+			return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawPillButton(gtx, wm.tr.T("share.png_image"), &wm.shareCardBtn, theme.ColorSandGold)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if wm.shareCardCopyBtn.Clicked(gtx) {
+				wm.shareQuoteCard(s, true)
 			}
-			return wm.drawPillButton(gtx, "LinkedIn", &wm.shareSheetBtn,
-				color.NRGBA{R: 10, G: 102, B: 194, A: 255})
+			return wm.drawPillButton(gtx, wm.tr.T("share.copy_image"), &wm.shareCardCopyBtn, theme.ColorCyberCyan)
 		}),
 	)
 }
 
+// shareQuoteCard renders the selected quote of s as a PNG card, then saves it
+// to a chosen folder or copies it to the clipboard.
+func (wm *WindowManager) shareQuoteCard(s *domain.ReadingSheet, toClipboard bool) {
+	if len(s.Quotes) == 0 {
+		wm.shareSheetStatus = wm.tr.T("share.card_needs_quote")
+		return
+	}
+	if wm.shareQuoteIdx >= len(s.Quotes) {
+		wm.shareQuoteIdx = 0
+	}
+	idx := wm.shareQuoteIdx
+	wm.shareSheetStatus = wm.tr.T("share.quote_card_rendering", idx+1)
+	wm.shareCard(func(svc *service.SharingService) ([]byte, error) {
+		return svc.QuoteCard(context.Background(), s.ID, idx)
+	}, wm.tr.T("share.quote_card_name", s.BookTitle), toClipboard, func(msg string) { wm.shareSheetStatus = msg })
+}
+
+// shareCard renders a card in the background and either copies it to the
+// clipboard or writes it to a folder picked by the user. report receives the
+// outcome.
+func (wm *WindowManager) shareCard(render func(svc *service.SharingService) ([]byte, error), name string, toClipboard bool, report func(msg string)) {
+	if wm.sharingSvc == nil {
+		report(wm.tr.T("common.service_unavailable"))
+		return
+	}
+	go func() {
+		defer wm.window.Invalidate()
+		png, err := render(wm.sharingSvc)
+		if err != nil {
+			report(wm.tr.T("common.error", err))
+			return
+		}
+		if toClipboard {
+			if err := copyImageToClipboard(png); err != nil {
+				report(wm.tr.T("common.error", err))
+			} else {
+				report(wm.tr.T("share.image_copied"))
+			}
+			return
+		}
+		path, err := wm.sharingSvc.SaveCard(png, service.PickExportDirectory(), name)
+		if err != nil {
+			report(wm.tr.T("common.error", err))
+			return
+		}
+		report(wm.tr.T("share.image_saved", path))
+	}()
+}
+
 // =============================================================================
 // HELPERS
 // =============================================================================
@@ -1041,7 +1124,7 @@ func (wm *WindowManager) loadSheets() {
 	}
 	sheets, err := wm.sheetSvc.ListSheets(context.Background())
 	if err != nil {
-		log.Printf("[Sheets] Erreur : %v", err)
+		slog.Error("reading sheets unavailable", "component", "ui", "error", err)
 	} else {
 		wm.sheets = sheets
 	}
@@ -1087,7 +1170,7 @@ func (wm *WindowManager) submitNewSheet() {
 	go func() {
 		_, err := wm.sheetSvc.CreateSheet(context.Background(), book.ID, summary, rating, quotes, tags)
 		if err != nil {
-			log.Printf("[Sheets] CreateSheet: %v", err)
+			slog.Error("reading sheet not created", "component", "ui", "book_id", book.ID, "error", err)
 			return
 		}
 		wm.sheetsLoaded = false
@@ -1162,15 +1245,15 @@ func drawSheetEmptyIcon(gtx layout.Context, ox, oy int) {
 	paint.FillShape(gtx.Ops, col, clip.Outline{Path: rp.End()}.Op())
 }
 
-func buildShareText(s *domain.ReadingSheet) string {
+func buildShareText(tr *i18n.Localizer, s *domain.ReadingSheet) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Ma fiche de lecture : \"%s\"\n", s.BookTitle))
-	sb.WriteString(fmt.Sprintf("Note : %s\n", sheetStarStr(s.Rating)))
+	sb.WriteString(tr.T("sheets.post.title", s.BookTitle))
+	sb.WriteString(tr.T("sheets.post.rating", sheetStarStr(s.Rating)))
 	if s.Summary != "" {
-		sb.WriteString(fmt.Sprintf("\nRésumé : %s\n", s.Summary))
+		sb.WriteString(tr.T("sheets.post.summary", s.Summary))
 	}
 	if len(s.Quotes) > 0 {
-		sb.WriteString("\nCitations :\n")
+		sb.WriteString(tr.T("sheets.post.quotes"))
 		for i, q := range s.Quotes {
 			sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, q))
 		}
@@ -1178,7 +1261,7 @@ func buildShareText(s *domain.ReadingSheet) string {
 	if len(s.Tags) > 0 {
 		sb.WriteString("\n#" + strings.Join(s.Tags, " #"))
 	}
-	sb.WriteString("\n\n#Orus #lecture #livres")
+	sb.WriteString("\n\n" + tr.T("sheets.post.hashtags"))
 	return sb.String()
 }
 
@@ -1202,6 +1285,44 @@ func copyToClipboard(text string) error {
 	return cmd.Run()
 }
 
+// copyImageToClipboard puts PNG data on the clipboard with the platform tools.
+func copyImageToClipboard(png []byte) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("wl-copy"); err == nil && os.Getenv("WAYLAND_DISPLAY") != "" {
+			cmd = exec.Command("wl-copy", "--type", "image/png")
+		} else {
+			cmd = exec.Command("xclip", "-selection", "clipboard", "-t", "image/png", "-i")
+		}
+		cmd.Stdin = bytes.NewReader(png)
+		return cmd.Run()
+	case "darwin", "windows":
+		// osascript et PowerShell lisent l'image depuis un fichier.
+		f, err := os.CreateTemp("", "orus_carte_*.png")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		if _, err := f.Write(png); err != nil {
+			f.Close()
+			return err
+		}
+		f.Close()
+		if runtime.GOOS == "darwin" {
+			cmd = exec.Command("osascript", "-e",
+				fmt.Sprintf(`set the clipboard to (read (POSIX file %q) as «class PNGf»)`, f.Name()))
+		} else {
+			cmd = exec.Command("powershell", "-STA", "-Command",
+				"Add-Type -AssemblyName System.Windows.Forms; "+
+					"[System.Windows.Forms.Clipboard]::SetImage([System.Drawing.Image]::FromFile('"+f.Name()+"'))")
+		}
+		return cmd.Run()
+	default:
+		return fmt.Errorf("plateforme non supportée")
+	}
+}
+
 func openURL(url string) {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
@@ -2,11 +2,11 @@ package views
 
 import (
 	"context"
-	"fmt"
 	"image"
-	"log"
+	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -17,35 +17,48 @@ import (
 
 	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/service"
 )
 
 type reminderFormState struct {
-	labelEditor  widget.Editor
-	hourEditor   widget.Editor
-	minuteEditor widget.Editor
-	freqBtns     [4]widget.Clickable
-	selectedFreq int // 0=daily,1=weekly,2=weekdays,3=once
-	saveBtn      widget.Clickable
-	showForm     bool
-	newBtn       widget.Clickable
-	statusMsg    string
+	labelEditor   widget.Editor
+	hourEditor    widget.Editor
+	minuteEditor  widget.Editor
+	freqBtns      [4]widget.Clickable
+	selectedFreq  int // 0=daily,1=weekly,2=weekdays,3=once
+	skipReadToday widget.Bool
+	saveBtn       widget.Clickable
+	showForm      bool
+	newBtn        widget.Clickable
+	statusMsg     string
+
+	// Règles globales : heures calmes, regroupement, intervalle minimal.
+	showPolicy    bool
+	policyLoaded  bool
+	policyBtn     widget.Clickable
+	quietStart    widget.Editor
+	quietEnd      widget.Editor
+	minInterval   widget.Editor
+	coalesce      widget.Bool
+	savePolicyBtn widget.Clickable
+	policyMsg     string
 }
 
-var freqOptions = []struct {
-	Label string
-	Value domain.ReminderFrequency
-}{
-	{"Tous les jours", domain.FrequencyDaily},
-	{"Chaque semaine", domain.FrequencyWeekly},
-	{"Lun–Ven", domain.FrequencyWeekdays},
-	{"Une seule fois", domain.FrequencyOnce},
+// freqOptions sont proposées dans le formulaire ; leur libellé court est
+// "reminders.option.<fréquence>".
+var freqOptions = []domain.ReminderFrequency{
+	domain.FrequencyDaily,
+	domain.FrequencyWeekly,
+	domain.FrequencyWeekdays,
+	domain.FrequencyOnce,
 }
 
 func (wm *WindowManager) drawRemindersView(gtx layout.Context) layout.Dimensions {
 	if !wm.remindersLoaded && wm.reminderSvc != nil {
 		reminders, err := wm.reminderSvc.ListReminders(context.Background())
 		if err != nil {
-			log.Printf("[Reminders] Erreur chargement : %v", err)
+			slog.Error("reminders unavailable", "component", "ui", "error", err)
 		} else {
 			wm.reminders = reminders
 		}
@@ -58,18 +71,31 @@ func (wm *WindowManager) drawRemindersView(gtx layout.Context) layout.Dimensions
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					lbl := material.H5(wm.theme, "⏰ Rappels de lecture")
+					lbl := material.H5(wm.theme, wm.tr.T("reminders.title"))
 					lbl.Font.Weight = font.Bold
 					lbl.Color = theme.ColorPureBlack
 					return lbl.Layout(gtx)
 				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					if wm.reminderForm.policyBtn.Clicked(gtx) {
+						wm.reminderForm.showPolicy = !wm.reminderForm.showPolicy
+						wm.reminderForm.policyLoaded = false
+					}
+					label := wm.tr.T("reminders.policy")
+					if wm.reminderForm.showPolicy {
+						label = wm.tr.T("common.cancel_x")
+					}
+					return layout.Inset{Right: 12}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return wm.drawPillButton(gtx, label, &wm.reminderForm.policyBtn, theme.ColorSandGold)
+					})
+				}),
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					if wm.reminderForm.newBtn.Clicked(gtx) {
 						wm.reminderForm.showForm = !wm.reminderForm.showForm
 					}
-					label := "+ Nouveau rappel"
+					label := wm.tr.T("reminders.new")
 					if wm.reminderForm.showForm {
-						label = "✕ Annuler"
+						label = wm.tr.T("common.cancel_x")
 					}
 					return wm.drawPillButton(gtx, label, &wm.reminderForm.newBtn, theme.ColorCyberCyan)
 				}),
@@ -78,6 +104,14 @@ func (wm *WindowManager) drawRemindersView(gtx layout.Context) layout.Dimensions
 
 		layout.Rigid(layout.Spacer{Height: 24}.Layout),
 
+		// Règles globales
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !wm.reminderForm.showPolicy {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Bottom: 24}.Layout(gtx, wm.drawReminderPolicy)
+		}),
+
 		// Formulaire
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			if !wm.reminderForm.showForm {
@@ -89,7 +123,7 @@ func (wm *WindowManager) drawRemindersView(gtx layout.Context) layout.Dimensions
 		// Liste
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			if len(wm.reminders) == 0 {
-				lbl := material.Label(wm.theme, 15, "Aucun rappel configuré. Créez-en un pour maintenir votre habitude de lecture !")
+				lbl := material.Label(wm.theme, 15, wm.tr.T("reminders.empty"))
 				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 130)
 				return layout.Center.Layout(gtx, lbl.Layout)
 			}
@@ -99,7 +133,7 @@ func (wm *WindowManager) drawRemindersView(gtx layout.Context) layout.Dimensions
 }
 
 func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions {
-	cl := clip.UniformRRect(image.Rectangle{Max: image.Point{X: gtx.Constraints.Max.X, Y: 380}}, 10).Push(gtx.Ops)
+	cl := clip.UniformRRect(image.Rectangle{Max: image.Point{X: gtx.Constraints.Max.X, Y: 420}}, 10).Push(gtx.Ops)
 	paint.Fill(gtx.Ops, theme.WithAlpha(theme.ColorCyberCyan, 10))
 	cl.Pop()
 
@@ -107,14 +141,14 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				lbl := material.Label(wm.theme, 14, "Nouveau rappel de lecture")
+				lbl := material.Label(wm.theme, 14, wm.tr.T("reminders.form_title"))
 				lbl.Font.Weight = font.Bold
 				return layout.Inset{Bottom: 16}.Layout(gtx, lbl.Layout)
 			}),
 
 			// Message
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return wm.drawLabeledField(gtx, "Message du rappel", &wm.reminderForm.labelEditor, "📖 C'est l'heure de lire !")
+				return wm.drawLabeledField(gtx, wm.tr.T("reminders.message"), &wm.reminderForm.labelEditor, wm.tr.T("reminders.message_hint"))
 			}),
 			layout.Rigid(layout.Spacer{Height: 12}.Layout),
 
@@ -123,7 +157,7 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						gtx.Constraints.Max.X = 80
-						return wm.drawLabeledField(gtx, "Heure", &wm.reminderForm.hourEditor, "21")
+						return wm.drawLabeledField(gtx, wm.tr.T("reminders.hour"), &wm.reminderForm.hourEditor, "21")
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						lbl := material.Label(wm.theme, 20, " h ")
@@ -132,7 +166,7 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						gtx.Constraints.Max.X = 80
-						return wm.drawLabeledField(gtx, "Minute", &wm.reminderForm.minuteEditor, "00")
+						return wm.drawLabeledField(gtx, wm.tr.T("reminders.minute"), &wm.reminderForm.minuteEditor, "00")
 					}),
 				)
 			}),
@@ -140,7 +174,7 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 
 			// Fréquence
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				lbl := material.Label(wm.theme, 13, "Fréquence :")
+				lbl := material.Label(wm.theme, 13, wm.tr.T("reminders.frequency"))
 				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 170)
 				return layout.Inset{Bottom: 8}.Layout(gtx, lbl.Layout)
 			}),
@@ -164,7 +198,7 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 						cl.Pop()
 						return layout.Inset{Left: 8}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 							return wm.reminderForm.freqBtns[idx].Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								lbl := material.Label(wm.theme, 13, freqOptions[idx].Label)
+								lbl := material.Label(wm.theme, 13, wm.tr.T("reminders.option."+string(freqOptions[idx])))
 								if active {
 									lbl.Font.Weight = font.Bold
 									lbl.Color = theme.ColorCyberCyan
@@ -178,6 +212,16 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 				}
 				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, chips...)
 			}),
+			layout.Rigid(layout.Spacer{Height: 12}.Layout),
+
+			// Silence si une session a déjà eu lieu aujourd'hui
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				cb := material.CheckBox(wm.theme, &wm.reminderForm.skipReadToday, wm.tr.T("reminders.skip_if_read"))
+				cb.Color = theme.WithAlpha(theme.ColorPureBlack, 170)
+				cb.IconColor = theme.ColorCyberCyan
+				cb.TextSize = 13
+				return cb.Layout(gtx)
+			}),
 			layout.Rigid(layout.Spacer{Height: 20}.Layout),
 
 			// Statut
@@ -195,7 +239,7 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 				if wm.reminderForm.saveBtn.Clicked(gtx) {
 					wm.submitReminderForm()
 				}
-				return wm.drawPillButton(gtx, "✓ Créer le rappel", &wm.reminderForm.saveBtn, theme.ColorSandGold)
+				return wm.drawPillButton(gtx, wm.tr.T("reminders.create"), &wm.reminderForm.saveBtn, theme.ColorSandGold)
 			}),
 		)
 	})
@@ -203,39 +247,164 @@ func (wm *WindowManager) drawReminderForm(gtx layout.Context) layout.Dimensions
 
 func (wm *WindowManager) submitReminderForm() {
 	if wm.reminderSvc == nil {
-		wm.reminderForm.statusMsg = "Service non disponible."
+		wm.reminderForm.statusMsg = wm.tr.T("common.service_unavailable")
 		return
 	}
 
 	hour, err := strconv.Atoi(strings.TrimSpace(wm.reminderForm.hourEditor.Text()))
 	if err != nil || hour < 0 || hour > 23 {
-		wm.reminderForm.statusMsg = "Heure invalide (0–23)."
+		wm.reminderForm.statusMsg = wm.tr.T("reminders.invalid_hour")
 		return
 	}
 	minute, err := strconv.Atoi(strings.TrimSpace(wm.reminderForm.minuteEditor.Text()))
 	if err != nil || minute < 0 || minute > 59 {
-		wm.reminderForm.statusMsg = "Minute invalide (0–59)."
+		wm.reminderForm.statusMsg = wm.tr.T("reminders.invalid_minute")
 		return
 	}
 
 	label := wm.reminderForm.labelEditor.Text()
-	freq := freqOptions[wm.reminderForm.selectedFreq].Value
+	freq := freqOptions[wm.reminderForm.selectedFreq]
 
-	_, err = wm.reminderSvc.AddReminder(context.Background(), "", "", label, hour, minute, freq)
+	r, err := wm.reminderSvc.AddReminder(context.Background(), "", "", label, hour, minute, freq)
 	if err != nil {
-		wm.reminderForm.statusMsg = "Erreur : " + err.Error()
+		wm.reminderForm.statusMsg = wm.tr.T("common.error", err)
 		return
 	}
+	if wm.reminderForm.skipReadToday.Value {
+		if err := wm.reminderSvc.SetSkipIfReadToday(context.Background(), r.ID, true); err != nil {
+			slog.Error("reminder option not saved", "component", "ui", "reminder_id", r.ID, "error", err)
+		}
+	}
 
 	wm.reminderForm.labelEditor.SetText("")
 	wm.reminderForm.hourEditor.SetText("")
 	wm.reminderForm.minuteEditor.SetText("")
 	wm.reminderForm.selectedFreq = 0
+	wm.reminderForm.skipReadToday.Value = false
 	wm.reminderForm.showForm = false
 	wm.remindersLoaded = false
 	wm.reminderForm.statusMsg = ""
 }
 
+// drawReminderPolicy edits the rules applied to every reminder: the quiet
+// hours, the grouping of simultaneous reminders and the minimal interval.
+func (wm *WindowManager) drawReminderPolicy(gtx layout.Context) layout.Dimensions {
+	f := &wm.reminderForm
+	if !f.policyLoaded && wm.reminderSvc != nil {
+		p := wm.reminderSvc.Policy()
+		f.quietStart.SingleLine, f.quietEnd.SingleLine, f.minInterval.SingleLine = true, true, true
+		f.quietStart.SetText(service.FormatTimeOfDay(p.Quiet.Start))
+		f.quietEnd.SetText(service.FormatTimeOfDay(p.Quiet.End))
+		f.minInterval.SetText(strconv.Itoa(int(p.MinInterval / time.Minute)))
+		f.coalesce.Value = p.Coalesce
+		f.policyMsg = ""
+		f.policyLoaded = true
+	}
+
+	cl := clip.UniformRRect(image.Rectangle{Max: image.Point{X: gtx.Constraints.Max.X, Y: 300}}, 10).Push(gtx.Ops)
+	paint.Fill(gtx.Ops, theme.WithAlpha(theme.ColorSandGold, 12))
+	cl.Pop()
+
+	return layout.Inset{Top: 16, Bottom: 24, Left: 24, Right: 24}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				lbl := material.Label(wm.theme, 14, wm.tr.T("reminders.quiet_title"))
+				lbl.Font.Weight = font.Bold
+				return layout.Inset{Bottom: 4}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				lbl := material.Label(wm.theme, 12, wm.tr.T("reminders.quiet_hint"))
+				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 140)
+				return layout.Inset{Bottom: 12}.Layout(gtx, lbl.Layout)
+			}),
+
+			// Heures calmes HH:MM → HH:MM
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.End}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						gtx.Constraints.Max.X = 110
+						return wm.drawLabeledField(gtx, wm.tr.T("reminders.quiet_start"), &f.quietStart, "22:00")
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						lbl := material.Label(wm.theme, 20, " → ")
+						lbl.Color = theme.ColorSandGold
+						return layout.Inset{Bottom: 6}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						gtx.Constraints.Max.X = 110
+						return wm.drawLabeledField(gtx, wm.tr.T("reminders.quiet_end"), &f.quietEnd, "07:00")
+					}),
+				)
+			}),
+			layout.Rigid(layout.Spacer{Height: 12}.Layout),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Max.X = 320
+				return wm.drawLabeledField(gtx, wm.tr.T("reminders.min_interval"), &f.minInterval, "0")
+			}),
+			layout.Rigid(layout.Spacer{Height: 12}.Layout),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				cb := material.CheckBox(wm.theme, &f.coalesce, wm.tr.T("reminders.coalesce"))
+				cb.Color = theme.WithAlpha(theme.ColorPureBlack, 170)
+				cb.IconColor = theme.ColorSandGold
+				cb.TextSize = 13
+				return cb.Layout(gtx)
+			}),
+			layout.Rigid(layout.Spacer{Height: 16}.Layout),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if f.policyMsg == "" {
+					return layout.Dimensions{}
+				}
+				lbl := material.Label(wm.theme, 13, f.policyMsg)
+				lbl.Color = theme.ColorSandGold
+				return layout.Inset{Bottom: 8}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if f.savePolicyBtn.Clicked(gtx) {
+					wm.submitReminderPolicy()
+				}
+				return wm.drawPillButton(gtx, wm.tr.T("reminders.policy_save"), &f.savePolicyBtn, theme.ColorSandGold)
+			}),
+		)
+	})
+}
+
+func (wm *WindowManager) submitReminderPolicy() {
+	f := &wm.reminderForm
+	if wm.reminderSvc == nil {
+		f.policyMsg = wm.tr.T("common.service_unavailable")
+		return
+	}
+	start, err := service.ParseTimeOfDay(f.quietStart.Text())
+	if err != nil {
+		f.policyMsg = wm.tr.T("reminders.invalid_time")
+		return
+	}
+	end, err := service.ParseTimeOfDay(f.quietEnd.Text())
+	if err != nil {
+		f.policyMsg = wm.tr.T("reminders.invalid_time")
+		return
+	}
+	minutes, err := strconv.Atoi(strings.TrimSpace(f.minInterval.Text()))
+	if err != nil || minutes < 0 {
+		f.policyMsg = wm.tr.T("reminders.invalid_interval")
+		return
+	}
+	policy := service.ReminderPolicy{
+		Quiet:       service.QuietHours{Start: start, End: end},
+		Coalesce:    f.coalesce.Value,
+		MinInterval: time.Duration(minutes) * time.Minute,
+	}
+	if err := wm.reminderSvc.SetPolicy(policy); err != nil {
+		slog.Error("reminder rules not saved", "component", "ui", "error", err)
+		f.policyMsg = wm.tr.T("common.error", err)
+		return
+	}
+	f.policyMsg = wm.tr.T("reminders.policy_saved")
+}
+
 func (wm *WindowManager) drawReminderList(gtx layout.Context) layout.Dimensions {
 	var rows []layout.FlexChild
 	for _, r := range wm.reminders {
@@ -263,7 +432,7 @@ func (wm *WindowManager) drawReminderCard(gtx layout.Context, r *domain.Reminder
 
 			// Heure
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				timeStr := fmt.Sprintf("%02d:%02d", r.Hour, r.Minute)
+				timeStr := wm.tr.Date(time.Date(2000, 1, 1, r.Hour, r.Minute, 0, 0, time.UTC), i18n.TimeOfDay)
 				lbl := material.Label(wm.theme, 28, timeStr)
 				lbl.Font.Weight = font.Bold
 				if !r.Enabled {
@@ -287,7 +456,11 @@ func (wm *WindowManager) drawReminderCard(gtx layout.Context, r *domain.Reminder
 					}),
 					layout.Rigid(layout.Spacer{Height: 4}.Layout),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						lbl := material.Label(wm.theme, 12, r.FrequencyLabel())
+						freq := wm.tr.T(r.Frequency.MessageKey())
+						if r.SkipIfReadToday {
+							freq += wm.tr.T("reminders.skip_suffix")
+						}
+						lbl := material.Label(wm.theme, 12, freq)
 						lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 140)
 						return lbl.Layout(gtx)
 					}),
@@ -296,10 +469,10 @@ func (wm *WindowManager) drawReminderCard(gtx layout.Context, r *domain.Reminder
 
 			// Badge ON/OFF
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				badge := "● Actif"
+				badge := wm.tr.T("reminders.active")
 				col := theme.ColorSandGold
 				if !r.Enabled {
-					badge = "○ Inactif"
+					badge = wm.tr.T("reminders.inactive")
 					col = theme.WithAlpha(theme.ColorPureBlack, 100)
 				}
 				lbl := material.Label(wm.theme, 12, badge)
@@ -0,0 +1,723 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"image"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// shareAction is one card of the Partager tab. run is called on its own
+// goroutine and reports through sharingViewState.statusMsg.
+type shareAction struct {
+	title   string
+	desc    string
+	button  string
+	pending string // message affiché pendant le sélecteur natif
+	run     func()
+	// Bouton secondaire optionnel, exécuté sur le thread UI.
+	secondary   string
+	onSecondary func()
+}
+
+type sharingViewState struct {
+	list      widget.List
+	btns      []widget.Clickable
+	altBtns   []widget.Clickable
+	statusMsg string
+	// Modèles d'export : liste rechargée à chaque changement de sélection.
+	templates   []service.ExportTemplate
+	templateIdx int
+	// Export des annotations : livre choisi et filtre des surlignages.
+	annotBookIdx       int
+	highlightFilterIdx int
+	// Exports avec les champs chiffrés en clair, sur demande explicite.
+	decryptExports bool
+	// Imports en deux temps : chemin analysé à blanc, en attente de confirmation.
+	pendingLibImport  string
+	pendingCSVImport  string
+	pendingCalibreDir string
+}
+
+// ==========================================================
+// SHARING VIEW
+// ==========================================================
+
+func (wm *WindowManager) shareActions() []shareAction {
+	confirm := func(pending string) string {
+		if pending != "" {
+			return wm.tr.T("share.confirm")
+		}
+		return wm.tr.T("share.analyse")
+	}
+	return []shareAction{
+		wm.exportAction(wm.tr.T("share.library_md"),
+			wm.tr.T("share.library_md_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportLibrary(ctx, service.ShareFormatMarkdown, dir)
+			}),
+		wm.exportAction(wm.tr.T("share.library_json"),
+			wm.tr.T("share.library_json_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportLibrary(ctx, service.ShareFormatJSON, dir)
+			}),
+		wm.exportAction(wm.tr.T("share.library_html"),
+			wm.tr.T("share.library_html_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportLibrary(ctx, service.ShareFormatHTML, dir)
+			}),
+		wm.templateExportAction(),
+		wm.exportAction(wm.tr.T("share.bibtex"),
+			wm.tr.T("share.bibtex_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportCitations(ctx, nil, service.ShareFormatBibTeX, dir)
+			}),
+		wm.exportAction(wm.tr.T("share.csl"),
+			wm.tr.T("share.csl_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportCitations(ctx, nil, service.ShareFormatCSLJSON, dir)
+			}),
+		wm.bookAnnotationsAction(),
+		wm.highlightsAction(wm.tr.T("share.highlights_readwise"), service.ShareFormatReadwise),
+		wm.highlightsAction(wm.tr.T("share.highlights_md"), service.ShareFormatMarkdown),
+		wm.exportAction(wm.tr.T("share.goodreads"),
+			wm.tr.T("share.goodreads_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportGoodreadsCSV(ctx, dir)
+			}),
+		wm.exportAction(wm.tr.T("share.ical"),
+			wm.tr.T("share.ical_desc"),
+			func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+				return svc.ExportRemindersICS(ctx, dir)
+			}),
+		wm.vaultFolderAction(),
+		wm.vaultSyncAction(),
+		wm.deviceSyncAction(),
+		wm.backupAction(),
+		wm.diagnosticsAction(),
+		wm.languageAction(),
+		wm.encryptionAction(),
+		wm.oldBackupsAction(),
+		wm.decryptExportsAction(),
+		{
+			title:   wm.tr.T("share.restore"),
+			desc:    wm.tr.T("share.restore_desc"),
+			button:  confirm(wm.sharing.pendingLibImport),
+			pending: wm.tr.T("share.picking_file"),
+			run: func() {
+				wm.twoStepImport(&wm.sharing.pendingLibImport, wm.tr.T("share.restore_dialog"), wm.tr.T("share.restore_filter"), "*.json",
+					func(svc *service.SharingService, path string, opts service.ImportOptions) (*service.ImportReport, error) {
+						return svc.ImportLibrary(context.Background(), path, opts)
+					})
+			},
+		},
+		{
+			title:   wm.tr.T("share.history_import"),
+			desc:    wm.tr.T("share.history_import_desc"),
+			button:  confirm(wm.sharing.pendingCSVImport),
+			pending: wm.tr.T("share.picking_file"),
+			run: func() {
+				wm.twoStepImport(&wm.sharing.pendingCSVImport, wm.tr.T("share.history_dialog"), wm.tr.T("share.history_filter"), "*.csv",
+					func(svc *service.SharingService, path string, opts service.ImportOptions) (*service.ImportReport, error) {
+						return svc.ImportReadingCSV(context.Background(), path, opts)
+					})
+			},
+		},
+		{
+			title:   wm.tr.T("share.calibre"),
+			desc:    wm.tr.T("share.calibre_desc"),
+			button:  confirm(wm.sharing.pendingCalibreDir),
+			pending: wm.tr.T("share.picking_folder"),
+			run:     wm.importCalibre,
+		},
+		{
+			title:   wm.tr.T("share.ical_import"),
+			desc:    wm.tr.T("share.ical_import_desc"),
+			button:  wm.tr.T("share.import"),
+			pending: wm.tr.T("share.picking_file"),
+			run:     wm.importRemindersICS,
+		},
+	}
+}
+
+func (wm *WindowManager) drawSharingView(gtx layout.Context) layout.Dimensions {
+	actions := wm.shareActions()
+	if len(wm.sharing.btns) != len(actions) {
+		wm.sharing.btns = make([]widget.Clickable, len(actions))
+		wm.sharing.altBtns = make([]widget.Clickable, len(actions))
+	}
+	wm.sharing.list.Axis = layout.Vertical
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.H5(wm.theme, wm.tr.T("share.title"))
+			lbl.Font.Weight = font.Bold
+			return layout.Inset{Bottom: 8}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Label(wm.theme, 14, wm.tr.T("share.subtitle"))
+			lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 155)
+			return layout.Inset{Bottom: 16}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if wm.sharing.statusMsg == "" {
+				return layout.Dimensions{}
+			}
+			lbl := material.Label(wm.theme, 14, wm.sharing.statusMsg)
+			lbl.Color = theme.ColorCyberCyan
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: 16}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return material.List(wm.theme, &wm.sharing.list).Layout(gtx, len(actions), func(gtx layout.Context, i int) layout.Dimensions {
+				return layout.Inset{Bottom: 16, Right: 12}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return wm.drawShareCard(gtx, actions[i], &wm.sharing.btns[i], &wm.sharing.altBtns[i])
+				})
+			})
+		}),
+	)
+}
+
+func (wm *WindowManager) drawShareCard(gtx layout.Context, action shareAction, btn, altBtn *widget.Clickable) layout.Dimensions {
+	cl := clip.UniformRRect(image.Rectangle{Max: image.Point{X: gtx.Constraints.Max.X, Y: 110}}, 10).Push(gtx.Ops)
+	paint.Fill(gtx.Ops, theme.WithAlpha(theme.ColorCyberCyan, 7))
+	cl.Pop()
+	return layout.Inset{Top: 20, Left: 24, Right: 24, Bottom: 20}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						lbl := material.Label(wm.theme, 15, action.title)
+						lbl.Font.Weight = font.Bold
+						return layout.Inset{Bottom: 5}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						lbl := material.Label(wm.theme, 13, action.desc)
+						lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
+						return lbl.Layout(gtx)
+					}),
+				)
+			}),
+			layout.Rigid(layout.Spacer{Width: 20}.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if action.secondary == "" {
+					return layout.Dimensions{}
+				}
+				if altBtn.Clicked(gtx) {
+					action.onSecondary()
+				}
+				return layout.Inset{Right: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return wm.drawPillButton(gtx, action.secondary, altBtn, theme.ColorCyberCyan)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if btn.Clicked(gtx) {
+					go action.run()
+					wm.sharing.statusMsg = action.pending
+				}
+				return wm.drawPillButton(gtx, action.button, btn, theme.ColorSandGold)
+			}),
+		)
+	})
+}
+
+// exportAction builds a card that asks for a folder and writes one file.
+func (wm *WindowManager) exportAction(title, desc string, export func(ctx context.Context, svc *service.SharingService, dir string) (string, error)) shareAction {
+	return shareAction{
+		title:   title,
+		desc:    desc,
+		button:  wm.tr.T("share.export"),
+		pending: wm.tr.T("share.picking_folder"),
+		run: func() {
+			defer wm.window.Invalidate()
+			if wm.sharingSvc == nil {
+				wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+				return
+			}
+			path, err := export(wm.exportContext(), wm.sharingSvc, service.PickExportDirectory())
+			if err != nil {
+				wm.sharing.statusMsg = wm.tr.T("common.error", err)
+			} else {
+				wm.sharing.statusMsg = wm.tr.T("share.exported", path)
+			}
+		},
+	}
+}
+
+// templateExportAction renders the library with the selected export template;
+// the secondary button cycles through the templates of the data directory.
+func (wm *WindowManager) templateExportAction() shareAction {
+	if wm.sharingSvc != nil && wm.sharing.templates == nil {
+		wm.sharing.templates = wm.sharingSvc.ListTemplates()
+	}
+	desc := wm.tr.T("share.no_template")
+	var selected service.ExportTemplate
+	if n := len(wm.sharing.templates); n > 0 {
+		selected = wm.sharing.templates[wm.sharing.templateIdx%n]
+		desc = wm.tr.T("share.template", selected.Name, selected.Ext)
+		if selected.Builtin() {
+			desc += wm.tr.T("share.template_builtin")
+		}
+		if selected.Err != nil {
+			desc += wm.tr.T("share.template_invalid", selected.Err)
+		} else if wm.sharingSvc.TemplateDir() != "" {
+			desc += wm.tr.T("share.template_dir", wm.sharingSvc.TemplateDir())
+		}
+	}
+	action := wm.exportAction(wm.tr.T("share.template_export"), desc,
+		func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+			return svc.ExportLibraryWithTemplate(ctx, selected.Name, dir)
+		})
+	action.secondary = wm.tr.T("share.next_template")
+	action.onSecondary = func() {
+		if wm.sharingSvc == nil {
+			return
+		}
+		wm.sharing.templates = wm.sharingSvc.ListTemplates()
+		wm.sharing.templateIdx++
+	}
+	return action
+}
+
+// bookAnnotationsAction exports the bookmarks and highlights of one book; the
+// secondary button cycles through the library.
+func (wm *WindowManager) bookAnnotationsAction() shareAction {
+	desc := wm.tr.T("share.no_book")
+	var book *domain.Book
+	if n := len(wm.books); n > 0 {
+		book = wm.books[wm.sharing.annotBookIdx%n]
+		desc = wm.tr.T("share.annotations_desc", book.Title)
+	}
+	action := wm.exportAction(wm.tr.T("share.annotations"), desc,
+		func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+			if book == nil {
+				return "", errors.New(wm.tr.T("share.no_book_selected"))
+			}
+			return svc.ExportBookAnnotations(ctx, book.ID, service.ShareFormatMarkdown, dir)
+		})
+	action.secondary = wm.tr.T("share.next_book")
+	action.onSecondary = func() { wm.sharing.annotBookIdx++ }
+	return action
+}
+
+// highlightFilters lists the filters offered for the library-wide highlights
+// export: everything, the last 30 days, then one per reading sheet tag.
+func (wm *WindowManager) highlightFilters() []service.HighlightFilter {
+	filters := []service.HighlightFilter{{}, {Since: time.Now().AddDate(0, 0, -30)}}
+	seen := map[string]bool{}
+	for _, sheet := range wm.sheets {
+		for _, tag := range sheet.Tags {
+			if key := strings.ToLower(tag); !seen[key] {
+				seen[key] = true
+				filters = append(filters, service.HighlightFilter{Tag: tag})
+			}
+		}
+	}
+	return filters
+}
+
+// highlightsAction exports the highlights of the whole library; the secondary
+// button cycles through the filters, shared by both formats.
+func (wm *WindowManager) highlightsAction(title string, format service.ShareFormat) shareAction {
+	filters := wm.highlightFilters()
+	filter := filters[wm.sharing.highlightFilterIdx%len(filters)]
+	label := wm.tr.T("share.filter_all")
+	switch {
+	case filter.Tag != "":
+		label = wm.tr.T("share.filter_tag", filter.Tag)
+	case !filter.Since.IsZero():
+		label = wm.tr.N("share.filter_days", 30)
+	}
+	action := wm.exportAction(title, wm.tr.T("share.filter", label),
+		func(ctx context.Context, svc *service.SharingService, dir string) (string, error) {
+			return svc.ExportHighlights(ctx, filter, format, dir)
+		})
+	action.secondary = wm.tr.T("share.next_filter")
+	action.onSecondary = func() { wm.sharing.highlightFilterIdx++ }
+	return action
+}
+
+// vaultFolderAction chooses the Obsidian/Logseq vault folder.
+func (wm *WindowManager) vaultFolderAction() shareAction {
+	desc := wm.tr.T("share.vault_desc")
+	if wm.vaultSvc != nil {
+		if dir := wm.vaultSvc.Config().Dir; dir != "" {
+			desc = wm.tr.T("share.folder", dir)
+		}
+	}
+	return shareAction{
+		title:   wm.tr.T("share.vault"),
+		desc:    desc,
+		button:  wm.tr.T("share.choose"),
+		pending: wm.tr.T("share.picking_folder"),
+		run: func() {
+			defer wm.window.Invalidate()
+			if wm.vaultSvc == nil {
+				wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+				return
+			}
+			dir, _ := openFolderDialog(wm.tr.T("dialog.vault_folder"), "")
+			if dir == "" {
+				wm.sharing.statusMsg = ""
+				return
+			}
+			cfg := wm.vaultSvc.Config()
+			cfg.Dir = dir
+			if err := wm.vaultSvc.SetVault(cfg); err != nil {
+				wm.sharing.statusMsg = wm.tr.T("common.error", err)
+				return
+			}
+			wm.runVaultSync()
+		},
+	}
+}
+
+// vaultSyncAction syncs the vault on demand; the secondary button toggles the
+// automatic sync after each sheet change.
+func (wm *WindowManager) vaultSyncAction() shareAction {
+	auto := wm.tr.T("share.auto_off")
+	if wm.vaultSvc != nil && wm.vaultSvc.Config().Auto {
+		auto = wm.tr.T("share.auto_on")
+	}
+	return shareAction{
+		title:   wm.tr.T("share.vault_sync"),
+		desc:    wm.tr.T("share.vault_sync_desc"),
+		button:  wm.tr.T("share.sync"),
+		pending: wm.tr.T("share.syncing"),
+		run: func() {
+			defer wm.window.Invalidate()
+			wm.runVaultSync()
+		},
+		secondary: auto,
+		onSecondary: func() {
+			if wm.vaultSvc == nil {
+				return
+			}
+			cfg := wm.vaultSvc.Config()
+			cfg.Auto = !cfg.Auto
+			if err := wm.vaultSvc.SetVault(cfg); err != nil {
+				wm.sharing.statusMsg = wm.tr.T("common.error", err)
+			}
+		},
+	}
+}
+
+// deviceSyncAction syncs with the other devices through the WebDAV server or
+// shared folder, configured in the sync dialog at the first press.
+func (wm *WindowManager) deviceSyncAction() shareAction {
+	desc := wm.tr.T("share.devices_desc")
+	if wm.syncSvc != nil {
+		if target := wm.syncSvc.Target(); target.Location != "" {
+			desc = wm.tr.T("share.folder", target.Location)
+			if target.Kind == port.SyncTargetWebDAV {
+				desc = wm.tr.T("share.webdav", target.Location)
+			}
+			if target.Books {
+				desc += wm.tr.T("share.devices_books")
+			}
+			desc += wm.tr.N("share.devices_auto", 5)
+		}
+	}
+	return shareAction{
+		title:   wm.tr.T("share.devices"),
+		desc:    desc,
+		button:  wm.tr.T("share.sync"),
+		pending: wm.tr.T("share.syncing"),
+		run: func() {
+			defer wm.window.Invalidate()
+			if wm.syncSvc == nil {
+				wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+				return
+			}
+			if wm.syncSvc.Target().Location == "" {
+				wm.sharing.statusMsg = ""
+				wm.uiChan <- wm.openSyncDialog
+				return
+			}
+			_ = wm.runDeviceSync()
+		},
+		secondary: wm.tr.T("share.configure"),
+		onSecondary: func() {
+			if wm.syncSvc != nil {
+				wm.openSyncDialog()
+			}
+		},
+	}
+}
+
+// runDeviceSync syncs now and reports the outcome in the status line.
+func (wm *WindowManager) runDeviceSync() error {
+	report, err := wm.syncSvc.Sync(context.Background())
+	if err != nil {
+		wm.sharing.statusMsg = wm.syncErrorText(err)
+		return err
+	}
+	wm.sharing.statusMsg = wm.tr.N("sync.report", report.Devices, report.Exported, report.Applied)
+	if report.Uploaded+report.Downloaded > 0 {
+		wm.sharing.statusMsg += wm.tr.N("sync.report_books", report.Uploaded, report.Downloaded)
+	}
+	if report.Failed > 0 {
+		wm.sharing.statusMsg += wm.tr.N("sync.report_skipped", report.Failed)
+	}
+	if report.Applied > 0 || report.Downloaded > 0 {
+		wm.uiChan <- func() {
+			wm.booksLoaded, wm.sheetsLoaded, wm.remindersLoaded, wm.dashboardLoaded = false, false, false, false
+		}
+	}
+	return nil
+}
+
+// backupAction shows when the database was last backed up and takes a
+// snapshot on demand. Restoring is a command-line operation, with Orus closed.
+func (wm *WindowManager) backupAction() shareAction {
+	desc := wm.tr.T("share.no_backup")
+	if wm.backupSvc != nil {
+		if last, err := wm.backupSvc.LastBackup(); err != nil {
+			desc = wm.tr.T("share.backups_unreadable", err)
+		} else if last != nil {
+			desc = wm.tr.T("share.last_backup", wm.tr.Date(last.Time, i18n.DateTime))
+			if last.Kind != service.BackupAutomatic {
+				desc += wm.tr.T("share.backup_premigration")
+			}
+		}
+		desc += wm.tr.T("share.backup_hint", wm.backupSvc.Dir())
+	}
+	return shareAction{
+		title:   wm.tr.T("share.backups"),
+		desc:    desc,
+		button:  wm.tr.T("share.backup_now"),
+		pending: wm.tr.T("share.backing_up"),
+		run: func() {
+			defer wm.window.Invalidate()
+			if wm.backupSvc == nil {
+				wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+				return
+			}
+			backup, err := wm.backupSvc.BackupNow(context.Background())
+			if err != nil {
+				wm.sharing.statusMsg = wm.tr.T("common.error", err)
+			} else {
+				wm.sharing.statusMsg = wm.tr.T("share.backed_up", backup.Path)
+			}
+		},
+	}
+}
+
+// diagnosticsAction copies the recent log and a summary of the installation
+// to the clipboard, to be pasted in a bug report.
+func (wm *WindowManager) diagnosticsAction() shareAction {
+	desc := wm.tr.T("share.diagnostics_desc")
+	if wm.diagnosticsSvc != nil {
+		if f := wm.diagnosticsSvc.Info().LogFile; f != "" {
+			desc += wm.tr.T("share.diagnostics_log", f)
+		}
+	}
+	return shareAction{
+		title:   wm.tr.T("share.diagnostics"),
+		desc:    desc,
+		button:  wm.tr.T("common.copy"),
+		pending: wm.tr.T("share.diagnostics_pending"),
+		run: func() {
+			defer wm.window.Invalidate()
+			if wm.diagnosticsSvc == nil {
+				wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+				return
+			}
+			if err := copyToClipboard(wm.diagnosticsSvc.Report(context.Background())); err != nil {
+				wm.sharing.statusMsg = wm.tr.T("common.error", err)
+				return
+			}
+			wm.sharing.statusMsg = wm.tr.T("share.diagnostics_copied")
+		},
+	}
+}
+
+// languageAction switches the interface and the exports to the next
+// supported language, kept in the settings for the next launch.
+func (wm *WindowManager) languageAction() shareAction {
+	return shareAction{
+		title:  wm.tr.T("share.language"),
+		desc:   wm.tr.T("share.language_desc", wm.tr.T("language."+string(wm.tr.Locale()))),
+		button: wm.tr.T("share.language_next"),
+		run:    func() { wm.uiChan <- wm.nextLanguage },
+	}
+}
+
+// nextLanguage moves to the language after the current one. It must run on
+// the UI thread.
+func (wm *WindowManager) nextLanguage() {
+	locales := i18n.Supported()
+	next := locales[0]
+	for i, l := range locales {
+		if l == wm.tr.Locale() {
+			next = locales[(i+1)%len(locales)]
+		}
+	}
+	wm.setLanguage(next)
+}
+
+// setLanguage translates the interface and the service outputs into l and
+// saves the choice.
+func (wm *WindowManager) setLanguage(l i18n.Locale) {
+	wm.tr = i18n.New(l)
+	if wm.sharingSvc != nil {
+		wm.sharingSvc.SetLocalizer(wm.tr)
+	}
+	if wm.reminderSvc != nil {
+		wm.reminderSvc.SetLocalizer(wm.tr)
+	}
+	if wm.vaultSvc != nil {
+		wm.vaultSvc.SetLocalizer(wm.tr)
+	}
+	if wm.saveLanguage != nil {
+		if err := wm.saveLanguage(l); err != nil {
+			slog.Error("failed to save language", "component", "ui", "language", l, "error", err)
+		}
+	}
+	wm.sharing.statusMsg = wm.tr.T("share.language_changed", wm.tr.T("language."+string(l)))
+	wm.window.Invalidate()
+}
+
+func (wm *WindowManager) runVaultSync() {
+	if wm.vaultSvc == nil {
+		wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+		return
+	}
+	report, err := wm.vaultSvc.SyncAll(wm.exportContext())
+	switch {
+	case errors.Is(err, service.ErrNoVault):
+		wm.sharing.statusMsg = wm.tr.T("share.vault_missing")
+	case err != nil:
+		wm.sharing.statusMsg = wm.tr.T("common.error", err)
+	default:
+		wm.sharing.statusMsg = wm.tr.T("share.vault_report", report.Created, report.Updated, report.Unchanged)
+	}
+}
+
+// twoStepImport runs a dry run on the chosen file and shows its report; the
+// next press performs the real import of the same file.
+func (wm *WindowManager) twoStepImport(pending *string, title, filterName, pattern string,
+	run func(svc *service.SharingService, path string, opts service.ImportOptions) (*service.ImportReport, error)) {
+	defer wm.window.Invalidate()
+	if wm.sharingSvc == nil {
+		wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+		return
+	}
+	path := *pending
+	dryRun := path == ""
+	if dryRun {
+		path = pickFile(title, filterName, pattern)
+		if path == "" {
+			wm.sharing.statusMsg = ""
+			return
+		}
+	}
+	report, err := run(wm.sharingSvc, path, service.ImportOptions{DryRun: dryRun})
+	if err != nil {
+		*pending = ""
+		wm.sharing.statusMsg = wm.tr.T("common.error", err)
+		return
+	}
+	if dryRun {
+		*pending = path
+		wm.sharing.statusMsg = wm.tr.T("share.import_preview", importSummary(wm.tr, report))
+		return
+	}
+	*pending = ""
+	wm.sharing.statusMsg = wm.tr.T("share.imported", importSummary(wm.tr, report))
+	// Les livres, fiches, sessions et annotations importés arrivent par les
+	// événements ; aucun ne décrit les rappels.
+	wm.remindersLoaded = false
+}
+
+func importSummary(tr *i18n.Localizer, report *service.ImportReport) string {
+	msg := strings.Join([]string{
+		tr.N("import.books_added", report.BooksAdded),
+		tr.N("import.books_matched", report.BooksMatched),
+		tr.N("import.sheets", report.SheetsAdded+report.SheetsUpdated),
+		tr.N("import.sessions", report.SessionsImported),
+		tr.N("import.annotations", report.AnnotationsImported),
+		tr.N("import.reminders", report.RemindersImported),
+	}, ", ")
+	if n := len(report.Conflicts); n > 0 {
+		first := report.Conflicts[0]
+		msg += tr.N("import.conflicts", n, first.BookTitle, tr.T("import.conflict."+string(first.Kind)))
+	}
+	return msg
+}
+
+func (wm *WindowManager) importRemindersICS() {
+	defer wm.window.Invalidate()
+	if wm.sharingSvc == nil {
+		wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+		return
+	}
+	path := pickFile(wm.tr.T("share.ical_dialog"), wm.tr.T("share.ical_filter"), "*.ics")
+	if path == "" {
+		wm.sharing.statusMsg = ""
+		return
+	}
+	imported, errs := wm.sharingSvc.ImportRemindersICS(context.Background(), path)
+	wm.sharing.statusMsg = wm.tr.N("share.reminders_imported", len(imported))
+	if len(errs) > 0 {
+		wm.sharing.statusMsg += wm.tr.N("share.reminders_skipped", len(errs), errs[0])
+	}
+	wm.remindersLoaded = false
+}
+
+// importCalibre analyses a Calibre library folder on the first press and
+// imports it on the second, reporting progress book by book.
+func (wm *WindowManager) importCalibre() {
+	defer wm.window.Invalidate()
+	if wm.libSvc == nil || wm.openLibrary == nil {
+		wm.sharing.statusMsg = wm.tr.T("common.service_unavailable")
+		return
+	}
+	dir := wm.sharing.pendingCalibreDir
+	dryRun := dir == ""
+	if dryRun {
+		dir, _ = openFolderDialog(wm.tr.T("dialog.calibre_folder"), "")
+		if dir == "" {
+			wm.sharing.statusMsg = ""
+			return
+		}
+	}
+	src, err := wm.openLibrary(dir)
+	if err != nil {
+		wm.sharing.pendingCalibreDir = ""
+		wm.sharing.statusMsg = wm.tr.T("common.error", err)
+		return
+	}
+	report, err := wm.libSvc.ImportFromSource(context.Background(), src, service.ImportOptions{DryRun: dryRun},
+		func(done, total int, title string) {
+			wm.sharing.statusMsg = wm.tr.T("share.calibre_progress", done, total, title)
+			wm.window.Invalidate()
+		})
+	if err != nil {
+		wm.sharing.pendingCalibreDir = ""
+		wm.sharing.statusMsg = wm.tr.T("common.error", err)
+		return
+	}
+	if dryRun {
+		wm.sharing.pendingCalibreDir = dir
+		wm.sharing.statusMsg = wm.tr.T("share.import_preview", importSummary(wm.tr, report))
+		return
+	}
+	wm.sharing.pendingCalibreDir = ""
+	wm.sharing.statusMsg = wm.tr.T("share.imported", importSummary(wm.tr, report))
+}
@@ -0,0 +1,223 @@
+package views
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"net/url"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// syncDialog configures where the devices meet: a WebDAV server (Nextcloud,
+// ownCloud...) or, through the folder button, a local synced folder.
+type syncDialog struct {
+	open     bool
+	busy     bool // enregistrement et première synchronisation en cours
+	errMsg   string
+	url      widget.Editor
+	username widget.Editor
+	password widget.Editor
+	books    widget.Bool
+	submit   widget.Clickable
+	folder   widget.Clickable
+	cancel   widget.Clickable
+}
+
+// openSyncDialog shows the dialog filled with the current WebDAV target. It
+// must run on the UI thread.
+func (wm *WindowManager) openSyncDialog() {
+	d := &wm.syncDialog
+	d.open, d.busy, d.errMsg = true, false, ""
+	d.url = widget.Editor{SingleLine: true, Submit: true}
+	d.username = widget.Editor{SingleLine: true, Submit: true}
+	d.password = newPassphraseEditor()
+	d.books.Value = false
+	if wm.syncSvc != nil {
+		target := wm.syncSvc.Target()
+		d.books.Value = target.Books
+		if target.Kind == port.SyncTargetWebDAV {
+			d.url.SetText(target.Location)
+			d.username.SetText(target.Username)
+			d.password.SetText(target.Password)
+		}
+	}
+	wm.window.Invalidate()
+}
+
+// submitSyncDialog saves the WebDAV target, then runs a first sync on a
+// goroutine so that a wrong URL or password shows up in the dialog.
+func (wm *WindowManager) submitSyncDialog() {
+	d := &wm.syncDialog
+	if d.busy || wm.syncSvc == nil {
+		return
+	}
+	raw := strings.TrimSpace(d.url.Text())
+	if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		d.errMsg = wm.tr.T("sync.invalid_url")
+		return
+	}
+	target := port.SyncTarget{
+		Kind:     port.SyncTargetWebDAV,
+		Location: raw,
+		Username: strings.TrimSpace(d.username.Text()),
+		Password: d.password.Text(),
+		Books:    d.books.Value,
+	}
+	d.busy, d.errMsg = true, ""
+	go func() {
+		err := wm.syncSvc.SetTarget(target)
+		if err == nil {
+			err = wm.runDeviceSync()
+		}
+		wm.uiChan <- func() {
+			d.busy = false
+			if err != nil {
+				d.errMsg = wm.syncErrorText(err)
+			} else {
+				d.open = false
+			}
+			wm.window.Invalidate()
+		}
+	}()
+}
+
+// syncErrorText describes why a sync failed, for the status line or the dialog.
+func (wm *WindowManager) syncErrorText(err error) string {
+	if errors.Is(err, service.ErrSyncEncrypted) {
+		return wm.tr.T("sync.encrypted")
+	}
+	return wm.tr.T("common.error", err)
+}
+
+// pickSyncFolder replaces the target with a local folder chosen in the
+// system dialog, keeping the book files option of the dialog.
+func (wm *WindowManager) pickSyncFolder() {
+	d := &wm.syncDialog
+	if d.busy || wm.syncSvc == nil {
+		return
+	}
+	books := d.books.Value
+	d.busy, d.errMsg = true, ""
+	go func() {
+		dir, _ := openFolderDialog(wm.tr.T("dialog.sync_folder"), "")
+		var err error
+		if dir != "" {
+			if err = wm.syncSvc.SetTarget(port.SyncTarget{Kind: port.SyncTargetFolder, Location: dir, Books: books}); err == nil {
+				err = wm.runDeviceSync()
+			}
+		}
+		wm.uiChan <- func() {
+			d.busy = false
+			switch {
+			case err != nil:
+				d.errMsg = wm.syncErrorText(err)
+			case dir != "":
+				d.open = false
+			}
+			wm.window.Invalidate()
+		}
+	}()
+}
+
+func (wm *WindowManager) drawSyncDialog(gtx layout.Context) {
+	d := &wm.syncDialog
+	for _, ed := range []*widget.Editor{&d.url, &d.username, &d.password} {
+		for {
+			e, ok := ed.Update(gtx)
+			if !ok {
+				break
+			}
+			if _, ok := e.(widget.SubmitEvent); ok {
+				wm.submitSyncDialog()
+			}
+		}
+	}
+	if d.submit.Clicked(gtx) {
+		wm.submitSyncDialog()
+	}
+	if d.folder.Clicked(gtx) {
+		wm.pickSyncFolder()
+	}
+	if d.cancel.Clicked(gtx) && !d.busy {
+		d.open = false
+		return
+	}
+
+	W, H := gtx.Constraints.Max.X, gtx.Constraints.Max.Y
+	cl := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+	paint.Fill(gtx.Ops, color.NRGBA{R: 8, G: 6, B: 22, A: 160})
+	cl.Pop()
+	cardW, cardH := 520, 470
+	off := op.Offset(image.Pt((W-cardW)/2, (H-cardH)/2)).Push(gtx.Ops)
+	defer off.Pop()
+	cl = clip.UniformRRect(image.Rectangle{Max: image.Pt(cardW, cardH)}, 18).Push(gtx.Ops)
+	paint.Fill(gtx.Ops, color.NRGBA{R: 253, G: 251, B: 246, A: 255})
+	cl.Pop()
+
+	field := func(label string, ed *widget.Editor) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return wm.drawLabeledField(gtx, label, ed, "")
+			})
+		})
+	}
+	gtx.Constraints = layout.Exact(image.Pt(cardW, cardH))
+	layout.UniformInset(28).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				lbl := material.Label(wm.theme, 18, wm.tr.T("share.devices"))
+				lbl.Font.Weight = font.Bold
+				return layout.Inset{Bottom: 6}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				lbl := material.Label(wm.theme, 13, wm.tr.T("sync.dialog_desc"))
+				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
+				return layout.Inset{Bottom: 14}.Layout(gtx, lbl.Layout)
+			}),
+			field(wm.tr.T("sync.url"), &d.url),
+			field(wm.tr.T("common.username"), &d.username),
+			field(wm.tr.T("common.password"), &d.password),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				cb := material.CheckBox(wm.theme, &d.books, wm.tr.T("sync.books"))
+				return layout.Inset{Bottom: 10}.Layout(gtx, cb.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				msg, col := d.errMsg, color.NRGBA{R: 180, G: 40, B: 40, A: 255}
+				if d.busy {
+					msg, col = wm.tr.T("share.syncing"), theme.ColorCyberCyan
+				}
+				lbl := material.Label(wm.theme, 13, msg)
+				lbl.Color = col
+				return layout.Inset{Bottom: 10}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return wm.drawPillButton(gtx, wm.tr.T("sync.local_folder"), &d.folder, theme.ColorCyberCyan)
+					}),
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions { return layout.Dimensions{} }),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return wm.drawPillButton(gtx, wm.tr.T("common.cancel"), &d.cancel, theme.ColorCyberCyan)
+						})
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return wm.drawPillButton(gtx, wm.tr.T("common.save"), &d.submit, theme.ColorSandGold)
+					}),
+				)
+			}),
+		)
+	})
+}
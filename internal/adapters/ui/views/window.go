@@ -29,6 +29,7 @@ import (
 
 	"github.com/MiltonJ23/Orus/internal/adapters/ui/theme"
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
 	"github.com/MiltonJ23/Orus/internal/port"
 	"github.com/MiltonJ23/Orus/internal/service"
 )
@@ -38,28 +39,38 @@ type AppState int
 const (
 	StateSplash AppState = iota
 	StateHome
+	StateUnlock // base chiffrée, en attente de la phrase de passe
 )
 
 // WindowManager is the root UI controller.
 type WindowManager struct {
-	window        *app.Window
-	theme         *material.Theme
-	libSvc        *service.LibraryService
-	trackSvc      *service.TrackerService
-	sheetSvc      *service.ReadingSheetService
-	reminderSvc   *service.ReminderService
-	sharingSvc    *service.SharingService
-	contentReader port.ContentReader
-	state         AppState
-	appStartTime  time.Time
-	logo          image.Image
+	window         *app.Window
+	theme          *material.Theme
+	libSvc         *service.LibraryService
+	trackSvc       *service.TrackerService
+	sheetSvc       *service.ReadingSheetService
+	reminderSvc    *service.ReminderService
+	sharingSvc     *service.SharingService
+	vaultSvc       *service.VaultSyncService
+	backupSvc      *service.BackupService
+	encryptionSvc  *service.EncryptionService
+	syncSvc        *service.SyncService
+	diagnosticsSvc *service.DiagnosticsService
+	tr             *i18n.Localizer
+	saveLanguage   func(i18n.Locale) error
+	contentReader  port.ContentReader
+	openLibrary    port.LibrarySourceOpener
+	openCatalog    port.CatalogOpener
+	state          AppState
+	appStartTime   time.Time
+	logo           image.Image
 
 	// macOS traffic lights
 	btnClose widget.Clickable
 	btnMin   widget.Clickable
 	btnMax   widget.Clickable
 
-	// Sidebar
+	// Sidebar — clés des libellés d'onglets
 	tabs      []string
 	tabClicks []widget.Clickable
 	activeTab int
@@ -108,6 +119,8 @@ type WindowManager struct {
 	achievementShare      widget.Clickable
 	achievementTwitter    widget.Clickable
 	achievementLinkedIn   widget.Clickable
+	achievementCard       widget.Clickable
+	achievementStatus     string
 	confettiStart         time.Time // when confetti burst started
 	confettiActive        bool
 	dismissedAchievements map[string]bool // bookID → already shown; never re-show
@@ -115,6 +128,11 @@ type WindowManager struct {
 	// Social share buttons in detail view
 	shareTwitterBtn widget.Clickable
 	shareCopyBtn    widget.Clickable
+	// Cartes image : citation choisie dans la fiche, boutons d'export.
+	sheetQuoteBtns   []widget.Clickable
+	shareQuoteIdx    int
+	shareCardBtn     widget.Clickable
+	shareCardCopyBtn widget.Clickable
 
 	// Sheet filter
 	sheetFilterIdx  int
@@ -134,9 +152,14 @@ type WindowManager struct {
 	reminderBannerBtn widget.Clickable
 
 	// Sharing
-	shareStatusMsg string
-	shareLibBtn    widget.Clickable
-	shareExportBtn widget.Clickable
+	sharing sharingViewState
+	// Remote OPDS catalogs
+	catalog catalogViewState
+
+	// Encryption passphrase prompt (unlock at start-up, enable, change, disable)
+	passphrase passphraseDialog
+	// Device sync target (WebDAV server or local folder)
+	syncDialog syncDialog
 
 	// Book card action overlay (cover click → slide → archive/delete)
 	activeBookCardIdx  int // -1 = none
@@ -153,7 +176,6 @@ type WindowManager struct {
 	uiChan chan func()
 
 	// Reader
-	readerOpenedAt   time.Time
 	readerScrollList widget.List // vertical scroll within a reader page
 	readerActive     bool
 	readerBook       *domain.Book
@@ -190,8 +212,20 @@ func NewWindowManager(
 	sheet *service.ReadingSheetService,
 	reminder *service.ReminderService,
 	sharing *service.SharingService,
+	vault *service.VaultSyncService,
+	backup *service.BackupService,
+	encryption *service.EncryptionService,
+	syncer *service.SyncService,
 	contentReader port.ContentReader,
+	openLibrary port.LibrarySourceOpener,
+	openCatalog port.CatalogOpener,
+	diagnostics *service.DiagnosticsService,
+	tr *i18n.Localizer,
+	saveLanguage func(i18n.Locale) error,
 ) *WindowManager {
+	if tr == nil {
+		tr = i18n.New(i18n.DetectLocale())
+	}
 	th := material.NewTheme()
 	th.Shaper = text.NewShaper(text.WithCollection(gofont.Collection()))
 
@@ -202,8 +236,8 @@ func NewWindowManager(
 	}
 
 	menuTabs := []string{
-		"Home", "Tous", "A lire", "Termines",
-		"Fiches", "Rappels", "Partager", "Metriques",
+		"tab.home", "tab.all", "tab.unread", "tab.done",
+		"tab.sheets", "tab.reminders", "tab.share", "tab.catalogs", "tab.metrics",
 	}
 	clicks := make([]widget.Clickable, len(menuTabs))
 
@@ -215,7 +249,16 @@ func NewWindowManager(
 		sheetSvc:              sheet,
 		reminderSvc:           reminder,
 		sharingSvc:            sharing,
+		vaultSvc:              vault,
+		backupSvc:             backup,
+		encryptionSvc:         encryption,
+		syncSvc:               syncer,
 		contentReader:         contentReader,
+		openLibrary:           openLibrary,
+		openCatalog:           openCatalog,
+		diagnosticsSvc:        diagnostics,
+		tr:                    tr,
+		saveLanguage:          saveLanguage,
 		state:                 StateSplash,
 		appStartTime:          time.Now(),
 		logo:                  logoImg,
@@ -231,17 +274,10 @@ func NewWindowManager(
 		activeBookCardIdx:     -1,
 		overlayReadBtns:       []widget.Clickable{},
 		readerBgMode:          0,
+		catalog:               newCatalogViewState(),
 		uiChan:                make(chan func(), 128),
 	}
 
-	if reminder != nil {
-		reminder.SetCallback(func(r *domain.Reminder) {
-			wm.uiChan <- func() {
-				wm.activeReminder = r
-				wm.window.Invalidate()
-			}
-		})
-	}
 	return wm
 }
 
@@ -266,10 +302,15 @@ func (wm *WindowManager) Run() error {
 			elapsed := time.Since(wm.appStartTime).Seconds()
 			if wm.state == StateSplash && elapsed > 6.5 {
 				wm.state = StateHome
+				if wm.encryptionSvc != nil && wm.encryptionSvc.Locked() {
+					wm.state = StateUnlock
+				}
 			}
 			if wm.state == StateSplash {
 				wm.layoutSplashScreen(gtx, elapsed)
 				wm.window.Invalidate()
+			} else if wm.state == StateUnlock {
+				wm.layoutUnlockScreen(gtx)
 			} else {
 				// Live search: capture editor changes every frame
 				wm.searchQuery = wm.searchEditor.Text()
@@ -280,7 +321,17 @@ func (wm *WindowManager) Run() error {
 			if e.State == key.Press {
 				// ESC closes overlay or reader
 				if e.Name == key.NameEscape {
-					if wm.achievementBook != nil {
+					if wm.passphrase.open {
+						if wm.passphrase.mode != passphraseUnlock && !wm.passphrase.busy {
+							wm.passphrase.open = false
+							wm.window.Invalidate()
+						}
+					} else if wm.syncDialog.open {
+						if !wm.syncDialog.busy {
+							wm.syncDialog.open = false
+							wm.window.Invalidate()
+						}
+					} else if wm.achievementBook != nil {
 						if wm.dismissedAchievements == nil {
 							wm.dismissedAchievements = make(map[string]bool)
 						}
@@ -701,6 +752,12 @@ func (wm *WindowManager) layoutHomeScreen(gtx layout.Context) layout.Dimensions
 	if wm.achievementBook != nil {
 		wm.drawAchievementModal(gtx)
 	}
+	if wm.syncDialog.open {
+		wm.drawSyncDialog(gtx)
+	}
+	if wm.passphrase.open {
+		wm.drawPassphraseDialog(gtx)
+	}
 	wm.drawMacControls(gtx)
 	return layout.Dimensions{Size: gtx.Constraints.Max}
 }
@@ -718,9 +775,11 @@ func (wm *WindowManager) routeMainContent(gtx layout.Context) layout.Dimensions
 	case 6:
 		return wm.drawSharingView(gtx)
 	case 7:
+		return wm.drawCatalogView(gtx)
+	case 8:
 		return wm.drawMetrics(gtx)
 	default:
-		return layout.Center.Layout(gtx, material.H4(wm.theme, "En construction").Layout)
+		return layout.Center.Layout(gtx, material.H4(wm.theme, wm.tr.T("common.under_construction")).Layout)
 	}
 }
 
@@ -746,7 +805,7 @@ func (wm *WindowManager) drawReminderBanner(gtx layout.Context) {
 	cl.Pop()
 	wm.reminderBannerBtn.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Inset{Top: 13, Left: 24}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			msg := fmt.Sprintf(">>  %s    |    Cliquer pour fermer", wm.activeReminder.Label)
+			msg := wm.tr.T("reminders.banner", wm.activeReminder.Label)
 			lbl := material.Label(wm.theme, 14, msg)
 			lbl.Color = theme.ColorGlassWhite
 			lbl.Font.Weight = font.Bold
@@ -770,7 +829,7 @@ func (wm *WindowManager) drawSidebar(gtx layout.Context) layout.Dimensions {
 					paint.Fill(gtx.Ops, theme.WithAlpha(theme.ColorCyberCyan, 18))
 					cl.Pop()
 					return layout.Inset{Top: 6, Bottom: 6, Left: 10}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						ed := material.Editor(wm.theme, &wm.searchEditor, "Rechercher...")
+						ed := material.Editor(wm.theme, &wm.searchEditor, wm.tr.T("sidebar.search"))
 						ed.Color = theme.ColorPureBlack
 						return ed.Layout(gtx)
 					})
@@ -798,7 +857,7 @@ func (wm *WindowManager) drawSidebarTabs(gtx layout.Context) layout.Dimensions {
 			children = append(children,
 				layout.Rigid(layout.Spacer{Height: 22}.Layout),
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					lbl := material.Label(wm.theme, 10, "BIBLIOTHEQUE")
+					lbl := material.Label(wm.theme, 10, wm.tr.T("sidebar.library"))
 					lbl.Color = theme.WithAlpha(theme.ColorCyberCyan, 170)
 					lbl.Font.Weight = font.Bold
 					return layout.Inset{Left: 10, Bottom: 8}.Layout(gtx, lbl.Layout)
@@ -808,13 +867,13 @@ func (wm *WindowManager) drawSidebarTabs(gtx layout.Context) layout.Dimensions {
 			children = append(children,
 				layout.Rigid(layout.Spacer{Height: 22}.Layout),
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					lbl := material.Label(wm.theme, 10, "OUTILS")
+					lbl := material.Label(wm.theme, 10, wm.tr.T("sidebar.tools"))
 					lbl.Color = theme.WithAlpha(theme.ColorCyberCyan, 170)
 					lbl.Font.Weight = font.Bold
 					return layout.Inset{Left: 10, Bottom: 8}.Layout(gtx, lbl.Layout)
 				}))
 		}
-		if idx == 7 {
+		if idx == 8 {
 			children = append(children, layout.Flexed(1, layout.Spacer{}.Layout))
 		}
 		child := layout.Rigid(func(gtx layout.Context) layout.Dimensions {
@@ -846,7 +905,7 @@ func (wm *WindowManager) drawSidebarTabs(gtx layout.Context) layout.Dimensions {
 								fw = font.Bold
 								tc = theme.ColorPureBlack
 							}
-							lbl := material.Label(wm.theme, 14, wm.tabs[idx])
+							lbl := material.Label(wm.theme, 14, wm.tr.T(wm.tabs[idx]))
 							lbl.Color = tc
 							lbl.Font.Weight = fw
 							return lbl.Layout(gtx)
@@ -930,7 +989,15 @@ func (wm *WindowManager) drawTabIcon(gtx layout.Context, idx int, active bool, s
 		p.Close()
 		paint.FillShape(gtx.Ops, col, clip.Outline{Path: p.End()}.Op())
 		drawCircle(gtx, 3, float32(sz-2), 2, col)
-	case 7: // Metriques — bar chart
+	case 7: // Catalogues — globe
+		drawCircle(gtx, half, half, half-1, theme.WithAlpha(col, 50))
+		cl := clip.Rect{Min: image.Point{X: 1, Y: int(half) - 1}, Max: image.Point{X: sz - 1, Y: int(half) + 1}}.Push(gtx.Ops)
+		paint.Fill(gtx.Ops, col)
+		cl.Pop()
+		cl2 := clip.UniformRRect(image.Rectangle{Min: image.Point{X: int(half) - 3, Y: 1}, Max: image.Point{X: int(half) + 3, Y: sz - 1}}, 3).Push(gtx.Ops)
+		paint.Fill(gtx.Ops, theme.WithAlpha(col, 140))
+		cl2.Pop()
+	case 8: // Metriques — bar chart
 		for _, b := range []struct{ x, h int }{{2, 8}, {6, 12}, {10, 6}, {14, 10}} {
 			cl := clip.Rect{Min: image.Point{X: b.x, Y: sz - b.h}, Max: image.Point{X: b.x + 3, Y: sz}}.Push(gtx.Ops)
 			paint.Fill(gtx.Ops, col)
@@ -968,18 +1035,18 @@ func (wm *WindowManager) drawDashboard(gtx layout.Context) layout.Dimensions {
 
 	var completion float32
 	centerLabel := "0%"
-	subLabel := "Aucune session"
-	bookTitle := "Aucun livre en cours"
-	authorLabel := "Importez un livre pour commencer"
+	subLabel := wm.tr.T("dashboard.no_session")
+	bookTitle := wm.tr.T("dashboard.no_book")
+	authorLabel := wm.tr.T("dashboard.import_hint")
 
 	if wm.currentBook != nil && wm.currentSession != nil {
 		completion = float32(wm.currentSession.CalculateCompletion()) / 100.0
 		centerLabel = fmt.Sprintf("%.0f%%", wm.currentSession.CalculateCompletion())
-		subLabel = fmt.Sprintf("Page %d / %d", wm.currentSession.CurrentPage, wm.currentSession.TotalPages)
+		subLabel = wm.tr.T("dashboard.page_of", wm.currentSession.CurrentPage, wm.currentSession.TotalPages)
 		bookTitle = wm.currentBook.Title
 		authorLabel = wm.currentBook.Author
 		if authorLabel == "" {
-			authorLabel = "Auteur inconnu"
+			authorLabel = wm.tr.T("common.unknown_author")
 		}
 	}
 
@@ -988,7 +1055,7 @@ func (wm *WindowManager) drawDashboard(gtx layout.Context) layout.Dimensions {
 			wm.openBookInReader(wm.currentBook)
 		} else {
 			// No book yet — trigger import
-			wm.importStatusMsg = "Ouverture du sélecteur..."
+			wm.importStatusMsg = wm.tr.T("library.opening_picker")
 			go wm.importBooksFromPicker()
 		}
 	}
@@ -1028,7 +1095,7 @@ func (wm *WindowManager) drawDashboard(gtx layout.Context) layout.Dimensions {
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							lbl := material.Label(wm.theme, 12, "EN COURS DE LECTURE")
+							lbl := material.Label(wm.theme, 12, wm.tr.T("dashboard.reading_now"))
 							lbl.Color = theme.WithAlpha(theme.ColorCyberCyan, 160)
 							lbl.Font.Weight = font.Bold
 							return layout.Inset{Bottom: 10}.Layout(gtx, lbl.Layout)
@@ -1045,9 +1112,9 @@ func (wm *WindowManager) drawDashboard(gtx layout.Context) layout.Dimensions {
 							return layout.Inset{Bottom: 36}.Layout(gtx, lbl.Layout)
 						}),
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							label := "Continuer la lecture"
+							label := wm.tr.T("dashboard.continue")
 							if wm.currentBook == nil {
-								label = "Importer un livre"
+								label = wm.tr.T("dashboard.import")
 							}
 							return wm.drawGlowCTA(gtx, label, &wm.continueReadBtn, theme.ColorCyberCyan)
 						}),
@@ -1070,26 +1137,26 @@ func (wm *WindowManager) drawDashboard(gtx layout.Context) layout.Dimensions {
 			}
 			avgStr := "—"
 			if sessionCount > 0 {
-				avgStr = fmt.Sprintf("%d min estimees", (totalPages*2)/sessionCount)
+				avgStr = wm.tr.T("dashboard.estimated_minutes", (totalPages*2)/sessionCount)
 			}
 			pagesStr, finStr := "—", "—"
 			if totalPages > 0 {
-				pagesStr = fmt.Sprintf("%d pages", totalPages)
+				pagesStr = wm.tr.N("dashboard.pages", totalPages)
 			}
 			if booksFinished > 0 {
-				finStr = fmt.Sprintf("%d livre(s)", booksFinished)
+				finStr = wm.tr.N("dashboard.books", booksFinished)
 			}
 			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Temps moyen estime", avgStr, "")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("dashboard.average_time"), avgStr, "")
 				}),
 				layout.Rigid(layout.Spacer{Width: 24}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Livres termines", finStr, "")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.books_finished"), finStr, "")
 				}),
 				layout.Rigid(layout.Spacer{Width: 24}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Pages lues au total", pagesStr, "")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("dashboard.total_pages"), pagesStr, "")
 				}),
 			)
 		}),
@@ -1135,7 +1202,7 @@ func (wm *WindowManager) drawSpeedometer(gtx layout.Context, completion float32,
 				return layout.Inset{Top: 4}.Layout(gtx, lbl.Layout)
 			}),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				lbl := material.Label(wm.theme, 10, "COMPLETE")
+				lbl := material.Label(wm.theme, 10, wm.tr.T("dashboard.complete"))
 				lbl.Color = theme.WithAlpha(theme.ColorCyberCyan, 160)
 				lbl.Font.Weight = font.Bold
 				lbl.Alignment = text.Middle
@@ -1146,102 +1213,6 @@ func (wm *WindowManager) drawSpeedometer(gtx layout.Context, completion float32,
 	return layout.Dimensions{Size: image.Point{X: size, Y: size}}
 }
 
-// ==========================================================
-// SHARING VIEW
-// ==========================================================
-
-func (wm *WindowManager) drawSharingView(gtx layout.Context) layout.Dimensions {
-	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			lbl := material.H5(wm.theme, "Partager")
-			lbl.Font.Weight = font.Bold
-			return layout.Inset{Bottom: 8}.Layout(gtx, lbl.Layout)
-		}),
-		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			lbl := material.Label(wm.theme, 14, "Exportez votre bibliotheque et vos fiches dans le dossier de votre choix.")
-			lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 155)
-			return layout.Inset{Bottom: 32}.Layout(gtx, lbl.Layout)
-		}),
-		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			return wm.drawShareCard(gtx, "Bibliotheque complete — Markdown",
-				"Un fichier .md lisible avec tous vos livres et fiches.",
-				&wm.shareLibBtn, func() {
-					if wm.sharingSvc == nil {
-						wm.shareStatusMsg = "Service non disponible."
-						return
-					}
-					dir := service.PickExportDirectory()
-					path, err := wm.sharingSvc.ExportLibrary(context.Background(), service.ShareFormatMarkdown, dir)
-					if err != nil {
-						wm.shareStatusMsg = "Erreur : " + err.Error()
-					} else {
-						wm.shareStatusMsg = "Exporte -> " + path
-					}
-				})
-		}),
-		layout.Rigid(layout.Spacer{Height: 16}.Layout),
-		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			return wm.drawShareCard(gtx, "Bibliotheque complete — JSON",
-				"Format structure ideal pour un backup ou une integration.",
-				&wm.shareExportBtn, func() {
-					if wm.sharingSvc == nil {
-						wm.shareStatusMsg = "Service non disponible."
-						return
-					}
-					dir := service.PickExportDirectory()
-					path, err := wm.sharingSvc.ExportLibrary(context.Background(), service.ShareFormatJSON, dir)
-					if err != nil {
-						wm.shareStatusMsg = "Erreur : " + err.Error()
-					} else {
-						wm.shareStatusMsg = "Exporte -> " + path
-					}
-				})
-		}),
-		layout.Rigid(layout.Spacer{Height: 28}.Layout),
-		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			if wm.shareStatusMsg == "" {
-				return layout.Dimensions{}
-			}
-			lbl := material.Label(wm.theme, 14, wm.shareStatusMsg)
-			lbl.Color = theme.ColorCyberCyan
-			lbl.Font.Weight = font.SemiBold
-			return lbl.Layout(gtx)
-		}),
-	)
-}
-
-func (wm *WindowManager) drawShareCard(gtx layout.Context, title, desc string, btn *widget.Clickable, onPress func()) layout.Dimensions {
-	cl := clip.UniformRRect(image.Rectangle{Max: image.Point{X: gtx.Constraints.Max.X, Y: 110}}, 10).Push(gtx.Ops)
-	paint.Fill(gtx.Ops, theme.WithAlpha(theme.ColorCyberCyan, 7))
-	cl.Pop()
-	return layout.Inset{Top: 20, Left: 24, Right: 24, Bottom: 20}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
-			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						lbl := material.Label(wm.theme, 15, title)
-						lbl.Font.Weight = font.Bold
-						return layout.Inset{Bottom: 5}.Layout(gtx, lbl.Layout)
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						lbl := material.Label(wm.theme, 13, desc)
-						lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
-						return lbl.Layout(gtx)
-					}),
-				)
-			}),
-			layout.Rigid(layout.Spacer{Width: 20}.Layout),
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if btn.Clicked(gtx) {
-					go onPress()
-					wm.shareStatusMsg = "Selection du dossier..."
-				}
-				return wm.drawPillButton(gtx, "Exporter", btn, theme.ColorSandGold)
-			}),
-		)
-	})
-}
-
 // ==========================================================
 // METRICS
 // ==========================================================
@@ -1289,18 +1260,13 @@ func (wm *WindowManager) computeMetrics() (bestDay, bestHour string, sessions []
 			maxHour = h
 		}
 	}
-	frDays := map[time.Weekday]string{
-		time.Sunday: "Dimanche", time.Monday: "Lundi", time.Tuesday: "Mardi",
-		time.Wednesday: "Mercredi", time.Thursday: "Jeudi",
-		time.Friday: "Vendredi", time.Saturday: "Samedi",
-	}
-	bestDay = frDays[maxDay]
+	bestDay = wm.tr.Weekday(maxDay)
 	// Show a clean 2-hour window label
 	endH := maxHour + 2
 	if endH > 23 {
 		endH = 23
 	}
-	bestHour = fmt.Sprintf("%02dh — %02dh", maxHour, endH)
+	bestHour = wm.tr.T("metrics.hour_range", maxHour, endH)
 	sort.Slice(sessions, func(i, j int) bool {
 		return sessions[i].LastReadingTime.After(sessions[j].LastReadingTime)
 	})
@@ -1328,26 +1294,26 @@ func (wm *WindowManager) drawMetrics(gtx layout.Context) layout.Dimensions {
 
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			lbl := material.H5(wm.theme, "Statistiques de lecture")
+			lbl := material.H5(wm.theme, wm.tr.T("metrics.title"))
 			lbl.Font.Weight = font.Bold
 			return layout.Inset{Bottom: 28}.Layout(gtx, lbl.Layout)
 		}),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Sessions totales", fmt.Sprintf("%d", sessionCount), "Toutes les ouvertures de livres")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.sessions"), fmt.Sprintf("%d", sessionCount), wm.tr.T("metrics.sessions_desc"))
 				}),
 				layout.Rigid(layout.Spacer{Width: 20}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Pages lues", fmt.Sprintf("%d", totalPages), "Cumul de toutes les sessions")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.pages"), fmt.Sprintf("%d", totalPages), wm.tr.T("metrics.pages_desc"))
 				}),
 				layout.Rigid(layout.Spacer{Width: 20}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Livres termines", fmt.Sprintf("%d", completedBooks), "Sessions arrivees en fin")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.books_finished"), fmt.Sprintf("%d", completedBooks), wm.tr.T("metrics.books_finished_desc"))
 				}),
 				layout.Rigid(layout.Spacer{Width: 20}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Livres distincts", fmt.Sprintf("%d", len(uniqueBooks)), "")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.distinct_books"), fmt.Sprintf("%d", len(uniqueBooks)), "")
 				}),
 			)
 		}),
@@ -1355,23 +1321,23 @@ func (wm *WindowManager) drawMetrics(gtx layout.Context) layout.Dimensions {
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Jour le plus actif", bestDay, "Vos sessions sont plus longues ce jour-la.")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.best_day"), bestDay, wm.tr.T("metrics.best_day_desc"))
 				}),
 				layout.Rigid(layout.Spacer{Width: 20}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return wm.drawAnalyticCard(gtx, "Heure de predilection", bestHour, "Vous lisez principalement dans cette tranche.")
+					return wm.drawAnalyticCard(gtx, wm.tr.T("metrics.best_hour"), bestHour, wm.tr.T("metrics.best_hour_desc"))
 				}),
 			)
 		}),
 		layout.Rigid(layout.Spacer{Height: 32}.Layout),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			lbl := material.H6(wm.theme, "Historique des sessions")
+			lbl := material.H6(wm.theme, wm.tr.T("metrics.history"))
 			lbl.Font.Weight = font.Bold
 			return layout.Inset{Bottom: 14}.Layout(gtx, lbl.Layout)
 		}),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			if len(history) == 0 {
-				lbl := material.Label(wm.theme, 14, "Aucune session enregistree. Commencez a lire !")
+				lbl := material.Label(wm.theme, 14, wm.tr.T("metrics.empty"))
 				lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 130)
 				return lbl.Layout(gtx)
 			}
@@ -1395,12 +1361,12 @@ func (wm *WindowManager) drawMetrics(gtx layout.Context) layout.Dimensions {
 								return lbl.Layout(gtx)
 							}),
 							layout.Flexed(0.3, func(gtx layout.Context) layout.Dimensions {
-								lbl := material.Label(wm.theme, 13, session.LastReadingTime.Format("02 Jan 15:04"))
+								lbl := material.Label(wm.theme, 13, wm.tr.Date(session.LastReadingTime, i18n.DateDayMonth))
 								lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 140)
 								return lbl.Layout(gtx)
 							}),
 							layout.Flexed(0.15, func(gtx layout.Context) layout.Dimensions {
-								lbl := material.Label(wm.theme, 14, fmt.Sprintf("p.%d", session.CurrentPage))
+								lbl := material.Label(wm.theme, 14, wm.tr.T("metrics.page_short", session.CurrentPage))
 								lbl.Color = theme.ColorCyberCyan
 								lbl.Font.Weight = font.Bold
 								return lbl.Layout(gtx)
@@ -1499,35 +1465,16 @@ func (wm *WindowManager) matchesSearch(b *domain.Book) bool {
 }
 
 // saveReaderProgress persists the current page via TrackerService in a goroutine.
-// UI state mutations are dispatched through uiChan to the main thread — no data races.
+// The dashboard refresh and the achievement modal follow from the PageTurned and
+// BookFinished events, see HandleEvent.
 func (wm *WindowManager) saveReaderProgress() {
 	if wm.trackSvc == nil || wm.readerSession == nil {
 		return
 	}
 	ses := wm.readerSession
-	book := wm.readerBook
 	page := wm.readerPage + 1
-	openedAt := wm.readerOpenedAt // capture before goroutine
 	go func() {
 		_ = wm.trackSvc.UpdateProgress(context.Background(), page, ses)
-		// All UI mutations sent to main thread via buffered channel
-		wm.uiChan <- func() {
-			wm.dashboardLoaded = false
-			wm.metricsLoaded = false
-			wm.bookStatusLoaded = false
-			if ses.IsBookComplete() && book != nil && wm.achievementBook == nil {
-				if wm.dismissedAchievements == nil {
-					wm.dismissedAchievements = make(map[string]bool)
-				}
-				if !wm.dismissedAchievements[book.ID] {
-					wm.achievementBook = book
-					wm.achievementReadMin = int(time.Since(openedAt).Minutes())
-					wm.confettiStart = time.Now()
-					wm.confettiActive = true
-				}
-			}
-		}
-		wm.window.Invalidate()
 	}()
 }
 
@@ -1573,12 +1520,12 @@ func (wm *WindowManager) filterBooksByTab(books []*domain.Book) []*domain.Book {
 // importBooksFromPicker opens a native file dialog for multi-file import.
 func (wm *WindowManager) importBooksFromPicker() {
 	if wm.libSvc == nil {
-		wm.importStatusMsg = "LibraryService non disponible."
+		wm.importStatusMsg = wm.tr.T("library.unavailable")
 		return
 	}
-	paths := pickMultipleFiles()
+	paths := pickMultipleFiles(wm.tr.T("dialog.import_books"), wm.tr.T("dialog.books_filter"))
 	if len(paths) == 0 {
-		wm.importStatusMsg = "Aucun fichier selectionne."
+		wm.importStatusMsg = wm.tr.T("library.no_file")
 		wm.window.Invalidate()
 		return
 	}
@@ -1587,10 +1534,10 @@ func (wm *WindowManager) importBooksFromPicker() {
 		wm.booksLoaded = false
 		wm.dashboardLoaded = false
 		wm.bookStatusLoaded = false
-		wm.importStatusMsg = fmt.Sprintf("%d livre(s) importe(s).", len(books))
+		wm.importStatusMsg = wm.tr.N("library.imported", len(books))
 	}
 	if len(errs) > 0 {
-		wm.importStatusMsg += fmt.Sprintf(" %d erreur(s).", len(errs))
+		wm.importStatusMsg += " " + wm.tr.N("common.errors", len(errs))
 	}
 	wm.window.Invalidate()
 }
@@ -1731,7 +1678,7 @@ func (wm *WindowManager) drawAchievementModal(gtx layout.Context) {
 						paint.Fill(gtx.Ops, theme.WithAlpha(bookCol, 50))
 						pill.Pop()
 						layout.Center.Layout(gtxB, func(gtx layout.Context) layout.Dimensions {
-							lbl := material.Label(wm.theme, 12, "LIVRE TERMINÉ  ✓")
+							lbl := material.Label(wm.theme, 12, wm.tr.T("achievement.badge"))
 							lbl.Font.Weight = font.Bold
 							lbl.Color = bookCol
 							return lbl.Layout(gtx)
@@ -1760,11 +1707,11 @@ func (wm *WindowManager) drawAchievementModal(gtx layout.Context) {
 					var timeStr string
 					switch {
 					case m < 1:
-						timeStr = "Session de lecture terminée"
+						timeStr = wm.tr.T("achievement.session_done")
 					case m < 60:
-						timeStr = fmt.Sprintf("%d minutes de lecture cette session", m)
+						timeStr = wm.tr.N("achievement.session_minutes", m)
 					default:
-						timeStr = fmt.Sprintf("%dh%02d de lecture cette session", m/60, m%60)
+						timeStr = wm.tr.T("achievement.session_hours", m/60, m%60)
 					}
 					return layout.Inset{Bottom: unit.Dp(32)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 						return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
@@ -1777,7 +1724,7 @@ func (wm *WindowManager) drawAchievementModal(gtx layout.Context) {
 				// Share label
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return layout.Inset{Bottom: unit.Dp(16)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						lbl := material.Label(wm.theme, 13, "Partager cet exploit :")
+						lbl := material.Label(wm.theme, 13, wm.tr.T("achievement.share"))
 						lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
 						lbl.Font.Weight = font.SemiBold
 						return lbl.Layout(gtx)
@@ -1816,18 +1763,44 @@ func (wm *WindowManager) drawAchievementModal(gtx layout.Context) {
 									t := wm.buildAchievementShareText(book)
 									_ = copyToClipboard(t)
 								}
-								return wm.achievementShare.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-									return drawShareBadge(gtx, "Copier le texte", color.NRGBA{R: 68, G: 68, B: 80, A: 255}, wm.theme,
-										wm.achievementShare.Hovered(), wm.achievementShare.Pressed())
+								return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return wm.achievementShare.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+										return drawShareBadge(gtx, wm.tr.T("share.copy_text"), color.NRGBA{R: 68, G: 68, B: 80, A: 255}, wm.theme,
+											wm.achievementShare.Hovered(), wm.achievementShare.Pressed())
+									})
+								})
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if wm.achievementCard.Clicked(gtx) {
+									wm.achievementStatus = wm.tr.T("share.card_rendering")
+									wm.shareCard(func(svc *service.SharingService) ([]byte, error) {
+										return svc.FinishedCard(context.Background(), book.ID)
+									}, wm.tr.T("achievement.card_name", book.Title), false, func(msg string) { wm.achievementStatus = msg })
+								}
+								return wm.achievementCard.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return drawShareBadge(gtx, wm.tr.T("share.png_image"), theme.ColorSandGold, wm.theme,
+										wm.achievementCard.Hovered(), wm.achievementCard.Pressed())
 								})
 							}),
 						)
 					})
 				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					if wm.achievementStatus == "" {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Bottom: unit.Dp(16)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							lbl := material.Label(wm.theme, 12, wm.achievementStatus)
+							lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 150)
+							return lbl.Layout(gtx)
+						})
+					})
+				}),
 				// Dismiss hint — prominent
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						lbl := material.Label(wm.theme, 12, "Cliquer en dehors  ·  Échap pour fermer")
+						lbl := material.Label(wm.theme, 12, wm.tr.T("achievement.dismiss"))
 						lbl.Color = theme.WithAlpha(theme.ColorPureBlack, 80)
 						return lbl.Layout(gtx)
 					})
@@ -1861,13 +1834,13 @@ func (wm *WindowManager) buildAchievementShareText(book *domain.Book) string {
 	case m < 1:
 		timePhrase = ""
 	case m < 30:
-		timePhrase = fmt.Sprintf("en %d minutes chrono", m)
+		timePhrase = wm.tr.N("achievement.time.quick", m)
 	case m < 60:
-		timePhrase = fmt.Sprintf("en %d minutes de lecture pure", m)
+		timePhrase = wm.tr.N("achievement.time.minutes", m)
 	case m == 60:
-		timePhrase = "en exactement 1 heure"
+		timePhrase = wm.tr.T("achievement.time.hour")
 	default:
-		timePhrase = fmt.Sprintf("en %dh%02d", m/60, m%60)
+		timePhrase = wm.tr.T("achievement.time.hours", m/60, m%60)
 	}
 
 	// Pick angle based on title hash — deterministic but varied
@@ -1876,56 +1849,20 @@ func (wm *WindowManager) buildAchievementShareText(book *domain.Book) string {
 		angle += int(c)
 	}
 	angle = angle % 5
+	post := fmt.Sprintf("achievement.post.%d.", angle)
 
 	var sb strings.Builder
-	switch angle {
-	case 0:
-		sb.WriteString(fmt.Sprintf("Vient de tomber le rideau sur \"%s\".", title))
-		if timePhrase != "" {
-			sb.WriteString(fmt.Sprintf(" %s.", capitalise(timePhrase)))
-		}
-		sb.WriteString("\n\nCe livre m'a forcé à remettre en question des choses que je croyais acquises.")
-		sb.WriteString("\n\nVous l'avez lu ? Dites-moi ce que vous en avez pensé. 👇")
-	case 1:
-		sb.WriteString(fmt.Sprintf("Terminé : \"%s\"", title))
-		if timePhrase != "" {
-			sb.WriteString(fmt.Sprintf(" (%s)", timePhrase))
-		}
-		sb.WriteString(".\n\nIl y a des livres qu'on ferme et qu'on oublie.")
-		sb.WriteString("\nIl y en a d'autres qui continuent de tourner dans la tête longtemps après.")
-		sb.WriteString("\n\nCelui-ci fait partie de la deuxième catégorie.")
-		sb.WriteString("\n\nQuel livre vous a le plus marqué ces 6 derniers mois ?")
-	case 2:
-		sb.WriteString(fmt.Sprintf("Pages finales de \"%s\" — done.", title))
-		if timePhrase != "" {
-			sb.WriteString(fmt.Sprintf("\n%s.", capitalise(timePhrase)))
-		}
-		sb.WriteString("\n\nOn sous-estime à quel point lire régulièrement transforme la façon dont on pense,")
-		sb.WriteString("\ndont on parle, dont on prend des décisions.")
-		sb.WriteString("\n\nC'est le seul entraînement mental qui ne se voit pas mais qui change tout.")
-		sb.WriteString("\n\nVous lisez quoi en ce moment ?")
-	case 3:
-		if timePhrase != "" {
-			sb.WriteString(fmt.Sprintf("%s pour finir \"%s\".", capitalise(timePhrase), title))
-		} else {
-			sb.WriteString(fmt.Sprintf("Fin de \"%s\".", title))
-		}
-		sb.WriteString("\n\nChaque livre terminé est une version de vous-même en plus.")
-		sb.WriteString("\nNon pas parce que vous avez \"lu\", mais parce que vous avez réfléchi.")
-		sb.WriteString("\n\nMerci à ceux qui m'ont recommandé ce titre. Vous savez qui vous êtes. 🙏")
-		sb.WriteString("\n\nProchaine lecture ? Je suis preneur de suggestions sérieuses.")
-	default:
-		sb.WriteString(fmt.Sprintf("Un livre de plus dans la colonne des terminés : \"%s\".", title))
-		if timePhrase != "" {
-			sb.WriteString(fmt.Sprintf(" %s.", capitalise(timePhrase)))
+	if timePhrase == "" {
+		sb.WriteString(wm.tr.T(post+"title", title))
+	} else {
+		if angle != 1 { // l'angle 1 glisse la durée entre parenthèses
+			timePhrase = capitalise(timePhrase)
 		}
-		sb.WriteString("\n\nOn parle beaucoup de productivité, de croissance, d'optimisation.")
-		sb.WriteString("\nMais rien ne compresse autant d'expérience en aussi peu de temps qu'un bon livre.")
-		sb.WriteString("\n\nC'est le meilleur ROI qui existe. Point.")
-		sb.WriteString("\n\nQu'est-ce que vous lisez pour progresser en ce moment ?")
+		sb.WriteString(wm.tr.T(post+"title_time", title, timePhrase))
 	}
+	sb.WriteString(wm.tr.T(post + "body"))
 
-	sb.WriteString("\n\n#lecture #livres #Orus")
+	sb.WriteString("\n\n" + wm.tr.T("achievement.hashtags"))
 	return sb.String()
 }
 
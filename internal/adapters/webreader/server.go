@@ -0,0 +1,341 @@
+// Package webreader serves a browser reader for the library, so that books
+// can be read on a tablet or another computer of the house. Pages are the
+// ContentReader chunks of the desktop reader and progress goes through
+// TrackerService, so both readers resume at the same place. Every request
+// needs HTTP basic authentication.
+package webreader
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/httpserve"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// DefaultAddr is where `orus web` listens without an explicit address: every
+// interface, so that the tablets of the network can reach it.
+const DefaultAddr = ":7880"
+
+// Username is the basic auth user of the reader.
+const Username = "orus"
+
+const (
+	maxBodySize  = 64 << 10 // la plus grosse requête est un surlignage
+	cachedBooks  = 4        // livres découpés gardés en mémoire
+	maxNoteRunes = 2000
+)
+
+//go:embed static
+var static embed.FS
+
+// Server answers the web reader requests from the services.
+type Server struct {
+	library *service.LibraryService
+	tracker *service.TrackerService
+	annots  *service.AnnotationService
+	content port.ContentReader
+	handler http.Handler // mux derrière l'authentification
+	logger  *slog.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*domain.ReadingSession // dernière session ouverte par les navigateurs, par livre
+	pages    []bookPages                       // découpages récents, le plus récent en dernier
+}
+
+type bookPages struct {
+	key   string // ID et date de mise à jour du livre
+	pages []string
+}
+
+// NewServer creates a web reader over the services, protected by the given
+// basic auth credentials. A nil logger uses slog.Default().
+func NewServer(library *service.LibraryService, tracker *service.TrackerService, annots *service.AnnotationService,
+	content port.ContentReader, username, password string, logger *slog.Logger) (*Server, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("web reader credentials are required")
+	}
+	s := &Server{library: library, tracker: tracker, annots: annots, content: content,
+		logger: httpserve.ComponentLogger(logger, "web"), sessions: map[string]*domain.ReadingSession{}}
+	assets, _ := fs.Sub(static, "static")
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(assets))
+	mux.HandleFunc("GET /api/books", s.listBooks)
+	mux.HandleFunc("GET /api/books/{id}/cover", s.cover)
+	mux.HandleFunc("POST /api/books/{id}/open", s.openBook)
+	mux.HandleFunc("GET /api/books/{id}/pages/{page}", s.page)
+	mux.HandleFunc("PUT /api/books/{id}/progress", s.progress)
+	mux.HandleFunc("GET /api/books/{id}/highlights", s.highlights)
+	mux.HandleFunc("POST /api/books/{id}/highlights", s.addHighlight)
+	mux.HandleFunc("DELETE /api/highlights/{id}", s.deleteHighlight)
+	s.handler = httpserve.BasicAuth(username, password, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		mux.ServeHTTP(w, r)
+	}))
+	return s, nil
+}
+
+// ServeHTTP checks the credentials, then dispatches the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the reader on addr until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	return httpserve.ListenAndServe(ctx, addr, s)
+}
+
+// bookPages returns the pages of a book as the desktop reader cuts them.
+// The last books read stay in memory: cutting a book means parsing it.
+func (s *Server) bookPages(ctx context.Context, b *domain.Book) ([]string, error) {
+	key := b.ID + "@" + b.UpdatedAt.String()
+	s.mu.Lock()
+	for i, c := range s.pages {
+		if c.key == key {
+			s.pages = append(append(s.pages[:i:i], s.pages[i+1:]...), c)
+			s.mu.Unlock()
+			return c.pages, nil
+		}
+	}
+	s.mu.Unlock()
+
+	pages, err := s.content.ReadBookText(ctx, b.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.pages = append(s.pages, bookPages{key: key, pages: pages})
+	if len(s.pages) > cachedBooks {
+		s.pages = s.pages[1:]
+	}
+	s.mu.Unlock()
+	return pages, nil
+}
+
+// --- Library ---
+
+type bookJSON struct {
+	ID         string    `json:"id"`
+	Title      string    `json:"title"`
+	Author     string    `json:"author"`
+	Format     string    `json:"format"`
+	Cover      bool      `json:"cover"`
+	Status     string    `json:"status"`      // unread, reading, done
+	Page       int       `json:"page"`        // dernière page lue, 0 sans session
+	TotalPages int       `json:"total_pages"` // pages du livre selon ses métadonnées
+	LastRead   time.Time `json:"last_read"`   // zéro sans session
+	Completion float64   `json:"completion"`  // pourcentage
+	AddedAt    time.Time `json:"added_at"`
+}
+
+// listBooks returns the library, the books being read first.
+func (s *Server) listBooks(w http.ResponseWriter, r *http.Request) {
+	books, err := s.library.GetLibrary(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	status, err := s.tracker.BookCompletionStatus(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	sessions, err := s.tracker.GetRecentSessions(r.Context())
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	last := map[string]*domain.ReadingSession{}
+	for _, ses := range sessions {
+		last[ses.BookID] = ses
+	}
+	out := make([]bookJSON, 0, len(books))
+	for _, b := range books {
+		j := bookJSON{ID: b.ID, Title: b.Title, Author: b.Author, Format: string(b.Format), Cover: len(b.CoverImage) > 0,
+			Status: status[b.ID], TotalPages: b.TotalPages, AddedAt: b.AddedAt}
+		if ses := last[b.ID]; ses != nil {
+			j.Page, j.LastRead, j.Completion = ses.CurrentPage, ses.LastReadingTime, ses.CalculateCompletion()
+		}
+		out = append(out, j)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].LastRead.Equal(out[j].LastRead) {
+			return out[i].LastRead.After(out[j].LastRead)
+		}
+		return strings.ToLower(out[i].Title) < strings.ToLower(out[j].Title)
+	})
+	httpserve.WriteJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) cover(w http.ResponseWriter, r *http.Request) {
+	b, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	if len(b.CoverImage) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(b.CoverImage))
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(b.CoverImage)
+}
+
+// --- Reading ---
+
+type openJSON struct {
+	Session string `json:"session"`
+	Page    int    `json:"page"`  // page où reprendre, à partir de 1
+	Pages   int    `json:"pages"` // nombre de pages du lecteur
+}
+
+// openBook starts a reading session, like opening the book on the desktop,
+// and says where to resume.
+func (s *Server) openBook(w http.ResponseWriter, r *http.Request) {
+	b, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	pages, err := s.bookPages(r.Context(), b)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	ses, err := s.tracker.OpenBook(r.Context(), b.ID)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	s.mu.Lock()
+	s.sessions[b.ID] = ses
+	s.mu.Unlock()
+	httpserve.WriteJSON(w, http.StatusOK, openJSON{Session: ses.SessionID, Page: min(max(ses.CurrentPage, 1), len(pages)), Pages: len(pages)})
+}
+
+// page returns the text of one page, from 1.
+func (s *Server) page(w http.ResponseWriter, r *http.Request) {
+	b, err := httpserve.FindBook(r.Context(), s.library, r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	pages, err := s.bookPages(r.Context(), b)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	n, err := strconv.Atoi(r.PathValue("page"))
+	if err != nil || n < 1 || n > len(pages) {
+		httpserve.WriteError(w, http.StatusNotFound, fmt.Errorf("page %s out of 1..%d", r.PathValue("page"), len(pages)))
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, map[string]any{"page": n, "pages": len(pages), "text": pages[n-1]})
+}
+
+// progress saves the page reached in the latest session openBook opened for
+// the book; a browser still holding an older session moves on to it. A book
+// the server has no session for, after a restart, is opened again.
+func (s *Server) progress(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Session string `json:"session"`
+		Page    int    `json:"page"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if body.Page < 1 {
+		httpserve.Fail(w, s.logger, domain.ErrInvalidSessionPage)
+		return
+	}
+	bookID := r.PathValue("id")
+	s.mu.Lock()
+	ses := s.sessions[bookID]
+	s.mu.Unlock()
+	if ses == nil {
+		var err error
+		if ses, err = s.tracker.OpenBook(r.Context(), bookID); err != nil {
+			httpserve.Fail(w, s.logger, err)
+			return
+		}
+		s.mu.Lock()
+		s.sessions[bookID] = ses
+		s.mu.Unlock()
+	}
+	s.mu.Lock()
+	err := s.tracker.UpdateProgress(r.Context(), body.Page, ses)
+	page, done := ses.CurrentPage, ses.IsBookComplete()
+	s.mu.Unlock()
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusOK, map[string]any{"session": ses.SessionID, "page": page, "done": done})
+}
+
+// --- Highlights ---
+
+type highlightJSON struct {
+	ID        string    `json:"id"`
+	Page      int       `json:"page"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *Server) highlights(w http.ResponseWriter, r *http.Request) {
+	annots, err := s.annots.ListAnnotationsForBook(r.Context(), r.PathValue("id"))
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	out := []highlightJSON{}
+	for _, a := range annots {
+		if a.AnnotationType == domain.AnnotationHighlight {
+			out = append(out, highlightJSON{ID: a.ID, Page: a.PageNo, Note: a.Note, CreatedAt: a.CreatedAt})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Page < out[j].Page })
+	httpserve.WriteJSON(w, http.StatusOK, out)
+}
+
+// addHighlight saves a highlight of a page; the note holds the selected
+// passage, followed by the reader's comment if any.
+func (s *Server) addHighlight(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Page int    `json:"page"`
+		Note string `json:"note"`
+	}
+	if !httpserve.Decode(w, r, &body) {
+		return
+	}
+	if n := []rune(body.Note); len(n) > maxNoteRunes {
+		body.Note = string(n[:maxNoteRunes])
+	}
+	a, err := s.annots.AddAnnotationWithNote(r.Context(), r.PathValue("id"), domain.AnnotationHighlight, body.Page, body.Note)
+	if err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	httpserve.WriteJSON(w, http.StatusCreated, highlightJSON{ID: a.ID, Page: a.PageNo, Note: a.Note, CreatedAt: a.CreatedAt})
+}
+
+func (s *Server) deleteHighlight(w http.ResponseWriter, r *http.Request) {
+	if err := s.annots.DeleteAnnotation(r.Context(), r.PathValue("id")); err != nil {
+		httpserve.Fail(w, s.logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
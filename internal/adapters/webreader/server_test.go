@@ -0,0 +1,275 @@
+package webreader_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/adapters/webreader"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+var fakeNow = time.Date(2026, 3, 10, 21, 0, 0, 0, time.UTC)
+
+// fakeContent cuts every book into the same pages and counts the cuts.
+type fakeContent struct {
+	pages []string
+	reads int
+}
+
+func (f *fakeContent) ReadBookText(ctx context.Context, filePath string) ([]string, error) {
+	f.reads++
+	return f.pages, nil
+}
+
+type reader struct {
+	t       *testing.T
+	store   *sqlite.Storage
+	tracker *service.TrackerService
+	clock   *clock.FakeClock
+	content *fakeContent
+	srv     *httptest.Server
+}
+
+func newReader(t *testing.T) *reader {
+	t.Helper()
+	store, err := sqlite.NewStorage(filepath.Join(t.TempDir(), "orus.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	clk := clock.NewFakeClock(fakeNow)
+	tracker := service.NewTrackerService(store, store, clk)
+	content := &fakeContent{pages: []string{"Au commencement.", "Le desert d'Arrakis.", "L'epice doit couler.", "Le ver geant.", "Fin."}}
+	server, err := webreader.NewServer(
+		service.NewLibraryService(store, store, store, nil, nil, clk),
+		tracker,
+		service.NewAnnotationService(store, store, nil, clk),
+		content,
+		webreader.Username, "tablette", nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	return &reader{t: t, store: store, tracker: tracker, clock: clk, content: content, srv: srv}
+}
+
+func (r *reader) addBook(title string, pages int) *domain.Book {
+	r.t.Helper()
+	b, err := domain.NewBook(title, "Frank Herbert", "/livres/"+title+".epub", domain.FormatEPUB, pages, fakeNow)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	if err := r.store.Save(context.Background(), b); err != nil {
+		r.t.Fatal(err)
+	}
+	return b
+}
+
+// do sends an authenticated request and decodes the JSON answer into out.
+func (r *reader) do(method, path string, body any, out any) int {
+	r.t.Helper()
+	var payload io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		payload = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(method, r.srv.URL+path, payload)
+	req.SetBasicAuth(webreader.Username, "tablette")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			r.t.Fatalf("%s %s: invalid JSON: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+type opened struct {
+	Session string `json:"session"`
+	Page    int    `json:"page"`
+	Pages   int    `json:"pages"`
+}
+
+func TestWebReader_RequiresCredentials(t *testing.T) {
+	r := newReader(t)
+	for _, pass := range []string{"", "faux"} {
+		req, _ := http.NewRequest(http.MethodGet, r.srv.URL+"/api/books", nil)
+		if pass != "" {
+			req.SetBasicAuth(webreader.Username, pass)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(resp.Header.Get("WWW-Authenticate"), "Basic") {
+			t.Errorf("password %q: expected a basic auth challenge, got %d", pass, resp.StatusCode)
+		}
+	}
+	if _, err := webreader.NewServer(nil, nil, nil, nil, webreader.Username, "", nil); err == nil {
+		t.Error("expected an empty password to be refused")
+	}
+}
+
+func TestWebReader_ServesFrontend(t *testing.T) {
+	r := newReader(t)
+	req, _ := http.NewRequest(http.MethodGet, r.srv.URL+"/", nil)
+	req.SetBasicAuth(webreader.Username, "tablette")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	page, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(page), `<script src="app.js">`) {
+		t.Fatalf("expected the embedded index.html, got %d %q", resp.StatusCode, page)
+	}
+}
+
+func TestWebReader_ProgressIsSharedWithDesktop(t *testing.T) {
+	ctx := context.Background()
+	r := newReader(t)
+	dune := r.addBook("Dune", 5)
+	fondation := r.addBook("Fondation", 5)
+
+	var first opened
+	if code := r.do(http.MethodPost, "/api/books/"+dune.ID+"/open", nil, &first); code != http.StatusOK {
+		t.Fatalf("open: %d", code)
+	}
+	if first.Page != 1 || first.Pages != 5 || first.Session == "" {
+		t.Fatalf("a new book should open at page 1 of 5, got %+v", first)
+	}
+
+	var page struct {
+		Page  int    `json:"page"`
+		Pages int    `json:"pages"`
+		Text  string `json:"text"`
+	}
+	if code := r.do(http.MethodGet, "/api/books/"+dune.ID+"/pages/3", nil, &page); code != http.StatusOK || page.Text != "L'epice doit couler." {
+		t.Fatalf("page 3: %d %+v", code, page)
+	}
+	if code := r.do(http.MethodGet, "/api/books/"+dune.ID+"/pages/6", nil, nil); code != http.StatusNotFound {
+		t.Errorf("page past the end: expected 404, got %d", code)
+	}
+	if r.content.reads != 1 {
+		t.Errorf("the pages should be cut once and cached, got %d cuts", r.content.reads)
+	}
+
+	r.clock.Advance(time.Minute)
+	if code := r.do(http.MethodPut, "/api/books/"+dune.ID+"/progress", map[string]any{"session": first.Session, "page": 3}, nil); code != http.StatusOK {
+		t.Fatalf("progress: %d", code)
+	}
+
+	// Le lecteur de bureau reprend où le navigateur s'est arrêté...
+	r.clock.Advance(time.Minute)
+	desktop, err := r.tracker.OpenBook(ctx, dune.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desktop.CurrentPage != 3 {
+		t.Fatalf("desktop should resume at page 3, got %d", desktop.CurrentPage)
+	}
+	// ...et inversement.
+	r.clock.Advance(time.Minute)
+	if err := r.tracker.UpdateProgress(ctx, 4, desktop); err != nil {
+		t.Fatal(err)
+	}
+	r.clock.Advance(time.Minute)
+	var again opened
+	r.do(http.MethodPost, "/api/books/"+dune.ID+"/open", nil, &again)
+	if again.Page != 4 {
+		t.Fatalf("web reader should resume at page 4, got %d", again.Page)
+	}
+
+	var books []struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		Page   int    `json:"page"`
+	}
+	r.do(http.MethodGet, "/api/books", nil, &books)
+	if len(books) != 2 || books[0].ID != dune.ID || books[0].Status != "reading" || books[0].Page != 4 || books[1].Status != "unread" {
+		t.Fatalf("expected Dune first, being read at page 4, got %+v", books)
+	}
+
+	// Une ancienne session passe à la dernière ouverte pour le livre.
+	var res struct {
+		Session string `json:"session"`
+		Page    int    `json:"page"`
+		Done    bool   `json:"done"`
+	}
+	if code := r.do(http.MethodPut, "/api/books/"+dune.ID+"/progress", map[string]any{"session": first.Session, "page": 5}, &res); code != http.StatusOK {
+		t.Fatalf("progress with an older session: %d", code)
+	}
+	if res.Session != again.Session || res.Page != 5 || !res.Done {
+		t.Fatalf("expected the latest session %s, finished at page 5, got %+v", again.Session, res)
+	}
+	// Un livre sans session ici, après un redémarrage du serveur, est rouvert.
+	if code := r.do(http.MethodPut, "/api/books/"+fondation.ID+"/progress", map[string]any{"session": "perdue", "page": 2}, &res); code != http.StatusOK {
+		t.Fatalf("progress with an unknown session: %d", code)
+	}
+	if res.Session == "perdue" || res.Session == "" || res.Page != 2 {
+		t.Fatalf("expected a new session at page 2, got %+v", res)
+	}
+	if code := r.do(http.MethodPut, "/api/books/"+dune.ID+"/progress", map[string]any{"session": res.Session, "page": 0}, nil); code != http.StatusBadRequest {
+		t.Errorf("page 0: expected 400, got %d", code)
+	}
+	if code := r.do(http.MethodPost, "/api/books/inconnu/open", nil, nil); code != http.StatusNotFound {
+		t.Errorf("unknown book: expected 404, got %d", code)
+	}
+}
+
+func TestWebReader_Highlights(t *testing.T) {
+	r := newReader(t)
+	dune := r.addBook("Dune", 5)
+
+	var created struct {
+		ID   string `json:"id"`
+		Page int    `json:"page"`
+		Note string `json:"note"`
+	}
+	body := map[string]any{"page": 3, "note": "L'epice doit couler.\n— leitmotiv"}
+	if code := r.do(http.MethodPost, "/api/books/"+dune.ID+"/highlights", body, &created); code != http.StatusCreated {
+		t.Fatalf("add highlight: %d", code)
+	}
+	if created.ID == "" || created.Page != 3 {
+		t.Fatalf("unexpected highlight %+v", created)
+	}
+	if code := r.do(http.MethodPost, "/api/books/"+dune.ID+"/highlights", map[string]any{"page": 9, "note": "x"}, nil); code != http.StatusBadRequest {
+		t.Errorf("page past the end: expected 400, got %d", code)
+	}
+
+	var list []struct {
+		ID   string `json:"id"`
+		Note string `json:"note"`
+	}
+	r.do(http.MethodGet, "/api/books/"+dune.ID+"/highlights", nil, &list)
+	if len(list) != 1 || list[0].Note != body["note"] {
+		t.Fatalf("unexpected highlights %+v", list)
+	}
+
+	if code := r.do(http.MethodDelete, "/api/highlights/"+created.ID, nil, nil); code != http.StatusNoContent {
+		t.Fatalf("delete highlight: %d", code)
+	}
+	list = nil
+	r.do(http.MethodGet, "/api/books/"+dune.ID+"/highlights", nil, &list)
+	if len(list) != 0 {
+		t.Fatalf("expected no highlight left, got %+v", list)
+	}
+}
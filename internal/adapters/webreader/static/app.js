@@ -0,0 +1,293 @@
+// Lecteur web d'Orus : bibliotheque, pages du lecteur de bureau, progression
+// et surlignages par l'API du serveur. Pas de dependance ni de compilation.
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+const COMMENT_SEP = "\n— ";
+
+const state = {
+  books: [],
+  book: null,      // livre ouvert
+  session: "",     // session de lecture ouverte par le serveur
+  page: 1,
+  pages: 1,
+  text: "",
+  highlights: [],
+};
+
+async function api(method, path, body) {
+  const opts = { method, headers: {} };
+  if (body !== undefined) {
+    opts.headers["Content-Type"] = "application/json";
+    opts.body = JSON.stringify(body);
+  }
+  const resp = await fetch(path, opts);
+  if (resp.status === 204) return null;
+  const data = await resp.json().catch(() => ({}));
+  if (!resp.ok) {
+    const err = new Error(data.error || resp.statusText);
+    err.status = resp.status;
+    throw err;
+  }
+  return data;
+}
+
+function show(view) {
+  $("library").hidden = view !== "library";
+  $("reader").hidden = view !== "reader";
+  $("filter").hidden = view !== "library";
+}
+
+// --- Bibliotheque ---
+
+async function loadLibrary() {
+  show("library");
+  $("library-status").textContent = "Chargement...";
+  try {
+    state.books = await api("GET", "/api/books");
+    renderLibrary();
+  } catch (e) {
+    $("library-status").textContent = "Bibliotheque indisponible : " + e.message;
+  }
+}
+
+function renderLibrary() {
+  const q = $("filter").value.trim().toLowerCase();
+  const list = $("books");
+  list.replaceChildren();
+  const books = state.books.filter((b) => !q || (b.title + " " + b.author).toLowerCase().includes(q));
+  $("library-status").textContent = books.length ? "" : "Aucun livre.";
+  for (const b of books) {
+    const li = document.createElement("li");
+    const cover = document.createElement("div");
+    cover.className = "cover";
+    cover.textContent = b.title;
+    if (b.cover) {
+      cover.classList.add("has-image");
+      cover.style.backgroundImage = `url("/api/books/${encodeURIComponent(b.id)}/cover")`;
+    }
+    const title = document.createElement("div");
+    title.className = "title";
+    title.textContent = b.title;
+    const author = document.createElement("div");
+    author.className = "author";
+    author.textContent = b.author;
+    li.append(cover, title, author);
+    if (b.status === "reading") {
+      const bar = document.createElement("progress");
+      bar.max = 100;
+      bar.value = b.completion;
+      li.append(bar);
+    }
+    li.addEventListener("click", () => { location.hash = "#book/" + b.id; });
+    list.append(li);
+  }
+}
+
+// --- Lecture ---
+
+async function openBook(id) {
+  show("reader");
+  if (!state.books.length) {
+    state.books = await api("GET", "/api/books").catch(() => []);
+  }
+  state.book = state.books.find((b) => b.id === id) || { id, title: "" };
+  $("book-title").textContent = state.book.title;
+  $("page").textContent = "Chargement...";
+  status("");
+  try {
+    const opened = await api("POST", `/api/books/${encodeURIComponent(id)}/open`);
+    state.session = opened.session;
+    state.pages = opened.pages;
+    await loadHighlights();
+    await goTo(opened.page, false);
+  } catch (e) {
+    $("page").textContent = "";
+    status("Livre illisible : " + e.message);
+  }
+}
+
+async function goTo(page, save = true) {
+  page = Math.min(Math.max(page, 1), state.pages);
+  const data = await api("GET", `/api/books/${encodeURIComponent(state.book.id)}/pages/${page}`);
+  state.page = data.page;
+  state.pages = data.pages;
+  state.text = data.text;
+  renderPage();
+  window.scrollTo(0, 0);
+  if (save) saveProgress();
+}
+
+function renderPage() {
+  const article = $("page");
+  article.replaceChildren();
+  // Les passages surlignes de la page, du plus long au plus court.
+  const passages = state.highlights
+    .filter((h) => h.page === state.page)
+    .map((h) => h.note.split(COMMENT_SEP)[0].trim())
+    .filter((p) => p && state.text.includes(p))
+    .sort((a, b) => b.length - a.length);
+  let rest = state.text;
+  while (rest) {
+    let at = -1, found = "";
+    for (const p of passages) {
+      const i = rest.indexOf(p);
+      if (i >= 0 && (at < 0 || i < at)) { at = i; found = p; }
+    }
+    if (at < 0) { article.append(rest); break; }
+    article.append(rest.slice(0, at));
+    const mark = document.createElement("mark");
+    mark.textContent = found;
+    article.append(mark);
+    rest = rest.slice(at + found.length);
+  }
+  $("position").textContent = `${state.page} / ${state.pages}`;
+  $("prev").disabled = state.page <= 1;
+  $("next").disabled = state.page >= state.pages;
+}
+
+async function saveProgress() {
+  try {
+    const res = await api("PUT", `/api/books/${encodeURIComponent(state.book.id)}/progress`,
+      { session: state.session, page: state.page });
+    state.session = res.session;
+    if (res.done) status("Livre termine. Bravo !");
+  } catch (e) {
+    status("Progression non enregistree : " + e.message);
+  }
+}
+
+// resync reprend la page enregistree ailleurs (application de bureau, autre
+// navigateur) quand l'onglet redevient visible.
+async function resync() {
+  if (!state.book || $("reader").hidden) return;
+  const books = await api("GET", "/api/books").catch(() => null);
+  const fresh = books && books.find((b) => b.id === state.book.id);
+  if (!fresh || fresh.page === state.page) return;
+  state.books = books;
+  await openBook(state.book.id);
+}
+
+function status(msg) {
+  $("reader-status").textContent = msg;
+}
+
+// --- Surlignages ---
+
+async function loadHighlights() {
+  try {
+    state.highlights = await api("GET", `/api/books/${encodeURIComponent(state.book.id)}/highlights`);
+  } catch (e) {
+    state.highlights = [];
+    if (e.status === 423) status("Base chiffree : surlignages indisponibles.");
+  }
+  renderHighlights();
+}
+
+function renderHighlights() {
+  const list = $("highlight-list");
+  list.replaceChildren();
+  for (const h of state.highlights) {
+    const li = document.createElement("li");
+    const text = document.createElement("span");
+    text.className = "text";
+    text.textContent = h.note || "(page entiere)";
+    text.addEventListener("click", () => goTo(h.page));
+    const page = document.createElement("span");
+    page.className = "page";
+    page.textContent = "p. " + h.page;
+    const del = document.createElement("button");
+    del.textContent = "✕";
+    del.title = "Supprimer";
+    del.addEventListener("click", async () => {
+      await api("DELETE", `/api/highlights/${encodeURIComponent(h.id)}`).catch((e) => status(e.message));
+      await loadHighlights();
+      renderPage();
+    });
+    li.append(text, page, del);
+    list.append(li);
+  }
+  if (!state.highlights.length) {
+    const li = document.createElement("li");
+    li.textContent = "Selectionnez un passage puis touchez « Surligner ».";
+    list.append(li);
+  }
+}
+
+function selectedPassage() {
+  const sel = window.getSelection();
+  if (!sel || sel.isCollapsed || !$("page").contains(sel.anchorNode)) return null;
+  const text = sel.toString().trim();
+  return text ? { text, rect: sel.getRangeAt(0).getBoundingClientRect() } : null;
+}
+
+function placeHighlightButton() {
+  const btn = $("highlight");
+  const passage = selectedPassage();
+  if (!passage) { btn.hidden = true; return; }
+  btn.style.top = Math.max(passage.rect.top - 44, 8) + "px";
+  btn.style.left = Math.max(passage.rect.left, 8) + "px";
+  btn.hidden = false;
+}
+
+async function addHighlight() {
+  const passage = selectedPassage();
+  $("highlight").hidden = true;
+  if (!passage) return;
+  const comment = (window.prompt("Commentaire (facultatif)") || "").trim();
+  const note = comment ? passage.text + COMMENT_SEP + comment : passage.text;
+  try {
+    await api("POST", `/api/books/${encodeURIComponent(state.book.id)}/highlights`, { page: state.page, note });
+    window.getSelection().removeAllRanges();
+    await loadHighlights();
+    renderPage();
+    status("Passage surligne.");
+  } catch (e) {
+    status("Surlignage impossible : " + e.message);
+  }
+}
+
+// --- Reglages et evenements ---
+
+function setFontSize(px) {
+  px = Math.min(Math.max(px, 13), 32);
+  document.documentElement.style.setProperty("--font-size", px + "px");
+  localStorage.setItem("orus-font-size", String(px));
+}
+
+function route() {
+  const m = location.hash.match(/^#book\/(.+)$/);
+  if (m) openBook(decodeURIComponent(m[1]));
+  else { state.book = null; loadLibrary(); }
+}
+
+$("filter").addEventListener("input", renderLibrary);
+$("close").addEventListener("click", () => { location.hash = ""; });
+$("prev").addEventListener("click", () => goTo(state.page - 1));
+$("next").addEventListener("click", () => goTo(state.page + 1));
+$("smaller").addEventListener("click", () => setFontSize(parseInt(localStorage.getItem("orus-font-size") || "19", 10) - 2));
+$("larger").addEventListener("click", () => setFontSize(parseInt(localStorage.getItem("orus-font-size") || "19", 10) + 2));
+$("show-highlights").addEventListener("click", () => { $("highlights").hidden = !$("highlights").hidden; });
+$("highlight").addEventListener("mousedown", (e) => e.preventDefault());
+$("highlight").addEventListener("click", addHighlight);
+document.addEventListener("selectionchange", placeHighlightButton);
+document.addEventListener("keydown", (e) => {
+  if ($("reader").hidden || e.target.tagName === "INPUT") return;
+  if (e.key === "ArrowLeft" && state.page > 1) goTo(state.page - 1);
+  if (e.key === "ArrowRight" && state.page < state.pages) goTo(state.page + 1);
+});
+// Balayage horizontal sur tablette.
+let touchX = null;
+$("page").addEventListener("touchstart", (e) => { touchX = e.touches.length === 1 ? e.touches[0].clientX : null; }, { passive: true });
+$("page").addEventListener("touchend", (e) => {
+  if (touchX === null || selectedPassage()) return;
+  const dx = e.changedTouches[0].clientX - touchX;
+  if (dx > 80 && state.page > 1) goTo(state.page - 1);
+  if (dx < -80 && state.page < state.pages) goTo(state.page + 1);
+  touchX = null;
+});
+document.addEventListener("visibilitychange", () => { if (!document.hidden) resync(); });
+window.addEventListener("hashchange", route);
+
+setFontSize(parseInt(localStorage.getItem("orus-font-size") || "19", 10));
+route();
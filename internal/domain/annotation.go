@@ -28,11 +28,12 @@ type Annotation struct {
 	BookID         string         `json:"book_id"`
 	AnnotationType AnnotationType `json:"type"`
 	PageNo         int            `json:"page_no"`
+	Note           string         `json:"note,omitempty"` // commentaire libre, vide par défaut
 	CreatedAt      time.Time      `json:"created_at"`
 }
 
 // NewAnnotation creates a new annotation (bookmark or highlight) for a specific page.
-func NewAnnotation(bookID string, annotationType AnnotationType, pageNo int) (*Annotation, error) {
+func NewAnnotation(bookID string, annotationType AnnotationType, pageNo int, now time.Time) (*Annotation, error) {
 	if pageNo < 1 {
 		return nil, ErrInvalidPageNumber
 	}
@@ -45,6 +46,6 @@ func NewAnnotation(bookID string, annotationType AnnotationType, pageNo int) (*A
 		BookID:         bookID,
 		AnnotationType: annotationType,
 		PageNo:         pageNo,
-		CreatedAt:      time.Now(),
+		CreatedAt:      now,
 	}, nil
 }
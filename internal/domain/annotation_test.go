@@ -3,29 +3,30 @@ package domain_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 )
 
 func TestNewAnnotation(t *testing.T) {
-	_, err := domain.NewAnnotation("3ubiosdfo24", domain.AnnotationBookmark, 30)
+	_, err := domain.NewAnnotation("3ubiosdfo24", domain.AnnotationBookmark, 30, time.Now())
 	if err != nil {
 		t.Fatalf("expected no errors, got %v", err)
 	}
 
-	_, errBookID := domain.NewAnnotation("", domain.AnnotationBookmark, 30)
+	_, errBookID := domain.NewAnnotation("", domain.AnnotationBookmark, 30, time.Now())
 	if !errors.Is(errBookID, domain.ErrInvalidBookId) {
 		t.Errorf("expected error to be ErrInvalidBookId, instead got %v", errBookID)
 	}
 
-	_, errPage := domain.NewAnnotation("3ubiosdfo24", domain.AnnotationBookmark, -2)
+	_, errPage := domain.NewAnnotation("3ubiosdfo24", domain.AnnotationBookmark, -2, time.Now())
 	if !errors.Is(errPage, domain.ErrInvalidPageNumber) {
 		t.Errorf("expected error to be ErrInvalidPageNumber, instead got %v", errPage)
 	}
 }
 
 func TestAnnotationType(t *testing.T) {
-	bookmark, err := domain.NewAnnotation("kln934nalj4fs", domain.AnnotationBookmark, 30)
+	bookmark, err := domain.NewAnnotation("kln934nalj4fs", domain.AnnotationBookmark, 30, time.Now())
 	if err != nil {
 		t.Fatalf("expected no errors, got %v", err)
 	}
@@ -33,7 +34,7 @@ func TestAnnotationType(t *testing.T) {
 		t.Fatalf("expected type 'bookmark', got %v", bookmark.AnnotationType)
 	}
 
-	highlight, err := domain.NewAnnotation("kln934nalj4fs", domain.AnnotationHighlight, 30)
+	highlight, err := domain.NewAnnotation("kln934nalj4fs", domain.AnnotationHighlight, 30, time.Now())
 	if err != nil {
 		t.Fatalf("expected no errors, got %v", err)
 	}
@@ -43,8 +44,8 @@ func TestAnnotationType(t *testing.T) {
 }
 
 func TestAnnotationIDUniqueness(t *testing.T) {
-	a1, _ := domain.NewAnnotation("book1", domain.AnnotationBookmark, 1)
-	a2, _ := domain.NewAnnotation("book1", domain.AnnotationBookmark, 1)
+	a1, _ := domain.NewAnnotation("book1", domain.AnnotationBookmark, 1, time.Now())
+	a2, _ := domain.NewAnnotation("book1", domain.AnnotationBookmark, 1, time.Now())
 	if a1.ID == a2.ID {
 		t.Fatal("two annotations created with the same ID")
 	}
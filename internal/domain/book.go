@@ -33,14 +33,21 @@ type Book struct {
 	FilePath   string
 	Format     BookFormat
 	TotalPages int
+	// Series and SeriesIndex place the book in a series ("" when standalone).
+	Series      string
+	SeriesIndex float64
+	// ISBN, Publisher and Year come from the file metadata when available.
+	ISBN       string
+	Publisher  string
+	Year       int    // publication year, 0 when unknown
 	CoverImage []byte // Optional: Store cover image as bytes, can be nil if not available
 	AddedAt    time.Time
 	UpdatedAt  time.Time
 }
 
-// NewBook creates a new Book with validated fields. Returns an error if title or
-// filePath is empty.
-func NewBook(title, author, filePath string, format BookFormat, totalPages int) (*Book, error) {
+// NewBook creates a new Book with validated fields, added at now. Returns an
+// error if title or filePath is empty.
+func NewBook(title, author, filePath string, format BookFormat, totalPages int, now time.Time) (*Book, error) {
 	if title == "" {
 		return nil, ErrInvalidBookTitle
 	}
@@ -55,8 +62,8 @@ func NewBook(title, author, filePath string, format BookFormat, totalPages int)
 		Format:     format,
 		TotalPages: totalPages,
 		CoverImage: []byte{}, // This can be set later using a method to update the cover image
-		AddedAt:    time.Now(),
-		UpdatedAt:  time.Now(),
+		AddedAt:    now,
+		UpdatedAt:  now,
 	}, nil
 }
 
@@ -67,4 +74,7 @@ type BookMetadata struct {
 	TotalPages int
 	FilePath   string
 	Format     BookFormat
+	ISBN       string
+	Publisher  string
+	Year       int
 }
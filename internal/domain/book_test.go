@@ -3,13 +3,14 @@ package domain_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 )
 
 func TestNewBook_Validation(t *testing.T) {
 	// Test Valid Book
-	book, err := domain.NewBook("Dune", "Frank Herbert", "/path/dune.pdf", domain.FormatPDF, 500)
+	book, err := domain.NewBook("Dune", "Frank Herbert", "/path/dune.pdf", domain.FormatPDF, 500, time.Now())
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -18,7 +19,7 @@ func TestNewBook_Validation(t *testing.T) {
 	}
 
 	// Test Empty Title
-	_, err = domain.NewBook("", "Author", "path", domain.FormatPDF, 100)
+	_, err = domain.NewBook("", "Author", "path", domain.FormatPDF, 100, time.Now())
 	if !errors.Is(err, domain.ErrInvalidBookTitle) {
 		t.Errorf("Expected ErrInvalidBookTitle, got %v", err)
 	}
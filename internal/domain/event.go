@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// Event is something that happened to the library, published by a service
+// once the change is saved. Subscribers switch on the concrete type.
+type Event interface {
+	EventName() string
+}
+
+// BookImported is published when a book joins the library, from a file, an
+// external library, a catalog or another device.
+type BookImported struct {
+	Book *Book
+}
+
+// BookDeleted is published when a book leaves the library.
+type BookDeleted struct {
+	BookID string
+}
+
+// PageTurned is published when the reading position of a book is saved.
+type PageTurned struct {
+	BookID     string
+	SessionID  string
+	Page       int
+	TotalPages int
+}
+
+// BookFinished is published when a session reaches the last page of a book
+// that was not finished yet, after its PageTurned.
+type BookFinished struct {
+	BookID    string
+	SessionID string
+	Duration  time.Duration // durée de la session qui a terminé le livre
+}
+
+// SheetUpdated is published when the reading sheet of a book is created,
+// edited or deleted.
+type SheetUpdated struct {
+	BookID  string
+	SheetID string
+	Deleted bool
+}
+
+// AnnotationChanged is published when an annotation is added or deleted.
+// Deletions only know the annotation, so BookID is then empty.
+type AnnotationChanged struct {
+	BookID       string
+	AnnotationID string
+	Deleted      bool
+}
+
+// ReminderFired is published for every reminder that rings, after its
+// notification.
+type ReminderFired struct {
+	Reminder *Reminder
+}
+
+func (BookImported) EventName() string      { return "book.imported" }
+func (BookDeleted) EventName() string       { return "book.deleted" }
+func (PageTurned) EventName() string        { return "page.turned" }
+func (BookFinished) EventName() string      { return "book.finished" }
+func (SheetUpdated) EventName() string      { return "sheet.updated" }
+func (AnnotationChanged) EventName() string { return "annotation.changed" }
+func (ReminderFired) EventName() string     { return "reminder.fired" }
@@ -26,8 +26,8 @@ type ReadingSheet struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// NewReadingSheet crée une fiche de lecture valide
-func NewReadingSheet(bookID, bookTitle, summary string, rating int, quotes, tags []string) (*ReadingSheet, error) {
+// NewReadingSheet crée une fiche de lecture valide, datée de now
+func NewReadingSheet(bookID, bookTitle, summary string, rating int, quotes, tags []string, now time.Time) (*ReadingSheet, error) {
 	if bookID == "" {
 		return nil, ErrInvalidBookId
 	}
@@ -39,7 +39,6 @@ func NewReadingSheet(bookID, bookTitle, summary string, rating int, quotes, tags
 	cleanQuotes := filterEmpty(quotes)
 	cleanTags := filterEmpty(tags)
 
-	now := time.Now()
 	return &ReadingSheet{
 		ID:        uuid.New().String(),
 		BookID:    bookID,
@@ -53,37 +52,37 @@ func NewReadingSheet(bookID, bookTitle, summary string, rating int, quotes, tags
 	}, nil
 }
 
-// AddQuote ajoute une citation à la fiche
-func (rs *ReadingSheet) AddQuote(quote string) {
+// AddQuote ajoute une citation à la fiche, modifiée à now
+func (rs *ReadingSheet) AddQuote(quote string, now time.Time) {
 	q := strings.TrimSpace(quote)
 	if q != "" {
 		rs.Quotes = append(rs.Quotes, q)
-		rs.UpdatedAt = time.Now()
+		rs.UpdatedAt = now
 	}
 }
 
-// RemoveQuote supprime une citation par son index
-func (rs *ReadingSheet) RemoveQuote(index int) {
+// RemoveQuote supprime une citation par son index, modifiée à now
+func (rs *ReadingSheet) RemoveQuote(index int, now time.Time) {
 	if index < 0 || index >= len(rs.Quotes) {
 		return
 	}
 	rs.Quotes = append(rs.Quotes[:index], rs.Quotes[index+1:]...)
-	rs.UpdatedAt = time.Now()
+	rs.UpdatedAt = now
 }
 
-// UpdateSummary met à jour le résumé
-func (rs *ReadingSheet) UpdateSummary(summary string) {
+// UpdateSummary met à jour le résumé, modifié à now
+func (rs *ReadingSheet) UpdateSummary(summary string, now time.Time) {
 	rs.Summary = strings.TrimSpace(summary)
-	rs.UpdatedAt = time.Now()
+	rs.UpdatedAt = now
 }
 
-// UpdateRating met à jour la note (valide entre 0 et 5)
-func (rs *ReadingSheet) UpdateRating(rating int) error {
+// UpdateRating met à jour la note (valide entre 0 et 5), modifiée à now
+func (rs *ReadingSheet) UpdateRating(rating int, now time.Time) error {
 	if rating < 0 || rating > 5 {
 		return ErrInvalidRating
 	}
 	rs.Rating = rating
-	rs.UpdatedAt = time.Now()
+	rs.UpdatedAt = now
 	return nil
 }
 
@@ -34,10 +34,13 @@ type Reminder struct {
 	Enabled   bool              `json:"enabled"`
 	NextRing  time.Time         `json:"next_ring"` // prochaine occurrence calculée
 	CreatedAt time.Time         `json:"created_at"`
+	// SkipIfReadToday silences the reminder when a reading session already
+	// happened the same day (on BookID, or on any book for a global reminder).
+	SkipIfReadToday bool `json:"skip_if_read_today"`
 }
 
-// NewReminder crée un rappel valide
-func NewReminder(bookID, bookTitle, label string, hour, minute int, freq ReminderFrequency) (*Reminder, error) {
+// NewReminder crée un rappel valide ; la première occurrence est calculée depuis now
+func NewReminder(bookID, bookTitle, label string, hour, minute int, freq ReminderFrequency, now time.Time) (*Reminder, error) {
 	if hour < 0 || hour > 23 {
 		return nil, ErrInvalidReminderTime
 	}
@@ -57,9 +60,9 @@ func NewReminder(bookID, bookTitle, label string, hour, minute int, freq Reminde
 		Minute:    minute,
 		Frequency: freq,
 		Enabled:   true,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
 	}
-	r.NextRing = r.ComputeNextRing(time.Now())
+	r.NextRing = r.ComputeNextRing(now)
 	return r, nil
 }
 
@@ -104,18 +107,7 @@ func (r *Reminder) Advance(from time.Time) {
 	}
 }
 
-// FrequencyLabel retourne le label lisible de la fréquence
-func (r *Reminder) FrequencyLabel() string {
-	switch r.Frequency {
-	case FrequencyDaily:
-		return "Tous les jours"
-	case FrequencyWeekly:
-		return "Chaque semaine"
-	case FrequencyWeekdays:
-		return "Jours ouvrés (Lun-Ven)"
-	case FrequencyOnce:
-		return "Une seule fois"
-	default:
-		return string(r.Frequency)
-	}
+// MessageKey nomme le libellé de la fréquence dans les catalogues i18n.
+func (f ReminderFrequency) MessageKey() string {
+	return "reminder.frequency." + string(f)
 }
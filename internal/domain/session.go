@@ -17,10 +17,12 @@ type ReadingSession struct {
 	BookID          string    `json:"book_id"`
 	TotalPages      int       `json:"total_pages"`
 	CurrentPage     int       `json:"current_page"`
+	StartedAt       time.Time `json:"started_at"`
 	LastReadingTime time.Time `json:"last_reading_time"`
 }
 
-// NewSession creates a new ReadingSession with validated fields.
+// NewSession creates a new ReadingSession with validated fields. The session
+// starts at lastReadingTime.
 func NewSession(bookId string, totalPages, currentPages int, lastReadingTime time.Time) (*ReadingSession, error) {
 	if bookId == "" {
 		return nil, ErrInvalidBookId
@@ -33,6 +35,7 @@ func NewSession(bookId string, totalPages, currentPages int, lastReadingTime tim
 	r.BookID = bookId
 	r.TotalPages = totalPages
 	r.CurrentPage = currentPages
+	r.StartedAt = lastReadingTime
 	r.LastReadingTime = lastReadingTime
 
 	return &r, nil
@@ -52,8 +55,18 @@ func (r *ReadingSession) IsBookComplete() bool {
 	return r.CurrentPage >= r.TotalPages
 }
 
-// UpdatePosition moves the reader to a new page, clamping to valid bounds.
-func (r *ReadingSession) UpdatePosition(page int) {
+// Duration returns how long the session lasted, from StartedAt to the last
+// page turn. Sessions without a start time report zero.
+func (r *ReadingSession) Duration() time.Duration {
+	if r.StartedAt.IsZero() || r.LastReadingTime.Before(r.StartedAt) {
+		return 0
+	}
+	return r.LastReadingTime.Sub(r.StartedAt)
+}
+
+// UpdatePosition moves the reader to a new page at the given time, clamping
+// to valid bounds.
+func (r *ReadingSession) UpdatePosition(page int, at time.Time) {
 	if page < 1 {
 		r.CurrentPage = 1
 		return
@@ -64,5 +77,5 @@ func (r *ReadingSession) UpdatePosition(page int) {
 	}
 
 	r.CurrentPage = page
-	r.LastReadingTime = time.Now()
+	r.LastReadingTime = at
 }
@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 )
@@ -39,20 +40,41 @@ func TestUpdatePosition(t *testing.T) {
 	s := domain.ReadingSession{TotalPages: 100, CurrentPage: 50}
 
 	// Case 1: Normal update
-	s.UpdatePosition(60)
+	s.UpdatePosition(60, time.Now())
 	if s.CurrentPage != 60 {
 		t.Errorf("Expected page 60, got %d", s.CurrentPage)
 	}
 
 	// Case 2: Underflow (User tries to go to page -1)
-	s.UpdatePosition(-5)
+	s.UpdatePosition(-5, time.Now())
 	if s.CurrentPage != 1 {
 		t.Errorf("Expected page 1 (clamped), got %d", s.CurrentPage)
 	}
 
 	// Case 3: Overflow (User tries to go past end)
-	s.UpdatePosition(150)
+	s.UpdatePosition(150, time.Now())
 	if s.CurrentPage != 100 {
 		t.Errorf("Expected page 100 (clamped), got %d", s.CurrentPage)
 	}
 }
+
+func TestSessionDuration(t *testing.T) {
+	start := time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC)
+	s, err := domain.NewSession("book-1", 100, 1, start)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if s.Duration() != 0 {
+		t.Errorf("expected a fresh session to last 0, got %s", s.Duration())
+	}
+
+	s.UpdatePosition(12, start.Add(40*time.Minute))
+	if s.Duration() != 40*time.Minute {
+		t.Errorf("expected 40m, got %s", s.Duration())
+	}
+
+	legacy := domain.ReadingSession{LastReadingTime: start}
+	if legacy.Duration() != 0 {
+		t.Errorf("expected sessions without start to last 0, got %s", legacy.Duration())
+	}
+}
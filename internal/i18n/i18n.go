@@ -0,0 +1,189 @@
+// Package i18n translates the user-facing strings of Orus. Messages live in
+// the JSON catalogs of locales/, one per language, keyed by dotted names
+// such as "library.empty". A message missing from a catalog falls back to
+// English, then to its key.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale is a language supported by the catalogs.
+type Locale string
+
+const (
+	English Locale = "en"
+	French  Locale = "fr"
+)
+
+// Supported lists the languages offered in the settings, in display order.
+func Supported() []Locale { return []Locale{English, French} }
+
+//go:embed locales/*.json
+var files embed.FS
+
+// catalogs holds the messages of every locale, loaded once.
+var catalogs = mustLoad()
+
+func mustLoad() map[Locale]map[string]string {
+	out := make(map[Locale]map[string]string)
+	for _, l := range Supported() {
+		data, err := files.ReadFile("locales/" + string(l) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: catalog %s missing: %v", l, err))
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: catalog %s invalid: %v", l, err))
+		}
+		out[l] = messages
+	}
+	return out
+}
+
+// Keys returns the message keys of a locale's catalog.
+func Keys(l Locale) []string {
+	keys := make([]string, 0, len(catalogs[l]))
+	for k := range catalogs[l] {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Message returns the raw message of key in l's catalog, untranslated
+// placeholders included, and whether it exists.
+func Message(l Locale, key string) (string, bool) {
+	m, ok := catalogs[l][key]
+	return m, ok
+}
+
+// ParseLocale reads a language tag such as "fr", "en-GB" or "fr_FR.UTF-8".
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, l := range Supported() {
+		if tag == string(l) {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// DetectLocale picks the language of the environment (LC_ALL, LC_MESSAGES,
+// LANG), French when none is supported.
+func DetectLocale() Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if l, ok := ParseLocale(os.Getenv(name)); ok {
+			return l
+		}
+	}
+	return French
+}
+
+// Localizer formats messages, plurals, dates and numbers for one locale.
+type Localizer struct {
+	locale   Locale
+	messages map[string]string
+}
+
+// New returns a Localizer for l; an unsupported locale gets English.
+func New(l Locale) *Localizer {
+	messages, ok := catalogs[l]
+	if !ok {
+		l, messages = English, catalogs[English]
+	}
+	return &Localizer{locale: l, messages: messages}
+}
+
+// Locale returns the language of the Localizer.
+func (l *Localizer) Locale() Locale { return l.locale }
+
+func (l *Localizer) lookup(key string) string {
+	if m, ok := l.messages[key]; ok {
+		return m
+	}
+	if m, ok := catalogs[English][key]; ok {
+		return m
+	}
+	return key
+}
+
+// T returns the message of key, formatted with args like fmt.Sprintf.
+func (l *Localizer) T(key string, args ...any) string {
+	m := l.lookup(key)
+	if len(args) == 0 {
+		return m
+	}
+	return fmt.Sprintf(m, args...)
+}
+
+// N returns the plural form of key matching n: the message of key+".one"
+// or key+".other", formatted with n then args.
+func (l *Localizer) N(key string, n int, args ...any) string {
+	return fmt.Sprintf(l.lookup(key+"."+pluralForm(l.locale, n)), append([]any{n}, args...)...)
+}
+
+// pluralForm applies the CLDR cardinal rules: French treats 0 and 1 as
+// singular, English only 1.
+func pluralForm(l Locale, n int) string {
+	if n == 1 || (l == French && n == 0) {
+		return "one"
+	}
+	return "other"
+}
+
+// DateStyle is a way of writing dates, defined by each catalog.
+type DateStyle string
+
+const (
+	DateShort    DateStyle = "date.short"     // 10/03/2026, 03/10/2026
+	DateMedium   DateStyle = "date.medium"    // 10 mars 2026, Mar 10, 2026
+	DateLong     DateStyle = "date.long"      // 10 mars 2026, March 10, 2026
+	DateFull     DateStyle = "date.full"      // mardi 10 mars 2026
+	DateTime     DateStyle = "date.time"      // 10/03/2026 21:00
+	DateDayMonth DateStyle = "date.day_month" // 10 mars 21:00
+	TimeOfDay    DateStyle = "date.clock"     // 21:00, 9:00 PM
+)
+
+// Catalog layouts are Go layouts where {month}, {mon}, {weekday} and {wday}
+// stand for the translated names, which time.Format only knows in English.
+var nameTokens = []struct{ token, mark string }{
+	{"{month}", "\x00M\x00"}, {"{mon}", "\x00m\x00"}, {"{weekday}", "\x00W\x00"}, {"{wday}", "\x00w\x00"},
+}
+
+// Date formats t in the given style.
+func (l *Localizer) Date(t time.Time, style DateStyle) string {
+	layout := l.lookup(string(style))
+	for _, n := range nameTokens {
+		layout = strings.ReplaceAll(layout, n.token, n.mark)
+	}
+	out := t.Format(layout)
+	return strings.NewReplacer(
+		"\x00M\x00", l.Month(t.Month()),
+		"\x00m\x00", l.lookup("month.short."+strconv.Itoa(int(t.Month()))),
+		"\x00W\x00", l.Weekday(t.Weekday()),
+		"\x00w\x00", l.lookup("weekday.short."+strconv.Itoa(int(t.Weekday()))),
+	).Replace(out)
+}
+
+// Month returns the name of m.
+func (l *Localizer) Month(m time.Month) string { return l.lookup("month." + strconv.Itoa(int(m))) }
+
+// Weekday returns the name of d.
+func (l *Localizer) Weekday(d time.Weekday) string {
+	return l.lookup("weekday." + strconv.Itoa(int(d)))
+}
+
+// Decimal formats f with prec decimals and the locale's decimal separator.
+func (l *Localizer) Decimal(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	return strings.Replace(s, ".", l.lookup("number.decimal"), 1)
+}
@@ -0,0 +1,315 @@
+package i18n_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+var verbPattern = regexp.MustCompile(`%(?:\[\d+\])?[-+# 0]*\d*(?:\.\d+)?([a-zA-Z%])`)
+
+// verbs returns the sorted verb letters of a message, ignoring argument
+// indexes so that translations may reorder their arguments.
+func verbs(msg string) string {
+	var out []string
+	for _, m := range verbPattern.FindAllStringSubmatch(msg, -1) {
+		out = append(out, m[1])
+	}
+	sort.Strings(out)
+	return strings.Join(out, "")
+}
+
+func has(l i18n.Locale, key string) bool {
+	_, ok := i18n.Message(l, key)
+	return ok
+}
+
+func TestCatalogs_SameKeys(t *testing.T) {
+	for _, l := range i18n.Supported() {
+		for _, other := range i18n.Supported() {
+			for _, key := range i18n.Keys(l) {
+				if !has(other, key) {
+					t.Errorf("%q is in the %s catalog but missing from %s", key, l, other)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogs_SameVerbs(t *testing.T) {
+	for _, key := range i18n.Keys(i18n.English) {
+		en, _ := i18n.Message(i18n.English, key)
+		for _, l := range i18n.Supported() {
+			if msg, ok := i18n.Message(l, key); ok && verbs(msg) != verbs(en) {
+				t.Errorf("%q: %s message %q does not take the arguments of %q", key, l, msg, en)
+			}
+		}
+	}
+}
+
+func TestCatalogs_PluralPairs(t *testing.T) {
+	for _, l := range i18n.Supported() {
+		for _, key := range i18n.Keys(l) {
+			base, form, _ := cutLast(key)
+			switch form {
+			case "one":
+				if !has(l, base+".other") {
+					t.Errorf("%s: %q has no .other form", l, base)
+				}
+			case "other":
+				if !has(l, base+".one") {
+					t.Errorf("%s: %q has no .one form", l, base)
+				}
+			}
+		}
+	}
+}
+
+func cutLast(key string) (string, string, bool) {
+	i := strings.LastIndex(key, ".")
+	if i < 0 {
+		return key, "", false
+	}
+	return key[:i], key[i+1:], true
+}
+
+var (
+	callPattern     = regexp.MustCompile(`\.(T|N)\("([a-z0-9_.]+)"`)
+	tmplPattern     = regexp.MustCompile(`[{(]-?\s*(t|n) "([a-z0-9_.]+)"`)
+	literalPattern  = regexp.MustCompile(`"([a-z_]+\.[a-z0-9_.]+)"`)
+	namespacesCache map[string]bool
+)
+
+func namespaces() map[string]bool {
+	if namespacesCache == nil {
+		namespacesCache = make(map[string]bool)
+		for _, key := range i18n.Keys(i18n.English) {
+			ns, _, _ := strings.Cut(key, ".")
+			namespacesCache[ns] = true
+		}
+	}
+	return namespacesCache
+}
+
+// checkKey reports a key used by the sources that no catalog defines. fn is
+// "T" for a message, "N" for a plural and "" for a bare string literal of the
+// UI, which may also name a group of keys completed at run time.
+func checkKey(t *testing.T, where, fn, key string) {
+	t.Helper()
+	for _, l := range i18n.Supported() {
+		switch {
+		case fn == "N" || fn == "n":
+			if !has(l, key+".one") || !has(l, key+".other") {
+				t.Errorf("%s: plural %q missing from the %s catalog", where, key, l)
+			}
+		case fn != "":
+			if !has(l, key) {
+				t.Errorf("%s: %q missing from the %s catalog", where, key, l)
+			}
+		default:
+			if has(l, key) || (has(l, key+".one") && has(l, key+".other")) {
+				continue
+			}
+			prefix := strings.TrimSuffix(key, ".") + "."
+			found := false
+			for _, k := range i18n.Keys(l) {
+				if strings.HasPrefix(k, prefix) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s: %q names no message of the %s catalog", where, key, l)
+			}
+		}
+	}
+}
+
+func TestSources_UseExistingKeys(t *testing.T) {
+	checked := 0
+	for _, root := range []string{"..", "../../cmd"} {
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			isGo, isTmpl := strings.HasSuffix(path, ".go"), strings.HasSuffix(path, ".tmpl")
+			inViews := strings.Contains(filepath.ToSlash(path), "adapters/ui/")
+			if !isGo && !isTmpl {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for n, line := range strings.Split(string(data), "\n") {
+				where := fmt.Sprintf("%s:%d", path, n+1)
+				for _, m := range tmplPattern.FindAllStringSubmatch(line, -1) {
+					checkKey(t, where, m[1], m[2])
+					checked++
+				}
+				if !isGo {
+					continue
+				}
+				for _, m := range callPattern.FindAllStringSubmatch(line, -1) {
+					if strings.HasSuffix(m[2], ".") {
+						checkKey(t, where, "", m[2]) // complété à l'exécution
+					} else {
+						checkKey(t, where, m[1], m[2])
+					}
+					checked++
+				}
+				if !inViews {
+					continue
+				}
+				for _, m := range literalPattern.FindAllStringSubmatch(line, -1) {
+					if ns, _, _ := strings.Cut(m[1], "."); namespaces()[ns] {
+						checkKey(t, where, "", m[1])
+						checked++
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("failed to scan %s: %v", root, err)
+		}
+	}
+	if checked < 100 {
+		t.Errorf("only %d keys found in the sources, the scan is broken", checked)
+	}
+}
+
+// TestSources_DynamicKeys covers the keys built from values at run time.
+func TestSources_DynamicKeys(t *testing.T) {
+	var keys []string
+	for _, f := range []domain.ReminderFrequency{domain.FrequencyDaily, domain.FrequencyWeekly, domain.FrequencyWeekdays, domain.FrequencyOnce} {
+		keys = append(keys, f.MessageKey(), "reminders.option."+string(f))
+	}
+	for _, mode := range []string{"enable", "change", "disable", "unlock"} {
+		keys = append(keys, "encryption."+mode+".title", "encryption."+mode+".desc", "encryption."+mode+".button")
+	}
+	for angle := 0; angle < 5; angle++ {
+		post := fmt.Sprintf("achievement.post.%d.", angle)
+		keys = append(keys, post+"title", post+"title_time", post+"body")
+	}
+	for _, kind := range []service.ConflictKind{service.ConflictSheetNewerLocally, service.ConflictMissingFile, service.ConflictAmbiguousMatch} {
+		keys = append(keys, "import.conflict."+string(kind))
+	}
+	for _, l := range i18n.Supported() {
+		keys = append(keys, "language."+string(l))
+	}
+	for m := 1; m <= 12; m++ {
+		keys = append(keys, fmt.Sprintf("month.%d", m), fmt.Sprintf("month.short.%d", m))
+	}
+	for d := 0; d < 7; d++ {
+		keys = append(keys, fmt.Sprintf("weekday.%d", d), fmt.Sprintf("weekday.short.%d", d))
+	}
+	for _, style := range []i18n.DateStyle{i18n.DateShort, i18n.DateMedium, i18n.DateLong, i18n.DateFull,
+		i18n.DateTime, i18n.DateDayMonth, i18n.TimeOfDay} {
+		keys = append(keys, string(style))
+	}
+	keys = append(keys, "number.decimal")
+	for _, key := range keys {
+		checkKey(t, "dynamic", "T", key)
+	}
+}
+
+func TestLocalizer_Plural(t *testing.T) {
+	tests := []struct {
+		locale i18n.Locale
+		n      int
+		want   string
+	}{
+		{i18n.English, 0, "0 books"},
+		{i18n.English, 1, "1 book"},
+		{i18n.English, 2, "2 books"},
+		{i18n.French, 0, "0 livre"},
+		{i18n.French, 1, "1 livre"},
+		{i18n.French, 3, "3 livres"},
+	}
+	for _, tt := range tests {
+		if got := i18n.New(tt.locale).N("export.books", tt.n); got != tt.want {
+			t.Errorf("%s N(%d) = %q, want %q", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizer_Date(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 21, 5, 0, 0, time.UTC)
+	tests := []struct {
+		locale i18n.Locale
+		style  i18n.DateStyle
+		want   string
+	}{
+		{i18n.English, i18n.DateShort, "03/10/2026"},
+		{i18n.English, i18n.DateMedium, "Mar 10, 2026"},
+		{i18n.English, i18n.DateFull, "Tuesday, March 10, 2026"},
+		{i18n.English, i18n.TimeOfDay, "9:05 PM"},
+		{i18n.French, i18n.DateShort, "10/03/2026"},
+		{i18n.French, i18n.DateLong, "10 mars 2026"},
+		{i18n.French, i18n.DateFull, "mardi 10 mars 2026"},
+		{i18n.French, i18n.TimeOfDay, "21:05"},
+	}
+	for _, tt := range tests {
+		if got := i18n.New(tt.locale).Date(at, tt.style); got != tt.want {
+			t.Errorf("%s Date(%s) = %q, want %q", tt.locale, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizer_Fallback(t *testing.T) {
+	tr := i18n.New(i18n.Locale("de"))
+	if tr.Locale() != i18n.English {
+		t.Errorf("expected English for an unsupported locale, got %s", tr.Locale())
+	}
+	if got := tr.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("expected the key for a missing message, got %q", got)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	for tag, want := range map[string]i18n.Locale{"fr": i18n.French, "en-GB": i18n.English, "fr_FR.UTF-8": i18n.French} {
+		if got, ok := i18n.ParseLocale(tag); !ok || got != want {
+			t.Errorf("ParseLocale(%q) = %q, %v, want %q", tag, got, ok, want)
+		}
+	}
+	if _, ok := i18n.ParseLocale("de_DE"); ok {
+		t.Error("expected de_DE to be unsupported")
+	}
+}
+
+func TestLanguageSettings(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if l, err := i18n.LoadLanguage(path); err != nil || l != i18n.English {
+		t.Fatalf("expected the environment language without settings, got %q, %v", l, err)
+	}
+	if err := i18n.SaveLanguage(path, i18n.French); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if l, err := i18n.LoadLanguage(path); err != nil || l != i18n.French {
+		t.Errorf("expected the saved language, got %q, %v", l, err)
+	}
+
+	// Les autres réglages du fichier survivent au changement de langue.
+	if err := os.WriteFile(path, []byte(`{"language":"fr","reminders":{"coalesce":true}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := i18n.SaveLanguage(path, i18n.English); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"coalesce": true`) || !strings.Contains(string(data), `"en"`) {
+		t.Errorf("expected the other settings kept, got %s", data)
+	}
+}
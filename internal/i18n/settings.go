@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// settings is the part of settings.json, next to the database, read by this
+// package.
+type settings struct {
+	Language Locale `json:"language"`
+}
+
+// LoadLanguage reads the language chosen in the settings at path. A missing
+// file or an unsupported language falls back to DetectLocale.
+func LoadLanguage(path string) (Locale, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DetectLocale(), nil
+	}
+	if err != nil {
+		return DetectLocale(), fmt.Errorf("failed to read settings: %w", err)
+	}
+	var s settings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return DetectLocale(), fmt.Errorf("failed to decode settings: %w", err)
+	}
+	if l, ok := ParseLocale(string(s.Language)); ok {
+		return l, nil
+	}
+	return DetectLocale(), nil
+}
+
+// SaveLanguage writes l as the language of the settings at path. The other
+// entries of the file, such as the reminder rules, are kept.
+func SaveLanguage(path string, l Locale) error {
+	entries := map[string]json.RawMessage{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return fmt.Errorf("failed to decode settings: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+	entries["language"], _ = json.Marshal(l)
+	data, _ := json.MarshalIndent(entries, "", "  ")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// DatabaseSnapshotter writes consistent copies of the live database.
+type DatabaseSnapshotter interface {
+	// Snapshot copies the database to destPath while it stays in use. The
+	// file appears only once complete.
+	Snapshot(ctx context.Context, destPath string) error
+}
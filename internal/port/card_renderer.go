@@ -0,0 +1,29 @@
+package port
+
+import "time"
+
+// QuoteCard is a quote or a highlighted passage to render as an image.
+type QuoteCard struct {
+	Quote     string
+	BookTitle string
+	Author    string
+	Page      int // 0 when the quote carries no page reference
+}
+
+// FinishedCard celebrates a finished book with its reading statistics.
+type FinishedCard struct {
+	BookTitle   string
+	Author      string
+	Pages       int
+	Sessions    int
+	ReadingTime time.Duration
+	Days        int // jours entre la première et la dernière séance, bornes incluses
+	Rating      int // 0 to 5, 0 when the book has no reading sheet
+	FinishedAt  time.Time
+}
+
+// CardRenderer draws share cards offscreen and returns them PNG-encoded.
+type CardRenderer interface {
+	RenderQuoteCard(card QuoteCard) ([]byte, error)
+	RenderFinishedCard(card FinishedCard) ([]byte, error)
+}
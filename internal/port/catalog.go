@@ -0,0 +1,67 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// Catalog is a remote book catalog, such as the OPDS feed of a Calibre
+// content server.
+type Catalog struct {
+	URL      string
+	Username string // vide quand le catalogue est public
+	Password string
+}
+
+// CatalogFile is a downloadable file of a catalog book.
+type CatalogFile struct {
+	URL    string
+	Type   string            // type MIME annoncé par le catalogue
+	Format domain.BookFormat // "" when Orus cannot open the file
+}
+
+// CatalogEntry is an entry of a catalog feed: a sub-catalog to browse when
+// Link is set, a book to download otherwise.
+type CatalogEntry struct {
+	ID        string
+	Title     string
+	Authors   []string
+	Summary   string // plain text
+	Tags      []string
+	Publisher string
+	Year      int // 0 when unknown
+	ISBN      string
+	Link      string // URL du sous-catalogue
+	Files     []CatalogFile
+	Cover     string // URL of the cover image, "" without cover
+}
+
+// CatalogFeed is one page of a catalog.
+type CatalogFeed struct {
+	URL      string
+	Title    string
+	Entries  []CatalogEntry
+	Next     string // page suivante, vide sur la dernière
+	Previous string
+	// Search is the search URL template, holding {searchTerms}; "" when
+	// the catalog cannot be searched.
+	Search string
+}
+
+// CatalogClient browses a remote catalog and downloads its files.
+type CatalogClient interface {
+	// Root returns the first page of the catalog.
+	Root(ctx context.Context) (*CatalogFeed, error)
+	// Fetch returns the feed at url, taken from a Link, Next or Previous.
+	Fetch(ctx context.Context, url string) (*CatalogFeed, error)
+	// Search runs query with the Search template of a feed.
+	Search(ctx context.Context, template, query string) (*CatalogFeed, error)
+	// Download opens the file at url and returns the file name the server
+	// suggests for it, "" when it does not say.
+	Download(ctx context.Context, url string) (io.ReadCloser, string, error)
+}
+
+// CatalogOpener connects to the catalog described by c.
+type CatalogOpener func(c Catalog) (CatalogClient, error)
@@ -0,0 +1,17 @@
+package port
+
+import "time"
+
+// Clock abstracts the passage of time so that services and the reminder
+// scheduler can be driven deterministically in tests.
+type Clock interface {
+	Now() time.Time
+	// NewTicker returns a ticker delivering the clock's time every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the subset of time.Ticker used by the scheduler.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
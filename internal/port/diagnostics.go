@@ -0,0 +1,7 @@
+package port
+
+// LogReader gives back the end of the application log for bug reports.
+type LogReader interface {
+	// Tail returns the most recent log lines, oldest first, within maxBytes.
+	Tail(maxBytes int) ([]byte, error)
+}
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrStoreLocked indicates that encrypted columns were read or written
+	// before Unlock.
+	ErrStoreLocked = errors.New("encrypted store is locked")
+	// ErrWrongPassphrase indicates that a passphrase does not match the key.
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+)
+
+// EncryptedStore manages the opt-in encryption of the sensitive columns of
+// a repository: reading sheet summaries and quotes, annotation notes.
+type EncryptedStore interface {
+	EncryptionEnabled() bool
+	// Locked reports whether encryption is enabled and no key is loaded yet.
+	Locked() bool
+	Unlock(passphrase string) error
+	// EnableEncryption derives a key from passphrase and encrypts the
+	// existing rows; the store is unlocked afterwards.
+	EnableEncryption(ctx context.Context, passphrase string) error
+	// ChangePassphrase re-encrypts every row with a key derived from next.
+	ChangePassphrase(ctx context.Context, current, next string) error
+	// DisableEncryption decrypts every row and forgets the key.
+	DisableEncryption(ctx context.Context, passphrase string) error
+}
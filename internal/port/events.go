@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// EventPublisher delivers domain events to the subscribers of the
+// application. Publish does not report the failures of subscribers: the
+// change the event describes is already saved.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
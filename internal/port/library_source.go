@@ -0,0 +1,35 @@
+package port
+
+import (
+	"context"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// ExternalBook is a book as described by another library manager.
+type ExternalBook struct {
+	SourceID    string // identifiant dans la bibliothèque d'origine
+	Title       string
+	Authors     []string
+	Series      string
+	SeriesIndex float64
+	Tags        []string
+	Rating      int    // 0 to 5
+	Comments    string // plain text
+	ISBN        string
+	Publisher   string
+	Year        int // 0 when unknown
+	// FilePath is the best readable file (EPUB, then PDF); empty when the
+	// book has no format Orus can open.
+	FilePath string
+	Format   domain.BookFormat
+	Cover    []byte // JPEG thumbnail, nil when the book has no cover
+}
+
+// LibrarySource lists the books of an external library, such as Calibre.
+type LibrarySource interface {
+	ListBooks(ctx context.Context) ([]ExternalBook, error)
+}
+
+// LibrarySourceOpener opens the external library stored in dir.
+type LibrarySourceOpener func(dir string) (LibrarySource, error)
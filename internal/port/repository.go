@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 )
@@ -19,6 +20,9 @@ type SessionRepository interface {
 	SaveSession(ctx context.Context, session *domain.ReadingSession) error
 	GetSessionByID(ctx context.Context, bookID string) ([]*domain.ReadingSession, error)
 	GetLastReadingSession(ctx context.Context, bookID string) (*domain.ReadingSession, error)
+	// ListSessionsSince returns every session, across all books, whose last
+	// reading time is at or after since, most recent first.
+	ListSessionsSince(ctx context.Context, since time.Time) ([]*domain.ReadingSession, error)
 }
 
 // AnnotationRepository defines the contract for annotation persistence.
@@ -0,0 +1,59 @@
+package port
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrSyncObjectNotFound indicates that a sync object does not exist.
+	ErrSyncObjectNotFound = errors.New("sync object not found")
+	// ErrSyncNotModified indicates that a sync object still has the ETag given to Get.
+	ErrSyncNotModified = errors.New("sync object not modified")
+	// ErrSyncETagMismatch indicates that a sync object changed, or appeared,
+	// since the ETag given to Put was read.
+	ErrSyncETagMismatch = errors.New("sync object changed since it was read")
+)
+
+// SyncObject describes a file of a sync backend.
+type SyncObject struct {
+	Name string // chemin relatif, séparé par des "/"
+	ETag string
+	Size int64
+}
+
+// SyncBackend stores the files shared by the devices of a user: the change
+// logs, and optionally the book files. Names are slash-separated paths
+// relative to the root of the backend.
+type SyncBackend interface {
+	// List returns the files directly inside dir ("" for the root). A missing
+	// dir is empty.
+	List(ctx context.Context, dir string) ([]SyncObject, error)
+	// Get returns the content and ETag of name. When ifNoneMatch is the
+	// current ETag, it returns ErrSyncNotModified instead.
+	Get(ctx context.Context, name, ifNoneMatch string) ([]byte, string, error)
+	// Put writes name if its ETag is still ifMatch, or if it does not exist
+	// when ifMatch is empty, and returns the new ETag. Otherwise it returns
+	// ErrSyncETagMismatch and writes nothing.
+	Put(ctx context.Context, name string, data []byte, ifMatch string) (string, error)
+}
+
+// Kinds of SyncTarget.
+const (
+	SyncTargetFolder = "folder"
+	SyncTargetWebDAV = "webdav"
+)
+
+// SyncTarget is where a device syncs: a local folder shared by a file sync
+// tool, or a WebDAV collection.
+type SyncTarget struct {
+	Kind     string `json:"kind"`
+	Location string `json:"location"` // dossier local ou URL de la collection
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Books also transfers the book files, for the devices that lack them.
+	Books bool `json:"books,omitempty"`
+}
+
+// SyncBackendOpener opens the backend described by target.
+type SyncBackendOpener func(target SyncTarget) (SyncBackend, error)
@@ -0,0 +1,24 @@
+package port
+
+import "context"
+
+// Repositories groups the repositories of one storage, as handed to the
+// function of a unit of work.
+type Repositories struct {
+	Books       BookRepository
+	Sessions    SessionRepository
+	Annotations AnnotationRepository
+	Sheets      ReadingSheetRepository
+	Reminders   ReminderRepository
+}
+
+// UnitOfWork groups writes to several repositories so that they all land or
+// none does.
+type UnitOfWork interface {
+	// WithTx calls fn with repositories bound to a single transaction. The
+	// writes made through them are kept when fn returns nil and discarded
+	// when it returns an error, which WithTx returns unchanged, or panics.
+	// fn must only use the repositories it is given: the storage itself
+	// waits for the unit of work to end.
+	WithTx(ctx context.Context, fn func(repos Repositories) error) error
+}
@@ -3,7 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 	"github.com/MiltonJ23/Orus/internal/port"
@@ -13,15 +14,26 @@ import (
 type AnnotationService struct {
 	annotRepo port.AnnotationRepository
 	bookRepo  port.BookRepository
+	logger    *slog.Logger
+	clock     port.Clock
+	events    eventSink
 }
 
 // NewAnnotationService creates a new AnnotationService with the given dependencies.
-func NewAnnotationService(annotRepo port.AnnotationRepository, bookRepo port.BookRepository) *AnnotationService {
-	return &AnnotationService{annotRepo: annotRepo, bookRepo: bookRepo}
+func NewAnnotationService(annotRepo port.AnnotationRepository, bookRepo port.BookRepository, logger *slog.Logger, clock port.Clock) *AnnotationService {
+	return &AnnotationService{annotRepo: annotRepo, bookRepo: bookRepo, logger: componentLogger(logger, "annotation"), clock: clock}
 }
 
+// SetEvents sets the publisher of the AnnotationChanged events.
+func (a *AnnotationService) SetEvents(pub port.EventPublisher) { a.events.pub = pub }
+
 // AddAnnotation creates a new annotation (bookmark or highlight) for a specific page.
 func (a *AnnotationService) AddAnnotation(ctx context.Context, bookID string, annotationType domain.AnnotationType, pageNo int) (*domain.Annotation, error) {
+	return a.AddAnnotationWithNote(ctx, bookID, annotationType, pageNo, "")
+}
+
+// AddAnnotationWithNote creates an annotation carrying a free-text note.
+func (a *AnnotationService) AddAnnotationWithNote(ctx context.Context, bookID string, annotationType domain.AnnotationType, pageNo int, note string) (*domain.Annotation, error) {
 	// Verify the book exists
 	book, err := a.bookRepo.GetByID(ctx, bookID)
 	if err != nil {
@@ -30,19 +42,21 @@ func (a *AnnotationService) AddAnnotation(ctx context.Context, bookID string, an
 
 	// Validate page is within the book's range
 	if book.TotalPages > 0 && pageNo > book.TotalPages {
-		return nil, fmt.Errorf("page %d exceeds book total pages (%d)", pageNo, book.TotalPages)
+		return nil, fmt.Errorf("page %d exceeds book total pages (%d): %w", pageNo, book.TotalPages, domain.ErrInvalidPageNumber)
 	}
 
-	annot, err := domain.NewAnnotation(bookID, annotationType, pageNo)
+	annot, err := domain.NewAnnotation(bookID, annotationType, pageNo, a.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("invalid annotation: %w", err)
 	}
+	annot.Note = strings.TrimSpace(note)
 
 	if err := a.annotRepo.SaveAnnotation(ctx, annot); err != nil {
 		return nil, fmt.Errorf("save annotation: %w", err)
 	}
 
-	log.Printf("[Annotation] Ajoutée : type=%s page=%d livre=%s", annotationType, pageNo, bookID)
+	a.logger.Info("annotation added", "annotation_id", annot.ID, "type", annotationType, "page", pageNo, "book_id", bookID)
+	a.events.publish(ctx, domain.AnnotationChanged{BookID: bookID, AnnotationID: annot.ID})
 	return annot, nil
 }
 
@@ -81,7 +95,8 @@ func (a *AnnotationService) DeleteAnnotation(ctx context.Context, annotationID s
 	if err := a.annotRepo.DeleteAnnotation(ctx, annotationID); err != nil {
 		return fmt.Errorf("delete annotation: %w", err)
 	}
-	log.Printf("[Annotation] Supprimée : id=%s", annotationID)
+	a.logger.Info("annotation deleted", "annotation_id", annotationID)
+	a.events.publish(ctx, domain.AnnotationChanged{AnnotationID: annotationID, Deleted: true})
 	return nil
 }
 
@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"errors"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
 	"testing"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
@@ -138,7 +139,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Success - Bookmark", func(t *testing.T) {
 		bookRepo := makeBookRepo()
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		annot, err := svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 42)
 		if err != nil {
@@ -161,7 +162,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Success - Highlight", func(t *testing.T) {
 		bookRepo := makeBookRepo()
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		annot, err := svc.AddAnnotation(ctx, "book-1", domain.AnnotationHighlight, 10)
 		if err != nil {
@@ -175,7 +176,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Error - Book Not Found", func(t *testing.T) {
 		bookRepo := makeBookRepo()
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, err := svc.AddAnnotation(ctx, "nonexistent", domain.AnnotationBookmark, 1)
 		if err == nil {
@@ -186,7 +187,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Error - Page Exceeds Total", func(t *testing.T) {
 		bookRepo := makeBookRepo()
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, err := svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 999)
 		if err == nil {
@@ -197,7 +198,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Error - Invalid Page Number", func(t *testing.T) {
 		bookRepo := makeBookRepo()
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, err := svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 0)
 		if err == nil {
@@ -208,7 +209,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Error - Save Failure", func(t *testing.T) {
 		bookRepo := makeBookRepo()
 		annotRepo := &mockAnnotationRepo{failSave: true}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, err := svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 1)
 		if err == nil {
@@ -219,7 +220,7 @@ func TestAnnotationService_AddAnnotation(t *testing.T) {
 	t.Run("Error - Book Repo Failure", func(t *testing.T) {
 		bookRepo := &mockAnnotBookRepo{failGet: true}
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, err := svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 1)
 		if err == nil {
@@ -238,7 +239,7 @@ func TestAnnotationService_ListAnnotationsForBook(t *testing.T) {
 			},
 		}
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		// Add multiple annotations
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 10)
@@ -256,7 +257,7 @@ func TestAnnotationService_ListAnnotationsForBook(t *testing.T) {
 
 	t.Run("Success - No Annotations", func(t *testing.T) {
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, nil)
+		svc := service.NewAnnotationService(annotRepo, nil, nil, clock.NewSystemClock())
 
 		annots, err := svc.ListAnnotationsForBook(ctx, "book-1")
 		if err != nil {
@@ -269,7 +270,7 @@ func TestAnnotationService_ListAnnotationsForBook(t *testing.T) {
 
 	t.Run("Error - Repo Failure", func(t *testing.T) {
 		annotRepo := &mockAnnotationRepo{failList: true}
-		svc := service.NewAnnotationService(annotRepo, nil)
+		svc := service.NewAnnotationService(annotRepo, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.ListAnnotationsForBook(ctx, "book-1")
 		if err == nil {
@@ -288,7 +289,7 @@ func TestAnnotationService_GetAnnotationsByPage(t *testing.T) {
 			},
 		}
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 42)
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationHighlight, 42)
@@ -305,7 +306,7 @@ func TestAnnotationService_GetAnnotationsByPage(t *testing.T) {
 
 	t.Run("Error - Repo Failure", func(t *testing.T) {
 		annotRepo := &mockAnnotationRepo{failByPage: true}
-		svc := service.NewAnnotationService(annotRepo, nil)
+		svc := service.NewAnnotationService(annotRepo, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.GetAnnotationsByPage(ctx, "book-1", 42)
 		if err == nil {
@@ -324,7 +325,7 @@ func TestAnnotationService_GetAnnotationsByType(t *testing.T) {
 			},
 		}
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 10)
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 20)
@@ -349,7 +350,7 @@ func TestAnnotationService_GetAnnotationsByType(t *testing.T) {
 
 	t.Run("Error - Repo Failure", func(t *testing.T) {
 		annotRepo := &mockAnnotationRepo{failByType: true}
-		svc := service.NewAnnotationService(annotRepo, nil)
+		svc := service.NewAnnotationService(annotRepo, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.GetAnnotationsByType(ctx, domain.AnnotationBookmark)
 		if err == nil {
@@ -368,7 +369,7 @@ func TestAnnotationService_DeleteAnnotation(t *testing.T) {
 			},
 		}
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		annot, _ := svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 10)
 
@@ -382,7 +383,7 @@ func TestAnnotationService_DeleteAnnotation(t *testing.T) {
 	})
 
 	t.Run("Error - Empty ID", func(t *testing.T) {
-		svc := service.NewAnnotationService(&mockAnnotationRepo{}, nil)
+		svc := service.NewAnnotationService(&mockAnnotationRepo{}, nil, nil, clock.NewSystemClock())
 
 		err := svc.DeleteAnnotation(ctx, "")
 		if err == nil {
@@ -392,7 +393,7 @@ func TestAnnotationService_DeleteAnnotation(t *testing.T) {
 
 	t.Run("Error - Repo Failure", func(t *testing.T) {
 		annotRepo := &mockAnnotationRepo{failDelete: true}
-		svc := service.NewAnnotationService(annotRepo, nil)
+		svc := service.NewAnnotationService(annotRepo, nil, nil, clock.NewSystemClock())
 
 		err := svc.DeleteAnnotation(ctx, "some-id")
 		if err == nil {
@@ -411,7 +412,7 @@ func TestAnnotationService_CountAnnotationsForBook(t *testing.T) {
 			},
 		}
 		annotRepo := &mockAnnotationRepo{}
-		svc := service.NewAnnotationService(annotRepo, bookRepo)
+		svc := service.NewAnnotationService(annotRepo, bookRepo, nil, clock.NewSystemClock())
 
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationBookmark, 10)
 		_, _ = svc.AddAnnotation(ctx, "book-1", domain.AnnotationHighlight, 20)
@@ -427,7 +428,7 @@ func TestAnnotationService_CountAnnotationsForBook(t *testing.T) {
 
 	t.Run("Error - Repo Failure", func(t *testing.T) {
 		annotRepo := &mockAnnotationRepo{failList: true}
-		svc := service.NewAnnotationService(annotRepo, nil)
+		svc := service.NewAnnotationService(annotRepo, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.CountAnnotationsForBook(ctx, "book-1")
 		if err == nil {
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// BackupInterval is the age after which BackupIfDue takes a new snapshot.
+const BackupInterval = 24 * time.Hour
+
+// backupCheckInterval is how often the scheduler calls BackupIfDue: the
+// application may stay open for days, or sleep through the due time.
+const backupCheckInterval = time.Hour
+
+// Les instantanés automatiques s'appellent orus-20260310-210000.db ; les
+// autres fichiers du dossier (avant migration, copies manuelles) ne sont
+// jamais supprimés.
+const (
+	backupPrefix     = "orus-"
+	backupTimeLayout = "20060102-150405"
+)
+
+// BackupKind tells rotating snapshots from the other files of the folder.
+type BackupKind string
+
+const (
+	BackupAutomatic BackupKind = "auto"
+	BackupOther     BackupKind = "other" // avant migration ou copie manuelle
+)
+
+// Backup is one database snapshot of the backup folder.
+type Backup struct {
+	Path string
+	Time time.Time
+	Size int64
+	Kind BackupKind
+}
+
+// BackupRetention bounds the automatic snapshots kept by rotation: the newest
+// snapshot of each of the last Daily days that have one, and of each of the
+// last Weekly ISO weeks. The newest snapshot is always kept.
+type BackupRetention struct {
+	Daily  int
+	Weekly int
+}
+
+// DefaultBackupRetention keeps a week of daily snapshots and a month of
+// weekly ones.
+func DefaultBackupRetention() BackupRetention {
+	return BackupRetention{Daily: 7, Weekly: 4}
+}
+
+// BackupService writes rotating timestamped snapshots of the database.
+// Restoring one is done by the "orus restore" command, with the database
+// closed.
+type BackupService struct {
+	db        port.DatabaseSnapshotter
+	dir       string
+	logger    *slog.Logger
+	clock     port.Clock
+	retention BackupRetention
+
+	mu     sync.Mutex // sérialise les sauvegardes et protège last
+	last   *Backup
+	loaded bool
+	stop   chan struct{}
+}
+
+// NewBackupService creates a BackupService writing its snapshots to dir.
+func NewBackupService(db port.DatabaseSnapshotter, dir string, logger *slog.Logger, clock port.Clock) *BackupService {
+	return &BackupService{db: db, dir: dir, logger: componentLogger(logger, "backup"), clock: clock,
+		retention: DefaultBackupRetention(), stop: make(chan struct{})}
+}
+
+// SetRetention replaces the rotation policy, applied at the next backup.
+func (b *BackupService) SetRetention(r BackupRetention) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.retention = r
+}
+
+// Dir returns the backup folder.
+func (b *BackupService) Dir() string { return b.dir }
+
+// BackupNow snapshots the database, then prunes the automatic snapshots
+// beyond the retention policy.
+func (b *BackupService) BackupNow(ctx context.Context) (*Backup, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.backup(ctx)
+}
+
+// BackupIfDue snapshots the database when the newest automatic snapshot is
+// older than BackupInterval. It returns nil when no backup was due.
+func (b *BackupService) BackupIfDue(ctx context.Context) (*Backup, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	backups, err := b.list()
+	if err != nil {
+		return nil, err
+	}
+	now := b.clock.Now()
+	for _, backup := range backups {
+		if backup.Kind == BackupAutomatic {
+			if now.Sub(backup.Time) < BackupInterval {
+				return nil, nil
+			}
+			break
+		}
+	}
+	return b.backup(ctx)
+}
+
+func (b *BackupService) backup(ctx context.Context) (*Backup, error) {
+	now := b.clock.Now()
+	path := filepath.Join(b.dir, backupPrefix+now.Format(backupTimeLayout)+".db")
+	if err := b.db.Snapshot(ctx, path); err != nil {
+		b.logger.Error("backup failed", "path", path, "error", err)
+		return nil, err
+	}
+	backup := &Backup{Path: path, Time: now, Kind: BackupAutomatic}
+	if info, err := os.Stat(path); err == nil {
+		backup.Size = info.Size()
+	}
+	b.logger.Info("backup written", "path", path, "size", backup.Size, "duration", b.clock.Now().Sub(now))
+	b.last, b.loaded = backup, true
+	if err := b.prune(); err != nil {
+		// La sauvegarde est faite ; seule la rotation a échoué.
+		b.logger.Warn("backup rotation failed", "dir", b.dir, "error", err)
+	}
+	return backup, nil
+}
+
+// ReplaceAll deletes every snapshot of the backup folder, automatic or not,
+// then takes a fresh one. Snapshots taken before encryption was enabled hold
+// the sheets and notes in clear; this leaves only an encrypted copy. It
+// returns the new snapshot and the number of files deleted.
+func (b *BackupService) ReplaceAll(ctx context.Context) (*Backup, int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	backups, err := b.list()
+	if err != nil {
+		return nil, 0, err
+	}
+	removed := 0
+	for _, backup := range backups {
+		if err := os.Remove(backup.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, removed, fmt.Errorf("failed to delete backup: %w", err)
+		}
+		removed++
+	}
+	b.last, b.loaded = nil, true
+	b.logger.Info("backups deleted", "dir", b.dir, "count", removed)
+	backup, err := b.backup(ctx)
+	return backup, removed, err
+}
+
+// List returns the snapshots of the backup folder, newest first.
+func (b *BackupService) List() ([]Backup, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.list()
+}
+
+func (b *BackupService) list() ([]Backup, error) {
+	entries, err := os.ReadDir(b.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	var backups []Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".db" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backup := Backup{Path: filepath.Join(b.dir, name), Time: info.ModTime(), Size: info.Size(), Kind: BackupOther}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), ".db")
+		if t, err := time.ParseInLocation(backupTimeLayout, stamp, b.clock.Now().Location()); err == nil {
+			backup.Time, backup.Kind = t, BackupAutomatic
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Time.After(backups[j].Time) })
+	return backups, nil
+}
+
+// LastBackup returns the newest snapshot of any kind, or nil when the folder
+// has none. The folder is read once; later backups update the cached value.
+func (b *BackupService) LastBackup() (*Backup, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.loaded {
+		backups, err := b.list()
+		if err != nil {
+			return nil, err
+		}
+		if len(backups) > 0 {
+			b.last = &backups[0]
+		}
+		b.loaded = true
+	}
+	return b.last, nil
+}
+
+// prune deletes the automatic snapshots that no retention slot keeps.
+func (b *BackupService) prune() error {
+	backups, err := b.list()
+	if err != nil {
+		return err
+	}
+	days, weeks := map[string]bool{}, map[string]bool{}
+	first := true
+	for _, backup := range backups {
+		if backup.Kind != BackupAutomatic {
+			continue
+		}
+		keep := first
+		first = false
+		day := backup.Time.Format("2006-01-02")
+		if !days[day] && len(days) < b.retention.Daily {
+			days[day] = true
+			keep = true
+		}
+		year, w := backup.Time.ISOWeek()
+		week := fmt.Sprintf("%d-%02d", year, w)
+		if !weeks[week] && len(weeks) < b.retention.Weekly {
+			weeks[week] = true
+			keep = true
+		}
+		if !keep {
+			if err := os.Remove(backup.Path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// StartScheduler blocks, taking a snapshot at start-up and then whenever
+// BackupIfDue finds the last one too old. Call Stop() to terminate it.
+func (b *BackupService) StartScheduler() {
+	b.logger.Info("automatic backups enabled", "dir", b.dir)
+	b.runIfDue()
+	ticker := b.clock.NewTicker(backupCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C():
+			b.runIfDue()
+		}
+	}
+}
+
+// Stop terminates the scheduler goroutine.
+func (b *BackupService) Stop() { close(b.stop) }
+
+func (b *BackupService) runIfDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := b.BackupIfDue(ctx); err != nil {
+		b.logger.Error("automatic backup failed", "error", err)
+	}
+}
@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// fileSnapshotter writes a placeholder file for each snapshot.
+type fileSnapshotter struct {
+	calls int
+	fail  bool
+}
+
+func (f *fileSnapshotter) Snapshot(_ context.Context, destPath string) error {
+	if f.fail {
+		return errors.New("disk full")
+	}
+	f.calls++
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(destPath, []byte("sqlite"), 0o600)
+}
+
+func backupNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestBackupService_BackupIfDue(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "backups")
+	clk := clock.NewFakeClock(fakeNow)
+	db := &fileSnapshotter{}
+	svc := service.NewBackupService(db, dir, nil, clk)
+
+	if last, err := svc.LastBackup(); err != nil || last != nil {
+		t.Fatalf("expected no backup in a missing folder, got %v, %v", last, err)
+	}
+	backup, err := svc.BackupIfDue(ctx)
+	if err != nil || backup == nil {
+		t.Fatalf("expected a first backup, got %v, %v", backup, err)
+	}
+	if filepath.Base(backup.Path) != "orus-20260310-210000.db" || backup.Kind != service.BackupAutomatic || backup.Size != 6 {
+		t.Errorf("unexpected backup %+v", backup)
+	}
+
+	clk.Advance(23 * time.Hour)
+	if backup, _ := svc.BackupIfDue(ctx); backup != nil || db.calls != 1 {
+		t.Errorf("expected no backup before BackupInterval, got %+v", backup)
+	}
+	clk.Advance(time.Hour)
+	if backup, _ := svc.BackupIfDue(ctx); backup == nil || db.calls != 2 {
+		t.Error("expected a backup once BackupInterval elapsed")
+	}
+	last, _ := svc.LastBackup()
+	if last == nil || !last.Time.Equal(clk.Now()) {
+		t.Errorf("expected the new backup as last, got %+v", last)
+	}
+
+	db.fail = true
+	clk.Advance(48 * time.Hour)
+	if _, err := svc.BackupIfDue(ctx); err == nil {
+		t.Error("expected the snapshot error")
+	}
+}
+
+func TestBackupService_Rotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	// Jeudi 1er janvier 2026 ; la dernière sauvegarde tombe le dimanche 1er mars.
+	clk := clock.NewFakeClock(time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC))
+	svc := service.NewBackupService(&fileSnapshotter{}, dir, nil, clk)
+
+	// Les fichiers qui ne suivent pas le nommage automatique ne tournent pas.
+	manual := filepath.Join(dir, "orus-pre-migration-v4-20251201-090000.db")
+	_ = os.WriteFile(manual, []byte("old"), 0o600)
+	old := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	_ = os.Chtimes(manual, old, old)
+
+	for i := 0; i < 60; i++ {
+		if _, err := svc.BackupNow(ctx); err != nil {
+			t.Fatalf("backup %d failed: %v", i, err)
+		}
+		clk.Advance(24 * time.Hour)
+	}
+
+	want := []string{
+		"orus-20260208-210000.db", "orus-20260215-210000.db", "orus-20260222-210000.db",
+		"orus-20260223-210000.db", "orus-20260224-210000.db", "orus-20260225-210000.db",
+		"orus-20260226-210000.db", "orus-20260227-210000.db", "orus-20260228-210000.db",
+		"orus-20260301-210000.db", "orus-pre-migration-v4-20251201-090000.db",
+	}
+	got := backupNames(t, dir)
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	backups, _ := svc.List()
+	if backups[0].Kind != service.BackupAutomatic || backups[len(backups)-1].Kind != service.BackupOther {
+		t.Errorf("expected newest first and the pre-migration copy last, got %+v", backups)
+	}
+
+	svc.SetRetention(service.BackupRetention{})
+	if _, err := svc.BackupNow(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := backupNames(t, dir); len(got) != 2 {
+		t.Errorf("expected the newest snapshot and the manual copy only, got %v", got)
+	}
+}
+
+func TestBackupService_ReplaceAll(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "backups")
+	clk := clock.NewFakeClock(fakeNow)
+	svc := service.NewBackupService(&fileSnapshotter{}, dir, nil, clk)
+	if _, err := svc.BackupNow(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "orus-pre-migration-v3-20260301-100000.db"), []byte("sqlite"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("a moi"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	clk.Advance(time.Minute)
+	backup, removed, err := svc.ReplaceAll(ctx)
+	if err != nil || removed != 2 {
+		t.Fatalf("expected the 2 snapshots deleted, got %d, %v", removed, err)
+	}
+	if got := backupNames(t, dir); len(got) != 2 || got[0] != "notes.txt" || got[1] != filepath.Base(backup.Path) {
+		t.Errorf("expected only the new snapshot next to the other files, got %v", got)
+	}
+	if last, _ := svc.LastBackup(); last == nil || last.Path != backup.Path {
+		t.Errorf("expected the new snapshot as last, got %+v", last)
+	}
+}
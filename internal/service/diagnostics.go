@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// DiagnosticsLogSize bounds the log excerpt of a diagnostics report.
+const DiagnosticsLogSize = 64 << 10
+
+// DiagnosticsInfo describes the installation in diagnostics reports.
+type DiagnosticsInfo struct {
+	Version  string
+	DataDir  string
+	LogFile  string // vide quand le journal n'est écrit que sur la console
+	LogLevel string
+}
+
+// DiagnosticsService bundles the recent logs and a summary of the
+// environment, to be pasted in a bug report.
+type DiagnosticsService struct {
+	logs  port.LogReader
+	books port.BookRepository
+	info  DiagnosticsInfo
+	clock port.Clock
+}
+
+// NewDiagnosticsService creates a new DiagnosticsService. logs may be nil
+// when no log file could be opened.
+func NewDiagnosticsService(logs port.LogReader, books port.BookRepository, info DiagnosticsInfo, clock port.Clock) *DiagnosticsService {
+	return &DiagnosticsService{logs: logs, books: books, info: info, clock: clock}
+}
+
+// Info returns the description of the installation.
+func (d *DiagnosticsService) Info() DiagnosticsInfo { return d.info }
+
+// Report returns the diagnostics as plain text. What cannot be read is
+// reported in the text rather than failing it: a report matters most when
+// something is broken.
+func (d *DiagnosticsService) Report(ctx context.Context) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Orus diagnostics, %s\n\n", d.clock.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&sb, "version:   %s\n", d.info.Version)
+	fmt.Fprintf(&sb, "go:        %s %s/%s, %d CPU\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	fmt.Fprintf(&sb, "data dir:  %s\n", d.info.DataDir)
+	fmt.Fprintf(&sb, "log file:  %s\n", orDash(d.info.LogFile))
+	fmt.Fprintf(&sb, "log level: %s\n", d.info.LogLevel)
+	if books, err := d.books.ListAll(ctx); err != nil {
+		fmt.Fprintf(&sb, "books:     unavailable (%v)\n", err)
+	} else {
+		fmt.Fprintf(&sb, "books:     %d\n", len(books))
+	}
+
+	sb.WriteString("\n--- recent log ---\n")
+	if d.logs == nil {
+		sb.WriteString("(no log file)\n")
+		return sb.String()
+	}
+	tail, err := d.logs.Tail(DiagnosticsLogSize)
+	if err != nil {
+		fmt.Fprintf(&sb, "(log unavailable: %v)\n", err)
+		return sb.String()
+	}
+	sb.Write(tail)
+	return sb.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+type fakeLogReader struct {
+	data []byte
+	err  error
+	max  int
+}
+
+func (f *fakeLogReader) Tail(maxBytes int) ([]byte, error) {
+	f.max = maxBytes
+	return f.data, f.err
+}
+
+func TestDiagnosticsService_Report(t *testing.T) {
+	logs := &fakeLogReader{data: []byte("level=ERROR msg=\"book not saved\" component=library\n")}
+	info := service.DiagnosticsInfo{Version: "1.4.0", DataDir: "/home/lea/orus", LogFile: "/home/lea/orus/logs/orus.log", LogLevel: "DEBUG"}
+	svc := service.NewDiagnosticsService(logs, &mockLibBookRepo{}, info, clock.NewFakeClock(fakeNow))
+
+	report := svc.Report(context.Background())
+	for _, want := range []string{
+		"Orus diagnostics, 2026-03-10T21:00:00Z",
+		"version:   1.4.0",
+		"data dir:  /home/lea/orus",
+		"log file:  /home/lea/orus/logs/orus.log",
+		"log level: DEBUG",
+		"books:     1",
+		"--- recent log ---\nlevel=ERROR msg=\"book not saved\"",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report lacks %q:\n%s", want, report)
+		}
+	}
+	if logs.max != service.DiagnosticsLogSize {
+		t.Errorf("expected the log to be bounded to %d bytes, got %d", service.DiagnosticsLogSize, logs.max)
+	}
+}
+
+func TestDiagnosticsService_ReportsWhatIsUnavailable(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(fakeNow)
+
+	report := service.NewDiagnosticsService(&fakeLogReader{err: errors.New("disque plein")}, &mockLibBookRepo{failList: true},
+		service.DiagnosticsInfo{}, clk).Report(ctx)
+	for _, want := range []string{"books:     unavailable (db list error)", "(log unavailable: disque plein)", "log file:  -"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report lacks %q:\n%s", want, report)
+		}
+	}
+
+	report = service.NewDiagnosticsService(nil, &mockLibBookRepo{}, service.DiagnosticsInfo{}, clk).Report(ctx)
+	if !strings.HasSuffix(report, "(no log file)\n") {
+		t.Errorf("expected a report without log file, got:\n%s", report)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// MinPassphraseLength is the shortest passphrase accepted for encryption.
+const MinPassphraseLength = 8
+
+// ErrWeakPassphrase indicates a passphrase shorter than MinPassphraseLength.
+var ErrWeakPassphrase = fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+
+// EncryptionService drives the opt-in encryption of reading sheet summaries,
+// quotes and annotation notes. The work is done by the store; the service
+// validates passphrases and is what the UI talks to.
+type EncryptionService struct {
+	store port.EncryptedStore
+}
+
+// NewEncryptionService creates a new EncryptionService over store.
+func NewEncryptionService(store port.EncryptedStore) *EncryptionService {
+	return &EncryptionService{store: store}
+}
+
+// Enabled reports whether sensitive columns are encrypted.
+func (e *EncryptionService) Enabled() bool { return e.store.EncryptionEnabled() }
+
+// Locked reports whether the passphrase must be entered before sheets and
+// notes can be read.
+func (e *EncryptionService) Locked() bool { return e.store.Locked() }
+
+// Unlock loads the key; it returns port.ErrWrongPassphrase on mismatch.
+func (e *EncryptionService) Unlock(passphrase string) error {
+	if passphrase == "" {
+		return port.ErrWrongPassphrase
+	}
+	return e.store.Unlock(passphrase)
+}
+
+// Enable encrypts the existing sheets and notes with passphrase.
+func (e *EncryptionService) Enable(ctx context.Context, passphrase string) error {
+	if len([]rune(passphrase)) < MinPassphraseLength {
+		return ErrWeakPassphrase
+	}
+	return e.store.EnableEncryption(ctx, passphrase)
+}
+
+// ChangePassphrase re-encrypts everything under next.
+func (e *EncryptionService) ChangePassphrase(ctx context.Context, current, next string) error {
+	if len([]rune(next)) < MinPassphraseLength {
+		return ErrWeakPassphrase
+	}
+	if current == next {
+		return errors.New("new passphrase must differ from the current one")
+	}
+	return e.store.ChangePassphrase(ctx, current, next)
+}
+
+// Disable stores sheets and notes in clear again.
+func (e *EncryptionService) Disable(ctx context.Context, passphrase string) error {
+	return e.store.DisableEncryption(ctx, passphrase)
+}
+
+type decryptionKey struct{}
+
+// WithDecryption marks ctx as an explicit request to include encrypted
+// fields in exports. Without it, a SharingService set up with SetEncryption
+// leaves summaries, quotes and notes out while encryption is enabled.
+func WithDecryption(ctx context.Context) context.Context {
+	return context.WithValue(ctx, decryptionKey{}, true)
+}
+
+func decryptionRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(decryptionKey{}).(bool)
+	return requested
+}
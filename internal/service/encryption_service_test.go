@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// fakeEncryptedStore keeps the passphrase in clear; only the state matters here.
+type fakeEncryptedStore struct {
+	passphrase string
+	locked     bool
+}
+
+func (f *fakeEncryptedStore) EncryptionEnabled() bool { return f.passphrase != "" }
+func (f *fakeEncryptedStore) Locked() bool            { return f.locked }
+
+func (f *fakeEncryptedStore) Unlock(passphrase string) error {
+	if passphrase != f.passphrase {
+		return port.ErrWrongPassphrase
+	}
+	f.locked = false
+	return nil
+}
+
+func (f *fakeEncryptedStore) EnableEncryption(_ context.Context, passphrase string) error {
+	f.passphrase = passphrase
+	return nil
+}
+
+func (f *fakeEncryptedStore) ChangePassphrase(_ context.Context, current, next string) error {
+	if current != f.passphrase {
+		return port.ErrWrongPassphrase
+	}
+	f.passphrase = next
+	return nil
+}
+
+func (f *fakeEncryptedStore) DisableEncryption(_ context.Context, passphrase string) error {
+	if passphrase != f.passphrase {
+		return port.ErrWrongPassphrase
+	}
+	f.passphrase = ""
+	return nil
+}
+
+func TestEncryptionService_Passphrases(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeEncryptedStore{}
+	svc := service.NewEncryptionService(store)
+
+	if err := svc.Enable(ctx, "court"); !errors.Is(err, service.ErrWeakPassphrase) {
+		t.Errorf("expected ErrWeakPassphrase, got %v", err)
+	}
+	if err := svc.Enable(ctx, "phrase de passe"); err != nil || !svc.Enabled() {
+		t.Fatalf("expected encryption enabled, got %v", err)
+	}
+	store.locked = true
+	if err := svc.Unlock(""); !errors.Is(err, port.ErrWrongPassphrase) || !svc.Locked() {
+		t.Errorf("expected an empty passphrase to be rejected, got %v", err)
+	}
+	if err := svc.Unlock("phrase de passe"); err != nil || svc.Locked() {
+		t.Errorf("expected unlock, got %v", err)
+	}
+	if err := svc.ChangePassphrase(ctx, "phrase de passe", "phrase de passe"); err == nil {
+		t.Error("expected an unchanged passphrase to be rejected")
+	}
+	if err := svc.ChangePassphrase(ctx, "mauvaise phrase", "nouvelle phrase"); !errors.Is(err, port.ErrWrongPassphrase) {
+		t.Errorf("expected ErrWrongPassphrase, got %v", err)
+	}
+	if err := svc.ChangePassphrase(ctx, "phrase de passe", "nouvelle phrase"); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+	if err := svc.Disable(ctx, "nouvelle phrase"); err != nil || svc.Enabled() {
+		t.Errorf("expected encryption disabled, got %v", err)
+	}
+}
+
+func TestSharingService_RedactsEncryptedFields(t *testing.T) {
+	ctx := context.Background()
+	books := &mockSharingBookRepo{books: []*domain.Book{{ID: "b1", Title: "Journal", Author: "Moi"}}}
+	sheets := newMockSharingSheetRepo()
+	sheet, _ := domain.NewReadingSheet("b1", "Journal", "Pensees intimes", 3, []string{"Une citation privee"}, []string{"perso"}, fakeNow)
+	sheets.sheets[sheet.ID] = sheet
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 4, Note: "note secrete", CreatedAt: fakeNow},
+	}}
+	store := &fakeEncryptedStore{passphrase: "phrase de passe"}
+	svc := service.NewSharingService(books, sheets, nil, nil, annots, nil, clock.NewFakeClock(fakeNow))
+	svc.SetEncryption(store)
+
+	export := func(ctx context.Context) string {
+		t.Helper()
+		path, err := svc.ExportLibrary(ctx, service.ShareFormatJSON, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		return string(data)
+	}
+
+	redacted := export(ctx)
+	for _, secret := range []string{"Pensees intimes", "Une citation privee", "note secrete"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("expected %q to be left out without WithDecryption", secret)
+		}
+	}
+	if !strings.Contains(redacted, "perso") || !strings.Contains(redacted, `"page_no": 4`) {
+		t.Errorf("expected tags and page locators to stay, got:\n%s", redacted)
+	}
+	if sheet.Summary != "Pensees intimes" || annots.annotations[0].Note != "note secrete" {
+		t.Error("expected the repository values to be left untouched")
+	}
+
+	if clear := export(service.WithDecryption(ctx)); !strings.Contains(clear, "Pensees intimes") || !strings.Contains(clear, "note secrete") {
+		t.Errorf("expected the clear text on demand, got:\n%s", clear)
+	}
+
+	store.passphrase = ""
+	if clear := export(ctx); !strings.Contains(clear, "Une citation privee") {
+		t.Error("expected nothing to be left out once encryption is disabled")
+	}
+}
+
+func TestVaultSyncService_RedactsEncryptedFields(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultFixture(t)
+	f.annots.annotations[0].Note = "note secrete"
+	store := &fakeEncryptedStore{passphrase: "phrase de passe"}
+	f.vault.SetEncryption(store)
+	f.sheetSvc.SetEvents(eventFunc(f.vault.HandleEvent))
+	if err := f.vault.SetVault(service.VaultConfig{Dir: f.dir, Auto: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.sheetSvc.CreateSheet(ctx, f.book.ID, "Pensees intimes", 4, []string{"Une citation privee"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	f.vault.Wait()
+	note := f.read(t, "Dune- Le Messie.md")
+	for _, secret := range []string{"Pensees intimes", "Une citation privee", "note secrete"} {
+		if strings.Contains(note, secret) {
+			t.Errorf("expected %q to stay out of the vault, got:\n%s", secret, note)
+		}
+	}
+	if !strings.Contains(note, "rating: 4") {
+		t.Errorf("expected the unencrypted fields to be synced, got:\n%s", note)
+	}
+
+	if _, err := f.vault.SyncAll(service.WithDecryption(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	if note := f.read(t, "Dune- Le Messie.md"); !strings.Contains(note, "Pensees intimes") {
+		t.Errorf("expected the clear text on demand, got:\n%s", note)
+	}
+}
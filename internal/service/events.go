@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// eventSink publishes the events of a service. Without a publisher, set
+// through the SetEvents of the service, events go nowhere.
+type eventSink struct {
+	pub port.EventPublisher
+}
+
+func (e eventSink) publish(ctx context.Context, event domain.Event) {
+	if e.pub != nil {
+		e.pub.Publish(ctx, event)
+	}
+}
+
+// eventQueue holds the events of a unit of work, to publish them once it
+// commits.
+type eventQueue []domain.Event
+
+func (q *eventQueue) Publish(_ context.Context, event domain.Event) { *q = append(*q, event) }
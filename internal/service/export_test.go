@@ -0,0 +1,6 @@
+package service
+
+import "time"
+
+// CheckDueReminders exposes the scheduler tick to the external test package.
+func (s *ReminderService) CheckDueReminders(now time.Time) { s.checkDueReminders(now) }
@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 	"github.com/MiltonJ23/Orus/internal/port"
@@ -15,44 +16,132 @@ var ErrBookAlreadyExists = errors.New("book already exists")
 
 // LibraryService handles book import and library management.
 type LibraryService struct {
-	repo      port.BookRepository
-	extractor port.MetadataExtractor
+	repo         port.BookRepository
+	sheetRepo    port.ReadingSheetRepository
+	reminderRepo port.ReminderRepository
+	extractor    port.MetadataExtractor
+	logger       *slog.Logger
+	clock        port.Clock
+	booksDir     string // dossier des livres téléchargés depuis un catalogue
+	events       eventSink
+	tx           txRunner
 }
 
 // NewLibraryService creates a new LibraryService with the given dependencies.
-func NewLibraryService(repo port.BookRepository, extractor port.MetadataExtractor) *LibraryService {
-	return &LibraryService{repo, extractor}
+// sheetRepo may be nil; imports from external libraries then skip reading
+// sheets. reminderRepo may be nil; deleting a book then keeps its reminders.
+func NewLibraryService(repo port.BookRepository, sheetRepo port.ReadingSheetRepository, reminderRepo port.ReminderRepository,
+	extractor port.MetadataExtractor, logger *slog.Logger, clock port.Clock) *LibraryService {
+	return &LibraryService{repo: repo, sheetRepo: sheetRepo, reminderRepo: reminderRepo, extractor: extractor,
+		logger: componentLogger(logger, "library"), clock: clock}
+}
+
+// SetBooksDir sets the folder receiving the books downloaded from catalogs.
+func (l *LibraryService) SetBooksDir(dir string) {
+	l.booksDir = dir
+}
+
+// SetEvents sets the publisher of the BookImported and BookDeleted events,
+// and of the SheetUpdated events of the sheets created by an import.
+func (l *LibraryService) SetEvents(pub port.EventPublisher) { l.events.pub = pub }
+
+// SetUnitOfWork makes the deletion of a book and the import of a book with its
+// reading sheet atomic.
+func (l *LibraryService) SetUnitOfWork(uow port.UnitOfWork) { l.tx.uow = uow }
+
+// repositories returns the repositories of the service, for txRunner.run.
+func (l *LibraryService) repositories() port.Repositories {
+	return port.Repositories{Books: l.repo, Sheets: l.sheetRepo, Reminders: l.reminderRepo}
 }
 
 // ImportBook imports a single book by file path.
 func (l *LibraryService) ImportBook(ctx context.Context, filePath string) (*domain.Book, error) {
-	log.Printf("[Import] Tentative : %s", filePath)
+	return l.importBook(ctx, filePath, nil)
+}
+
+// importBook imports the file at filePath. The non-empty fields of known,
+// the description of the book by a catalog, take precedence over the file
+// metadata; its comments and tags go to a new reading sheet.
+func (l *LibraryService) importBook(ctx context.Context, filePath string, known *port.ExternalBook) (*domain.Book, error) {
+	start := l.clock.Now()
+	l.logger.Debug("import started", "path", filePath)
 
 	metadata, err := l.extractor.ExtractInfo(ctx, filePath)
 	if err != nil {
-		log.Printf("[Import] Echec extraction metadonnees : %v", err)
+		l.logger.Warn("metadata extraction failed", "path", filePath, "error", err)
 		return nil, fmt.Errorf("extraction metadonnees : %w", err)
 	}
-	log.Printf("[Import] Metadonnees OK — titre=%q auteur=%q pages=%d", metadata.Title, metadata.Author, metadata.TotalPages)
+	l.logger.Debug("metadata extracted", "path", filePath, "title", metadata.Title, "author", metadata.Author, "pages", metadata.TotalPages)
+	if known != nil {
+		prefill(metadata, known)
+	}
 
-	book, err := domain.NewBook(metadata.Title, metadata.Author, metadata.FilePath, metadata.Format, metadata.TotalPages)
+	now := l.clock.Now()
+	book, err := domain.NewBook(metadata.Title, metadata.Author, metadata.FilePath, metadata.Format, metadata.TotalPages, now)
 	if err != nil {
-		log.Printf("[Import] Echec creation domaine : %v", err)
+		l.logger.Warn("invalid book metadata", "path", filePath, "error", err)
 		return nil, fmt.Errorf("creation livre : %w", err)
 	}
+	book.ISBN, book.Publisher, book.Year = metadata.ISBN, metadata.Publisher, metadata.Year
+	if known != nil {
+		book.Series, book.SeriesIndex, book.CoverImage = known.Series, known.SeriesIndex, known.Cover
+	}
 
-	if err := l.repo.Save(ctx, book); err != nil {
-		log.Printf("[Import] Echec sauvegarde BDD : %v", err)
-		return nil, fmt.Errorf("sauvegarde BDD : %w", err)
+	var sheet *domain.ReadingSheet
+	if known != nil && l.sheetRepo != nil {
+		// Une fiche invalide ne fait pas échouer l'import : le livre arrive sans elle.
+		if sheet, err = newExternalSheet(book, *known, now); err != nil {
+			l.logger.Warn("reading sheet not created", "book_id", book.ID, "error", err)
+			sheet = nil
+		}
+	}
+	// Avec une unité de travail, jamais de livre importé sans sa fiche valide.
+	err = l.tx.run(ctx, l.repositories(), func(repos port.Repositories) error {
+		if err := repos.Books.Save(ctx, book); err != nil {
+			return fmt.Errorf("sauvegarde BDD : %w", err)
+		}
+		if sheet != nil {
+			if err := repos.Sheets.SaveSheet(ctx, sheet); err != nil {
+				return fmt.Errorf("sauvegarde fiche : %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		l.logger.Error("book not saved", "path", filePath, "error", err)
+		return nil, err
 	}
 
-	log.Printf("[Import] Succes : %q (id=%s)", book.Title, book.ID)
+	l.logger.Info("book imported", "book_id", book.ID, "title", book.Title, "path", filePath, "duration", l.clock.Now().Sub(start))
+	l.events.publish(ctx, domain.BookImported{Book: book})
+	if sheet != nil {
+		l.events.publish(ctx, domain.SheetUpdated{BookID: book.ID, SheetID: sheet.ID})
+	}
 	return book, nil
 }
 
+// prefill replaces the file metadata with the non-empty fields of known.
+func prefill(metadata *domain.BookMetadata, known *port.ExternalBook) {
+	if known.Title != "" {
+		metadata.Title = known.Title
+	}
+	if len(known.Authors) > 0 {
+		metadata.Author = strings.Join(known.Authors, " & ")
+	}
+	if known.ISBN != "" {
+		metadata.ISBN = known.ISBN
+	}
+	if known.Publisher != "" {
+		metadata.Publisher = known.Publisher
+	}
+	if known.Year > 0 {
+		metadata.Year = known.Year
+	}
+}
+
 // ImportBooks imports multiple books; returns successes and per-file errors.
 func (l *LibraryService) ImportBooks(ctx context.Context, filePaths []string) ([]*domain.Book, []error) {
-	log.Printf("[Import] %d fichier(s) recu(s)", len(filePaths))
+	l.logger.Info("importing files", "count", len(filePaths))
 	var books []*domain.Book
 	var errs []error
 	for _, fp := range filePaths {
@@ -71,7 +160,31 @@ func (l *LibraryService) GetLibrary(ctx context.Context) ([]*domain.Book, error)
 	return l.repo.ListAll(ctx)
 }
 
-// DeleteBook permanently removes a book.
+// DeleteBook permanently removes a book; the storage deletes its sessions,
+// annotations and sheet with it. With a unit of work, the reminders of the
+// book go too, in the same transaction.
 func (l *LibraryService) DeleteBook(ctx context.Context, bookID string) error {
-	return l.repo.Delete(ctx, bookID)
+	err := l.tx.run(ctx, l.repositories(), func(repos port.Repositories) error {
+		if repos.Reminders != nil {
+			// Les rappels n'ont pas de clé étrangère : rien ne les supprime en cascade.
+			reminders, err := repos.Reminders.ListAllReminders(ctx)
+			if err != nil {
+				return fmt.Errorf("lecture rappels : %w", err)
+			}
+			for _, r := range reminders {
+				if r.BookID != bookID {
+					continue
+				}
+				if err := repos.Reminders.DeleteReminder(ctx, r.ID); err != nil {
+					return fmt.Errorf("suppression rappel : %w", err)
+				}
+			}
+		}
+		return repos.Books.Delete(ctx, bookID)
+	})
+	if err != nil {
+		return err
+	}
+	l.events.publish(ctx, domain.BookDeleted{BookID: bookID})
+	return nil
 }
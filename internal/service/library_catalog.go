@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// ErrNoReadableFile indicates a catalog book without an EPUB or PDF file.
+var ErrNoReadableFile = errors.New("no EPUB or PDF file")
+
+// maxCoverSize bounds the cover images downloaded from catalogs.
+const maxCoverSize = 10 << 20
+
+// ImportFromCatalog downloads a book of a remote catalog into the books
+// folder and imports it like ImportBook, with the title, authors, ISBN,
+// publisher and year of the catalog taking precedence over the file
+// metadata. The cover is downloaded too, and the summary and tags go to a
+// new reading sheet.
+//
+// A book already in the library, matched by title and author, is not
+// downloaded again: ImportFromCatalog then returns ErrBookAlreadyExists.
+func (l *LibraryService) ImportFromCatalog(ctx context.Context, client port.CatalogClient, entry port.CatalogEntry) (*domain.Book, error) {
+	if l.booksDir == "" {
+		return nil, errors.New("no folder for downloaded books")
+	}
+	file, ok := pickCatalogFile(entry.Files)
+	if !ok {
+		return nil, fmt.Errorf("%q : %w", entry.Title, ErrNoReadableFile)
+	}
+	existing, err := l.repo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lecture bibliotheque : %w", err)
+	}
+	m := newBookMatcher(existing)
+	for _, author := range append([]string{strings.Join(entry.Authors, " & ")}, entry.Authors...) {
+		if found, _ := m.match(LibraryEntry{Book: &domain.Book{Title: entry.Title, Author: author}}); found != nil {
+			return found, fmt.Errorf("%q : %w", entry.Title, ErrBookAlreadyExists)
+		}
+	}
+
+	l.logger.Info("downloading from catalog", "title", entry.Title, "url", file.URL)
+	dest, err := l.downloadCatalogFile(ctx, client, entry, file)
+	if err != nil {
+		return nil, err
+	}
+	known := &port.ExternalBook{
+		SourceID:  entry.ID,
+		Title:     entry.Title,
+		Authors:   entry.Authors,
+		Tags:      entry.Tags,
+		Comments:  entry.Summary,
+		ISBN:      entry.ISBN,
+		Publisher: entry.Publisher,
+		Year:      entry.Year,
+		FilePath:  dest,
+		Format:    file.Format,
+	}
+	if entry.Cover != "" {
+		if known.Cover, err = downloadCover(ctx, client, entry.Cover); err != nil {
+			l.logger.Warn("catalog cover unavailable", "title", entry.Title, "url", entry.Cover, "error", err)
+		}
+	}
+	book, err := l.importBook(ctx, dest, known)
+	if err != nil {
+		os.Remove(dest)
+		return nil, err
+	}
+	return book, nil
+}
+
+// pickCatalogFile returns the EPUB file of a catalog book, else its PDF.
+func pickCatalogFile(files []port.CatalogFile) (port.CatalogFile, bool) {
+	for _, format := range []domain.BookFormat{domain.FormatEPUB, domain.FormatPDF} {
+		for _, f := range files {
+			if f.Format == format {
+				return f, true
+			}
+		}
+	}
+	return port.CatalogFile{}, false
+}
+
+// downloadCatalogFile writes file into the books folder under a name that
+// does not exist yet, and returns its path. A failed download leaves nothing
+// behind.
+func (l *LibraryService) downloadCatalogFile(ctx context.Context, client port.CatalogClient, entry port.CatalogEntry, file port.CatalogFile) (string, error) {
+	body, suggested, err := client.Download(ctx, file.URL)
+	if err != nil {
+		return "", fmt.Errorf("telechargement %q : %w", entry.Title, err)
+	}
+	defer body.Close()
+	if err := os.MkdirAll(l.booksDir, 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(l.booksDir, ".download-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(tmp, body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("telechargement %q : %w", entry.Title, err)
+	}
+
+	name := catalogFileName(suggested, file, entry.Title)
+	ext := filepath.Ext(name)
+	dest := filepath.Join(l.booksDir, name)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(dest); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		dest = filepath.Join(l.booksDir, fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), i, ext))
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return dest, nil
+}
+
+// catalogFileName chooses the local name of a downloaded book: the name the
+// server suggests, else the last segment of the URL, else the title. The
+// extension always matches the format, since the extractor relies on it.
+func catalogFileName(suggested string, file port.CatalogFile, title string) string {
+	ext := "." + strings.ToLower(string(file.Format))
+	name := strings.TrimSpace(filepath.Base(filepath.FromSlash(suggested)))
+	if !strings.EqualFold(filepath.Ext(name), ext) {
+		name = ""
+		if u, err := url.Parse(file.URL); err == nil {
+			if base, err := url.PathUnescape(path.Base(u.Path)); err == nil && strings.EqualFold(path.Ext(base), ext) {
+				name = base
+			}
+		}
+	}
+	if name == "" {
+		name = title + ext
+	}
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	if strings.HasPrefix(name, ".") {
+		name = "livre" + name
+	}
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ext
+}
+
+func downloadCover(ctx context.Context, client port.CatalogClient, coverURL string) ([]byte, error) {
+	body, _, err := client.Download(ctx, coverURL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxCoverSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxCoverSize {
+		return nil, errors.New("cover too large")
+	}
+	return data, nil
+}
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// fakeCatalog serves files by URL; names are the suggested file names.
+type fakeCatalog struct {
+	files map[string]string
+	names map[string]string
+}
+
+func (f *fakeCatalog) Root(context.Context) (*port.CatalogFeed, error) { return nil, nil }
+func (f *fakeCatalog) Fetch(context.Context, string) (*port.CatalogFeed, error) {
+	return nil, nil
+}
+func (f *fakeCatalog) Search(context.Context, string, string) (*port.CatalogFeed, error) {
+	return nil, nil
+}
+
+func (f *fakeCatalog) Download(_ context.Context, url string) (io.ReadCloser, string, error) {
+	data, ok := f.files[url]
+	if !ok {
+		return nil, "", errors.New("404 Not Found")
+	}
+	return io.NopCloser(strings.NewReader(data)), f.names[url], nil
+}
+
+func TestLibraryService_ImportFromCatalog(t *testing.T) {
+	ctx := context.Background()
+	newFixture := func() (*service.LibraryService, *mockAnnotBookRepo, *mockSharingSheetRepo, string) {
+		books := &mockAnnotBookRepo{}
+		sheets := newMockSharingSheetRepo()
+		svc := service.NewLibraryService(books, sheets, nil, &pathExtractor{}, nil, clock.NewFakeClock(fakeNow))
+		dir := filepath.Join(t.TempDir(), "books")
+		svc.SetBooksDir(dir)
+		return svc, books, sheets, dir
+	}
+	catalog := &fakeCatalog{
+		files: map[string]string{
+			"http://calibre/get/pdf/1":  "pdf",
+			"http://calibre/get/epub/1": "epub",
+			"http://calibre/cover/1":    "\xFF\xD8",
+			"http://calibre/get/epub/2": "autre epub",
+		},
+		names: map[string]string{"http://calibre/get/epub/1": "Dune - Frank Herbert.epub", "http://calibre/get/epub/2": "Dune - Frank Herbert.epub"},
+	}
+	dune := port.CatalogEntry{
+		ID: "urn:uuid:1", Title: "Dune", Authors: []string{"Frank Herbert"}, Summary: "Arrakis.",
+		Tags: []string{"sf"}, Publisher: "Chilton", Year: 1965, ISBN: "9780441013593",
+		Files: []port.CatalogFile{
+			{URL: "http://calibre/get/pdf/1", Format: domain.FormatPDF},
+			{URL: "http://calibre/get/mobi/1"},
+			{URL: "http://calibre/get/epub/1", Format: domain.FormatEPUB},
+		},
+		Cover: "http://calibre/cover/1",
+	}
+
+	t.Run("Import", func(t *testing.T) {
+		svc, books, sheets, dir := newFixture()
+		book, err := svc.ImportFromCatalog(ctx, catalog, dune)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if book.FilePath != filepath.Join(dir, "Dune - Frank Herbert.epub") || book.Title != "Dune" || book.Author != "Frank Herbert" ||
+			book.ISBN != "9780441013593" || book.Year != 1965 || book.Publisher != "Chilton" || book.TotalPages != 250 || len(book.CoverImage) != 2 {
+			t.Fatalf("unexpected book: %+v", book)
+		}
+		if data, _ := os.ReadFile(book.FilePath); string(data) != "epub" {
+			t.Errorf("expected the EPUB file to be downloaded, got %q", data)
+		}
+		if len(books.books) != 1 {
+			t.Errorf("expected one saved book, got %d", len(books.books))
+		}
+		sheet, _ := sheets.GetSheetByBookID(ctx, book.ID)
+		if sheet == nil || sheet.Summary != "Arrakis." || len(sheet.Tags) != 1 {
+			t.Errorf("unexpected sheet: %+v", sheet)
+		}
+
+		if _, err := svc.ImportFromCatalog(ctx, catalog, dune); !errors.Is(err, service.ErrBookAlreadyExists) {
+			t.Errorf("expected ErrBookAlreadyExists, got: %v", err)
+		}
+		other := port.CatalogEntry{Title: "Dune", Authors: []string{"Autre"},
+			Files: []port.CatalogFile{{URL: "http://calibre/get/epub/2", Format: domain.FormatEPUB}}}
+		book2, err := svc.ImportFromCatalog(ctx, catalog, other)
+		if err != nil || filepath.Base(book2.FilePath) != "Dune - Frank Herbert (2).epub" {
+			t.Errorf("expected a second file name, got %v, %v", book2, err)
+		}
+	})
+
+	t.Run("NoReadableFile", func(t *testing.T) {
+		svc, _, _, _ := newFixture()
+		entry := port.CatalogEntry{Title: "Mobi", Files: []port.CatalogFile{{URL: "http://calibre/get/mobi/1", Type: "application/x-mobipocket-ebook"}}}
+		if _, err := svc.ImportFromCatalog(ctx, catalog, entry); !errors.Is(err, service.ErrNoReadableFile) {
+			t.Errorf("expected ErrNoReadableFile, got: %v", err)
+		}
+	})
+
+	t.Run("DownloadError", func(t *testing.T) {
+		svc, books, _, dir := newFixture()
+		entry := port.CatalogEntry{Title: "Absent", Files: []port.CatalogFile{{URL: "http://calibre/get/epub/9", Format: domain.FormatEPUB}}}
+		if _, err := svc.ImportFromCatalog(ctx, catalog, entry); err == nil {
+			t.Fatal("expected a download error")
+		}
+		files, _ := os.ReadDir(dir)
+		if len(files) != 0 || len(books.books) != 0 {
+			t.Errorf("expected nothing left behind, got %d file(s)", len(files))
+		}
+	})
+	t.Run("SheetNotSaved", func(t *testing.T) {
+		store := memory.NewStorage()
+		svc := service.NewLibraryService(store, store, store, &pathExtractor{}, nil, clock.NewFakeClock(fakeNow))
+		dir := filepath.Join(t.TempDir(), "books")
+		svc.SetBooksDir(dir)
+		svc.SetUnitOfWork(failingUoW{store: store, fail: "sheets"})
+		if _, err := svc.ImportFromCatalog(ctx, catalog, dune); !errors.Is(err, errDiskFull) {
+			t.Fatalf("expected the storage error, got: %v", err)
+		}
+		if books, _ := store.ListAll(ctx); len(books) != 0 {
+			t.Errorf("expected the book rolled back with its sheet, got %d book(s)", len(books))
+		}
+		if files, _ := os.ReadDir(dir); len(files) != 0 {
+			t.Errorf("expected the downloaded file removed, got %d file(s)", len(files))
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// ConflictUnreadableFile: the book file exists but its metadata cannot be extracted.
+const ConflictUnreadableFile ConflictKind = "unreadable_file"
+
+// ImportProgress is called after each book of an external library import,
+// with the number of books processed so far.
+type ImportProgress func(done, total int, title string)
+
+// ImportFromSource imports the books of an external library such as Calibre.
+//
+// Books already in the library, matched by file path or by title and
+// author, are skipped and counted in BooksMatched. New books reference the
+// external file in place, keep their series and cover, and get a reading
+// sheet holding the comments, rating and tags. Books without an EPUB or PDF
+// file are reported as ConflictMissingFile.
+//
+// A dry run neither writes nor opens the book files, so files that turn out
+// unreadable only show up as ConflictUnreadableFile during the real import.
+func (l *LibraryService) ImportFromSource(ctx context.Context, src port.LibrarySource, opts ImportOptions, progress ImportProgress) (*ImportReport, error) {
+	external, err := src.ListBooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lecture bibliotheque externe : %w", err)
+	}
+	existing, err := l.repo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lecture bibliotheque : %w", err)
+	}
+	start := l.clock.Now()
+	l.logger.Info("external library import started", "books", len(external), "dry_run", opts.DryRun)
+
+	m := newBookMatcher(existing)
+	report := &ImportReport{DryRun: opts.DryRun}
+	for i, eb := range external {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if err := l.importExternalBook(ctx, m, eb, report, opts); err != nil {
+			return report, err
+		}
+		if progress != nil {
+			progress(i+1, len(external), eb.Title)
+		}
+	}
+	l.logger.Info("external library import finished", "added", report.BooksAdded, "matched", report.BooksMatched,
+		"conflicts", len(report.Conflicts), "dry_run", opts.DryRun, "duration", l.clock.Now().Sub(start))
+	return report, nil
+}
+
+func (l *LibraryService) importExternalBook(ctx context.Context, m *bookMatcher, eb port.ExternalBook, report *ImportReport, opts ImportOptions) error {
+	if eb.FilePath == "" {
+		report.Conflicts = append(report.Conflicts, ImportConflict{ConflictMissingFile, eb.Title, "aucun fichier EPUB ou PDF"})
+		return nil
+	}
+	author := strings.Join(eb.Authors, " & ")
+	if found, _ := m.match(LibraryEntry{Book: &domain.Book{Title: eb.Title, Author: author, FilePath: eb.FilePath}}); found != nil {
+		report.BooksMatched++
+		return nil
+	}
+	if len(eb.Authors) > 1 {
+		if found, _ := m.match(LibraryEntry{Book: &domain.Book{Title: eb.Title, Author: eb.Authors[0]}}); found != nil {
+			report.BooksMatched++
+			return nil
+		}
+	}
+
+	pages := 0
+	var metadata *domain.BookMetadata
+	if !opts.DryRun && l.extractor != nil {
+		var err error
+		metadata, err = l.extractor.ExtractInfo(ctx, eb.FilePath)
+		if err != nil {
+			l.logger.Warn("metadata extraction failed", "path", eb.FilePath, "error", err)
+			report.Conflicts = append(report.Conflicts, ImportConflict{ConflictUnreadableFile, eb.Title, err.Error()})
+			return nil
+		}
+		pages = metadata.TotalPages
+	}
+
+	now := l.clock.Now()
+	book, err := domain.NewBook(eb.Title, author, eb.FilePath, eb.Format, pages, now)
+	if err != nil {
+		report.Conflicts = append(report.Conflicts, ImportConflict{ConflictUnreadableFile, eb.Title, err.Error()})
+		return nil
+	}
+	book.Series = eb.Series
+	book.SeriesIndex = eb.SeriesIndex
+	book.CoverImage = eb.Cover
+	if metadata != nil {
+		book.ISBN, book.Publisher, book.Year = metadata.ISBN, metadata.Publisher, metadata.Year
+	}
+	var sheet *domain.ReadingSheet
+	if l.sheetRepo != nil {
+		// Une fiche invalide ne fait pas échouer l'import : le livre arrive sans elle.
+		if sheet, err = newExternalSheet(book, eb, now); err != nil {
+			l.logger.Warn("reading sheet not created", "book_id", book.ID, "error", err)
+			sheet = nil
+		}
+	}
+	if !opts.DryRun {
+		// Avec une unité de travail, jamais de livre importé sans sa fiche valide.
+		err := l.tx.run(ctx, l.repositories(), func(repos port.Repositories) error {
+			if err := repos.Books.Save(ctx, book); err != nil {
+				return fmt.Errorf("sauvegarde BDD %q : %w", book.Title, err)
+			}
+			if sheet != nil {
+				if err := repos.Sheets.SaveSheet(ctx, sheet); err != nil {
+					return fmt.Errorf("sauvegarde fiche %q : %w", book.Title, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		l.events.publish(ctx, domain.BookImported{Book: book})
+		if sheet != nil {
+			l.events.publish(ctx, domain.SheetUpdated{BookID: book.ID, SheetID: sheet.ID})
+		}
+	}
+	m.add(book)
+	report.BooksAdded++
+	if sheet != nil {
+		report.SheetsAdded++
+	}
+	return nil
+}
+
+// newExternalSheet returns the reading sheet holding the comments, rating
+// and tags of eb, or nil when it has none of them.
+func newExternalSheet(book *domain.Book, eb port.ExternalBook, now time.Time) (*domain.ReadingSheet, error) {
+	if eb.Comments == "" && eb.Rating == 0 && len(eb.Tags) == 0 {
+		return nil, nil
+	}
+	return domain.NewReadingSheet(book.ID, book.Title, eb.Comments, eb.Rating, nil, eb.Tags, now)
+}
@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+type fakeLibrarySource struct {
+	books []port.ExternalBook
+	err   error
+}
+
+func (f *fakeLibrarySource) ListBooks(_ context.Context) ([]port.ExternalBook, error) {
+	return f.books, f.err
+}
+
+// pathExtractor returns 250 pages for every file except those in unreadable.
+type pathExtractor struct {
+	unreadable map[string]bool
+}
+
+func (p *pathExtractor) ExtractInfo(_ context.Context, path string) (*domain.BookMetadata, error) {
+	if p.unreadable[path] {
+		return nil, errors.New("fichier corrompu")
+	}
+	return &domain.BookMetadata{Title: "ignored", FilePath: path, Format: domain.FormatEPUB, TotalPages: 250}, nil
+}
+
+func calibreFixture() *fakeLibrarySource {
+	return &fakeLibrarySource{books: []port.ExternalBook{
+		{SourceID: "1", Title: "Dune", Authors: []string{"Frank Herbert"}, Series: "Dune", SeriesIndex: 1,
+			Tags: []string{"sf"}, Rating: 5, Comments: "Arrakis.", FilePath: "/calibre/Dune/Dune.epub",
+			Format: domain.FormatEPUB, Cover: []byte{0xFF, 0xD8}},
+		{SourceID: "2", Title: "Good Omens", Authors: []string{"Terry Pratchett", "Neil Gaiman"},
+			FilePath: "/calibre/Good Omens/Good Omens.pdf", Format: domain.FormatPDF},
+		{SourceID: "3", Title: "Papier seulement", Authors: []string{"Personne"}},
+		{SourceID: "4", Title: "Corrompu", Authors: []string{"X"}, FilePath: "/calibre/X/Corrompu.epub", Format: domain.FormatEPUB},
+		{SourceID: "5", Title: "Deja la", Authors: []string{"Moi"}, FilePath: "/calibre/Moi/Deja la.epub", Format: domain.FormatEPUB},
+	}}
+}
+
+func TestLibraryService_ImportFromSource(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+
+	newFixture := func() (*service.LibraryService, *mockAnnotBookRepo, *mockSharingSheetRepo) {
+		books := &mockAnnotBookRepo{}
+		present, _ := domain.NewBook(" deja LA", "moi ", "/ailleurs/deja.epub", domain.FormatEPUB, 10, fake.Now())
+		_ = books.Save(ctx, present)
+		sheets := newMockSharingSheetRepo()
+		extractor := &pathExtractor{unreadable: map[string]bool{"/calibre/X/Corrompu.epub": true}}
+		return service.NewLibraryService(books, sheets, nil, extractor, nil, fake), books, sheets
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		svc, books, sheets := newFixture()
+		var calls []int
+		report, err := svc.ImportFromSource(ctx, calibreFixture(), service.ImportOptions{DryRun: true},
+			func(done, total int, _ string) {
+				if total != 5 {
+					t.Errorf("expected total 5, got %d", total)
+				}
+				calls = append(calls, done)
+			})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		// Le dry run n'ouvre pas les fichiers : "Corrompu" passe encore.
+		if report.BooksAdded != 3 || report.BooksMatched != 1 || report.SheetsAdded != 1 || len(report.Conflicts) != 1 {
+			t.Errorf("unexpected dry-run report: %+v", report)
+		}
+		if len(calls) != 5 || calls[4] != 5 {
+			t.Errorf("expected progress after each book, got %v", calls)
+		}
+		if len(books.books) != 1 || len(sheets.sheets) != 0 {
+			t.Error("expected dry run to write nothing")
+		}
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		svc, books, sheets := newFixture()
+		report, err := svc.ImportFromSource(ctx, calibreFixture(), service.ImportOptions{}, nil)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if report.BooksAdded != 2 || report.BooksMatched != 1 || report.SheetsAdded != 1 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		kinds := map[service.ConflictKind]string{}
+		for _, c := range report.Conflicts {
+			kinds[c.Kind] = c.BookTitle
+		}
+		if kinds[service.ConflictMissingFile] != "Papier seulement" || kinds[service.ConflictUnreadableFile] != "Corrompu" {
+			t.Errorf("unexpected conflicts: %+v", report.Conflicts)
+		}
+
+		var dune *domain.Book
+		for _, b := range books.books {
+			if b.Title == "Dune" {
+				dune = b
+			}
+			if b.Title == "Good Omens" && b.Author != "Terry Pratchett & Neil Gaiman" {
+				t.Errorf("expected joined authors, got %q", b.Author)
+			}
+		}
+		if dune == nil || dune.Series != "Dune" || dune.SeriesIndex != 1 || len(dune.CoverImage) != 2 || dune.TotalPages != 250 {
+			t.Fatalf("unexpected Dune book: %+v", dune)
+		}
+		sheet, _ := sheets.GetSheetByBookID(ctx, dune.ID)
+		if sheet == nil || sheet.Summary != "Arrakis." || sheet.Rating != 5 || sheet.Tags[0] != "sf" {
+			t.Errorf("unexpected Dune sheet: %+v", sheet)
+		}
+
+		again, err := svc.ImportFromSource(ctx, calibreFixture(), service.ImportOptions{}, nil)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if again.BooksAdded != 0 || again.BooksMatched != 3 {
+			t.Errorf("expected books already imported to be skipped, got %+v", again)
+		}
+	})
+
+	t.Run("SourceError", func(t *testing.T) {
+		svc, _, _ := newFixture()
+		_, err := svc.ImportFromSource(ctx, &fakeLibrarySource{err: errors.New("base verrouillee")}, service.ImportOptions{}, nil)
+		if err == nil {
+			t.Fatal("expected source error")
+		}
+	})
+}
+
+func TestLibraryService_ImportFromSourceUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+	svc := service.NewLibraryService(store, store, store, &pathExtractor{}, nil, clock.NewFakeClock(fakeNow))
+	source := &fakeLibrarySource{books: calibreFixture().books[:1]} // Dune, avec fiche
+
+	svc.SetUnitOfWork(failingUoW{store: store, fail: "sheets"})
+	if _, err := svc.ImportFromSource(ctx, source, service.ImportOptions{}, nil); !errors.Is(err, errDiskFull) {
+		t.Fatalf("expected the storage error, got: %v", err)
+	}
+	if books, _ := store.ListAll(ctx); len(books) != 0 {
+		t.Errorf("expected no book without its sheet, got %d", len(books))
+	}
+
+	svc.SetUnitOfWork(store)
+	report, err := svc.ImportFromSource(ctx, source, service.ImportOptions{}, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	books, _ := store.ListAll(ctx)
+	if report.BooksAdded != 1 || report.SheetsAdded != 1 || len(books) != 1 {
+		t.Fatalf("unexpected import: %+v, %d books", report, len(books))
+	}
+	if _, err := store.GetSheetByBookID(ctx, books[0].ID); err != nil {
+		t.Errorf("expected the sheet with the book, got: %v", err)
+	}
+}
+
+func TestLibraryService_ImportFromSourceInvalidSheet(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+	svc := service.NewLibraryService(store, store, store, &pathExtractor{}, nil, clock.NewFakeClock(fakeNow))
+	svc.SetUnitOfWork(store)
+	dune := calibreFixture().books[0]
+	dune.Rating = 9 // note hors de 0..5 : la fiche est refusée, pas le livre
+
+	report, err := svc.ImportFromSource(ctx, &fakeLibrarySource{books: []port.ExternalBook{dune}}, service.ImportOptions{}, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	books, _ := store.ListAll(ctx)
+	if report.BooksAdded != 1 || report.SheetsAdded != 0 || len(books) != 1 {
+		t.Fatalf("expected the book without its sheet, got %+v, %d books", report, len(books))
+	}
+	if sheets, _ := store.ListAllSheets(ctx); len(sheets) != 0 {
+		t.Errorf("expected no sheet, got %d", len(sheets))
+	}
+}
@@ -3,9 +3,12 @@ package service_test
 import (
 	"context"
 	"errors"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
 	"testing"
 
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
 	"github.com/MiltonJ23/Orus/internal/service"
 )
 
@@ -62,6 +65,41 @@ func (m *mockExtractor) ExtractInfo(ctx context.Context, path string) (*domain.B
 	}, nil
 }
 
+// failingUoW runs units of work on a memory store where the writes of one
+// repository fail, to check that the other writes are rolled back.
+type failingUoW struct {
+	store *memory.Storage
+	fail  string // "books", "sheets" ou "reminders"
+}
+
+var errDiskFull = errors.New("disque plein")
+
+type failingBooks struct{ port.BookRepository }
+
+func (failingBooks) Delete(context.Context, string) error { return errDiskFull }
+
+type failingSheets struct{ port.ReadingSheetRepository }
+
+func (failingSheets) SaveSheet(context.Context, *domain.ReadingSheet) error { return errDiskFull }
+
+type failingReminders struct{ port.ReminderRepository }
+
+func (failingReminders) SaveReminder(context.Context, *domain.Reminder) error { return errDiskFull }
+
+func (u failingUoW) WithTx(ctx context.Context, fn func(repos port.Repositories) error) error {
+	return u.store.WithTx(ctx, func(repos port.Repositories) error {
+		switch u.fail {
+		case "books":
+			repos.Books = failingBooks{repos.Books}
+		case "sheets":
+			repos.Sheets = failingSheets{repos.Sheets}
+		case "reminders":
+			repos.Reminders = failingReminders{repos.Reminders}
+		}
+		return fn(repos)
+	})
+}
+
 // --- TESTS ---
 
 func TestLibraryService_ImportBook(t *testing.T) {
@@ -70,7 +108,7 @@ func TestLibraryService_ImportBook(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo := &mockLibBookRepo{}
 		extractor := &mockExtractor{}
-		svc := service.NewLibraryService(repo, extractor)
+		svc := service.NewLibraryService(repo, nil, nil, extractor, nil, clock.NewSystemClock())
 
 		book, err := svc.ImportBook(ctx, "/path/to/book.pdf")
 		if err != nil {
@@ -84,7 +122,7 @@ func TestLibraryService_ImportBook(t *testing.T) {
 	t.Run("Extraction Error", func(t *testing.T) {
 		repo := &mockLibBookRepo{}
 		extractor := &mockExtractor{failExtract: true}
-		svc := service.NewLibraryService(repo, extractor)
+		svc := service.NewLibraryService(repo, nil, nil, extractor, nil, clock.NewSystemClock())
 
 		_, err := svc.ImportBook(ctx, "/path/to/book.pdf")
 		if err == nil {
@@ -95,7 +133,7 @@ func TestLibraryService_ImportBook(t *testing.T) {
 	t.Run("Book Creation Error", func(t *testing.T) {
 		repo := &mockLibBookRepo{}
 		extractor := &mockExtractor{triggerBookCreationError: true}
-		svc := service.NewLibraryService(repo, extractor)
+		svc := service.NewLibraryService(repo, nil, nil, extractor, nil, clock.NewSystemClock())
 
 		_, err := svc.ImportBook(ctx, "/path/to/book.pdf")
 		if err == nil {
@@ -106,7 +144,7 @@ func TestLibraryService_ImportBook(t *testing.T) {
 	t.Run("Database Save Error", func(t *testing.T) {
 		repo := &mockLibBookRepo{failSave: true}
 		extractor := &mockExtractor{}
-		svc := service.NewLibraryService(repo, extractor)
+		svc := service.NewLibraryService(repo, nil, nil, extractor, nil, clock.NewSystemClock())
 
 		_, err := svc.ImportBook(ctx, "/path/to/book.pdf")
 		if err == nil {
@@ -115,12 +153,98 @@ func TestLibraryService_ImportBook(t *testing.T) {
 	})
 }
 
+func TestLibraryService_Events(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewLibraryService(&mockLibBookRepo{}, nil, nil, &mockExtractor{}, nil, clock.NewSystemClock())
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+
+	book, err := svc.ImportBook(ctx, "/path/to/book.pdf")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if err := svc.DeleteBook(ctx, book.ID); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	if got := events.names(); len(got) != 2 || got[0] != "book.imported" || got[1] != "book.deleted" {
+		t.Fatalf("expected book.imported then book.deleted, got %v", got)
+	}
+	if imported := events.events[0].(domain.BookImported); imported.Book != book {
+		t.Errorf("expected the imported book in the event, got %+v", imported.Book)
+	}
+	if deleted := events.events[1].(domain.BookDeleted); deleted.BookID != book.ID {
+		t.Errorf("expected book ID %q, got %q", book.ID, deleted.BookID)
+	}
+
+	if _, err := svc.ImportBook(ctx, "/path/to/book.pdf"); err != nil {
+		t.Fatal(err)
+	}
+	failing := service.NewLibraryService(&mockLibBookRepo{failSave: true}, nil, nil, &mockExtractor{}, nil, clock.NewSystemClock())
+	failing.SetEvents(events)
+	if _, err := failing.ImportBook(ctx, "/path/to/book.pdf"); err == nil {
+		t.Fatal("expected db save error, got nil")
+	}
+	if got := events.names(); len(got) != 3 {
+		t.Errorf("expected no event for a failed import, got %v", got)
+	}
+}
+
+func TestLibraryService_DeleteBookUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+	svc := service.NewLibraryService(store, store, store, &mockExtractor{}, nil, clock.NewFakeClock(fakeNow))
+	book, _ := domain.NewBook("Dune", "Frank Herbert", "/livres/dune.epub", domain.FormatEPUB, 600, fakeNow)
+	_ = store.Save(ctx, book)
+	own, _ := domain.NewReminder(book.ID, book.Title, "Finir Dune", 21, 0, domain.FrequencyDaily, fakeNow)
+	global, _ := domain.NewReminder("", "", "Lecture du soir", 21, 30, domain.FrequencyDaily, fakeNow)
+	_ = store.SaveReminder(ctx, own)
+	_ = store.SaveReminder(ctx, global)
+
+	svc.SetUnitOfWork(failingUoW{store: store, fail: "books"})
+	if err := svc.DeleteBook(ctx, book.ID); !errors.Is(err, errDiskFull) {
+		t.Fatalf("expected the storage error, got: %v", err)
+	}
+	if reminders, _ := store.ListAllReminders(ctx); len(reminders) != 2 {
+		t.Errorf("expected the reminders kept when the book stays, got %d", len(reminders))
+	}
+
+	svc.SetUnitOfWork(store)
+	if err := svc.DeleteBook(ctx, book.ID); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if _, err := store.GetByID(ctx, book.ID); !errors.Is(err, domain.ErrBookNotFound) {
+		t.Errorf("expected the book deleted, got: %v", err)
+	}
+	reminders, _ := store.ListAllReminders(ctx)
+	if len(reminders) != 1 || reminders[0].ID != global.ID {
+		t.Errorf("expected only the global reminder left, got %+v", reminders)
+	}
+}
+
+func TestLibraryService_DeleteBookWithoutUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+	svc := service.NewLibraryService(store, store, store, &mockExtractor{}, nil, clock.NewFakeClock(fakeNow))
+	book, _ := domain.NewBook("Dune", "Frank Herbert", "/livres/dune.epub", domain.FormatEPUB, 600, fakeNow)
+	_ = store.Save(ctx, book)
+	own, _ := domain.NewReminder(book.ID, book.Title, "Finir Dune", 21, 0, domain.FrequencyDaily, fakeNow)
+	_ = store.SaveReminder(ctx, own)
+
+	if err := svc.DeleteBook(ctx, book.ID); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if reminders, _ := store.ListAllReminders(ctx); len(reminders) != 0 {
+		t.Errorf("expected the reminders of the book deleted, got %+v", reminders)
+	}
+}
+
 func TestLibraryService_GetLibrary(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
 		repo := &mockLibBookRepo{}
-		svc := service.NewLibraryService(repo, nil)
+		svc := service.NewLibraryService(repo, nil, nil, nil, nil, clock.NewSystemClock())
 
 		books, err := svc.GetLibrary(ctx)
 		if err != nil {
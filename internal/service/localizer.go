@@ -0,0 +1,25 @@
+package service
+
+import (
+	"sync/atomic"
+
+	"github.com/MiltonJ23/Orus/internal/i18n"
+)
+
+// defaultLocalizer writes the outputs of services nobody gave a language.
+var defaultLocalizer = i18n.New(i18n.French)
+
+// localizerRef holds the language of a service's outputs. The UI may change
+// it while a scheduler or an export runs on another goroutine.
+type localizerRef struct {
+	p atomic.Pointer[i18n.Localizer]
+}
+
+func (r *localizerRef) get() *i18n.Localizer {
+	if tr := r.p.Load(); tr != nil {
+		return tr
+	}
+	return defaultLocalizer
+}
+
+func (r *localizerRef) set(tr *i18n.Localizer) { r.p.Store(tr) }
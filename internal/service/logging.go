@@ -0,0 +1,12 @@
+package service
+
+import "log/slog"
+
+// componentLogger tags the records of a service with its name. A nil logger
+// falls back to slog.Default(), which tests rely on.
+func componentLogger(logger *slog.Logger, component string) *slog.Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return logger.With("component", component)
+}
@@ -12,13 +12,18 @@ import (
 type ReadingSheetService struct {
 	sheetRepo port.ReadingSheetRepository
 	bookRepo  port.BookRepository
+	clock     port.Clock
+	events    eventSink
 }
 
 // NewReadingSheetService creates a new ReadingSheetService with the given dependencies.
-func NewReadingSheetService(sheetRepo port.ReadingSheetRepository, bookRepo port.BookRepository) *ReadingSheetService {
-	return &ReadingSheetService{sheetRepo: sheetRepo, bookRepo: bookRepo}
+func NewReadingSheetService(sheetRepo port.ReadingSheetRepository, bookRepo port.BookRepository, clock port.Clock) *ReadingSheetService {
+	return &ReadingSheetService{sheetRepo: sheetRepo, bookRepo: bookRepo, clock: clock}
 }
 
+// SetEvents sets the publisher of the SheetUpdated events.
+func (s *ReadingSheetService) SetEvents(pub port.EventPublisher) { s.events.pub = pub }
+
 // CreateSheet crée et persiste une nouvelle fiche de lecture
 func (s *ReadingSheetService) CreateSheet(ctx context.Context, bookID, summary string, rating int, quotes, tags []string) (*domain.ReadingSheet, error) {
 	// On récupère le titre du livre pour le dénormaliser
@@ -27,7 +32,7 @@ func (s *ReadingSheetService) CreateSheet(ctx context.Context, bookID, summary s
 		return nil, fmt.Errorf("book not found: %w", err)
 	}
 
-	sheet, err := domain.NewReadingSheet(bookID, book.Title, summary, rating, quotes, tags)
+	sheet, err := domain.NewReadingSheet(bookID, book.Title, summary, rating, quotes, tags, s.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("invalid reading sheet data: %w", err)
 	}
@@ -35,6 +40,7 @@ func (s *ReadingSheetService) CreateSheet(ctx context.Context, bookID, summary s
 	if err := s.sheetRepo.SaveSheet(ctx, sheet); err != nil {
 		return nil, fmt.Errorf("failed to persist reading sheet: %w", err)
 	}
+	s.events.publish(ctx, domain.SheetUpdated{BookID: bookID, SheetID: sheet.ID})
 	return sheet, nil
 }
 
@@ -58,8 +64,8 @@ func (s *ReadingSheetService) UpdateSummary(ctx context.Context, sheetID, newSum
 	if err != nil {
 		return err
 	}
-	sheet.UpdateSummary(newSummary)
-	return s.sheetRepo.UpdateSheet(ctx, sheet)
+	sheet.UpdateSummary(newSummary, s.clock.Now())
+	return s.update(ctx, sheet)
 }
 
 // SetRating met à jour la note d'une fiche
@@ -68,10 +74,10 @@ func (s *ReadingSheetService) SetRating(ctx context.Context, sheetID string, rat
 	if err != nil {
 		return err
 	}
-	if err := sheet.UpdateRating(rating); err != nil {
+	if err := sheet.UpdateRating(rating, s.clock.Now()); err != nil {
 		return err
 	}
-	return s.sheetRepo.UpdateSheet(ctx, sheet)
+	return s.update(ctx, sheet)
 }
 
 // AddQuote ajoute une citation à une fiche existante
@@ -80,11 +86,27 @@ func (s *ReadingSheetService) AddQuote(ctx context.Context, sheetID, quote strin
 	if err != nil {
 		return err
 	}
-	sheet.AddQuote(quote)
-	return s.sheetRepo.UpdateSheet(ctx, sheet)
+	sheet.AddQuote(quote, s.clock.Now())
+	return s.update(ctx, sheet)
 }
 
 // DeleteSheet supprime une fiche de lecture
 func (s *ReadingSheetService) DeleteSheet(ctx context.Context, sheetID string) error {
-	return s.sheetRepo.DeleteSheet(ctx, sheetID)
+	bookID := ""
+	if sheet, err := s.sheetRepo.GetSheetByID(ctx, sheetID); err == nil {
+		bookID = sheet.BookID
+	}
+	if err := s.sheetRepo.DeleteSheet(ctx, sheetID); err != nil {
+		return err
+	}
+	s.events.publish(ctx, domain.SheetUpdated{BookID: bookID, SheetID: sheetID, Deleted: true})
+	return nil
+}
+
+func (s *ReadingSheetService) update(ctx context.Context, sheet *domain.ReadingSheet) error {
+	if err := s.sheetRepo.UpdateSheet(ctx, sheet); err != nil {
+		return err
+	}
+	s.events.publish(ctx, domain.SheetUpdated{BookID: sheet.BookID, SheetID: sheet.ID})
+	return nil
 }
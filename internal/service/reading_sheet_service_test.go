@@ -3,7 +3,9 @@ package service_test
 import (
 	"context"
 	"errors"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
 	"testing"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 	"github.com/MiltonJ23/Orus/internal/service"
@@ -126,7 +128,7 @@ func TestReadingSheetService_CreateSheet(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, err := svc.CreateSheet(ctx, "book-1", "Great book", 4, []string{"quote1"}, []string{"fiction"})
 		if err != nil {
@@ -143,7 +145,7 @@ func TestReadingSheetService_CreateSheet(t *testing.T) {
 	t.Run("BookNotFound", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		_, err := svc.CreateSheet(ctx, "nonexistent", "summary", 3, nil, nil)
 		if err == nil {
@@ -154,7 +156,7 @@ func TestReadingSheetService_CreateSheet(t *testing.T) {
 	t.Run("InvalidRating", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		_, err := svc.CreateSheet(ctx, "book-1", "summary", 7, nil, nil)
 		if err == nil {
@@ -166,7 +168,7 @@ func TestReadingSheetService_CreateSheet(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		sheetRepo.failSave = true
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		_, err := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 		if err == nil {
@@ -181,7 +183,7 @@ func TestReadingSheetService_GetSheetForBook(t *testing.T) {
 	t.Run("Exists", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		_, _ = svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 
@@ -197,7 +199,7 @@ func TestReadingSheetService_GetSheetForBook(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, err := svc.GetSheetForBook(ctx, "book-1")
 		if err != nil {
@@ -215,7 +217,7 @@ func TestReadingSheetService_ListSheets(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		_, _ = svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 
@@ -232,7 +234,7 @@ func TestReadingSheetService_ListSheets(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		sheetRepo.failList = true
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		_, err := svc.ListSheets(ctx)
 		if err == nil {
@@ -247,7 +249,7 @@ func TestReadingSheetService_UpdateSummary(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, _ := svc.CreateSheet(ctx, "book-1", "old summary", 3, nil, nil)
 
@@ -264,7 +266,7 @@ func TestReadingSheetService_UpdateSummary(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		err := svc.UpdateSummary(ctx, "nonexistent", "new")
 		if err == nil {
@@ -279,7 +281,7 @@ func TestReadingSheetService_SetRating(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, _ := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 
@@ -296,7 +298,7 @@ func TestReadingSheetService_SetRating(t *testing.T) {
 	t.Run("InvalidRating", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, _ := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 
@@ -309,7 +311,7 @@ func TestReadingSheetService_SetRating(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		err := svc.SetRating(ctx, "nonexistent", 3)
 		if err == nil {
@@ -324,7 +326,7 @@ func TestReadingSheetService_AddQuote(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, _ := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 
@@ -338,10 +340,25 @@ func TestReadingSheetService_AddQuote(t *testing.T) {
 		}
 	})
 
+	t.Run("StampsClockTime", func(t *testing.T) {
+		sheetRepo := newMockSheetRepo()
+		fake := clock.NewFakeClock(fakeNow)
+		svc := service.NewReadingSheetService(sheetRepo, newMockSheetBookRepo(), fake)
+
+		sheet, _ := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
+		fake.Advance(time.Hour)
+		if err := svc.AddQuote(ctx, sheet.ID, "quote"); err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if got := sheetRepo.sheets[sheet.ID].UpdatedAt; !got.Equal(fakeNow.Add(time.Hour)) {
+			t.Errorf("expected UpdatedAt from the clock, got %s", got)
+		}
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		err := svc.AddQuote(ctx, "nonexistent", "quote")
 		if err == nil {
@@ -356,7 +373,7 @@ func TestReadingSheetService_DeleteSheet(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		sheet, _ := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
 
@@ -373,7 +390,7 @@ func TestReadingSheetService_DeleteSheet(t *testing.T) {
 		sheetRepo := newMockSheetRepo()
 		sheetRepo.failDelete = true
 		bookRepo := newMockSheetBookRepo()
-		svc := service.NewReadingSheetService(sheetRepo, bookRepo)
+		svc := service.NewReadingSheetService(sheetRepo, bookRepo, clock.NewSystemClock())
 
 		err := svc.DeleteSheet(ctx, "anything")
 		if err == nil {
@@ -381,3 +398,35 @@ func TestReadingSheetService_DeleteSheet(t *testing.T) {
 		}
 	})
 }
+
+func TestReadingSheetService_Events(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewReadingSheetService(newMockSheetRepo(), newMockSheetBookRepo(), clock.NewSystemClock())
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+
+	sheet, err := svc.CreateSheet(ctx, "book-1", "summary", 3, nil, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if err := svc.AddQuote(ctx, sheet.ID, "quote"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteSheet(ctx, sheet.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.SheetUpdated{
+		{BookID: "book-1", SheetID: sheet.ID},
+		{BookID: "book-1", SheetID: sheet.ID},
+		{BookID: "book-1", SheetID: sheet.ID, Deleted: true},
+	}
+	if len(events.events) != len(want) {
+		t.Fatalf("expected %d events, got %v", len(want), events.names())
+	}
+	for i, e := range events.events {
+		if e != want[i] {
+			t.Errorf("event %d: expected %+v, got %+v", i, want[i], e)
+		}
+	}
+}
@@ -2,36 +2,200 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
 	"github.com/MiltonJ23/Orus/internal/port"
 )
 
-// ReminderCallback is a function called when a reminder fires.
-type ReminderCallback func(reminder *domain.Reminder)
+// QuietHours is a daily window, expressed as offsets from midnight, during
+// which the scheduler stays silent. The window may wrap past midnight
+// (e.g. 22:00 → 07:00). Start == End disables it.
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports whether t falls inside the quiet window.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// ReminderPolicy groups the rules applied to due reminders before notifying.
+type ReminderPolicy struct {
+	Quiet QuietHours
+	// Coalesce merges reminders due on the same tick into one notification.
+	Coalesce bool
+	// MinInterval is the minimum delay between two notifications; reminders
+	// falling inside it are advanced without ringing. Zero disables it.
+	MinInterval time.Duration
+}
+
+// DefaultReminderPolicy coalesces simultaneous reminders and has no quiet hours.
+func DefaultReminderPolicy() ReminderPolicy {
+	return ReminderPolicy{Coalesce: true}
+}
+
+// ParseTimeOfDay reads a "HH:MM" time as an offset from midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// FormatTimeOfDay writes an offset from midnight as "HH:MM".
+func FormatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour)%24, int(d%time.Hour/time.Minute))
+}
+
+// reminderSettings is the "reminders" entry of settings.json.
+type reminderSettings struct {
+	QuietStart  string `json:"quiet_start"`
+	QuietEnd    string `json:"quiet_end"`
+	Coalesce    bool   `json:"coalesce"`
+	MinInterval int    `json:"min_interval_minutes"`
+}
+
+func (p ReminderPolicy) settings() reminderSettings {
+	return reminderSettings{
+		QuietStart:  FormatTimeOfDay(p.Quiet.Start),
+		QuietEnd:    FormatTimeOfDay(p.Quiet.End),
+		Coalesce:    p.Coalesce,
+		MinInterval: int(p.MinInterval / time.Minute),
+	}
+}
+
+func (rs reminderSettings) policy() (ReminderPolicy, error) {
+	p := ReminderPolicy{Coalesce: rs.Coalesce, MinInterval: time.Duration(rs.MinInterval) * time.Minute}
+	var err error
+	if rs.QuietStart != "" {
+		if p.Quiet.Start, err = ParseTimeOfDay(rs.QuietStart); err != nil {
+			return DefaultReminderPolicy(), err
+		}
+	}
+	if rs.QuietEnd != "" {
+		if p.Quiet.End, err = ParseTimeOfDay(rs.QuietEnd); err != nil {
+			return DefaultReminderPolicy(), err
+		}
+	}
+	return p, nil
+}
 
 // ReminderService manages reading reminders with a background scheduler.
 type ReminderService struct {
-	repo     port.ReminderRepository
-	notifier port.Notifier
-	onRing   ReminderCallback
-	stop     chan struct{}
+	repo         port.ReminderRepository
+	sessions     port.SessionRepository
+	notifier     port.Notifier
+	events       eventSink
+	logger       *slog.Logger
+	clock        port.Clock
+	mu           sync.Mutex // protège policy et settingsPath
+	policy       ReminderPolicy
+	settingsPath string
+	tr           localizerRef // langue des notifications, voir SetLocalizer
+	lastNotified time.Time
+	deferred     []string // rappels tombés pendant les heures calmes, par ID
+	stop         chan struct{}
 }
 
 // NewReminderService creates a new ReminderService with the given dependencies.
-func NewReminderService(repo port.ReminderRepository, notifier port.Notifier) *ReminderService {
-	return &ReminderService{repo: repo, notifier: notifier, stop: make(chan struct{})}
+// sessions may be nil, in which case SkipIfReadToday is never honoured.
+func NewReminderService(repo port.ReminderRepository, sessions port.SessionRepository, notifier port.Notifier,
+	logger *slog.Logger, clock port.Clock) *ReminderService {
+	return &ReminderService{
+		repo:     repo,
+		sessions: sessions,
+		notifier: notifier,
+		logger:   componentLogger(logger, "reminder"),
+		clock:    clock,
+		policy:   DefaultReminderPolicy(),
+		stop:     make(chan struct{}),
+	}
 }
 
-// SetCallback registers a function to be called when a reminder fires.
-func (s *ReminderService) SetCallback(cb ReminderCallback) { s.onRing = cb }
+// SetEvents sets the publisher of the ReminderFired events.
+func (s *ReminderService) SetEvents(pub port.EventPublisher) { s.events.pub = pub }
+
+// LoadPolicy reads the quiet hours and rate limiting rules from the
+// "reminders" entry of the settings file at path; SetPolicy saves them there
+// from then on. A missing file or entry keeps DefaultReminderPolicy.
+func (s *ReminderService) LoadPolicy(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settingsPath = path
+	entries, err := readSettings(path)
+	if err != nil {
+		return err
+	}
+	raw, ok := entries["reminders"]
+	if !ok {
+		return nil
+	}
+	var rs reminderSettings
+	if err := json.Unmarshal(raw, &rs); err != nil {
+		return fmt.Errorf("failed to decode reminder settings: %w", err)
+	}
+	policy, err := rs.policy()
+	if err != nil {
+		return err
+	}
+	s.policy = policy
+	return nil
+}
+
+// SetPolicy replaces the quiet hours and rate limiting rules, applied from the
+// next scheduler tick, and saves them when a settings file was loaded.
+func (s *ReminderService) SetPolicy(p ReminderPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = p
+	if s.settingsPath == "" {
+		return nil
+	}
+	entries, err := readSettings(s.settingsPath)
+	if err != nil {
+		return err
+	}
+	entries["reminders"], _ = json.Marshal(p.settings())
+	data, _ := json.MarshalIndent(entries, "", "  ")
+	if err := os.WriteFile(s.settingsPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	return nil
+}
+
+// SetLocalizer sets the language of the notifications, French by default.
+func (s *ReminderService) SetLocalizer(tr *i18n.Localizer) { s.tr.set(tr) }
+
+// Policy returns the rules currently applied by the scheduler.
+func (s *ReminderService) Policy() ReminderPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.policy
+}
 
 // AddReminder creates and persists a new reading reminder.
 func (s *ReminderService) AddReminder(ctx context.Context, bookID, bookTitle, label string, hour, minute int, freq domain.ReminderFrequency) (*domain.Reminder, error) {
-	r, err := domain.NewReminder(bookID, bookTitle, label, hour, minute, freq)
+	r, err := domain.NewReminder(bookID, bookTitle, label, hour, minute, freq, s.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("invalid reminder: %w", err)
 	}
@@ -54,8 +218,19 @@ func (s *ReminderService) ToggleReminder(ctx context.Context, id string) error {
 	}
 	r.Enabled = !r.Enabled
 	if r.Enabled {
-		r.NextRing = r.ComputeNextRing(time.Now())
+		r.NextRing = r.ComputeNextRing(s.clock.Now())
+	}
+	return s.repo.UpdateReminder(ctx, r)
+}
+
+// SetSkipIfReadToday toggles whether a reminder stays silent on days the
+// user has already read.
+func (s *ReminderService) SetSkipIfReadToday(ctx context.Context, id string, skip bool) error {
+	r, err := s.repo.GetReminderByID(ctx, id)
+	if err != nil {
+		return err
 	}
+	r.SkipIfReadToday = skip
 	return s.repo.UpdateReminder(ctx, r)
 }
 
@@ -64,15 +239,14 @@ func (s *ReminderService) ToggleReminder(ctx context.Context, id string) error {
 func (s *ReminderService) DismissReminder(ctx context.Context, id string) error {
 	r, err := s.repo.GetReminderByID(ctx, id)
 	if err != nil {
-		log.Printf("[ReminderService] DismissReminder: %s introuvable : %v", id, err)
+		s.logger.Warn("dismissed reminder not found", "reminder_id", id, "error", err)
 		return nil
 	}
-	r.Advance(time.Now())
+	r.Advance(s.clock.Now())
 	if err := s.repo.UpdateReminder(ctx, r); err != nil {
 		return fmt.Errorf("failed to persist dismiss: %w", err)
 	}
-	log.Printf("[ReminderService] Acquitté %q — enabled=%v, next=%s",
-		r.Label, r.Enabled, r.NextRing.Format("02/01 15:04"))
+	s.logger.Info("reminder dismissed", "reminder_id", r.ID, "label", r.Label, "enabled", r.Enabled, "next_ring", r.NextRing)
 	return nil
 }
 
@@ -81,18 +255,22 @@ func (s *ReminderService) DeleteReminder(ctx context.Context, id string) error {
 	return s.repo.DeleteReminder(ctx, id)
 }
 
+// SchedulerInterval is how often StartScheduler polls for due reminders.
+const SchedulerInterval = 30 * time.Second
+
 // StartScheduler runs the reminder polling loop. It checks for due reminders
-// every 30 seconds and sends notifications. Call Stop() to terminate.
+// every SchedulerInterval of clock time and sends notifications. Call Stop()
+// to terminate.
 func (s *ReminderService) StartScheduler() {
-	log.Println("[ReminderService] Planificateur démarré")
-	ticker := time.NewTicker(30 * time.Second)
+	s.logger.Info("scheduler started", "interval", SchedulerInterval)
+	ticker := s.clock.NewTicker(SchedulerInterval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-s.stop:
-			log.Println("[ReminderService] Planificateur arrêté")
+			s.logger.Info("scheduler stopped")
 			return
-		case now := <-ticker.C:
+		case now := <-ticker.C():
 			s.checkDueReminders(now)
 		}
 	}
@@ -101,32 +279,122 @@ func (s *ReminderService) StartScheduler() {
 // Stop terminates the scheduler goroutine.
 func (s *ReminderService) Stop() { close(s.stop) }
 
+// checkDueReminders advances every reminder due at now and notifies the ones
+// that survive the policy: SkipIfReadToday and MinInterval. Reminders due
+// during quiet hours are deferred to the first check after the quiet window,
+// where they ring with the others, merged when Coalesce is on.
 func (s *ReminderService) checkDueReminders(now time.Time) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	reminders, err := s.repo.ListEnabledReminders(ctx)
 	if err != nil {
-		log.Printf("[ReminderService] Erreur : %v", err)
+		s.logger.Error("reminders unavailable", "error", err)
 		return
 	}
+
+	policy := s.Policy()
+	quiet := policy.Quiet.Contains(now)
+	limited := policy.MinInterval > 0 && !s.lastNotified.IsZero() && now.Sub(s.lastNotified) < policy.MinInterval
+	var ringing []*domain.Reminder
+	consider := func(r *domain.Reminder) {
+		switch {
+		case limited:
+			s.logger.Info("reminder silenced", "reminder_id", r.ID, "label", r.Label, "reason", "rate limited")
+		case r.SkipIfReadToday && s.hasReadToday(ctx, r.BookID, now):
+			s.logger.Info("reminder skipped", "reminder_id", r.ID, "label", r.Label, "reason", "already read today")
+		default:
+			ringing = append(ringing, r)
+		}
+	}
+	if !quiet {
+		// Un rappel supprimé pendant les heures calmes ne sonne plus.
+		for _, id := range s.deferred {
+			if r, err := s.repo.GetReminderByID(ctx, id); err == nil {
+				consider(r)
+			}
+		}
+		s.deferred = nil
+	}
 	for _, r := range reminders {
 		if !r.IsDue(now) {
 			continue
 		}
-		if s.notifier != nil {
-			msg := r.Label
-			if r.BookTitle != "" {
-				msg = fmt.Sprintf("%s — %s", r.Label, r.BookTitle)
-			}
-			_ = s.notifier.Notify("📖 Orus — Rappel de lecture", msg)
-		}
-		if s.onRing != nil {
-			s.onRing(r)
+		if quiet {
+			s.logger.Info("reminder deferred", "reminder_id", r.ID, "label", r.Label, "reason", "quiet hours")
+			s.deferred = append(s.deferred, r.ID)
+		} else {
+			consider(r)
 		}
 		r.Advance(now)
 		if err := s.repo.UpdateReminder(ctx, r); err != nil {
-			log.Printf("[ReminderService] Update échoué : %v", err)
+			s.logger.Error("reminder not updated", "reminder_id", r.ID, "error", err)
 		}
 	}
+	if len(ringing) == 0 {
+		return
+	}
+
+	s.lastNotified = now
+	if s.notifier != nil {
+		if policy.Coalesce && len(ringing) > 1 {
+			labels := make([]string, len(ringing))
+			for i, r := range ringing {
+				labels[i] = reminderMessage(r)
+			}
+			title := s.tr.get().N("reminders.notify_many", len(ringing))
+			_ = s.notifier.Notify(title, strings.Join(labels, " · "))
+		} else {
+			for _, r := range ringing {
+				_ = s.notifier.Notify(s.tr.get().T("reminders.notify_one"), reminderMessage(r))
+			}
+		}
+	}
+	for _, r := range ringing {
+		s.events.publish(ctx, domain.ReminderFired{Reminder: r})
+	}
+}
+
+// hasReadToday reports whether a session was recorded since midnight, on
+// bookID or on any book when bookID is empty.
+func (s *ReminderService) hasReadToday(ctx context.Context, bookID string, now time.Time) bool {
+	if s.sessions == nil {
+		return false
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sessions, err := s.sessions.ListSessionsSince(ctx, midnight)
+	if err != nil {
+		s.logger.Warn("reading sessions unavailable", "book_id", bookID, "error", err)
+		return false
+	}
+	for _, ses := range sessions {
+		if bookID == "" || ses.BookID == bookID {
+			return true
+		}
+	}
+	return false
+}
+
+// readSettings returns the entries of the settings file at path, empty when
+// the file does not exist yet. Other entries are kept as they are on save.
+func readSettings(path string) (map[string]json.RawMessage, error) {
+	entries := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode settings: %w", err)
+	}
+	return entries, nil
+}
+
+func reminderMessage(r *domain.Reminder) string {
+	if r.BookTitle != "" {
+		return fmt.Sprintf("%s — %s", r.Label, r.BookTitle)
+	}
+	return r.Label
 }
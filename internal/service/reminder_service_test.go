@@ -3,6 +3,10 @@ package service_test
 import (
 	"context"
 	"errors"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,12 +92,14 @@ type mockNotifier struct {
 	lastTitle   string
 	lastMessage string
 	failNotify  bool
+	calls       int
 }
 
 func (m *mockNotifier) Notify(title, message string) error {
 	if m.failNotify {
 		return errors.New("notify error")
 	}
+	m.calls++
 	m.lastTitle = title
 	m.lastMessage = message
 	return nil
@@ -107,7 +113,7 @@ func TestReminderService_AddReminder(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo := newMockReminderRepo()
 		notifier := &mockNotifier{}
-		svc := service.NewReminderService(repo, notifier)
+		svc := service.NewReminderService(repo, nil, notifier, nil, clock.NewSystemClock())
 
 		r, err := svc.AddReminder(ctx, "book-1", "Test Book", "Read 30 min", 18, 30, domain.FrequencyDaily)
 		if err != nil {
@@ -126,7 +132,7 @@ func TestReminderService_AddReminder(t *testing.T) {
 
 	t.Run("InvalidTime", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.AddReminder(ctx, "book-1", "Test", "Read", 25, 0, domain.FrequencyDaily)
 		if err == nil {
@@ -137,7 +143,7 @@ func TestReminderService_AddReminder(t *testing.T) {
 	t.Run("SaveError", func(t *testing.T) {
 		repo := newMockReminderRepo()
 		repo.failSave = true
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.AddReminder(ctx, "book-1", "Test", "Read", 18, 30, domain.FrequencyDaily)
 		if err == nil {
@@ -151,7 +157,7 @@ func TestReminderService_ListReminders(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		_, _ = svc.AddReminder(ctx, "b1", "Book1", "Read", 8, 0, domain.FrequencyDaily)
 		_, _ = svc.AddReminder(ctx, "b2", "Book2", "Study", 9, 0, domain.FrequencyWeekly)
@@ -168,7 +174,7 @@ func TestReminderService_ListReminders(t *testing.T) {
 	t.Run("Error", func(t *testing.T) {
 		repo := newMockReminderRepo()
 		repo.failList = true
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		_, err := svc.ListReminders(ctx)
 		if err == nil {
@@ -182,7 +188,7 @@ func TestReminderService_ToggleReminder(t *testing.T) {
 
 	t.Run("Disable", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		r, _ := svc.AddReminder(ctx, "b1", "Book", "Read", 10, 0, domain.FrequencyDaily)
 		if !r.Enabled {
@@ -201,7 +207,7 @@ func TestReminderService_ToggleReminder(t *testing.T) {
 
 	t.Run("Enable", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		r, _ := svc.AddReminder(ctx, "b1", "Book", "Read", 10, 0, domain.FrequencyDaily)
 		_ = svc.ToggleReminder(ctx, r.ID)    // disable
@@ -217,7 +223,7 @@ func TestReminderService_ToggleReminder(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		err := svc.ToggleReminder(ctx, "nonexistent")
 		if err == nil {
@@ -231,7 +237,7 @@ func TestReminderService_DismissReminder(t *testing.T) {
 
 	t.Run("DismissOnce", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		r, _ := svc.AddReminder(ctx, "b1", "Book", "Read", 10, 0, domain.FrequencyOnce)
 
@@ -247,7 +253,7 @@ func TestReminderService_DismissReminder(t *testing.T) {
 
 	t.Run("DismissDaily", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		r, _ := svc.AddReminder(ctx, "b1", "Book", "Read", 10, 0, domain.FrequencyDaily)
 
@@ -264,7 +270,7 @@ func TestReminderService_DismissReminder(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		repo := newMockReminderRepo()
 		repo.failGet = true
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		err := svc.DismissReminder(ctx, "nonexistent")
 		if err != nil {
@@ -278,7 +284,7 @@ func TestReminderService_DeleteReminder(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		repo := newMockReminderRepo()
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		r, _ := svc.AddReminder(ctx, "b1", "Book", "Read", 10, 0, domain.FrequencyDaily)
 
@@ -294,7 +300,7 @@ func TestReminderService_DeleteReminder(t *testing.T) {
 	t.Run("Error", func(t *testing.T) {
 		repo := newMockReminderRepo()
 		repo.failDelete = true
-		svc := service.NewReminderService(repo, nil)
+		svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
 		err := svc.DeleteReminder(ctx, "anything")
 		if err == nil {
@@ -306,7 +312,7 @@ func TestReminderService_DeleteReminder(t *testing.T) {
 func TestReminderService_StartSchedulerAndStop(t *testing.T) {
 	repo := newMockReminderRepo()
 	notifier := &mockNotifier{}
-	svc := service.NewReminderService(repo, notifier)
+	svc := service.NewReminderService(repo, nil, notifier, nil, clock.NewSystemClock())
 
 	done := make(chan struct{})
 	go func() {
@@ -326,17 +332,333 @@ func TestReminderService_StartSchedulerAndStop(t *testing.T) {
 	}
 }
 
-func TestReminderService_SetCallback(t *testing.T) {
+func TestReminderService_SetEvents(t *testing.T) {
 	repo := newMockReminderRepo()
-	svc := service.NewReminderService(repo, nil)
+	svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
 
-	called := false
-	svc.SetCallback(func(r *domain.Reminder) {
-		called = true
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+
+	if len(events.names()) != 0 {
+		t.Error("expected no event before a reminder rings")
+	}
+}
+
+// --- POLICY TESTS (fake clock) ---
+
+type mockReminderSessionRepo struct {
+	mockSessionRepo
+	sessions []*domain.ReadingSession
+}
+
+func (m *mockReminderSessionRepo) ListSessionsSince(_ context.Context, since time.Time) ([]*domain.ReadingSession, error) {
+	var out []*domain.ReadingSession
+	for _, s := range m.sessions {
+		if !s.LastReadingTime.Before(since) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// fakeNow is the instant every policy test runs at: Tuesday 10 March 2026, 21:00.
+var fakeNow = time.Date(2026, time.March, 10, 21, 0, 0, 0, time.UTC)
+
+func dueReminder(repo *mockReminderRepo, id, bookID, label string, at time.Time) *domain.Reminder {
+	r := &domain.Reminder{
+		ID: id, BookID: bookID, Label: label,
+		Hour: at.Hour(), Minute: at.Minute(),
+		Frequency: domain.FrequencyDaily, Enabled: true, NextRing: at,
+	}
+	repo.reminders[id] = r
+	return r
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	tests := []struct {
+		name  string
+		quiet service.QuietHours
+		at    time.Time
+		want  bool
+	}{
+		{"Disabled", service.QuietHours{}, fakeNow, false},
+		{"SameDayInside", service.QuietHours{Start: 13 * time.Hour, End: 14 * time.Hour}, fakeNow.Add(-7*time.Hour - 30*time.Minute), true},
+		{"SameDayEndExclusive", service.QuietHours{Start: 13 * time.Hour, End: 21 * time.Hour}, fakeNow, false},
+		{"WrapEvening", service.QuietHours{Start: 20 * time.Hour, End: 7 * time.Hour}, fakeNow, true},
+		{"WrapMorning", service.QuietHours{Start: 20 * time.Hour, End: 7 * time.Hour}, fakeNow.Add(9 * time.Hour), true},
+		{"WrapOutside", service.QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour}, fakeNow, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quiet.Contains(tt.at); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.at.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderService_CheckDue_Notifies(t *testing.T) {
+	repo := newMockReminderRepo()
+	notifier := &mockNotifier{}
+	svc := service.NewReminderService(repo, nil, notifier, nil, clock.NewSystemClock())
+	r := dueReminder(repo, "r1", "", "Read", fakeNow)
+
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+	svc.CheckDueReminders(fakeNow.Add(10 * time.Second))
+
+	if notifier.calls != 1 || notifier.lastMessage != "Read" {
+		t.Errorf("expected one notification 'Read', got %d (%q)", notifier.calls, notifier.lastMessage)
+	}
+	if rung := events.names(); len(rung) != 1 || events.events[0].(domain.ReminderFired).Reminder.ID != "r1" {
+		t.Errorf("expected one ReminderFired for r1, got %v", rung)
+	}
+	if !r.NextRing.After(fakeNow) {
+		t.Errorf("expected NextRing advanced past %s, got %s", fakeNow, r.NextRing)
+	}
+}
+
+func TestReminderService_CheckDue_QuietHours(t *testing.T) {
+	// Heures calmes 22:00-07:00 ; fakeNow est à 21:00.
+	fake := clock.NewFakeClock(fakeNow)
+	repo := newMockReminderRepo()
+	notifier := &mockNotifier{}
+	svc := service.NewReminderService(repo, nil, notifier, nil, fake)
+	svc.SetPolicy(service.ReminderPolicy{Quiet: service.QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour}, Coalesce: true})
+	read := dueReminder(repo, "r1", "", "Read", fakeNow.Add(90*time.Minute))
+	dueReminder(repo, "r2", "", "Study", fakeNow.Add(2*time.Hour))
+	dueReminder(repo, "r3", "", "Gone", fakeNow.Add(3*time.Hour))
+
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+	for _, step := range []time.Duration{90 * time.Minute, 30 * time.Minute, time.Hour} {
+		fake.Advance(step)
+		svc.CheckDueReminders(fake.Now())
+	}
+	if notifier.calls != 0 || len(events.names()) != 0 {
+		t.Fatal("expected no notification during quiet hours")
+	}
+	if want := fakeNow.Add(24*time.Hour + 90*time.Minute); !read.NextRing.Equal(want) {
+		t.Errorf("expected the deferred reminder to keep its schedule, next at %s, got %s", want, read.NextRing)
+	}
+
+	delete(repo.reminders, "r3")
+	fake.Advance(7 * time.Hour) // 07:00
+	svc.CheckDueReminders(fake.Now())
+	if notifier.calls != 1 || !strings.Contains(notifier.lastMessage, "Read") || !strings.Contains(notifier.lastMessage, "Study") ||
+		strings.Contains(notifier.lastMessage, "Gone") {
+		t.Fatalf("expected one notification for Read and Study at the end of quiet hours, got %d (%q)", notifier.calls, notifier.lastMessage)
+	}
+	if rung := events.names(); len(rung) != 2 {
+		t.Errorf("expected a ReminderFired for each deferred reminder, got %v", rung)
+	}
+
+	fake.Advance(service.SchedulerInterval)
+	svc.CheckDueReminders(fake.Now())
+	if notifier.calls != 1 {
+		t.Errorf("expected the deferred reminders to ring once, got %d notifications", notifier.calls)
+	}
+}
+
+func TestReminderService_PolicySettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(`{"language": "en"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	svc := service.NewReminderService(newMockReminderRepo(), nil, nil, nil, clock.NewSystemClock())
+	if err := svc.LoadPolicy(path); err != nil || svc.Policy() != service.DefaultReminderPolicy() {
+		t.Fatalf("expected the default policy without a reminders entry, got %+v, %v", svc.Policy(), err)
+	}
+	want := service.ReminderPolicy{Quiet: service.QuietHours{Start: 22 * time.Hour, End: 7*time.Hour + 30*time.Minute}, MinInterval: time.Hour}
+	if err := svc.SetPolicy(want); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	reloaded := service.NewReminderService(newMockReminderRepo(), nil, nil, nil, clock.NewSystemClock())
+	if err := reloaded.LoadPolicy(path); err != nil || reloaded.Policy() != want {
+		t.Errorf("expected %+v after reload, got %+v, %v", want, reloaded.Policy(), err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"language": "en"`) || !strings.Contains(string(data), `"quiet_start": "22:00"`) {
+		t.Errorf("expected the language kept next to the reminder rules, got %s", data)
+	}
+}
+
+func TestReminderService_CheckDue_SkipIfReadToday(t *testing.T) {
+	read := &domain.ReadingSession{SessionID: "s1", BookID: "book-1", LastReadingTime: fakeNow.Add(-3 * time.Hour)}
+	yesterday := &domain.ReadingSession{SessionID: "s0", BookID: "book-2", LastReadingTime: fakeNow.Add(-22 * time.Hour)}
+
+	tests := []struct {
+		name       string
+		bookID     string
+		skip       bool
+		wantNotify int
+	}{
+		{"SameBookReadToday", "book-1", true, 0},
+		{"GlobalReadToday", "", true, 0},
+		{"OtherBookOnlyYesterday", "book-2", true, 1},
+		{"FlagOff", "book-1", false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockReminderRepo()
+			sessions := &mockReminderSessionRepo{sessions: []*domain.ReadingSession{read, yesterday}}
+			notifier := &mockNotifier{}
+			svc := service.NewReminderService(repo, sessions, notifier, nil, clock.NewSystemClock())
+			r := dueReminder(repo, "r1", tt.bookID, "Read", fakeNow)
+			r.SkipIfReadToday = tt.skip
+
+			svc.CheckDueReminders(fakeNow)
+			if notifier.calls != tt.wantNotify {
+				t.Errorf("expected %d notification(s), got %d", tt.wantNotify, notifier.calls)
+			}
+		})
+	}
+}
+
+func TestReminderService_CheckDue_Coalesce(t *testing.T) {
+	t.Run("Coalesced", func(t *testing.T) {
+		repo := newMockReminderRepo()
+		notifier := &mockNotifier{}
+		svc := service.NewReminderService(repo, nil, notifier, nil, clock.NewSystemClock())
+		dueReminder(repo, "r1", "", "Read", fakeNow)
+		dueReminder(repo, "r2", "", "Study", fakeNow)
+		dueReminder(repo, "r3", "", "Later", fakeNow.Add(time.Hour))
+
+		events := &eventRecorder{}
+		svc.SetEvents(events)
+		svc.CheckDueReminders(fakeNow)
+
+		if notifier.calls != 1 {
+			t.Fatalf("expected a single coalesced notification, got %d", notifier.calls)
+		}
+		if !strings.Contains(notifier.lastTitle, "2 rappels") {
+			t.Errorf("expected title to count 2 reminders, got %q", notifier.lastTitle)
+		}
+		if !strings.Contains(notifier.lastMessage, "Read") || !strings.Contains(notifier.lastMessage, "Study") {
+			t.Errorf("expected both labels in message, got %q", notifier.lastMessage)
+		}
+		if rung := events.names(); len(rung) != 2 {
+			t.Errorf("expected a ReminderFired for each due reminder, got %v", rung)
+		}
+	})
+
+	t.Run("Separate", func(t *testing.T) {
+		repo := newMockReminderRepo()
+		notifier := &mockNotifier{}
+		svc := service.NewReminderService(repo, nil, notifier, nil, clock.NewSystemClock())
+		svc.SetPolicy(service.ReminderPolicy{Coalesce: false})
+		dueReminder(repo, "r1", "", "Read", fakeNow)
+		dueReminder(repo, "r2", "", "Study", fakeNow)
+
+		svc.CheckDueReminders(fakeNow)
+		if notifier.calls != 2 {
+			t.Errorf("expected 2 notifications, got %d", notifier.calls)
+		}
 	})
+}
+
+func TestReminderService_CheckDue_MinInterval(t *testing.T) {
+	repo := newMockReminderRepo()
+	notifier := &mockNotifier{}
+	svc := service.NewReminderService(repo, nil, notifier, nil, clock.NewSystemClock())
+	svc.SetPolicy(service.ReminderPolicy{MinInterval: time.Hour})
+
+	dueReminder(repo, "r1", "", "Read", fakeNow)
+	svc.CheckDueReminders(fakeNow)
+
+	later := fakeNow.Add(30 * time.Minute)
+	dueReminder(repo, "r2", "", "Study", later)
+	svc.CheckDueReminders(later)
+	if notifier.calls != 1 {
+		t.Fatalf("expected second reminder to be rate limited, got %d notifications", notifier.calls)
+	}
+
+	muchLater := fakeNow.Add(2 * time.Hour)
+	dueReminder(repo, "r3", "", "Review", muchLater)
+	svc.CheckDueReminders(muchLater)
+	if notifier.calls != 2 {
+		t.Errorf("expected notification once interval elapsed, got %d", notifier.calls)
+	}
+}
+
+func TestReminderService_SetSkipIfReadToday(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockReminderRepo()
+	svc := service.NewReminderService(repo, nil, nil, nil, clock.NewSystemClock())
+	r, _ := svc.AddReminder(ctx, "b1", "Book", "Read", 10, 0, domain.FrequencyDaily)
+
+	if err := svc.SetSkipIfReadToday(ctx, r.ID, true); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !repo.reminders[r.ID].SkipIfReadToday {
+		t.Error("expected SkipIfReadToday to be persisted")
+	}
+	if err := svc.SetSkipIfReadToday(ctx, "missing", true); err == nil {
+		t.Error("expected error for unknown reminder")
+	}
+}
 
-	// SetCallback should not panic — just verify it doesn't
-	if called {
-		t.Error("callback should not have been called yet")
+// signalNotifier reports each notification on a channel so tests can wait
+// for the scheduler goroutine without sleeping.
+type signalNotifier struct{ got chan string }
+
+func (n *signalNotifier) Notify(_, message string) error {
+	n.got <- message
+	return nil
+}
+
+func TestReminderService_SchedulerFiresOnFakeClock(t *testing.T) {
+	fake := clock.NewFakeClock(fakeNow.Add(-service.SchedulerInterval))
+	repo := newMockReminderRepo()
+	notifier := &signalNotifier{got: make(chan string, 1)}
+	svc := service.NewReminderService(repo, nil, notifier, nil, fake)
+	r := dueReminder(repo, "r1", "", "Read", fakeNow)
+
+	done := make(chan struct{})
+	go func() {
+		svc.StartScheduler()
+		close(done)
+	}()
+	defer func() {
+		svc.Stop()
+		<-done
+	}()
+
+	fake.WaitForTickers(1)
+	fake.Advance(service.SchedulerInterval)
+
+	select {
+	case msg := <-notifier.got:
+		if msg != "Read" {
+			t.Errorf("expected 'Read', got %q", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not fire after advancing the fake clock")
+	}
+	if want := fakeNow.Add(24 * time.Hour); !r.NextRing.Equal(want) {
+		t.Errorf("expected NextRing %s, got %s", want, r.NextRing)
+	}
+}
+
+func TestReminderService_UsesClockForScheduling(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow) // 21:00
+	repo := newMockReminderRepo()
+	svc := service.NewReminderService(repo, nil, nil, nil, fake)
+
+	r, err := svc.AddReminder(ctx, "", "", "Read", 22, 30, domain.FrequencyDaily)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if want := time.Date(2026, time.March, 10, 22, 30, 0, 0, time.UTC); !r.NextRing.Equal(want) {
+		t.Errorf("expected first ring %s, got %s", want, r.NextRing)
+	}
+
+	fake.Advance(2 * time.Hour) // 23:00, past today's occurrence
+	if err := svc.DismissReminder(ctx, r.ID); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if want := time.Date(2026, time.March, 11, 22, 30, 0, 0, time.UTC); !repo.reminders[r.ID].NextRing.Equal(want) {
+		t.Errorf("expected dismiss to advance to %s, got %s", want, repo.reminders[r.ID].NextRing)
 	}
 }
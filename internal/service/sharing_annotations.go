@@ -0,0 +1,343 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// ShareFormatReadwise is the CSV layout Readwise accepts as highlight import.
+const ShareFormatReadwise ShareFormat = "readwise"
+
+// readwiseHeader is the column layout of the Readwise CSV import.
+var readwiseHeader = []string{"Highlight", "Title", "Author", "URL", "Note", "Location", "Date"}
+
+// readwiseBookmarkTag marks bookmarks in Readwise: a note starting with
+// ".tag" becomes a tag on import.
+const readwiseBookmarkTag = ".marque-page"
+
+// HighlightFilter narrows the library-wide highlights export. Zero values
+// match everything.
+type HighlightFilter struct {
+	Tag   string    // tag de fiche de lecture, sans tenir compte de la casse
+	Since time.Time // inclus
+	Until time.Time // exclu
+}
+
+// AnnotationExport is the document written by annotation exports.
+type AnnotationExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Books      []AnnotatedBook `json:"books"`
+}
+
+// AnnotatedBook holds the exported annotations of one book, by chapter.
+type AnnotatedBook struct {
+	BookID   string              `json:"book_id"`
+	Title    string              `json:"title"`
+	Author   string              `json:"author,omitempty"`
+	Chapters []AnnotationChapter `json:"chapters"`
+}
+
+// AnnotationChapter groups the annotations of consecutive reader pages. Title
+// is empty for books without chapter markers, such as PDFs.
+type AnnotationChapter struct {
+	Title       string               `json:"title"`
+	Annotations []ExportedAnnotation `json:"annotations"`
+}
+
+// ExportedAnnotation is a bookmark or a highlight with its page locator and
+// the opening of the annotated page.
+type ExportedAnnotation struct {
+	ID        string                `json:"id"`
+	Type      domain.AnnotationType `json:"type"`
+	Page      int                   `json:"page"`
+	Text      string                `json:"text,omitempty"`
+	Note      string                `json:"note,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+// SetContentReader gives annotation exports and highlight cards access to
+// the text of annotated pages. Without it, exports carry page locators only.
+func (s *SharingService) SetContentReader(reader port.ContentReader) {
+	s.contentReader = reader
+}
+
+// ExportBookAnnotations exports every bookmark and highlight of a book in
+// Markdown, JSON or Readwise CSV.
+func (s *SharingService) ExportBookAnnotations(ctx context.Context, bookID string, format ShareFormat, outputDir string) (string, error) {
+	if s.annotRepo == nil {
+		return "", fmt.Errorf("annotations unavailable")
+	}
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return "", fmt.Errorf("book not found: %w", err)
+	}
+	entry, err := s.annotatedBook(ctx, book, func(*domain.Annotation) bool { return true })
+	if err != nil {
+		return "", err
+	}
+	doc := &AnnotationExport{ExportedAt: s.clock.Now(), Books: []AnnotatedBook{entry}}
+	name := fmt.Sprintf("orus_annotations_%s_%s", sanitizeFileName(book.Title), s.clock.Now().Format("20060102"))
+	return s.writeAnnotations(doc, s.tr.get().T("export.annotations_title", book.Title), "", format, outputDir, name)
+}
+
+// ExportHighlights exports the highlights of the whole library, keeping the
+// books whose reading sheet has filter.Tag and the highlights created within
+// the filter dates.
+func (s *SharingService) ExportHighlights(ctx context.Context, filter HighlightFilter, format ShareFormat, outputDir string) (string, error) {
+	if s.annotRepo == nil {
+		return "", fmt.Errorf("annotations unavailable")
+	}
+	books, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list books: %w", err)
+	}
+	keep := func(a *domain.Annotation) bool {
+		return a.AnnotationType == domain.AnnotationHighlight &&
+			(filter.Since.IsZero() || !a.CreatedAt.Before(filter.Since)) &&
+			(filter.Until.IsZero() || a.CreatedAt.Before(filter.Until))
+	}
+	doc := &AnnotationExport{ExportedAt: s.clock.Now()}
+	for _, book := range books {
+		if filter.Tag != "" && !s.bookHasTag(ctx, book.ID, filter.Tag) {
+			continue
+		}
+		entry, err := s.annotatedBook(ctx, book, keep)
+		if err != nil {
+			return "", err
+		}
+		if len(entry.Chapters) > 0 {
+			doc.Books = append(doc.Books, entry)
+		}
+	}
+	name := "orus_surlignages_" + s.clock.Now().Format("20060102")
+	return s.writeAnnotations(doc, s.tr.get().T("export.highlights_title"), describeHighlightFilter(s.tr.get(), filter), format, outputDir, name)
+}
+
+func (s *SharingService) bookHasTag(ctx context.Context, bookID, tag string) bool {
+	sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, bookID)
+	if sheet == nil {
+		return false
+	}
+	for _, t := range sheet.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// annotatedBook collects the annotations of book accepted by keep, in page
+// order, grouped by the chapter each page starts in.
+func (s *SharingService) annotatedBook(ctx context.Context, book *domain.Book, keep func(*domain.Annotation) bool) (AnnotatedBook, error) {
+	entry := AnnotatedBook{BookID: book.ID, Title: book.Title, Author: book.Author}
+	annotations, err := s.annotRepo.ListAllAnnotationOfABook(ctx, book.ID)
+	if err != nil {
+		return entry, fmt.Errorf("failed to list annotations: %w", err)
+	}
+	var kept []*domain.Annotation
+	for _, a := range annotations {
+		if keep(a) {
+			kept = append(kept, a)
+		}
+	}
+	if len(kept) == 0 {
+		return entry, nil
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].PageNo != kept[j].PageNo {
+			return kept[i].PageNo < kept[j].PageNo
+		}
+		return kept[i].CreatedAt.Before(kept[j].CreatedAt)
+	})
+
+	var pages []string
+	if s.contentReader != nil && book.FilePath != "" {
+		if pages, err = s.contentReader.ReadBookText(ctx, book.FilePath); err != nil {
+			// Le fichier a pu être déplacé : on exporte quand même les repères.
+			s.logger.Warn("book text unavailable", "book_id", book.ID, "path", book.FilePath, "error", err)
+			pages = nil
+		}
+	}
+	chapters := pageChapters(pages)
+	for _, a := range kept {
+		item := ExportedAnnotation{ID: a.ID, Type: a.AnnotationType, Page: a.PageNo, Note: a.Note, CreatedAt: a.CreatedAt}
+		chapter := ""
+		if a.PageNo >= 1 && a.PageNo <= len(pages) {
+			item.Text = pageExcerpt(pages[a.PageNo-1])
+			chapter = chapters[a.PageNo-1]
+		}
+		if n := len(entry.Chapters); n == 0 || entry.Chapters[n-1].Title != chapter {
+			entry.Chapters = append(entry.Chapters, AnnotationChapter{Title: chapter})
+		}
+		last := &entry.Chapters[len(entry.Chapters)-1]
+		last.Annotations = append(last.Annotations, item)
+	}
+	return entry, nil
+}
+
+// pageChapters returns, for each reader page, the chapter it starts in, as
+// marked by the "═══ Chapitre N ═══" lines of the EPUB extractor.
+func pageChapters(pages []string) []string {
+	chapters := make([]string, len(pages))
+	current := ""
+	for i, page := range pages {
+		lines := strings.Split(page, "\n")
+		if title, ok := chapterMarker(lines[0]); ok {
+			current = title
+		}
+		chapters[i] = current
+		for _, line := range lines[1:] {
+			if title, ok := chapterMarker(line); ok {
+				current = title
+			}
+		}
+	}
+	return chapters
+}
+
+func chapterMarker(line string) (string, bool) {
+	if !strings.HasPrefix(line, "═══") {
+		return "", false
+	}
+	return strings.Trim(line, "═ "), true
+}
+
+func describeHighlightFilter(tr *i18n.Localizer, f HighlightFilter) string {
+	var parts []string
+	if f.Tag != "" {
+		parts = append(parts, tr.T("export.filter.tag", f.Tag))
+	}
+	if !f.Since.IsZero() {
+		parts = append(parts, tr.T("export.filter.since", tr.Date(f.Since, i18n.DateShort)))
+	}
+	if !f.Until.IsZero() {
+		parts = append(parts, tr.T("export.filter.until", tr.Date(f.Until, i18n.DateShort)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (s *SharingService) writeAnnotations(doc *AnnotationExport, title, subtitle string, format ShareFormat, outputDir, name string) (string, error) {
+	var content []byte
+	ext := string(format)
+	switch format {
+	case ShareFormatJSON:
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode json: %w", err)
+		}
+		content = data
+	case ShareFormatReadwise:
+		content = annotationsReadwiseCSV(doc)
+		ext = "csv"
+	case ShareFormatMarkdown:
+		content = []byte(annotationsMarkdown(s.tr.get(), doc, title, subtitle))
+	default:
+		return "", fmt.Errorf("unsupported annotation export format: %s", format)
+	}
+	filePath := filepath.Join(outputDir, name+"."+ext)
+	if err := os.WriteFile(filePath, content, 0600); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	return filePath, nil
+}
+
+// annotationsMarkdown renders one section per book when doc spans several
+// books, and puts the single book in the title otherwise.
+func annotationsMarkdown(tr *i18n.Localizer, doc *AnnotationExport, title, subtitle string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+	highlights, bookmarks := 0, 0
+	for _, b := range doc.Books {
+		for _, c := range b.Chapters {
+			for _, a := range c.Annotations {
+				if a.Type == domain.AnnotationHighlight {
+					highlights++
+				} else {
+					bookmarks++
+				}
+			}
+		}
+	}
+	fmt.Fprintf(&sb, "%s — %s, %s", tr.T("export.exported_on", tr.Date(doc.ExportedAt, i18n.DateLong)),
+		tr.N("export.highlight_count", highlights), tr.N("export.bookmark_count", bookmarks))
+	if subtitle != "" {
+		sb.WriteString(" · " + subtitle)
+	}
+	sb.WriteString("\n")
+	if len(doc.Books) == 0 {
+		sb.WriteString("\n" + tr.T("export.no_annotation") + "\n")
+	}
+
+	single := len(doc.Books) == 1 && strings.HasSuffix(title, doc.Books[0].Title)
+	for _, b := range doc.Books {
+		chapterLevel := "##"
+		if single {
+			if b.Author != "" {
+				fmt.Fprintf(&sb, "\n*%s*\n", b.Author)
+			}
+		} else {
+			chapterLevel = "###"
+			fmt.Fprintf(&sb, "\n## %s\n", b.Title)
+			if b.Author != "" {
+				fmt.Fprintf(&sb, "\n*%s*\n", b.Author)
+			}
+		}
+		for _, c := range b.Chapters {
+			if c.Title != "" {
+				fmt.Fprintf(&sb, "\n%s %s\n", chapterLevel, c.Title)
+			}
+			for _, a := range c.Annotations {
+				kind := tr.T("export.highlight")
+				if a.Type != domain.AnnotationHighlight {
+					kind = tr.T("export.bookmark")
+				}
+				fmt.Fprintf(&sb, "\n**p. %d** · %s · %s\n", a.Page, kind, tr.Date(a.CreatedAt, i18n.DateShort))
+				if a.Text != "" {
+					fmt.Fprintf(&sb, "\n> %s\n", a.Text)
+				}
+				if a.Note != "" {
+					fmt.Fprintf(&sb, "\n%s %s\n", tr.T("export.label.note"), a.Note)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+// annotationsReadwiseCSV writes one row per annotation. Readwise requires a
+// highlight text, so pages whose text is unknown are cited by their locator.
+func annotationsReadwiseCSV(doc *AnnotationExport) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(readwiseHeader)
+	for _, b := range doc.Books {
+		for _, c := range b.Chapters {
+			for _, a := range c.Annotations {
+				text := a.Text
+				if text == "" {
+					text = fmt.Sprintf("Page %d", a.Page)
+				}
+				note := a.Note
+				if a.Type != domain.AnnotationHighlight {
+					note = strings.TrimSpace(readwiseBookmarkTag + " " + note)
+				}
+				_ = w.Write([]string{text, b.Title, b.Author, "", note, strconv.Itoa(a.Page), a.CreatedAt.Format("2006-01-02 15:04:05")})
+			}
+		}
+	}
+	w.Flush()
+	return buf.Bytes()
+}
@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+func TestSharingService_AnnotationExports(t *testing.T) {
+	ctx := context.Background()
+	books := &mockSharingBookRepo{books: []*domain.Book{
+		{ID: "b1", Title: "Dune", Author: "Frank Herbert", FilePath: "/dune.epub"},
+		{ID: "b2", Title: "Fondation", Author: "Isaac Asimov"},
+	}}
+	sheets := newMockSharingSheetRepo()
+	sheet, _ := domain.NewReadingSheet("b1", "Dune", "", 4, nil, []string{"SF", "Classique"}, fakeNow)
+	sheets.sheets[sheet.ID] = sheet
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 20, 0, 0, 0, time.UTC) }
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a3", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 3, Note: "Le ver, enfin", CreatedAt: day(5)},
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 1, CreatedAt: day(1)},
+		{ID: "a2", BookID: "b1", AnnotationType: domain.AnnotationBookmark, PageNo: 2, Note: "reprendre ici", CreatedAt: day(2)},
+		{ID: "a4", BookID: "b2", AnnotationType: domain.AnnotationHighlight, PageNo: 7, CreatedAt: day(9)},
+	}}
+	svc := service.NewSharingService(books, sheets, nil, nil, annots, nil, clock.NewFakeClock(fakeNow))
+	svc.SetContentReader(&pagesReader{pages: []string{
+		"═══ Chapitre 1 ═══\nLa peur tue l'esprit.",
+		"Suite du premier chapitre.\n═══ Chapitre 2 ═══\nLe désert.",
+		"Le sable chante, le ver approche.",
+	}})
+
+	t.Run("BookMarkdown", func(t *testing.T) {
+		path, err := svc.ExportBookAnnotations(ctx, "b1", service.ShareFormatMarkdown, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if filepath.Base(path) != "orus_annotations_Dune_20260310.md" {
+			t.Errorf("unexpected file name %q", filepath.Base(path))
+		}
+		data, _ := os.ReadFile(path)
+		md := string(data)
+		for _, want := range []string{
+			"# Annotations — Dune", "Exporte le 10 mars 2026 — 2 surlignages, 1 marque-page",
+			"## Chapitre 1", "**p. 1** · Surlignage", "> La peur tue l'esprit.",
+			"**p. 2** · Marque-page", "Note : reprendre ici",
+			"## Chapitre 2", "> Le sable chante, le ver approche.", "Note : Le ver, enfin",
+		} {
+			if !strings.Contains(md, want) {
+				t.Errorf("expected %q in:\n%s", want, md)
+			}
+		}
+		// La page 2 commence dans le chapitre 1 : le marque-page y reste.
+		if strings.Index(md, "**p. 2**") > strings.Index(md, "## Chapitre 2") {
+			t.Error("expected page 2 under the chapter it starts in")
+		}
+	})
+
+	t.Run("BookJSON", func(t *testing.T) {
+		path, err := svc.ExportBookAnnotations(ctx, "b1", service.ShareFormatJSON, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		var doc service.AnnotationExport
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if len(doc.Books) != 1 || len(doc.Books[0].Chapters) != 2 {
+			t.Fatalf("expected one book with two chapters, got %+v", doc.Books)
+		}
+		first := doc.Books[0].Chapters[0]
+		if first.Title != "Chapitre 1" || len(first.Annotations) != 2 || first.Annotations[1].Note != "reprendre ici" {
+			t.Errorf("unexpected first chapter: %+v", first)
+		}
+	})
+
+	t.Run("ReadwiseCSV", func(t *testing.T) {
+		path, err := svc.ExportBookAnnotations(ctx, "b1", service.ShareFormatReadwise, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if filepath.Ext(path) != ".csv" {
+			t.Errorf("expected a .csv file, got %q", path)
+		}
+		f, _ := os.Open(path)
+		defer f.Close()
+		rows, err := csv.NewReader(f).ReadAll()
+		if err != nil {
+			t.Fatalf("invalid csv: %v", err)
+		}
+		if len(rows) != 4 || strings.Join(rows[0], ",") != "Highlight,Title,Author,URL,Note,Location,Date" {
+			t.Fatalf("unexpected rows: %v", rows)
+		}
+		if rows[2][4] != ".marque-page reprendre ici" || rows[2][5] != "2" {
+			t.Errorf("unexpected bookmark row: %v", rows[2])
+		}
+		if rows[3][0] != "Le sable chante, le ver approche." || rows[3][6] != "2026-03-05 20:00:00" {
+			t.Errorf("unexpected highlight row: %v", rows[3])
+		}
+	})
+
+	t.Run("HighlightsFilteredByTag", func(t *testing.T) {
+		path, err := svc.ExportHighlights(ctx, service.HighlightFilter{Tag: "sf"}, service.ShareFormatJSON, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if filepath.Base(path) != "orus_surlignages_20260310.json" {
+			t.Errorf("unexpected file name %q", filepath.Base(path))
+		}
+		data, _ := os.ReadFile(path)
+		var doc service.AnnotationExport
+		_ = json.Unmarshal(data, &doc)
+		if len(doc.Books) != 1 || doc.Books[0].BookID != "b1" {
+			t.Fatalf("expected only the tagged book, got %+v", doc.Books)
+		}
+		for _, c := range doc.Books[0].Chapters {
+			for _, a := range c.Annotations {
+				if a.Type != domain.AnnotationHighlight {
+					t.Errorf("expected highlights only, got %+v", a)
+				}
+			}
+		}
+	})
+
+	t.Run("HighlightsFilteredByDate", func(t *testing.T) {
+		filter := service.HighlightFilter{Since: day(5), Until: day(9)}
+		path, err := svc.ExportHighlights(ctx, filter, service.ShareFormatMarkdown, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		md := string(data)
+		if !strings.Contains(md, "**p. 3**") || strings.Contains(md, "**p. 1**") || strings.Contains(md, "Fondation") {
+			t.Errorf("expected only the highlight of March 5th, got:\n%s", md)
+		}
+		if !strings.Contains(md, "## Dune") || !strings.Contains(md, "### Chapitre 2") {
+			t.Errorf("expected book sections in the library export, got:\n%s", md)
+		}
+	})
+
+	t.Run("WithoutContentReader", func(t *testing.T) {
+		plain := service.NewSharingService(books, sheets, nil, nil, annots, nil, clock.NewFakeClock(fakeNow))
+		path, err := plain.ExportBookAnnotations(ctx, "b2", service.ShareFormatReadwise, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), "Page 7,Fondation,Isaac Asimov,,,7,") {
+			t.Errorf("expected the page locator as highlight text, got:\n%s", data)
+		}
+	})
+}
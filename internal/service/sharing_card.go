@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+var (
+	// ErrNoCardRenderer indicates share cards were requested before SetCardRenderer.
+	ErrNoCardRenderer = errors.New("no share card renderer configured")
+	// ErrNoHighlight indicates the page has no highlight to share.
+	ErrNoHighlight = errors.New("no highlight on this page")
+)
+
+// highlightExcerptLen bounds the passage taken from a highlighted page: a
+// reader page holds far more text than fits on a card.
+const highlightExcerptLen = 280
+
+// SetCardRenderer enables share cards. HighlightCard also needs the content
+// reader set by SetContentReader.
+func (s *SharingService) SetCardRenderer(renderer port.CardRenderer) {
+	s.cards = renderer
+}
+
+// QuoteCard renders the index-th quote of a reading sheet as a PNG card. A
+// trailing page reference such as "(p. 42)" moves to the card footer.
+func (s *SharingService) QuoteCard(ctx context.Context, sheetID string, index int) ([]byte, error) {
+	if s.cards == nil {
+		return nil, ErrNoCardRenderer
+	}
+	// La citation est choisie par l'utilisateur : pas de masquage.
+	sheet, err := s.sheetRepo.GetSheetByID(WithDecryption(ctx), sheetID)
+	if err != nil {
+		return nil, fmt.Errorf("sheet not found: %w", err)
+	}
+	if index < 0 || index >= len(sheet.Quotes) {
+		return nil, fmt.Errorf("quote %d out of range: sheet has %d quote(s)", index, len(sheet.Quotes))
+	}
+	book := s.sheetBook(ctx, sheet)
+	quote := parseQuote(sheet.Quotes[index])
+	return s.cards.RenderQuoteCard(port.QuoteCard{Quote: quote.Text, BookTitle: sheet.BookTitle, Author: book.Author, Page: quote.Page})
+}
+
+// HighlightCard renders the passage highlighted on a reader page as a PNG card.
+func (s *SharingService) HighlightCard(ctx context.Context, bookID string, page int) ([]byte, error) {
+	if s.cards == nil || s.contentReader == nil || s.annotRepo == nil {
+		return nil, ErrNoCardRenderer
+	}
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("book not found: %w", err)
+	}
+	annotations, err := s.annotRepo.GetAnnotationByPage(ctx, page, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list annotations: %w", err)
+	}
+	highlighted := false
+	for _, a := range annotations {
+		highlighted = highlighted || a.AnnotationType == domain.AnnotationHighlight
+	}
+	if !highlighted {
+		return nil, ErrNoHighlight
+	}
+	pages, err := s.contentReader.ReadBookText(ctx, book.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read book: %w", err)
+	}
+	if page < 1 || page > len(pages) {
+		return nil, fmt.Errorf("page %d out of range: book has %d page(s)", page, len(pages))
+	}
+	return s.cards.RenderQuoteCard(port.QuoteCard{Quote: pageExcerpt(pages[page-1]), BookTitle: book.Title, Author: book.Author, Page: page})
+}
+
+// FinishedCard renders the "book finished" card with the statistics of the
+// book's reading sessions and the rating of its sheet.
+func (s *SharingService) FinishedCard(ctx context.Context, bookID string) ([]byte, error) {
+	if s.cards == nil {
+		return nil, ErrNoCardRenderer
+	}
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("book not found: %w", err)
+	}
+	card := port.FinishedCard{BookTitle: book.Title, Author: book.Author, Pages: book.TotalPages, FinishedAt: s.clock.Now()}
+	if sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, bookID); sheet != nil {
+		card.Rating = sheet.Rating
+	}
+	if s.sessionRepo != nil {
+		sessions, err := s.sessionRepo.GetSessionByID(ctx, bookID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+		var first, last time.Time
+		for _, session := range sessions {
+			start := session.StartedAt
+			if start.IsZero() {
+				start = session.LastReadingTime // session antérieure à started_at
+			}
+			card.ReadingTime += session.LastReadingTime.Sub(start)
+			if first.IsZero() || start.Before(first) {
+				first = start
+			}
+			if session.LastReadingTime.After(last) {
+				last = session.LastReadingTime
+			}
+		}
+		card.Sessions = len(sessions)
+		if card.Sessions > 0 {
+			card.FinishedAt = last
+			card.Days = calendarDays(first, last)
+		}
+	}
+	return s.cards.RenderFinishedCard(card)
+}
+
+// SaveCard writes a rendered card as orus_carte_<name>_<date>.png.
+func (s *SharingService) SaveCard(png []byte, outputDir, name string) (string, error) {
+	filePath := filepath.Join(outputDir, fmt.Sprintf("orus_carte_%s_%s.png", sanitizeFileName(name), s.clock.Now().Format("20060102")))
+	if err := os.WriteFile(filePath, png, 0600); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	return filePath, nil
+}
+
+// pageExcerpt keeps the first sentences of a reader page, without the page
+// and chapter separators the extractor inserts.
+func pageExcerpt(page string) string {
+	var words []string
+	for _, line := range strings.Split(page, "\n") {
+		if strings.HasPrefix(line, "── Page") || strings.HasPrefix(line, "═══") {
+			continue
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+	text := strings.Join(words, " ")
+	if len(text) <= highlightExcerptLen {
+		return text
+	}
+	// Coupe sur une frontière de rune : une page CJK ne doit pas finir par
+	// un caractère tronqué.
+	limit := highlightExcerptLen
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	head := text[:limit]
+	if cut := strings.LastIndexAny(head, ".!?。！？"); cut >= highlightExcerptLen/3 {
+		_, size := utf8.DecodeRuneInString(head[cut:])
+		return head[:cut+size]
+	}
+	if cut := strings.LastIndex(head, " "); cut > 0 {
+		return head[:cut] + "…"
+	}
+	return head + "…"
+}
+
+// calendarDays counts the days from first to last, both included.
+func calendarDays(first, last time.Time) int {
+	y1, m1, d1 := first.Date()
+	y2, m2, d2 := last.Date()
+	from := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours()/24) + 1
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// recordingCardRenderer keeps the last card it was asked to draw.
+type recordingCardRenderer struct {
+	quote    port.QuoteCard
+	finished port.FinishedCard
+}
+
+func (r *recordingCardRenderer) RenderQuoteCard(card port.QuoteCard) ([]byte, error) {
+	r.quote = card
+	return []byte("png-quote"), nil
+}
+
+func (r *recordingCardRenderer) RenderFinishedCard(card port.FinishedCard) ([]byte, error) {
+	r.finished = card
+	return []byte("png-finished"), nil
+}
+
+type pagesReader struct{ pages []string }
+
+func (p *pagesReader) ReadBookText(_ context.Context, _ string) ([]string, error) {
+	return p.pages, nil
+}
+
+func TestSharingService_ShareCards(t *testing.T) {
+	ctx := context.Background()
+	books := &mockSharingBookRepo{books: []*domain.Book{
+		{ID: "b1", Title: "Dune", Author: "Frank Herbert", FilePath: "/dune.epub", TotalPages: 812},
+	}}
+	sheets := newMockSharingSheetRepo()
+	sheet, _ := domain.NewReadingSheet("b1", "Dune", "", 4, []string{"La peur tue l'esprit. (p. 42)"}, nil, fakeNow)
+	sheets.sheets[sheet.ID] = sheet
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 2},
+		{ID: "a2", BookID: "b1", AnnotationType: domain.AnnotationBookmark, PageNo: 1},
+	}}
+	sessions := &recordingSessionRepo{}
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	_ = sessions.SaveSession(ctx, &domain.ReadingSession{SessionID: "s1", BookID: "b1", StartedAt: day(1, 20), LastReadingTime: day(1, 21)})
+	_ = sessions.SaveSession(ctx, &domain.ReadingSession{SessionID: "s2", BookID: "b1", StartedAt: day(8, 19), LastReadingTime: day(8, 21).Add(30 * time.Minute)})
+
+	svc := service.NewSharingService(books, sheets, nil, sessions, annots, nil, clock.NewFakeClock(fakeNow))
+	if _, err := svc.QuoteCard(ctx, sheet.ID, 0); !errors.Is(err, service.ErrNoCardRenderer) {
+		t.Fatalf("expected ErrNoCardRenderer before SetCardRenderer, got: %v", err)
+	}
+	renderer := &recordingCardRenderer{}
+	long := strings.Repeat("Le desert avance sans bruit et sans fin ", 10)
+	svc.SetCardRenderer(renderer)
+	svc.SetContentReader(&pagesReader{pages: []string{
+		"═══ Chapitre 1 ═══\nPremière page.",
+		"Le sable chante.\nLe ver approche. " + long + "\n── Page 2 ──",
+	}})
+
+	t.Run("Quote", func(t *testing.T) {
+		png, err := svc.QuoteCard(ctx, sheet.ID, 0)
+		if err != nil || string(png) != "png-quote" {
+			t.Fatalf("expected the rendered card, got %q, %v", png, err)
+		}
+		want := port.QuoteCard{Quote: "La peur tue l'esprit.", BookTitle: "Dune", Author: "Frank Herbert", Page: 42}
+		if renderer.quote != want {
+			t.Errorf("expected %+v, got %+v", want, renderer.quote)
+		}
+		if _, err := svc.QuoteCard(ctx, sheet.ID, 1); err == nil {
+			t.Error("expected an error for a missing quote")
+		}
+	})
+
+	t.Run("Highlight", func(t *testing.T) {
+		if _, err := svc.HighlightCard(ctx, "b1", 2); err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		q := renderer.quote
+		if q.Page != 2 || !strings.HasPrefix(q.Quote, "Le sable chante. Le ver approche.") || len(q.Quote) > 290 {
+			t.Errorf("unexpected highlight card: %+v", q)
+		}
+		if strings.Contains(q.Quote, "── Page") {
+			t.Error("expected page separators to be dropped")
+		}
+		if _, err := svc.HighlightCard(ctx, "b1", 1); !errors.Is(err, service.ErrNoHighlight) {
+			t.Errorf("expected ErrNoHighlight on a bookmarked page, got: %v", err)
+		}
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		if _, err := svc.FinishedCard(ctx, "b1"); err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		f := renderer.finished
+		if f.Sessions != 2 || f.ReadingTime != 3*time.Hour+30*time.Minute || f.Days != 8 || f.Rating != 4 || f.Pages != 812 {
+			t.Errorf("unexpected finished card: %+v", f)
+		}
+		if !f.FinishedAt.Equal(day(8, 21).Add(30 * time.Minute)) {
+			t.Errorf("expected the last session as finish date, got %v", f.FinishedAt)
+		}
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		path, err := svc.SaveCard([]byte("png"), t.TempDir(), "Dune: citation")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if filepath.Base(path) != "orus_carte_Dune_citation_20260310.png" {
+			t.Errorf("unexpected file name %q", filepath.Base(path))
+		}
+		if data, _ := os.ReadFile(path); string(data) != "png" {
+			t.Errorf("unexpected content %q", data)
+		}
+	})
+}
+
+func TestSharingService_HighlightCardSpacelessText(t *testing.T) {
+	ctx := context.Background()
+	books := &mockSharingBookRepo{books: []*domain.Book{{ID: "b1", Title: "三体", FilePath: "/santi.epub"}}}
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 1},
+	}}
+	renderer := &recordingCardRenderer{}
+	svc := service.NewSharingService(books, newMockSharingSheetRepo(), nil, nil, annots, nil, clock.NewFakeClock(fakeNow))
+	svc.SetCardRenderer(renderer)
+	svc.SetContentReader(&pagesReader{pages: []string{strings.Repeat("长", 200)}})
+
+	if _, err := svc.HighlightCard(ctx, "b1", 1); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	q := renderer.quote.Quote
+	if !utf8.ValidString(q) || !strings.HasSuffix(q, "…") || len(q) > 290 {
+		t.Errorf("expected a hard cut on a rune boundary, got %q", q)
+	}
+	if !strings.HasPrefix(q, strings.Repeat("长", 90)) {
+		t.Errorf("expected the excerpt to keep the first characters, got %q", q)
+	}
+}
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	ShareFormatBibTeX  ShareFormat = "bib"
+	ShareFormatCSLJSON ShareFormat = "csl.json"
+)
+
+// citationName is one author split into family and given names. A name with
+// a single word only has Family.
+type citationName struct {
+	Family, Given string
+}
+
+// citationNote is a quote or a highlight with the page it refers to (0 when
+// the quote carries no page reference).
+type citationNote struct {
+	Text string // vide pour un surlignage
+	Page int
+}
+
+// citation gathers what both citation formats need for one book.
+type citation struct {
+	Key     string
+	Book    *domain.Book
+	Authors []citationName
+	Notes   []citationNote
+}
+
+// quotePagePattern matches a trailing page reference such as "(p. 42)",
+// "(p.42)" or "(page 42)" at the end of a reading-sheet quote.
+var quotePagePattern = regexp.MustCompile(`(?i)\s*[(\[]\s*(?:p\.?|pp\.?|page)\s*(\d+)\s*[)\]]\s*$`)
+
+// authorSeparator splits co-authors: "A & B", "A and B", "A et B", "A; B".
+var authorSeparator = regexp.MustCompile(`\s*(?:&|;|\band\b|\bet\b)\s*`)
+
+// nameParticles stay with the family name: "Ludwig van Beethoven" is cited as
+// "van Beethoven, Ludwig".
+var nameParticles = map[string]bool{
+	"de": true, "du": true, "des": true, "d'": true, "la": true, "le": true,
+	"van": true, "von": true, "der": true, "den": true, "da": true, "di": true, "del": true,
+}
+
+// ExportCitations writes the given books as a BibTeX or CSL-JSON bibliography.
+// An empty bookIDs exports the whole library.
+func (s *SharingService) ExportCitations(ctx context.Context, bookIDs []string, format ShareFormat, outputDir string) (string, error) {
+	var books []*domain.Book
+	if len(bookIDs) == 0 {
+		all, err := s.bookRepo.ListAll(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list books: %w", err)
+		}
+		books = all
+	}
+	for _, id := range bookIDs {
+		book, err := s.bookRepo.GetByID(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("book not found: %w", err)
+		}
+		books = append(books, book)
+	}
+	content, err := s.buildCitations(ctx, books, format)
+	if err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(outputDir, fmt.Sprintf("orus_citations_%s.%s", s.clock.Now().Format("20060102"), format))
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	return filePath, nil
+}
+
+// buildCitations renders books in a citation format.
+func (s *SharingService) buildCitations(ctx context.Context, books []*domain.Book, format ShareFormat) (string, error) {
+	entries, err := s.citations(ctx, books)
+	if err != nil {
+		return "", err
+	}
+	if format == ShareFormatCSLJSON {
+		return buildCSLJSON(s.tr.get(), entries)
+	}
+	return buildBibTeX(s.tr.get(), entries), nil
+}
+
+// citations collects names, keys and page-referenced notes for books. Keys
+// are unique within the export.
+func (s *SharingService) citations(ctx context.Context, books []*domain.Book) ([]citation, error) {
+	used := map[string]bool{}
+	entries := make([]citation, 0, len(books))
+	for _, book := range books {
+		c := citation{Book: book, Authors: splitAuthors(book.Author)}
+		c.Key = uniqueCitationKey(citationKey(c), used)
+
+		if sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, book.ID); sheet != nil {
+			for _, q := range sheet.Quotes {
+				c.Notes = append(c.Notes, parseQuote(q))
+			}
+		}
+		if s.annotRepo != nil {
+			annotations, err := s.annotRepo.ListAllAnnotationOfABook(ctx, book.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list annotations: %w", err)
+			}
+			for _, p := range (TemplateBook{Annotations: annotations}).withPages().Highlights {
+				c.Notes = append(c.Notes, citationNote{Page: p})
+			}
+		}
+		entries = append(entries, c)
+	}
+	return entries, nil
+}
+
+// parseQuote splits a trailing page reference off a quote.
+func parseQuote(q string) citationNote {
+	q = strings.TrimSpace(q)
+	m := quotePagePattern.FindStringSubmatchIndex(q)
+	if m == nil {
+		return citationNote{Text: q}
+	}
+	page, _ := strconv.Atoi(q[m[2]:m[3]])
+	return citationNote{Text: strings.TrimSpace(q[:m[0]]), Page: page}
+}
+
+// splitAuthors parses a free-form author field; "Nom, Prenom" is accepted for
+// each author. Placeholders written by the extractor count as no author.
+func splitAuthors(author string) []citationName {
+	author = strings.TrimSpace(author)
+	switch strings.ToLower(author) {
+	case "", "unknown", "unknown author", "inconnu":
+		return nil
+	}
+	var names []citationName
+	for _, part := range authorSeparator.Split(author, -1) {
+		if part = strings.TrimSpace(part); part != "" {
+			names = append(names, splitName(part))
+		}
+	}
+	return names
+}
+
+func splitName(name string) citationName {
+	if family, given, ok := strings.Cut(name, ","); ok {
+		return citationName{Family: strings.TrimSpace(family), Given: strings.TrimSpace(given)}
+	}
+	words := strings.Fields(name)
+	if len(words) == 1 {
+		return citationName{Family: words[0]}
+	}
+	i := len(words) - 1
+	for i > 1 && nameParticles[strings.ToLower(words[i-1])] {
+		i--
+	}
+	return citationName{Family: strings.Join(words[i:], " "), Given: strings.Join(words[:i], " ")}
+}
+
+// citationKey builds "familyYEARword" from the first author, the year and the
+// first significant title word, folded to lowercase ASCII.
+func citationKey(c citation) string {
+	var sb strings.Builder
+	if len(c.Authors) > 0 {
+		if family := strings.Fields(c.Authors[0].Family); len(family) > 0 {
+			sb.WriteString(asciiKey(family[len(family)-1]))
+		}
+	}
+	if c.Book.Year > 0 {
+		sb.WriteString(strconv.Itoa(c.Book.Year))
+	}
+	for _, w := range strings.Fields(c.Book.Title) {
+		if w = asciiKey(w); len(w) > 3 || (w != "" && sb.Len() == 0) {
+			sb.WriteString(w)
+			break
+		}
+	}
+	if sb.Len() == 0 {
+		return "livre"
+	}
+	return sb.String()
+}
+
+func uniqueCitationKey(key string, used map[string]bool) string {
+	candidate := key
+	for i := 0; used[candidate]; i++ {
+		candidate = key + string(rune('a'+i%26)) + strings.Repeat("z", i/26)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// asciiKey folds accents and keeps only lowercase letters and digits.
+func asciiKey(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// --- BibTeX ---
+
+func buildBibTeX(tr *i18n.Localizer, entries []citation) string {
+	var sb strings.Builder
+	for i, c := range entries {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		b := c.Book
+		kind := "book"
+		if b.Publisher == "" && b.Year == 0 {
+			kind = "misc"
+		}
+		fmt.Fprintf(&sb, "@%s{%s,\n", kind, c.Key)
+		if len(c.Authors) > 0 {
+			names := make([]string, len(c.Authors))
+			for j, n := range c.Authors {
+				names[j] = bibtexName(n)
+			}
+			bibtexField(&sb, "author", strings.Join(names, " and "))
+		}
+		// Double accolades : les styles BibTeX ne changent pas la casse du titre.
+		bibtexField(&sb, "title", "{"+bibtexEscape(b.Title)+"}")
+		if b.Publisher != "" {
+			bibtexField(&sb, "publisher", bibtexEscape(b.Publisher))
+		}
+		if b.Year > 0 {
+			bibtexField(&sb, "year", strconv.Itoa(b.Year))
+		}
+		if b.ISBN != "" {
+			bibtexField(&sb, "isbn", bibtexEscape(b.ISBN))
+		}
+		if b.Series != "" {
+			bibtexField(&sb, "series", bibtexEscape(b.Series))
+			if b.SeriesIndex > 0 {
+				bibtexField(&sb, "number", strconv.FormatFloat(b.SeriesIndex, 'f', -1, 64))
+			}
+		}
+		if b.TotalPages > 0 {
+			bibtexField(&sb, "pagetotal", strconv.Itoa(b.TotalPages))
+		}
+		if len(c.Notes) > 0 {
+			notes := make([]string, len(c.Notes))
+			for j, n := range c.Notes {
+				notes[j] = bibtexNote(tr, n)
+			}
+			bibtexField(&sb, "annote", strings.Join(notes, "\n  "))
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+func bibtexField(sb *strings.Builder, name, value string) {
+	fmt.Fprintf(sb, "  %-9s = {%s},\n", name, value)
+}
+
+// bibtexName writes "Family, Given"; a family name with several words is
+// braced so BibTeX does not split it.
+func bibtexName(n citationName) string {
+	family := bibtexEscape(n.Family)
+	if n.Given == "" {
+		return "{" + family + "}"
+	}
+	return family + ", " + bibtexEscape(n.Given)
+}
+
+// bibtexNote renders a quote or a highlight with a non-breaking page reference.
+func bibtexNote(tr *i18n.Localizer, n citationNote) string {
+	switch {
+	case n.Text == "":
+		return fmt.Sprintf("%s, p.~%d", tr.T("export.highlight"), n.Page)
+	case n.Page > 0:
+		return fmt.Sprintf("``%s'', p.~%d", bibtexEscape(n.Text), n.Page)
+	default:
+		return fmt.Sprintf("``%s''", bibtexEscape(n.Text))
+	}
+}
+
+// bibtexEscape escapes the characters LaTeX treats specially.
+func bibtexEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\textbackslash{}`)
+		case '{', '}', '%', '&', '$', '#', '_':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case '^':
+			sb.WriteString(`\^{}`)
+		case '~':
+			sb.WriteString(`\~{}`)
+		case '\n', '\r':
+			sb.WriteByte(' ')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// --- CSL-JSON ---
+
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+type cslItem struct {
+	ID               string    `json:"id"`
+	Type             string    `json:"type"`
+	Title            string    `json:"title"`
+	Author           []cslName `json:"author,omitempty"`
+	Publisher        string    `json:"publisher,omitempty"`
+	Issued           *cslDate  `json:"issued,omitempty"`
+	ISBN             string    `json:"ISBN,omitempty"`
+	CollectionTitle  string    `json:"collection-title,omitempty"`
+	CollectionNumber string    `json:"collection-number,omitempty"`
+	NumberOfPages    string    `json:"number-of-pages,omitempty"`
+	Annote           string    `json:"annote,omitempty"`
+}
+
+func buildCSLJSON(tr *i18n.Localizer, entries []citation) (string, error) {
+	items := make([]cslItem, 0, len(entries))
+	for _, c := range entries {
+		b := c.Book
+		item := cslItem{ID: c.Key, Type: "book", Title: b.Title, Publisher: b.Publisher, ISBN: b.ISBN, CollectionTitle: b.Series}
+		for _, n := range c.Authors {
+			if n.Given == "" {
+				item.Author = append(item.Author, cslName{Literal: n.Family})
+			} else {
+				item.Author = append(item.Author, cslName{Family: n.Family, Given: n.Given})
+			}
+		}
+		if b.Year > 0 {
+			item.Issued = &cslDate{DateParts: [][]int{{b.Year}}}
+		}
+		if b.Series != "" && b.SeriesIndex > 0 {
+			item.CollectionNumber = strconv.FormatFloat(b.SeriesIndex, 'f', -1, 64)
+		}
+		if b.TotalPages > 0 {
+			item.NumberOfPages = strconv.Itoa(b.TotalPages)
+		}
+		notes := make([]string, len(c.Notes))
+		for j, n := range c.Notes {
+			switch {
+			case n.Text == "":
+				notes[j] = fmt.Sprintf("%s, p. %d", tr.T("export.highlight"), n.Page)
+			case n.Page > 0:
+				notes[j] = tr.T("export.quote_page", n.Text, n.Page)
+			default:
+				notes[j] = tr.T("common.quoted", n.Text)
+			}
+		}
+		item.Annote = strings.Join(notes, "\n")
+		items = append(items, item)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(items); err != nil {
+		return "", fmt.Errorf("failed to encode csl-json: %w", err)
+	}
+	return buf.String(), nil
+}
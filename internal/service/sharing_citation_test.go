@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+func newCitationFixture() *service.SharingService {
+	books := &mockSharingBookRepo{books: []*domain.Book{
+		{ID: "b1", Title: "C & C++: 100% {sûr}_#1", Author: "Émile Zoë de La Fontaine & Bob Smith",
+			Publisher: "O'Reilly & Fils", Year: 2019, ISBN: "978-1-4842-2692-6", TotalPages: 320,
+			Series: "Guides", SeriesIndex: 2},
+		{ID: "b2", Title: "Notes", Author: "Unknown", TotalPages: 12},
+		{ID: "b3", Title: "C et rien d'autre", Author: "Zoë Fontaine", Year: 2019},
+		{ID: "b4", Title: "C et rien d'autre", Author: "Zoë Fontaine", Year: 2019},
+	}}
+	sheets := newMockSharingSheetRepo()
+	sheet, _ := domain.NewReadingSheet("b1", "C", "", 0,
+		[]string{"Le ~ et le ^ coûtent 5$ (p. 42)", "Sans page"}, nil, time.Time{})
+	sheets.sheets[sheet.ID] = sheet
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 12},
+		{ID: "a2", BookID: "b1", AnnotationType: domain.AnnotationBookmark, PageNo: 3},
+	}}
+	return service.NewSharingService(books, sheets, nil, nil, annots, nil, clock.NewFakeClock(fakeNow))
+}
+
+func TestSharingService_ExportCitationsBibTeX(t *testing.T) {
+	svc := newCitationFixture()
+	dir := t.TempDir()
+
+	path, err := svc.ExportCitations(context.Background(), nil, service.ShareFormatBibTeX, dir)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if filepath.Base(path) != "orus_citations_20260310.bib" {
+		t.Errorf("unexpected file name %q", filepath.Base(path))
+	}
+	data, _ := os.ReadFile(path)
+	out := string(data)
+
+	for _, want := range []string{
+		"@book{fontaine2019sur1,",
+		`title     = {{C \& C++: 100\% \{sûr\}\_\#1}},`,
+		"author    = {de La Fontaine, Émile Zoë and Smith, Bob},",
+		`publisher = {O'Reilly \& Fils},`,
+		"isbn      = {978-1-4842-2692-6},",
+		"number    = {2},",
+		`annote    = {` + "``" + `Le \~{} et le \^{} coûtent 5\$'', p.~42`,
+		"``Sans page''",
+		"Surlignage, p.~12}",
+		// Ni éditeur ni année : entrée @misc, sans auteur fictif.
+		"@misc{notes,\n  title     = {{Notes}},\n  pagetotal = {12},\n}",
+		"@book{fontaine2019rien,",
+		"@book{fontaine2019riena,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected BibTeX to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "p.~3") {
+		t.Error("expected bookmarks to be left out of citations")
+	}
+}
+
+func TestSharingService_ExportCitationsCSLJSON(t *testing.T) {
+	svc := newCitationFixture()
+	dir := t.TempDir()
+
+	path, err := svc.ExportCitations(context.Background(), []string{"b1", "b2"}, service.ShareFormatCSLJSON, dir)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !strings.HasSuffix(path, ".csl.json") {
+		t.Errorf("expected a .csl.json file, got %q", path)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), `\u0026`) {
+		t.Error("expected & to be written as-is")
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatalf("expected valid JSON, got: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	first := items[0]
+	if first["title"] != "C & C++: 100% {sûr}_#1" || first["ISBN"] != "978-1-4842-2692-6" || first["type"] != "book" {
+		t.Errorf("unexpected first item: %v", first)
+	}
+	authors := first["author"].([]any)
+	if a := authors[0].(map[string]any); a["family"] != "de La Fontaine" || a["given"] != "Émile Zoë" {
+		t.Errorf("unexpected first author: %v", a)
+	}
+	if issued := first["issued"].(map[string]any)["date-parts"].([]any)[0].([]any); issued[0] != float64(2019) {
+		t.Errorf("unexpected issued date: %v", issued)
+	}
+	want := "« Le ~ et le ^ coûtent 5$ », p. 42\n« Sans page »\nSurlignage, p. 12"
+	if first["annote"] != want {
+		t.Errorf("expected annote %q, got %q", want, first["annote"])
+	}
+
+	second := items[1]
+	if _, ok := second["author"]; ok {
+		t.Errorf("expected no author for an unknown author, got %v", second["author"])
+	}
+	if second["title"] != "Notes" || second["number-of-pages"] != "12" {
+		t.Errorf("unexpected fallback item: %v", second)
+	}
+}
+
+func TestSharingService_ExportBookInfoBibTeX(t *testing.T) {
+	svc := newCitationFixture()
+	path, err := svc.ExportBookInfo(context.Background(), "b2", service.ShareFormatBibTeX, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !strings.HasSuffix(path, ".bib") {
+		t.Errorf("expected a .bib file, got %q", path)
+	}
+	if _, err := svc.ExportCitations(context.Background(), []string{"absent"}, service.ShareFormatBibTeX, t.TempDir()); err == nil {
+		t.Error("expected an error for an unknown book")
+	}
+}
@@ -0,0 +1,516 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// CSVSource identifies the service a reading-history CSV was exported from.
+type CSVSource string
+
+const (
+	CSVSourceGoodreads  CSVSource = "goodreads"
+	CSVSourceStoryGraph CSVSource = "storygraph"
+)
+
+// ErrUnknownCSVFormat indicates a CSV whose header matches neither Goodreads nor StoryGraph.
+var ErrUnknownCSVFormat = errors.New("unrecognised reading history CSV")
+
+// ConflictUnmatchedRow: a CSV row matches no book of the library and is skipped.
+const ConflictUnmatchedRow ConflictKind = "unmatched_row"
+
+// goodreadsHeader is the column layout of a Goodreads library export, which
+// Goodreads also accepts as import.
+var goodreadsHeader = []string{
+	"Book Id", "Title", "Author", "Author l-f", "Additional Authors", "ISBN", "ISBN13",
+	"My Rating", "Average Rating", "Publisher", "Binding", "Number of Pages", "Year Published",
+	"Original Publication Year", "Date Read", "Date Added", "Bookshelves", "Bookshelves with positions",
+	"Exclusive Shelf", "My Review", "Spoiler", "Private Notes", "Read Count", "Owned Copies",
+}
+
+// Étagères exclusives de Goodreads : elles décrivent un statut, pas un thème.
+var goodreadsStatusShelves = map[string]bool{"read": true, "currently-reading": true, "to-read": true}
+
+const goodreadsDate = "2006/01/02"
+
+// readingRecord is a CSV row, whatever its source.
+type readingRecord struct {
+	line      int
+	title     string
+	author    string
+	rating    int
+	review    string
+	tags      []string
+	readDates []time.Time
+}
+
+// ImportReadingCSV imports a Goodreads or StoryGraph CSV export, detected from
+// its header. Each row is matched to an existing book by title and author;
+// its rating, review and shelves (or tags) create or update the book's reading
+// sheet, and each read date becomes a completed session. Rows without a
+// matching book are reported as ConflictUnmatchedRow. With a unit of work,
+// an import that fails writes nothing.
+func (s *SharingService) ImportReadingCSV(ctx context.Context, filePath string, opts ImportOptions) (*ImportReport, error) {
+	ctx = WithDecryption(ctx) // les fusions relisent les fiches existantes
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read: %w", err)
+	}
+	source, records, err := parseReadingCSV(decodeCSVText(data), s.clock.Now().Location())
+	if err != nil {
+		return nil, err
+	}
+
+	var report *ImportReport
+	err = s.inTx(ctx, opts.DryRun, func(tx *SharingService) (err error) {
+		report, err = tx.importReadingRecords(ctx, source, records, opts)
+		return err
+	})
+	return report, err
+}
+
+func (s *SharingService) importReadingRecords(ctx context.Context, source CSVSource, records []readingRecord, opts ImportOptions) (*ImportReport, error) {
+	books, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+	m := newBookMatcher(books)
+	report := &ImportReport{DryRun: opts.DryRun, Source: source}
+
+	for _, rec := range records {
+		book, ambiguous := matchByTitleAuthor(m, rec.title, rec.author)
+		if book == nil {
+			report.Conflicts = append(report.Conflicts, ImportConflict{ConflictUnmatchedRow, rec.title,
+				s.tr.get().T("import.detail.unmatched_row", rec.line)})
+			continue
+		}
+		if ambiguous {
+			report.Conflicts = append(report.Conflicts, ImportConflict{ConflictAmbiguousMatch, rec.title,
+				s.tr.get().T("import.detail.ambiguous_match")})
+		}
+		report.BooksMatched++
+		if err := s.mergeReadingRecord(ctx, book, rec, report, opts); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (s *SharingService) mergeReadingRecord(ctx context.Context, book *domain.Book, rec readingRecord, report *ImportReport, opts ImportOptions) error {
+	now := s.clock.Now()
+	current, err := s.sheetRepo.GetSheetByBookID(ctx, book.ID)
+	if err != nil && !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		return fmt.Errorf("failed to get sheet: %w", err)
+	}
+	switch {
+	case current == nil:
+		if rec.rating == 0 && rec.review == "" && len(rec.tags) == 0 {
+			break
+		}
+		sheet, err := domain.NewReadingSheet(book.ID, book.Title, rec.review, rec.rating, nil, rec.tags, now)
+		if err != nil {
+			return fmt.Errorf("ligne %d : %w", rec.line, err)
+		}
+		if !opts.DryRun {
+			if err := s.sheetRepo.SaveSheet(ctx, sheet); err != nil {
+				return fmt.Errorf("failed to save sheet: %w", err)
+			}
+			s.events.publish(ctx, domain.SheetUpdated{BookID: sheet.BookID, SheetID: sheet.ID})
+		}
+		report.SheetsAdded++
+	default:
+		updated := *current
+		changed := false
+		if rec.rating > 0 && rec.rating != updated.Rating {
+			updated.Rating = rec.rating
+			changed = true
+		}
+		if rec.review != "" && rec.review != updated.Summary {
+			updated.Summary = rec.review
+			changed = true
+		}
+		if tags := mergeTags(updated.Tags, rec.tags); len(tags) != len(updated.Tags) {
+			updated.Tags = tags
+			changed = true
+		}
+		if !changed {
+			break
+		}
+		updated.UpdatedAt = now
+		if !opts.DryRun {
+			if err := s.sheetRepo.UpdateSheet(ctx, &updated); err != nil {
+				return fmt.Errorf("failed to update sheet: %w", err)
+			}
+			s.events.publish(ctx, domain.SheetUpdated{BookID: updated.BookID, SheetID: updated.ID})
+		}
+		report.SheetsUpdated++
+	}
+
+	if s.sessionRepo == nil || len(rec.readDates) == 0 {
+		return nil
+	}
+	sessions, err := s.sessionRepo.GetSessionByID(ctx, book.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	finished := make(map[string]bool)
+	for _, ses := range sessions {
+		if ses.IsBookComplete() {
+			finished[ses.LastReadingTime.Format(goodreadsDate)] = true
+		}
+	}
+	for _, day := range rec.readDates {
+		if finished[day.Format(goodreadsDate)] {
+			continue
+		}
+		ses, err := domain.NewSession(book.ID, max(book.TotalPages, 1), max(book.TotalPages, 1), day)
+		if err != nil {
+			return fmt.Errorf("ligne %d : %w", rec.line, err)
+		}
+		if !opts.DryRun {
+			if err := s.sessionRepo.SaveSession(ctx, ses); err != nil {
+				return fmt.Errorf("failed to save session: %w", err)
+			}
+			s.events.publish(ctx, domain.PageTurned{BookID: ses.BookID, SessionID: ses.SessionID,
+				Page: ses.CurrentPage, TotalPages: ses.TotalPages})
+		}
+		finished[day.Format(goodreadsDate)] = true
+		report.SessionsImported++
+	}
+	return nil
+}
+
+// ExportGoodreadsCSV writes the library in the Goodreads export layout, which
+// Goodreads and StoryGraph both import. Finished sessions give the read date
+// and count; the sheet gives rating, review and shelves.
+func (s *SharingService) ExportGoodreadsCSV(ctx context.Context, outputDir string) (string, error) {
+	books, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list books: %w", err)
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(goodreadsHeader)
+	for _, book := range books {
+		sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, book.ID)
+		var sessions []*domain.ReadingSession
+		if s.sessionRepo != nil {
+			if sessions, err = s.sessionRepo.GetSessionByID(ctx, book.ID); err != nil {
+				return "", fmt.Errorf("failed to list sessions: %w", err)
+			}
+		}
+		_ = w.Write(goodreadsRow(book, sheet, sessions))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to encode csv: %w", err)
+	}
+	filePath := filepath.Join(outputDir, fmt.Sprintf("orus_goodreads_%s.csv", s.clock.Now().Format("20060102")))
+	if err := os.WriteFile(filePath, buf.Bytes(), 0600); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	return filePath, nil
+}
+
+func goodreadsRow(book *domain.Book, sheet *domain.ReadingSheet, sessions []*domain.ReadingSession) []string {
+	shelf := "to-read"
+	if len(sessions) > 0 {
+		shelf = "currently-reading"
+	}
+	var lastRead time.Time
+	readDays := make(map[string]bool)
+	for _, ses := range sessions {
+		if !ses.IsBookComplete() || ses.TotalPages == 0 {
+			continue
+		}
+		shelf = "read"
+		readDays[ses.LastReadingTime.Format(goodreadsDate)] = true
+		if ses.LastReadingTime.After(lastRead) {
+			lastRead = ses.LastReadingTime
+		}
+	}
+	rating, review, shelves := "0", "", ""
+	if sheet != nil {
+		rating = strconv.Itoa(sheet.Rating)
+		review = sheet.Summary
+		shelves = strings.Join(sheet.Tags, ", ")
+	}
+	dateRead := ""
+	if !lastRead.IsZero() {
+		dateRead = lastRead.Format(goodreadsDate)
+	}
+	pages := ""
+	if book.TotalPages > 0 {
+		pages = strconv.Itoa(book.TotalPages)
+	}
+	row := make(map[string]string, len(goodreadsHeader))
+	row["Title"] = book.Title
+	row["Author"] = book.Author
+	row["Author l-f"] = authorLastFirst(book.Author)
+	isbn10, isbn13 := goodreadsISBN(book.ISBN)
+	row["ISBN"] = `="` + isbn10 + `"`
+	row["ISBN13"] = `="` + isbn13 + `"`
+	row["My Rating"] = rating
+	row["Average Rating"] = "0"
+	row["Binding"] = "ebook"
+	row["Number of Pages"] = pages
+	row["Date Read"] = dateRead
+	row["Date Added"] = book.AddedAt.Format(goodreadsDate)
+	row["Bookshelves"] = shelves
+	row["Exclusive Shelf"] = shelf
+	row["My Review"] = review
+	row["Read Count"] = strconv.Itoa(len(readDays))
+	row["Owned Copies"] = "0"
+	out := make([]string, len(goodreadsHeader))
+	for i, col := range goodreadsHeader {
+		out[i] = row[col]
+	}
+	return out
+}
+
+// goodreadsISBN splits an ISBN between the ISBN and ISBN13 columns, without
+// hyphens or spaces. Anything that is not 10 or 13 characters long is dropped.
+func goodreadsISBN(isbn string) (isbn10, isbn13 string) {
+	digits := strings.NewReplacer("-", "", " ", "").Replace(isbn)
+	switch len(digits) {
+	case 10:
+		return digits, ""
+	case 13:
+		return "", digits
+	}
+	return "", ""
+}
+
+// authorLastFirst turns "Frank Herbert" into "Herbert, Frank".
+func authorLastFirst(author string) string {
+	parts := strings.Fields(author)
+	if len(parts) < 2 {
+		return author
+	}
+	return parts[len(parts)-1] + ", " + strings.Join(parts[:len(parts)-1], " ")
+}
+
+// decodeCSVText returns data as UTF-8. It honours UTF-8 and UTF-16 byte order
+// marks and falls back to Windows-1252, the usual encoding of CSV files
+// re-saved by spreadsheet software, when data is not valid UTF-8.
+func decodeCSVText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		dec := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder()
+		if out, err := dec.Bytes(data); err == nil {
+			return string(out)
+		}
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data = data[3:]
+	}
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	out, _ := charmap.Windows1252.NewDecoder().Bytes(data)
+	return string(out)
+}
+
+func parseReadingCSV(text string, loc *time.Location) (CSVSource, []readingRecord, error) {
+	r := csv.NewReader(strings.NewReader(text))
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrUnknownCSVFormat, err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.TrimSpace(name)] = i
+	}
+	var source CSVSource
+	switch {
+	case hasColumns(cols, "Title", "Author", "My Rating"):
+		source = CSVSourceGoodreads
+	case hasColumns(cols, "Title", "Authors", "Star Rating"):
+		source = CSVSourceStoryGraph
+	default:
+		return "", nil, ErrUnknownCSVFormat
+	}
+
+	var records []readingRecord
+	for {
+		row, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return source, records, fmt.Errorf("invalid csv: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		get := func(col string) string {
+			if i, ok := cols[col]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		if get("Title") == "" {
+			continue
+		}
+		var rec readingRecord
+		if source == CSVSourceGoodreads {
+			rec = goodreadsRecord(get, loc)
+		} else {
+			rec = storyGraphRecord(get, loc)
+		}
+		rec.line = line
+		records = append(records, rec)
+	}
+	return source, records, nil
+}
+
+func goodreadsRecord(get func(string) string, loc *time.Location) readingRecord {
+	rec := readingRecord{
+		title:  get("Title"),
+		author: get("Author"),
+		rating: parseRating(get("My Rating")),
+		review: cleanReview(get("My Review")),
+	}
+	for _, shelf := range splitList(get("Bookshelves")) {
+		if !goodreadsStatusShelves[shelf] {
+			rec.tags = append(rec.tags, shelf)
+		}
+	}
+	if d, ok := parseReadDate(get("Date Read"), loc); ok {
+		rec.readDates = append(rec.readDates, d)
+	}
+	return rec
+}
+
+func storyGraphRecord(get func(string) string, loc *time.Location) readingRecord {
+	rec := readingRecord{
+		title:  get("Title"),
+		rating: parseRating(get("Star Rating")),
+		review: cleanReview(get("Review")),
+		tags:   splitList(get("Tags")),
+	}
+	if authors := splitList(get("Authors")); len(authors) > 0 {
+		rec.author = authors[0]
+	}
+	// "Dates Read" : "2023/01/05-2023/01/20, 2024/02/01-2024/02/10" ; seule la fin compte.
+	for _, span := range splitList(get("Dates Read")) {
+		if d, ok := parseReadDate(rangeEnd(span), loc); ok {
+			rec.readDates = append(rec.readDates, d)
+		}
+	}
+	if len(rec.readDates) == 0 {
+		if d, ok := parseReadDate(get("Last Date Read"), loc); ok {
+			rec.readDates = append(rec.readDates, d)
+		}
+	}
+	return rec
+}
+
+// rangeEnd returns the last day of "start-end", or span itself when it is a
+// single day. ISO dates contain dashes too, hence the count.
+func rangeEnd(span string) string {
+	switch strings.Count(span, "-") {
+	case 1: // 2023/01/05-2023/01/20
+		return span[strings.Index(span, "-")+1:]
+	case 5: // 2023-01-05-2023-01-20
+		parts := strings.SplitN(span, "-", 4)
+		return parts[3]
+	}
+	return span
+}
+
+// parseReadDate reads a day and places it at noon, so that a later time zone
+// conversion cannot move it to another day.
+func parseReadDate(value string, loc *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{goodreadsDate, "2006-01-02", "2006/1/2"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.Add(12 * time.Hour), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseRating rounds StoryGraph's quarter stars and clamps to 0..5.
+func parseRating(value string) int {
+	f, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return min(int(math.Round(f)), 5)
+}
+
+var reviewBreaks = regexp.MustCompile(`(?i)<br\s*/?>`)
+
+// cleanReview turns the HTML line breaks of Goodreads reviews into newlines.
+func cleanReview(review string) string {
+	return strings.TrimSpace(reviewBreaks.ReplaceAllString(review, "\n"))
+}
+
+func splitList(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func hasColumns(cols map[string]int, names ...string) bool {
+	for _, n := range names {
+		if _, ok := cols[n]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeTags appends the tags of extra missing from tags, case-insensitively.
+func mergeTags(tags, extra []string) []string {
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		seen[strings.ToLower(t)] = true
+	}
+	out := append([]string(nil), tags...)
+	for _, t := range extra {
+		if !seen[strings.ToLower(t)] {
+			seen[strings.ToLower(t)] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+var seriesSuffix = regexp.MustCompile(`\s*\([^()]*#\s*[\d.]+\)\s*$`)
+
+// matchByTitleAuthor tries the title as exported, then without a Goodreads
+// series suffix ("Dune (Dune, #1)"), then without its subtitle.
+func matchByTitleAuthor(m *bookMatcher, title, author string) (*domain.Book, bool) {
+	candidates := []string{title, seriesSuffix.ReplaceAllString(title, "")}
+	if i := strings.Index(candidates[1], ":"); i > 0 {
+		candidates = append(candidates, candidates[1][:i])
+	}
+	for _, t := range candidates {
+		if b, ambiguous := m.match(LibraryEntry{Book: &domain.Book{Title: t, Author: author}}); b != nil {
+			return b, ambiguous
+		}
+	}
+	return nil, false
+}
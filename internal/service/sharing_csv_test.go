@@ -0,0 +1,205 @@
+package service_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// seedCSVLibrary adds the books referenced by the CSV fixtures.
+func seedCSVLibrary(t *testing.T, f *libraryFixture, fake *clock.FakeClock) (dune, prince *domain.Book) {
+	t.Helper()
+	ctx := context.Background()
+	dune, _ = domain.NewBook("Dune", "Frank Herbert", "/livres/dune.epub", domain.FormatEPUB, 604, fake.Now())
+	prince, _ = domain.NewBook("Le Petit Prince", "Antoine de Saint-Exupéry", "/livres/prince.pdf", domain.FormatPDF, 96, fake.Now())
+	_ = f.books.Save(ctx, dune)
+	_ = f.books.Save(ctx, prince)
+	return dune, prince
+}
+
+func completedSessions(f *libraryFixture, bookID string) []string {
+	var days []string
+	for _, s := range f.sessions.saved {
+		if s.BookID == bookID && s.IsBookComplete() {
+			days = append(days, s.LastReadingTime.Format("2006/01/02"))
+		}
+	}
+	return days
+}
+
+func TestSharingService_ImportReadingCSVGoodreads(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	f := newLibraryFixture(fake)
+	dune, prince := seedCSVLibrary(t, f, fake)
+	existing, _ := domain.NewReadingSheet(dune.ID, dune.Title, "", 3, nil, []string{"roman", "Sci-Fi"}, fake.Now())
+	_ = f.sheets.SaveSheet(ctx, existing)
+	events := &eventRecorder{}
+	f.svc.SetEvents(events)
+
+	report, err := f.svc.ImportReadingCSV(ctx, filepath.Join("testdata", "goodreads_library_export.csv"), service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if names := strings.Join(events.names(), " "); names != "sheet.updated page.turned sheet.updated" {
+		t.Errorf("expected an event per sheet and session, got %q", names)
+	}
+	if report.Source != service.CSVSourceGoodreads {
+		t.Errorf("expected goodreads source, got %q", report.Source)
+	}
+	if report.BooksMatched != 2 || report.SheetsUpdated != 1 || report.SheetsAdded != 1 || report.SessionsImported != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Kind != service.ConflictUnmatchedRow ||
+		!strings.Contains(report.Conflicts[0].Detail, "ligne 5") {
+		t.Errorf("expected unmatched row on line 5, got %+v", report.Conflicts)
+	}
+
+	sheet, _ := f.sheets.GetSheetByBookID(ctx, dune.ID)
+	wantReview := "Un chef-d'œuvre, \"vraiment\".\n\nRelu en 2024 ;\nsur deux lignes."
+	if sheet.Rating != 5 || sheet.Summary != wantReview {
+		t.Errorf("expected rating 5 and review %q, got %d %q", wantReview, sheet.Rating, sheet.Summary)
+	}
+	if strings.Join(sheet.Tags, "|") != "roman|Sci-Fi|favorites" {
+		t.Errorf("expected shelves merged into tags, got %v", sheet.Tags)
+	}
+	if days := completedSessions(f, dune.ID); len(days) != 1 || days[0] != "2024/03/02" {
+		t.Errorf("expected one completed session on 2024/03/02, got %v", days)
+	}
+
+	ps, _ := f.sheets.GetSheetByBookID(ctx, prince.ID)
+	if ps == nil || ps.Rating != 4 || len(ps.Tags) != 0 {
+		t.Errorf("expected new sheet rated 4 without status shelves, got %+v", ps)
+	}
+
+	again, err := f.svc.ImportReadingCSV(ctx, filepath.Join("testdata", "goodreads_library_export.csv"), service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if again.SheetsAdded+again.SheetsUpdated+again.SessionsImported != 0 {
+		t.Errorf("expected idempotent re-import, got %+v", again)
+	}
+}
+
+func TestSharingService_ImportReadingCSVStoryGraph(t *testing.T) {
+	for _, fixture := range []string{"storygraph_export.csv", "storygraph_utf16.csv"} {
+		t.Run(fixture, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clock.NewFakeClock(fakeNow)
+			f := newLibraryFixture(fake)
+			dune, prince := seedCSVLibrary(t, f, fake)
+
+			report, err := f.svc.ImportReadingCSV(ctx, filepath.Join("testdata", fixture), service.ImportOptions{})
+			if err != nil {
+				t.Fatalf("expected nil error, got: %v", err)
+			}
+			if report.Source != service.CSVSourceStoryGraph || report.BooksMatched != 2 {
+				t.Fatalf("unexpected report: %+v", report)
+			}
+			sheet, _ := f.sheets.GetSheetByBookID(ctx, dune.ID)
+			if sheet == nil || sheet.Rating != 5 || sheet.Summary != "Arrakis, l'épice et Muad'Dib." {
+				t.Errorf("unexpected Dune sheet: %+v", sheet)
+			}
+			if strings.Join(sheet.Tags, "|") != "classique|space" {
+				t.Errorf("expected tags, got %v", sheet.Tags)
+			}
+			if days := strings.Join(completedSessions(f, dune.ID), ","); !strings.Contains(days, "2023/01/20") || !strings.Contains(days, "2024/02/10") {
+				t.Errorf("expected the end of each read range, got %s", days)
+			}
+			ps, _ := f.sheets.GetSheetByBookID(ctx, prince.ID)
+			if ps == nil || ps.Rating != 4 {
+				t.Errorf("expected 3.75 stars rounded to 4, got %+v", ps)
+			}
+			if days := completedSessions(f, prince.ID); len(days) != 1 || days[0] != "2024/05/01" {
+				t.Errorf("expected last date read as session, got %v", days)
+			}
+		})
+	}
+}
+
+func TestSharingService_ImportReadingCSVEncodings(t *testing.T) {
+	for _, fixture := range []string{"goodreads_cp1252.csv", "goodreads_bom.csv"} {
+		t.Run(fixture, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clock.NewFakeClock(fakeNow)
+			f := newLibraryFixture(fake)
+			book, _ := domain.NewBook("Les Misérables", "Victor Hugo", "/livres/miserables.epub", domain.FormatEPUB, 1463, fake.Now())
+			_ = f.books.Save(ctx, book)
+
+			report, err := f.svc.ImportReadingCSV(ctx, filepath.Join("testdata", fixture), service.ImportOptions{})
+			if err != nil {
+				t.Fatalf("expected nil error, got: %v", err)
+			}
+			if report.BooksMatched != 1 {
+				t.Fatalf("expected accented title to match, got %+v", report)
+			}
+			sheet, _ := f.sheets.GetSheetByBookID(ctx, book.ID)
+			if sheet == nil || sheet.Summary != "C’était « magnifique » – inoubliable." {
+				t.Errorf("expected review decoded to UTF-8, got %+v", sheet)
+			}
+		})
+	}
+}
+
+func TestSharingService_ImportReadingCSVUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "autre.csv")
+	_ = os.WriteFile(path, []byte("nom,prenom\nHugo,Victor\n"), 0600)
+	f := newLibraryFixture(clock.NewFakeClock(fakeNow))
+
+	_, err := f.svc.ImportReadingCSV(context.Background(), path, service.ImportOptions{})
+	if !errors.Is(err, service.ErrUnknownCSVFormat) {
+		t.Errorf("expected ErrUnknownCSVFormat, got %v", err)
+	}
+}
+
+func TestSharingService_ExportGoodreadsCSV(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	f := newLibraryFixture(fake)
+	book, _ := domain.NewBook(`Le "Grand" Meaulnes, roman`, "Alain-Fournier", "/livres/meaulnes.epub", domain.FormatEPUB, 300, fake.Now())
+	book.ISBN = "978-2-07-036189-1"
+	_ = f.books.Save(ctx, book)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Nostalgie,\nadolescence", 4, nil, []string{"classique", "roman"}, fake.Now())
+	_ = f.sheets.SaveSheet(ctx, sheet)
+	done, _ := domain.NewSession(book.ID, 300, 300, fake.Now().AddDate(0, -1, 0))
+	_ = f.sessions.SaveSession(ctx, done)
+
+	path, err := f.svc.ExportGoodreadsCSV(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	file, _ := os.Open(path)
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("expected valid csv, got: %v", err)
+	}
+	if len(rows) != 2 || rows[0][1] != "Title" || rows[0][18] != "Exclusive Shelf" {
+		t.Fatalf("unexpected csv layout: %v", rows)
+	}
+	row := rows[1]
+	if row[1] != book.Title || row[7] != "4" || row[14] != "2026/02/10" || row[16] != "classique, roman" ||
+		row[18] != "read" || row[19] != "Nostalgie,\nadolescence" || row[22] != "1" {
+		t.Errorf("unexpected row: %q", row)
+	}
+	if row[5] != `=""` || row[6] != `="9782070361891"` {
+		t.Errorf("expected the ISBN in the ISBN13 column, got %q and %q", row[5], row[6])
+	}
+
+	// Réimporter son propre export ne change rien.
+	report, err := f.svc.ImportReadingCSV(ctx, path, service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.BooksMatched != 1 || report.SheetsUpdated+report.SessionsImported != 0 {
+		t.Errorf("expected export to round-trip unchanged, got %+v", report)
+	}
+}
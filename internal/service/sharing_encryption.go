@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// SetEncryption makes exports leave out the encrypted fields (sheet
+// summaries and quotes, annotation notes) while store has encryption
+// enabled, unless the export context comes from WithDecryption. Imports and
+// quote cards always see the clear text.
+func (s *SharingService) SetEncryption(store port.EncryptedStore) {
+	s.sheetRepo, s.annotRepo = redactingRepos(s.sheetRepo, s.annotRepo, store)
+}
+
+// SetEncryption keeps the encrypted fields out of the vault notes while store
+// has encryption enabled, as SharingService.SetEncryption does for exports.
+// Automatic syncs never decrypt; SyncAll does with a WithDecryption context.
+func (v *VaultSyncService) SetEncryption(store port.EncryptedStore) {
+	v.sheetRepo, v.annotRepo = redactingRepos(v.sheetRepo, v.annotRepo, store)
+}
+
+// redactingRepos wraps the repositories so that they leave out the encrypted
+// fields; annotRepo may be nil.
+func redactingRepos(sheetRepo port.ReadingSheetRepository, annotRepo port.AnnotationRepository,
+	store port.EncryptedStore) (port.ReadingSheetRepository, port.AnnotationRepository) {
+	sheetRepo = &redactingSheetRepo{ReadingSheetRepository: sheetRepo, store: store}
+	if annotRepo != nil {
+		annotRepo = &redactingAnnotationRepo{AnnotationRepository: annotRepo, store: store}
+	}
+	return sheetRepo, annotRepo
+}
+
+func redacting(ctx context.Context, store port.EncryptedStore) bool {
+	return store.EncryptionEnabled() && !decryptionRequested(ctx)
+}
+
+// redactingSheetRepo returns copies of the sheets without summary nor quotes.
+type redactingSheetRepo struct {
+	port.ReadingSheetRepository
+	store port.EncryptedStore
+}
+
+func (r *redactingSheetRepo) redact(ctx context.Context, sheet *domain.ReadingSheet) *domain.ReadingSheet {
+	if sheet == nil || !redacting(ctx, r.store) {
+		return sheet
+	}
+	clone := *sheet
+	clone.Summary, clone.Quotes = "", nil
+	return &clone
+}
+
+func (r *redactingSheetRepo) GetSheetByID(ctx context.Context, id string) (*domain.ReadingSheet, error) {
+	sheet, err := r.ReadingSheetRepository.GetSheetByID(ctx, id)
+	return r.redact(ctx, sheet), err
+}
+
+func (r *redactingSheetRepo) GetSheetByBookID(ctx context.Context, bookID string) (*domain.ReadingSheet, error) {
+	sheet, err := r.ReadingSheetRepository.GetSheetByBookID(ctx, bookID)
+	return r.redact(ctx, sheet), err
+}
+
+func (r *redactingSheetRepo) ListAllSheets(ctx context.Context) ([]*domain.ReadingSheet, error) {
+	sheets, err := r.ReadingSheetRepository.ListAllSheets(ctx)
+	for i := range sheets {
+		sheets[i] = r.redact(ctx, sheets[i])
+	}
+	return sheets, err
+}
+
+// redactingAnnotationRepo returns copies of the annotations without notes.
+type redactingAnnotationRepo struct {
+	port.AnnotationRepository
+	store port.EncryptedStore
+}
+
+func (r *redactingAnnotationRepo) redact(ctx context.Context, annotations []*domain.Annotation) []*domain.Annotation {
+	if !redacting(ctx, r.store) {
+		return annotations
+	}
+	for i, a := range annotations {
+		clone := *a
+		clone.Note = ""
+		annotations[i] = &clone
+	}
+	return annotations
+}
+
+func (r *redactingAnnotationRepo) GetAnnotationByPage(ctx context.Context, pageNo int, bookID string) ([]*domain.Annotation, error) {
+	annotations, err := r.AnnotationRepository.GetAnnotationByPage(ctx, pageNo, bookID)
+	return r.redact(ctx, annotations), err
+}
+
+func (r *redactingAnnotationRepo) GetAnnotationByType(ctx context.Context, annotationType string) ([]*domain.Annotation, error) {
+	annotations, err := r.AnnotationRepository.GetAnnotationByType(ctx, annotationType)
+	return r.redact(ctx, annotations), err
+}
+
+func (r *redactingAnnotationRepo) ListAllAnnotationOfABook(ctx context.Context, bookID string) ([]*domain.Annotation, error) {
+	annotations, err := r.AnnotationRepository.ListAllAnnotationOfABook(ctx, bookID)
+	return r.redact(ctx, annotations), err
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+)
+
+// htmlPalette mirrors the Mecha-Egyptian palette of theme/colors.go; the
+// service layer cannot import the UI adapter, so keep both in sync.
+var htmlPalette = struct {
+	VoidDark, SandGold, CyberCyan, GlassWhite, PureBlack string
+}{
+	VoidDark:   "#0D0D12",
+	SandGold:   "#F5A623",
+	CyberCyan:  "#2A3240",
+	GlassWhite: "#F8F9FA",
+	PureBlack:  "#000000",
+}
+
+// htmlBook is one book section of an HTML export.
+type htmlBook struct {
+	Anchor     string
+	Book       *domain.Book
+	Sheet      *domain.ReadingSheet
+	Cover      template.URL // data URI, vide sans couverture
+	Highlights []int        // pages surlignées, triées
+	Bookmarks  []int
+}
+
+type htmlDocument struct {
+	Lang       i18n.Locale
+	Title      string
+	ExportedAt time.Time
+	Palette    any
+	Books      []htmlBook
+}
+
+// htmlExportTemplate is parsed once; buildHTML binds its functions to the
+// export language.
+var htmlExportTemplate = template.Must(template.New("export").Funcs(template.FuncMap(templateFuncs(defaultLocalizer))).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+:root { --void: {{.Palette.VoidDark}}; --gold: {{.Palette.SandGold}}; --slate: {{.Palette.CyberCyan}}; --white: {{.Palette.GlassWhite}}; --black: {{.Palette.PureBlack}}; }
+* { box-sizing: border-box; }
+body { margin: 0; background: var(--void); color: var(--white); font: 15px/1.55 -apple-system, "Segoe UI", Roboto, sans-serif; }
+header, main { max-width: 960px; margin: 0 auto; padding: 24px; }
+header { border-bottom: 2px solid var(--gold); }
+h1 { margin: 0 0 4px; color: var(--gold); letter-spacing: .04em; }
+h2 { color: var(--gold); margin: 0 0 6px; }
+h3 { font-size: 13px; text-transform: uppercase; letter-spacing: .08em; color: var(--gold); margin: 18px 0 6px; }
+a { color: var(--gold); }
+.muted { opacity: .65; }
+input[type=search] { width: 100%; padding: 10px 14px; margin: 16px 0; border: 1px solid var(--gold); border-radius: 8px; background: var(--slate); color: var(--white); font-size: 15px; }
+table { width: 100%; border-collapse: collapse; }
+th, td { text-align: left; padding: 8px 10px; border-bottom: 1px solid var(--slate); }
+th { color: var(--gold); font-weight: 600; }
+tbody tr:hover { background: var(--slate); }
+section.book { display: flex; gap: 24px; margin: 32px 0; padding: 24px; background: var(--slate); border-radius: 12px; border-left: 4px solid var(--gold); }
+section.book img { width: 120px; height: auto; border-radius: 6px; box-shadow: 0 4px 14px var(--black); align-self: flex-start; }
+section.book > div { flex: 1; min-width: 0; }
+.stars { color: var(--gold); }
+.tag { display: inline-block; margin: 0 6px 6px 0; padding: 2px 10px; border-radius: 999px; border: 1px solid var(--gold); font-size: 12px; }
+blockquote { margin: 8px 0; padding: 6px 14px; border-left: 3px solid var(--gold); background: var(--void); border-radius: 0 6px 6px 0; }
+.summary { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<div class="muted">{{t "export.exported_on" (ldate "long" .ExportedAt)}} — {{n "export.books" (len .Books)}}</div>
+</header>
+<main>
+<input type="search" id="filter" placeholder="{{t "export.search"}}" aria-label="{{t "export.search_label"}}">
+<table id="library">
+<thead><tr><th>{{t "export.title"}}</th><th>{{t "export.author"}}</th><th>{{t "export.series"}}</th><th>{{t "export.format"}}</th><th>{{t "export.pages"}}</th><th>{{t "export.rating"}}</th></tr></thead>
+<tbody>
+{{- range .Books}}
+<tr data-search="{{.Book.Title}} {{.Book.Author}} {{.Book.Series}}{{with .Sheet}} {{range .Tags}}{{.}} {{end}}{{end}}">
+<td><a href="#{{.Anchor}}">{{.Book.Title}}</a></td><td>{{orUnknown .Book.Author}}</td>
+<td>{{if .Book.Series}}{{.Book.Series}}{{if .Book.SeriesIndex}} #{{.Book.SeriesIndex}}{{end}}{{end}}</td>
+<td>{{.Book.Format}}</td><td>{{.Book.TotalPages}}</td>
+<td class="stars">{{with .Sheet}}{{if .Rating}}{{.StarString}}{{end}}{{end}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+{{range .Books}}
+<section class="book" id="{{.Anchor}}">
+{{if .Cover}}<img src="{{.Cover}}" alt="{{t "export.cover" .Book.Title}}">{{end}}
+<div>
+<h2>{{.Book.Title}}</h2>
+<div class="muted">{{orUnknown .Book.Author}} · {{.Book.Format}} · {{n "export.page_count" .Book.TotalPages}} · {{t "export.added_on" (ldate "medium" .Book.AddedAt)}}</div>
+{{- with .Sheet}}
+<h3>{{t "export.sheet"}}</h3>
+{{if .Rating}}<div class="stars">{{.StarString}} ({{.Rating}}/5)</div>{{end}}
+{{if .Tags}}<div>{{range .Tags}}<span class="tag">{{.}}</span>{{end}}</div>{{end}}
+{{if .Summary}}<p class="summary">{{.Summary}}</p>{{end}}
+{{if .Quotes}}<h3>{{t "export.quotes"}}</h3>{{range .Quotes}}<blockquote>{{.}}</blockquote>{{end}}{{end}}
+{{- end}}
+{{if .Highlights}}<h3>{{t "export.highlights"}}</h3><div>{{t "export.pages"}} {{range $i, $p := .Highlights}}{{if $i}}, {{end}}{{$p}}{{end}}</div>{{end}}
+{{if .Bookmarks}}<h3>{{t "export.bookmarks"}}</h3><div>{{t "export.pages"}} {{range $i, $p := .Bookmarks}}{{if $i}}, {{end}}{{$p}}{{end}}</div>{{end}}
+</div>
+</section>
+{{end}}
+</main>
+<script>
+document.getElementById("filter").addEventListener("input", function (e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll("#library tbody tr").forEach(function (row) {
+    var match = row.dataset.search.toLowerCase().indexOf(q) !== -1;
+    row.style.display = match ? "" : "none";
+    var section = document.getElementById(row.querySelector("a").hash.slice(1));
+    if (section) { section.style.display = match ? "" : "none"; }
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+// buildHTML renders books as a single self-contained page: styles and script
+// are inline and covers are embedded as data URIs.
+func (s *SharingService) buildHTML(ctx context.Context, title string, books []*domain.Book) (string, error) {
+	tr := s.tr.get()
+	doc := htmlDocument{
+		Lang:       tr.Locale(),
+		Title:      title,
+		ExportedAt: s.clock.Now(),
+		Palette:    htmlPalette,
+	}
+	for i, book := range books {
+		entry := htmlBook{Anchor: fmt.Sprintf("livre-%d", i+1), Book: book, Cover: coverDataURI(book.CoverImage)}
+		entry.Sheet, _ = s.sheetRepo.GetSheetByBookID(ctx, book.ID)
+		if s.annotRepo != nil {
+			annotations, err := s.annotRepo.ListAllAnnotationOfABook(ctx, book.ID)
+			if err != nil {
+				return "", fmt.Errorf("failed to list annotations: %w", err)
+			}
+			for _, a := range annotations {
+				if a.AnnotationType == domain.AnnotationHighlight {
+					entry.Highlights = append(entry.Highlights, a.PageNo)
+				} else {
+					entry.Bookmarks = append(entry.Bookmarks, a.PageNo)
+				}
+			}
+			sort.Ints(entry.Highlights)
+			sort.Ints(entry.Bookmarks)
+		}
+		doc.Books = append(doc.Books, entry)
+	}
+	tmpl, err := htmlExportTemplate.Clone()
+	if err != nil {
+		return "", fmt.Errorf("failed to render html: %w", err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Funcs(template.FuncMap(templateFuncs(tr))).Execute(&sb, doc); err != nil {
+		return "", fmt.Errorf("failed to render html: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// coverDataURI embeds image bytes in a data URI, sniffing their MIME type.
+func coverDataURI(img []byte) template.URL {
+	if len(img) == 0 {
+		return ""
+	}
+	mime := http.DetectContentType(img)
+	if !strings.HasPrefix(mime, "image/") {
+		return ""
+	}
+	return template.URL("data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(img))
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+)
+
+// ErrUnsupportedRecurrence indicates a calendar event whose recurrence rule
+// cannot be expressed as a domain.ReminderFrequency.
+var ErrUnsupportedRecurrence = errors.New("unsupported recurrence rule")
+
+const (
+	icalDateTime   = "20060102T150405"
+	icalLineOctets = 75
+	icalBookIDProp = "X-ORUS-BOOK-ID"
+	icalUIDSuffix  = "@orus"
+)
+
+var icalWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ExportRemindersICS writes every enabled reminder as a VEVENT of an
+// iCalendar file (RFC 5545) and returns its path. Recurring reminders get an
+// RRULE derived from their frequency; times are floating local times, as the
+// reminders themselves follow the wall clock.
+func (s *SharingService) ExportRemindersICS(ctx context.Context, outputDir string) (string, error) {
+	reminders, err := s.reminderRepo.ListEnabledReminders(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list reminders: %w", err)
+	}
+	content := s.buildRemindersICS(reminders)
+	filePath := filepath.Join(outputDir, fmt.Sprintf("orus_rappels_%s.ics", s.clock.Now().Format("20060102")))
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	return filePath, nil
+}
+
+// ImportRemindersICS creates a reminder for every simple recurring VEVENT of
+// an iCalendar file: daily, weekly on one day, Monday to Friday, or a single
+// occurrence. Events that cannot be mapped are reported per event and skipped.
+// Events exported by Orus keep their reminder ID, taken from the UID, so
+// importing the same file again skips the reminders already present.
+func (s *SharingService) ImportRemindersICS(ctx context.Context, filePath string) ([]*domain.Reminder, []error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to open: %w", err)}
+	}
+	defer f.Close()
+
+	events, err := parseICSEvents(f)
+	if err != nil {
+		return nil, []error{err}
+	}
+	existing, err := s.reminderRepo.ListAllReminders(ctx)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to list reminders: %w", err)}
+	}
+	known := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		known[r.ID] = true
+	}
+	var reminders []*domain.Reminder
+	var errs []error
+	for _, ev := range events {
+		id, fromOrus := strings.CutSuffix(ev.uid, icalUIDSuffix)
+		if fromOrus && known[id] {
+			continue
+		}
+		r, err := s.reminderFromEvent(ev)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%q : %w", ev.summary, err))
+			continue
+		}
+		if fromOrus && id != "" {
+			r.ID = id
+			known[id] = true
+		}
+		if err := s.reminderRepo.SaveReminder(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("%q : %w", ev.summary, err))
+			continue
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, errs
+}
+
+func (s *SharingService) buildRemindersICS(reminders []*domain.Reminder) string {
+	stamp := s.clock.Now().UTC().Format(icalDateTime) + "Z"
+	var sb strings.Builder
+	writeICSLine(&sb, "BEGIN:VCALENDAR")
+	writeICSLine(&sb, "VERSION:2.0")
+	writeICSLine(&sb, "PRODID:-//Orus//Reading reminders//FR")
+	writeICSLine(&sb, "CALSCALE:GREGORIAN")
+	for _, r := range reminders {
+		writeICSLine(&sb, "BEGIN:VEVENT")
+		writeICSLine(&sb, "UID:"+r.ID+icalUIDSuffix)
+		writeICSLine(&sb, "DTSTAMP:"+stamp)
+		writeICSLine(&sb, "DTSTART:"+r.NextRing.Format(icalDateTime))
+		if rule := reminderRRule(r); rule != "" {
+			writeICSLine(&sb, "RRULE:"+rule)
+		}
+		writeICSLine(&sb, "SUMMARY:"+escapeICSText(r.Label))
+		if r.BookTitle != "" {
+			writeICSLine(&sb, "DESCRIPTION:"+escapeICSText(r.BookTitle))
+		}
+		if r.BookID != "" {
+			writeICSLine(&sb, icalBookIDProp+":"+escapeICSText(r.BookID))
+		}
+		writeICSLine(&sb, "BEGIN:VALARM")
+		writeICSLine(&sb, "ACTION:DISPLAY")
+		writeICSLine(&sb, "DESCRIPTION:"+escapeICSText(r.Label))
+		writeICSLine(&sb, "TRIGGER:PT0M")
+		writeICSLine(&sb, "END:VALARM")
+		writeICSLine(&sb, "END:VEVENT")
+	}
+	writeICSLine(&sb, "END:VCALENDAR")
+	return sb.String()
+}
+
+// reminderRRule maps a reminder frequency to an RRULE value ("" for once).
+func reminderRRule(r *domain.Reminder) string {
+	switch r.Frequency {
+	case domain.FrequencyDaily:
+		return "FREQ=DAILY"
+	case domain.FrequencyWeekly:
+		return "FREQ=WEEKLY;BYDAY=" + icalWeekdays[r.NextRing.Weekday()]
+	case domain.FrequencyWeekdays:
+		return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+	default:
+		return ""
+	}
+}
+
+// reminderFromEvent builds a reminder whose next ring follows the clock.
+func (s *SharingService) reminderFromEvent(ev icsEvent) (*domain.Reminder, error) {
+	if ev.dtstart.IsZero() {
+		return nil, errors.New("missing DTSTART")
+	}
+	freq, err := frequencyFromRRule(ev.rrule, ev.dtstart.Weekday())
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	start := time.Date(ev.dtstart.Year(), ev.dtstart.Month(), ev.dtstart.Day(),
+		ev.dtstart.Hour(), ev.dtstart.Minute(), 0, 0, now.Location())
+	if freq == domain.FrequencyOnce && !start.After(now) {
+		return nil, errors.New("single event is in the past")
+	}
+
+	r, err := domain.NewReminder(ev.bookID, ev.description, ev.summary, start.Hour(), start.Minute(), freq, now)
+	if err != nil {
+		return nil, err
+	}
+	switch freq {
+	case domain.FrequencyOnce:
+		r.NextRing = start
+	case domain.FrequencyWeekly:
+		for !start.After(now) {
+			start = start.AddDate(0, 0, 7)
+		}
+		r.NextRing = start
+	}
+	return r, nil
+}
+
+// frequencyFromRRule recognises the rules produced by reminderRRule, plus
+// their common equivalents (INTERVAL=1, WKST, a BYDAY matching DTSTART).
+func frequencyFromRRule(rule string, startDay time.Weekday) (domain.ReminderFrequency, error) {
+	if rule == "" {
+		return domain.FrequencyOnce, nil
+	}
+	parts := map[string]string{}
+	for _, kv := range strings.Split(rule, ";") {
+		k, v, _ := strings.Cut(kv, "=")
+		parts[strings.ToUpper(k)] = strings.ToUpper(v)
+	}
+	for k, v := range parts {
+		switch k {
+		case "FREQ", "BYDAY", "WKST":
+		case "INTERVAL":
+			if v != "1" {
+				return "", fmt.Errorf("%w: INTERVAL=%s", ErrUnsupportedRecurrence, v)
+			}
+		default:
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedRecurrence, k)
+		}
+	}
+	switch parts["FREQ"] {
+	case "DAILY":
+		if parts["BYDAY"] == "" {
+			return domain.FrequencyDaily, nil
+		}
+	case "WEEKLY":
+		switch parts["BYDAY"] {
+		case "", icalWeekdays[startDay]:
+			return domain.FrequencyWeekly, nil
+		case "MO,TU,WE,TH,FR":
+			return domain.FrequencyWeekdays, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedRecurrence, rule)
+}
+
+// writeICSLine writes a content line terminated by CRLF, folded so that no
+// physical line exceeds 75 octets, without splitting a UTF-8 sequence.
+func writeICSLine(sb *strings.Builder, line string) {
+	limit := icalLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalLineOctets - 1 // the leading space counts
+	}
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICSText(s string) string { return icsTextEscaper.Replace(s) }
+
+func unescapeICSText(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+type icsEvent struct {
+	uid         string
+	summary     string
+	description string
+	bookID      string
+	dtstart     time.Time
+	rrule       string
+}
+
+// parseICSEvents unfolds content lines and collects the VEVENT properties
+// Orus understands. Nested components (VALARM) are ignored.
+func parseICSEvents(r io.Reader) ([]icsEvent, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var events []icsEvent
+	var cur *icsEvent
+	depth := 0
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(name, ";")
+		name = strings.ToUpper(name)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			cur = &icsEvent{}
+			depth = 0
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT") && cur != nil:
+			events = append(events, *cur)
+			cur = nil
+			continue
+		case cur == nil:
+			continue
+		case name == "BEGIN":
+			depth++
+			continue
+		case name == "END":
+			depth--
+			continue
+		case depth > 0:
+			continue
+		}
+		switch name {
+		case "UID":
+			cur.uid = value
+		case "SUMMARY":
+			cur.summary = unescapeICSText(value)
+		case "DESCRIPTION":
+			cur.description = unescapeICSText(value)
+		case icalBookIDProp:
+			cur.bookID = unescapeICSText(value)
+		case "RRULE":
+			cur.rrule = value
+		case "DTSTART":
+			t, err := parseICSDateTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("DTSTART %q: %w", value, err)
+			}
+			cur.dtstart = t
+		}
+	}
+	return events, nil
+}
+
+// parseICSDateTime accepts floating, UTC ("Z") and TZID date-times as well as
+// plain dates. The wall-clock hour and minute are what matters to reminders.
+func parseICSDateTime(value, params string) (time.Time, error) {
+	loc := time.Local
+	for _, p := range strings.Split(params, ";") {
+		if k, v, ok := strings.Cut(p, "="); ok && strings.EqualFold(k, "TZID") {
+			if l, err := time.LoadLocation(strings.Trim(v, `"`)); err == nil {
+				loc = l
+			}
+		}
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icalDateTime+"Z", value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.In(time.Local), nil
+	}
+	if len(value) == len("20060102") {
+		return time.ParseInLocation("20060102", value, loc)
+	}
+	return time.ParseInLocation(icalDateTime, value, loc)
+}
+
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+func TestSharingService_RemindersICSRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	// Mardi 10 mars 2026, 21:00
+	fake := clock.NewFakeClock(fakeNow)
+
+	repo := newMockReminderRepo()
+	daily, _ := domain.NewReminder("b1", "Dune", "Lecture du soir", 22, 30, domain.FrequencyDaily, fake.Now())
+	weekly, _ := domain.NewReminder("b2", "Le Rouge et le Noir", "Chapitre; du, dimanche", 9, 0, domain.FrequencyWeekly, fake.Now())
+	weekly.NextRing = time.Date(2026, time.March, 15, 9, 0, 0, 0, time.UTC) // dimanche
+	weekdays, _ := domain.NewReminder("", "", "Pause déjeuner", 12, 15, domain.FrequencyWeekdays, fake.Now())
+	disabled, _ := domain.NewReminder("", "", "Désactivé", 8, 0, domain.FrequencyDaily, fake.Now())
+	disabled.Enabled = false
+	for _, r := range []*domain.Reminder{daily, weekly, weekdays, disabled} {
+		_ = repo.SaveReminder(ctx, r)
+	}
+
+	svc := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), repo, nil, nil, nil, fake)
+	path, err := svc.ExportRemindersICS(ctx, tmpDir)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if filepath.Base(path) != "orus_rappels_20260310.ics" {
+		t.Errorf("unexpected file name %q", filepath.Base(path))
+	}
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"RRULE:FREQ=DAILY\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=SU\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\n",
+		`SUMMARY:Chapitre\; du\, dimanche`,
+		"BEGIN:VALARM",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected calendar to contain %q", want)
+		}
+	}
+	if strings.Contains(content, "Désactivé") {
+		t.Error("expected disabled reminder to be left out")
+	}
+	if got := strings.Count(content, "BEGIN:VEVENT"); got != 3 {
+		t.Errorf("expected 3 events, got %d", got)
+	}
+
+	target := newMockReminderRepo()
+	importer := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), target, nil, nil, nil, fake)
+	imported, errs := importer.ImportRemindersICS(ctx, path)
+	if len(errs) != 0 {
+		t.Fatalf("expected no import errors, got: %v", errs)
+	}
+	if len(imported) != 3 {
+		t.Fatalf("expected 3 imported reminders, got %d", len(imported))
+	}
+	byLabel := map[string]*domain.Reminder{}
+	for _, r := range imported {
+		byLabel[r.Label] = r
+	}
+	w := byLabel["Chapitre; du, dimanche"]
+	if w == nil || w.Frequency != domain.FrequencyWeekly || w.BookID != "b2" || w.BookTitle != "Le Rouge et le Noir" {
+		t.Fatalf("weekly reminder not restored: %+v", w)
+	}
+	if w.NextRing.Weekday() != time.Sunday || w.Hour != 9 {
+		t.Errorf("expected next ring on Sunday 09:00, got %s", w.NextRing)
+	}
+	if d := byLabel["Lecture du soir"]; d == nil || d.Frequency != domain.FrequencyDaily || d.Hour != 22 || d.Minute != 30 {
+		t.Errorf("daily reminder not restored: %+v", d)
+	}
+	if wd := byLabel["Pause déjeuner"]; wd == nil || wd.Frequency != domain.FrequencyWeekdays {
+		t.Errorf("weekdays reminder not restored: %+v", wd)
+	}
+	if d := byLabel["Lecture du soir"]; d == nil || d.ID != daily.ID {
+		t.Errorf("expected the reminder ID to be kept from the UID, got %+v", d)
+	}
+}
+
+func TestSharingService_ImportRemindersICSTwice(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	source := newMockReminderRepo()
+	daily, _ := domain.NewReminder("b1", "Dune", "Lecture du soir", 22, 30, domain.FrequencyDaily, fake.Now())
+	weekdays, _ := domain.NewReminder("", "", "Pause déjeuner", 12, 15, domain.FrequencyWeekdays, fake.Now())
+	_ = source.SaveReminder(ctx, daily)
+	_ = source.SaveReminder(ctx, weekdays)
+	path, err := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), source, nil, nil, nil, fake).
+		ExportRemindersICS(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	target := newMockReminderRepo()
+	importer := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), target, nil, nil, nil, fake)
+	if imported, errs := importer.ImportRemindersICS(ctx, path); len(errs) != 0 || len(imported) != 2 {
+		t.Fatalf("expected 2 reminders on the first import, got %d, %v", len(imported), errs)
+	}
+	imported, errs := importer.ImportRemindersICS(ctx, path)
+	if len(errs) != 0 || len(imported) != 0 {
+		t.Errorf("expected the second import to skip every reminder, got %d, %v", len(imported), errs)
+	}
+	if len(target.reminders) != 2 {
+		t.Errorf("expected 2 stored reminders, got %d", len(target.reminders))
+	}
+}
+
+func TestSharingService_ExportRemindersICSFoldsLongLines(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	repo := newMockReminderRepo()
+	label := strings.Repeat("Relire « À la recherche du temps perdu » ", 4)
+	r, _ := domain.NewReminder("", "", label, 20, 0, domain.FrequencyDaily, fake.Now())
+	_ = repo.SaveReminder(ctx, r)
+
+	svc := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), repo, nil, nil, nil, fake)
+	path, err := svc.ExportRemindersICS(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets (%d): %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("folding split a UTF-8 sequence: %q", line)
+		}
+	}
+
+	imported, errs := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), newMockReminderRepo(), nil, nil, nil, fake).
+		ImportRemindersICS(ctx, path)
+	if len(errs) != 0 || len(imported) != 1 {
+		t.Fatalf("expected one reminder back, got %d (%v)", len(imported), errs)
+	}
+	if imported[0].Label != label {
+		t.Errorf("expected label %q after unfolding, got %q", label, imported[0].Label)
+	}
+}
+
+func TestSharingService_ImportRemindersICSForeignCalendar(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:Club de lecture",
+		"DTSTART;TZID=UTC:20260101T190000",
+		"RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=TH",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Tous les quinze jours",
+		"DTSTART:20260101T190000",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Salon du livre",
+		"DTSTART:20260320T100000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Passé",
+		"DTSTART:20250101T100000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	path := filepath.Join(t.TempDir(), "club.ics")
+	if err := os.WriteFile(path, []byte(ics), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newMockReminderRepo()
+	svc := service.NewSharingService(&mockSharingBookRepo{}, newMockSharingSheetRepo(), repo, nil, nil, nil, fake)
+	imported, errs := svc.ImportRemindersICS(ctx, path)
+	if len(imported) != 2 {
+		t.Fatalf("expected 2 imported reminders, got %d", len(imported))
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !errors.Is(errs[0], service.ErrUnsupportedRecurrence) {
+		t.Errorf("expected ErrUnsupportedRecurrence, got %v", errs[0])
+	}
+
+	club := imported[0]
+	if club.Frequency != domain.FrequencyWeekly || club.NextRing.Weekday() != time.Thursday {
+		t.Errorf("expected weekly Thursday reminder, got %s on %s", club.Frequency, club.NextRing.Weekday())
+	}
+	if !club.NextRing.After(fake.Now()) {
+		t.Errorf("expected next ring in the future, got %s", club.NextRing)
+	}
+	salon := imported[1]
+	if salon.Frequency != domain.FrequencyOnce || salon.NextRing.Day() != 20 {
+		t.Errorf("expected single reminder on the 20th, got %s %s", salon.Frequency, salon.NextRing)
+	}
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+)
+
+// LibraryFormatVersion is the version written in Orus JSON library exports.
+// Version 1 was a bare array of {book, reading_sheet} entries.
+const LibraryFormatVersion = 2
+
+// ErrUnsupportedFormatVersion indicates a JSON export written by a newer Orus.
+var ErrUnsupportedFormatVersion = errors.New("unsupported library format version")
+
+// LibraryExport is the document written by ExportLibrary in JSON.
+type LibraryExport struct {
+	FormatVersion int                `json:"format_version"`
+	ExportedAt    time.Time          `json:"exported_at"`
+	Books         []LibraryEntry     `json:"books"`
+	Reminders     []*domain.Reminder `json:"reminders,omitempty"`
+}
+
+// LibraryEntry groups a book with everything attached to it.
+type LibraryEntry struct {
+	Book        *domain.Book             `json:"book"`
+	FileHash    string                   `json:"file_hash,omitempty"` // SHA-256 du fichier
+	Sheet       *domain.ReadingSheet     `json:"reading_sheet,omitempty"`
+	Sessions    []*domain.ReadingSession `json:"sessions,omitempty"`
+	Annotations []*domain.Annotation     `json:"annotations,omitempty"`
+}
+
+// ImportOptions tunes ImportLibrary.
+type ImportOptions struct {
+	// DryRun computes the report without writing anything.
+	DryRun bool
+}
+
+// ConflictKind classifies an ImportConflict.
+type ConflictKind string
+
+const (
+	// ConflictSheetNewerLocally: the local sheet was edited after the exported one and is kept.
+	ConflictSheetNewerLocally ConflictKind = "sheet_newer_locally"
+	// ConflictMissingFile: the book file does not exist on this machine; the book is imported anyway.
+	ConflictMissingFile ConflictKind = "missing_file"
+	// ConflictAmbiguousMatch: several local books share the title and author; the first one is used.
+	ConflictAmbiguousMatch ConflictKind = "ambiguous_match"
+)
+
+// ImportConflict describes something the user may want to check after an import.
+// Detail is written in the language of SetLocalizer.
+type ImportConflict struct {
+	Kind      ConflictKind
+	BookTitle string
+	Detail    string
+}
+
+// ImportReport summarises what ImportLibrary did, or would do in dry-run mode.
+type ImportReport struct {
+	DryRun              bool
+	FormatVersion       int       // Orus JSON only
+	Source              CSVSource // reading-history CSV only
+	BooksAdded          int
+	BooksMatched        int
+	SheetsAdded         int
+	SheetsUpdated       int
+	SessionsImported    int
+	AnnotationsImported int
+	RemindersImported   int
+	Conflicts           []ImportConflict
+}
+
+// buildLibraryExport gathers the full library. Sessions, annotations and
+// reminders are included when the corresponding repository is configured.
+func (s *SharingService) buildLibraryExport(ctx context.Context, books []*domain.Book) (*LibraryExport, error) {
+	doc := &LibraryExport{FormatVersion: LibraryFormatVersion, ExportedAt: s.clock.Now(), Books: []LibraryEntry{}}
+	for _, book := range books {
+		entry := LibraryEntry{Book: book}
+		entry.FileHash, _ = fileHash(book.FilePath)
+		entry.Sheet, _ = s.sheetRepo.GetSheetByBookID(ctx, book.ID)
+		if s.sessionRepo != nil {
+			sessions, err := s.sessionRepo.GetSessionByID(ctx, book.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list sessions: %w", err)
+			}
+			entry.Sessions = sessions
+		}
+		if s.annotRepo != nil {
+			annotations, err := s.annotRepo.ListAllAnnotationOfABook(ctx, book.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list annotations: %w", err)
+			}
+			entry.Annotations = annotations
+		}
+		doc.Books = append(doc.Books, entry)
+	}
+	if s.reminderRepo != nil {
+		reminders, err := s.reminderRepo.ListAllReminders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reminders: %w", err)
+		}
+		doc.Reminders = reminders
+	}
+	return doc, nil
+}
+
+// ImportLibrary reads an Orus JSON export (any format version up to
+// LibraryFormatVersion) and merges it into the library.
+//
+// Books are matched by ID, then file hash, then file path, then title and
+// author; unmatched books are added. Sheets are upserted, the most recently
+// updated one winning. Sessions, annotations and reminders already present
+// are left untouched, so importing the same file twice is harmless. With a
+// unit of work, an import that fails writes nothing.
+func (s *SharingService) ImportLibrary(ctx context.Context, filePath string, opts ImportOptions) (*ImportReport, error) {
+	ctx = WithDecryption(ctx) // les fusions relisent les fiches existantes
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read: %w", err)
+	}
+	doc, err := decodeLibraryExport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var report *ImportReport
+	err = s.inTx(ctx, opts.DryRun, func(tx *SharingService) (err error) {
+		report, err = tx.importLibrary(ctx, doc, opts)
+		return err
+	})
+	return report, err
+}
+
+func (s *SharingService) importLibrary(ctx context.Context, doc *LibraryExport, opts ImportOptions) (*ImportReport, error) {
+	existing, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+	m := newBookMatcher(existing)
+	report := &ImportReport{DryRun: opts.DryRun, FormatVersion: doc.FormatVersion}
+	bookIDs := make(map[string]string) // ID exporté -> ID local
+
+	for _, entry := range doc.Books {
+		if entry.Book == nil {
+			continue
+		}
+		local, ambiguous := m.match(entry)
+		if ambiguous {
+			report.Conflicts = append(report.Conflicts, ImportConflict{ConflictAmbiguousMatch, entry.Book.Title,
+				s.tr.get().T("import.detail.ambiguous_match")})
+		}
+		if local != nil {
+			report.BooksMatched++
+		} else {
+			local = entry.Book
+			if _, err := os.Stat(local.FilePath); err != nil {
+				report.Conflicts = append(report.Conflicts, ImportConflict{ConflictMissingFile, local.Title, local.FilePath})
+			}
+			if !opts.DryRun {
+				if err := s.bookRepo.Save(ctx, local); err != nil {
+					return report, fmt.Errorf("failed to save book %q: %w", local.Title, err)
+				}
+				s.events.publish(ctx, domain.BookImported{Book: local})
+			}
+			m.add(local)
+			report.BooksAdded++
+		}
+		bookIDs[entry.Book.ID] = local.ID
+
+		if err := s.importEntryData(ctx, entry, local, report, opts); err != nil {
+			return report, err
+		}
+	}
+
+	if err := s.importReminders(ctx, doc.Reminders, bookIDs, report, opts); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func (s *SharingService) importEntryData(ctx context.Context, entry LibraryEntry, local *domain.Book, report *ImportReport, opts ImportOptions) error {
+	if entry.Sheet != nil {
+		if err := s.upsertSheet(ctx, entry.Sheet, local, report, opts); err != nil {
+			return err
+		}
+	}
+
+	if s.sessionRepo != nil && len(entry.Sessions) > 0 {
+		current, err := s.sessionRepo.GetSessionByID(ctx, local.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+		known := make(map[string]bool, len(current))
+		for _, ses := range current {
+			known[ses.SessionID] = true
+		}
+		for _, ses := range entry.Sessions {
+			if known[ses.SessionID] {
+				continue
+			}
+			imported := *ses
+			imported.BookID = local.ID
+			if !opts.DryRun {
+				if err := s.sessionRepo.SaveSession(ctx, &imported); err != nil {
+					return fmt.Errorf("failed to save session: %w", err)
+				}
+				s.events.publish(ctx, domain.PageTurned{BookID: imported.BookID, SessionID: imported.SessionID,
+					Page: imported.CurrentPage, TotalPages: imported.TotalPages})
+			}
+			report.SessionsImported++
+		}
+	}
+
+	if s.annotRepo != nil && len(entry.Annotations) > 0 {
+		current, err := s.annotRepo.ListAllAnnotationOfABook(ctx, local.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list annotations: %w", err)
+		}
+		// Plusieurs surlignages peuvent partager une page : seule la note les
+		// distingue quand l'identifiant diffère.
+		known := make(map[string]bool, 2*len(current))
+		for _, a := range current {
+			known[a.ID] = true
+			known[annotationKey(a)] = true
+		}
+		for _, a := range entry.Annotations {
+			if known[a.ID] || known[annotationKey(a)] {
+				continue
+			}
+			known[a.ID], known[annotationKey(a)] = true, true
+			imported := *a
+			imported.BookID = local.ID
+			if !opts.DryRun {
+				if err := s.annotRepo.SaveAnnotation(ctx, &imported); err != nil {
+					return fmt.Errorf("failed to save annotation: %w", err)
+				}
+				s.events.publish(ctx, domain.AnnotationChanged{BookID: imported.BookID, AnnotationID: imported.ID})
+			}
+			report.AnnotationsImported++
+		}
+	}
+	return nil
+}
+
+// annotationKey identifies an annotation by content, for copies made under
+// another ID.
+func annotationKey(a *domain.Annotation) string {
+	return fmt.Sprintf("%s#%d#%s", a.AnnotationType, a.PageNo, a.Note)
+}
+
+func (s *SharingService) upsertSheet(ctx context.Context, sheet *domain.ReadingSheet, local *domain.Book, report *ImportReport, opts ImportOptions) error {
+	current, err := s.sheetRepo.GetSheetByBookID(ctx, local.ID)
+	if err != nil && !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		return fmt.Errorf("failed to get sheet: %w", err)
+	}
+	imported := *sheet
+	imported.BookID = local.ID
+	switch {
+	case current == nil:
+		if !opts.DryRun {
+			if err := s.sheetRepo.SaveSheet(ctx, &imported); err != nil {
+				return fmt.Errorf("failed to save sheet: %w", err)
+			}
+			s.events.publish(ctx, domain.SheetUpdated{BookID: imported.BookID, SheetID: imported.ID})
+		}
+		report.SheetsAdded++
+	case current.UpdatedAt.After(sheet.UpdatedAt):
+		tr := s.tr.get()
+		report.Conflicts = append(report.Conflicts, ImportConflict{ConflictSheetNewerLocally, local.Title,
+			tr.T("import.detail.sheet_newer_locally", tr.Date(current.UpdatedAt, i18n.DateTime))})
+	case sheet.UpdatedAt.After(current.UpdatedAt):
+		imported.ID = current.ID
+		if !opts.DryRun {
+			if err := s.sheetRepo.UpdateSheet(ctx, &imported); err != nil {
+				return fmt.Errorf("failed to update sheet: %w", err)
+			}
+			s.events.publish(ctx, domain.SheetUpdated{BookID: imported.BookID, SheetID: imported.ID})
+		}
+		report.SheetsUpdated++
+	}
+	return nil
+}
+
+func (s *SharingService) importReminders(ctx context.Context, reminders []*domain.Reminder, bookIDs map[string]string, report *ImportReport, opts ImportOptions) error {
+	if s.reminderRepo == nil || len(reminders) == 0 {
+		return nil
+	}
+	current, err := s.reminderRepo.ListAllReminders(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list reminders: %w", err)
+	}
+	known := make(map[string]bool, len(current))
+	for _, r := range current {
+		known[r.ID] = true
+	}
+	for _, r := range reminders {
+		if known[r.ID] {
+			continue
+		}
+		imported := *r
+		if id, ok := bookIDs[r.BookID]; ok {
+			imported.BookID = id
+		}
+		if !opts.DryRun {
+			if err := s.reminderRepo.SaveReminder(ctx, &imported); err != nil {
+				return fmt.Errorf("failed to save reminder: %w", err)
+			}
+		}
+		report.RemindersImported++
+	}
+	return nil
+}
+
+// decodeLibraryExport accepts the current document and the version 1 array.
+func decodeLibraryExport(data []byte) (*LibraryExport, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) || bytes.Equal(trimmed, []byte("null")) {
+		var entries []LibraryEntry
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("invalid library export: %w", err)
+		}
+		return &LibraryExport{FormatVersion: 1, Books: entries}, nil
+	}
+	var doc LibraryExport
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return nil, fmt.Errorf("invalid library export: %w", err)
+	}
+	if doc.FormatVersion < 1 || doc.FormatVersion > LibraryFormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormatVersion, doc.FormatVersion)
+	}
+	return &doc, nil
+}
+
+// bookMatcher finds the local counterpart of an exported book. File hashes
+// are only computed when an exported entry carries one.
+type bookMatcher struct {
+	books  []*domain.Book
+	byID   map[string]*domain.Book
+	byPath map[string]*domain.Book
+	byKey  map[string][]*domain.Book
+	byHash map[string]*domain.Book
+}
+
+func newBookMatcher(books []*domain.Book) *bookMatcher {
+	m := &bookMatcher{
+		byID:   make(map[string]*domain.Book),
+		byPath: make(map[string]*domain.Book),
+		byKey:  make(map[string][]*domain.Book),
+	}
+	for _, b := range books {
+		m.add(b)
+	}
+	return m
+}
+
+func (m *bookMatcher) add(b *domain.Book) {
+	m.books = append(m.books, b)
+	m.byID[b.ID] = b
+	if b.FilePath != "" {
+		m.byPath[b.FilePath] = b
+	}
+	key := titleAuthorKey(b)
+	m.byKey[key] = append(m.byKey[key], b)
+	if m.byHash != nil {
+		if h, err := fileHash(b.FilePath); err == nil {
+			m.byHash[h] = b
+		}
+	}
+}
+
+func (m *bookMatcher) match(entry LibraryEntry) (book *domain.Book, ambiguous bool) {
+	if b, ok := m.byID[entry.Book.ID]; ok {
+		return b, false
+	}
+	if entry.FileHash != "" {
+		if m.byHash == nil {
+			m.byHash = make(map[string]*domain.Book)
+			for _, b := range m.books {
+				if h, err := fileHash(b.FilePath); err == nil {
+					m.byHash[h] = b
+				}
+			}
+		}
+		if b, ok := m.byHash[entry.FileHash]; ok {
+			return b, false
+		}
+	}
+	if b, ok := m.byPath[entry.Book.FilePath]; ok && entry.Book.FilePath != "" {
+		return b, false
+	}
+	if candidates := m.byKey[titleAuthorKey(entry.Book)]; len(candidates) > 0 {
+		return candidates[0], len(candidates) > 1
+	}
+	return nil, false
+}
+
+func titleAuthorKey(b *domain.Book) string {
+	return strings.ToLower(strings.TrimSpace(b.Title)) + "\x00" + strings.ToLower(strings.TrimSpace(b.Author))
+}
+
+// fileHash returns the hex SHA-256 of the file at path.
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
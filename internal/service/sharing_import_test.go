@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/memory"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+type libraryFixture struct {
+	books     *mockAnnotBookRepo
+	sheets    *mockSharingSheetRepo
+	reminders *mockReminderRepo
+	sessions  *recordingSessionRepo
+	annots    *mockAnnotationRepo
+	svc       *service.SharingService
+}
+
+func newLibraryFixture(c *clock.FakeClock) *libraryFixture {
+	f := &libraryFixture{
+		books:     &mockAnnotBookRepo{},
+		sheets:    newMockSharingSheetRepo(),
+		reminders: newMockReminderRepo(),
+		sessions:  &recordingSessionRepo{},
+		annots:    &mockAnnotationRepo{},
+	}
+	f.svc = service.NewSharingService(f.books, f.sheets, f.reminders, f.sessions, f.annots, nil, c)
+	return f
+}
+
+// seedSourceLibrary fills f with one book backed by a real file, its sheet,
+// two sessions, an annotation and a reminder.
+func seedSourceLibrary(t *testing.T, f *libraryFixture, now time.Time) *domain.Book {
+	t.Helper()
+	ctx := context.Background()
+	bookPath := filepath.Join(t.TempDir(), "dune.epub")
+	if err := os.WriteFile(bookPath, []byte("contenu du livre"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	book, _ := domain.NewBook("Dune", "Frank Herbert", bookPath, domain.FormatEPUB, 600, now)
+	_ = f.books.Save(ctx, book)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "Épice et politique", 5, []string{"La peur tue l'esprit"}, []string{"sf"}, now)
+	_ = f.sheets.SaveSheet(ctx, sheet)
+	s1, _ := domain.NewSession(book.ID, 600, 10, now.Add(-48*time.Hour))
+	s2, _ := domain.NewSession(book.ID, 600, 42, now.Add(-24*time.Hour))
+	_ = f.sessions.SaveSession(ctx, s1)
+	_ = f.sessions.SaveSession(ctx, s2)
+	a, _ := domain.NewAnnotation(book.ID, domain.AnnotationHighlight, 12, now)
+	_ = f.annots.SaveAnnotation(ctx, a)
+	r, _ := domain.NewReminder(book.ID, book.Title, "Lecture du soir", 21, 30, domain.FrequencyDaily, now)
+	_ = f.reminders.SaveReminder(ctx, r)
+	return book
+}
+
+func TestSharingService_ImportLibraryRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	src := newLibraryFixture(fake)
+	book := seedSourceLibrary(t, src, fake.Now())
+
+	path, err := src.svc.ExportLibrary(ctx, service.ShareFormatJSON, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	var doc service.LibraryExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("export is not a library document: %v", err)
+	}
+	if doc.FormatVersion != service.LibraryFormatVersion {
+		t.Errorf("expected format version %d, got %d", service.LibraryFormatVersion, doc.FormatVersion)
+	}
+	if len(doc.Books) != 1 || doc.Books[0].FileHash == "" {
+		t.Fatalf("expected one book with a file hash, got %+v", doc.Books)
+	}
+
+	dst := newLibraryFixture(fake)
+	report, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.BooksAdded != 1 || report.SheetsAdded != 1 || report.SessionsImported != 2 ||
+		report.AnnotationsImported != 1 || report.RemindersImported != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", report.Conflicts)
+	}
+	got, err := dst.books.GetByID(ctx, book.ID)
+	if err != nil || got.Title != "Dune" {
+		t.Fatalf("expected book to keep its ID, got %v (%v)", got, err)
+	}
+	sheet, _ := dst.sheets.GetSheetByBookID(ctx, book.ID)
+	if sheet == nil || sheet.Quotes[0] != "La peur tue l'esprit" {
+		t.Errorf("expected sheet to round-trip, got %+v", sheet)
+	}
+	if len(dst.sessions.saved) != 2 || len(dst.annots.annotations) != 1 || len(dst.reminders.reminders) != 1 {
+		t.Errorf("expected 2 sessions, 1 annotation and 1 reminder, got %d, %d, %d",
+			len(dst.sessions.saved), len(dst.annots.annotations), len(dst.reminders.reminders))
+	}
+
+	// Un second import ne duplique rien.
+	again, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if again.BooksMatched != 1 || again.BooksAdded != 0 || again.SessionsImported != 0 ||
+		again.AnnotationsImported != 0 || again.RemindersImported != 0 || again.SheetsUpdated != 0 {
+		t.Errorf("expected idempotent re-import, got %+v", again)
+	}
+}
+
+func TestSharingService_ImportLibraryKeepsHighlightsOnSamePage(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	src := newLibraryFixture(fake)
+	book := seedSourceLibrary(t, src, fake.Now())
+	for _, note := range []string{"Le désert", "La mélange"} {
+		a, _ := domain.NewAnnotation(book.ID, domain.AnnotationHighlight, 12, fake.Now())
+		a.Note = note
+		_ = src.annots.SaveAnnotation(ctx, a)
+	}
+	path, err := src.svc.ExportLibrary(ctx, service.ShareFormatJSON, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	// La cible a déjà le surlignage sans note, créé sous un autre identifiant.
+	dst := newLibraryFixture(fake)
+	_ = dst.books.Save(ctx, book)
+	copied, _ := domain.NewAnnotation(book.ID, domain.AnnotationHighlight, 12, fake.Now())
+	_ = dst.annots.SaveAnnotation(ctx, copied)
+
+	report, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.AnnotationsImported != 2 || len(dst.annots.annotations) != 3 {
+		t.Errorf("expected the 2 annotated highlights imported next to the local one, got %d imported, %d stored",
+			report.AnnotationsImported, len(dst.annots.annotations))
+	}
+}
+
+func TestSharingService_ImportLibraryUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	src := newLibraryFixture(fake)
+	seedSourceLibrary(t, src, fake.Now())
+	path, err := src.svc.ExportLibrary(ctx, service.ShareFormatJSON, t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	store := memory.NewStorage()
+	svc := service.NewSharingService(store, store, store, store, store, nil, fake)
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+	// Les rappels viennent en dernier : tout le reste est déjà écrit quand ils échouent.
+	svc.SetUnitOfWork(failingUoW{store: store, fail: "reminders"})
+	if _, err := svc.ImportLibrary(ctx, path, service.ImportOptions{}); !errors.Is(err, errDiskFull) {
+		t.Fatalf("expected the storage error, got: %v", err)
+	}
+	books, _ := store.ListAll(ctx)
+	sheets, _ := store.ListAllSheets(ctx)
+	if len(books) != 0 || len(sheets) != 0 {
+		t.Errorf("expected a failed import to write nothing, got %d books and %d sheets", len(books), len(sheets))
+	}
+	if names := events.names(); len(names) != 0 {
+		t.Errorf("expected no event for a failed import, got %v", names)
+	}
+
+	if _, err := svc.ImportLibrary(ctx, path, service.ImportOptions{DryRun: true}); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if names := events.names(); len(names) != 0 {
+		t.Errorf("expected no event for a dry run, got %v", names)
+	}
+
+	svc.SetUnitOfWork(store)
+	report, err := svc.ImportLibrary(ctx, path, service.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.BooksAdded != 1 || report.SheetsAdded != 1 || report.SessionsImported != 2 || report.RemindersImported != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	want := "book.imported sheet.updated page.turned page.turned annotation.changed"
+	if names := strings.Join(events.names(), " "); names != want {
+		t.Errorf("expected events %q, got %q", want, names)
+	}
+}
+
+func TestSharingService_ImportLibraryMatchesExistingBooks(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	src := newLibraryFixture(fake)
+	book := seedSourceLibrary(t, src, fake.Now().Add(-time.Hour))
+	path, _ := src.svc.ExportLibrary(ctx, service.ShareFormatJSON, t.TempDir())
+
+	t.Run("ByHash", func(t *testing.T) {
+		dst := newLibraryFixture(fake)
+		dst.svc.SetLocalizer(i18n.New(i18n.English))
+		// Même fichier, copié ailleurs et importé sous un autre titre.
+		copyPath := filepath.Join(t.TempDir(), "copie.epub")
+		_ = os.WriteFile(copyPath, []byte("contenu du livre"), 0600)
+		local, _ := domain.NewBook("dune (copie)", "", copyPath, domain.FormatEPUB, 600, fake.Now())
+		_ = dst.books.Save(ctx, local)
+		newer, _ := domain.NewReadingSheet(local.ID, local.Title, "Ma fiche locale", 3, nil, nil, fake.Now())
+		_ = dst.sheets.SaveSheet(ctx, newer)
+
+		report, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if report.BooksMatched != 1 || report.BooksAdded != 0 {
+			t.Fatalf("expected hash match, got %+v", report)
+		}
+		if len(report.Conflicts) != 1 || report.Conflicts[0].Kind != service.ConflictSheetNewerLocally ||
+			report.Conflicts[0].Detail != "local sheet edited on 03/10/2026 9:00 PM" {
+			t.Errorf("expected newer local sheet conflict, got %+v", report.Conflicts)
+		}
+		if s, _ := dst.sheets.GetSheetByBookID(ctx, local.ID); s.Summary != "Ma fiche locale" {
+			t.Errorf("expected local sheet to be kept, got %q", s.Summary)
+		}
+		for _, s := range dst.sessions.saved {
+			if s.BookID != local.ID {
+				t.Errorf("expected session remapped to %s, got %s", local.ID, s.BookID)
+			}
+		}
+		for _, r := range dst.reminders.reminders {
+			if r.BookID != local.ID {
+				t.Errorf("expected reminder remapped to %s, got %s", local.ID, r.BookID)
+			}
+		}
+	})
+
+	t.Run("ByTitleAndAuthor", func(t *testing.T) {
+		dst := newLibraryFixture(fake)
+		local, _ := domain.NewBook(" dune ", "FRANK HERBERT", "/ailleurs/dune.pdf", domain.FormatPDF, 600, fake.Now())
+		_ = dst.books.Save(ctx, local)
+		older, _ := domain.NewReadingSheet(local.ID, local.Title, "Ancienne", 2, nil, nil, fake.Now().Add(-72*time.Hour))
+		_ = dst.sheets.SaveSheet(ctx, older)
+
+		report, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if report.BooksMatched != 1 || report.SheetsUpdated != 1 {
+			t.Fatalf("expected title/author match and sheet update, got %+v", report)
+		}
+		s, _ := dst.sheets.GetSheetByBookID(ctx, local.ID)
+		if s.ID != older.ID || s.Summary != "Épice et politique" {
+			t.Errorf("expected local sheet updated in place, got %+v", s)
+		}
+		if _, err := dst.books.GetByID(ctx, book.ID); err == nil {
+			t.Error("expected no duplicate book")
+		}
+	})
+}
+
+func TestSharingService_ImportLibraryDryRun(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	src := newLibraryFixture(fake)
+	seedSourceLibrary(t, src, fake.Now())
+	path, _ := src.svc.ExportLibrary(ctx, service.ShareFormatJSON, t.TempDir())
+	_ = os.Remove(src.books.books[firstKey(src.books.books)].FilePath)
+
+	dst := newLibraryFixture(fake)
+	report, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !report.DryRun || report.BooksAdded != 1 || report.SessionsImported != 2 || report.RemindersImported != 1 {
+		t.Errorf("unexpected dry-run report: %+v", report)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Kind != service.ConflictMissingFile {
+		t.Errorf("expected missing file conflict, got %+v", report.Conflicts)
+	}
+	if len(dst.books.books) != 0 || len(dst.sheets.sheets) != 0 || len(dst.sessions.saved) != 0 ||
+		len(dst.annots.annotations) != 0 || len(dst.reminders.reminders) != 0 {
+		t.Error("expected dry run to write nothing")
+	}
+}
+
+func TestSharingService_ImportLibraryFormatVersions(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	dir := t.TempDir()
+
+	t.Run("LegacyArray", func(t *testing.T) {
+		legacy := `[{"book":{"ID":"b1","Title":"Ancien export","FilePath":"/x.pdf","Format":"PDF"},` +
+			`"reading_sheet":{"id":"s1","book_id":"b1","book_title":"Ancien export","rating":4}}]`
+		path := filepath.Join(dir, "v1.json")
+		_ = os.WriteFile(path, []byte(legacy), 0600)
+
+		dst := newLibraryFixture(fake)
+		report, err := dst.svc.ImportLibrary(ctx, path, service.ImportOptions{})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if report.FormatVersion != 1 || report.BooksAdded != 1 || report.SheetsAdded != 1 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("FutureVersion", func(t *testing.T) {
+		path := filepath.Join(dir, "v99.json")
+		_ = os.WriteFile(path, []byte(`{"format_version":99,"books":[]}`), 0600)
+
+		_, err := newLibraryFixture(fake).svc.ImportLibrary(ctx, path, service.ImportOptions{})
+		if !errors.Is(err, service.ErrUnsupportedFormatVersion) {
+			t.Errorf("expected ErrUnsupportedFormatVersion, got %v", err)
+		}
+	})
+}
+
+func firstKey(m map[string]*domain.Book) string {
+	for k := range m {
+		return k
+	}
+	return ""
+}
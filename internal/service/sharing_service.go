@@ -4,14 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
-	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
 	"github.com/MiltonJ23/Orus/internal/port"
 )
 
@@ -22,17 +23,80 @@ const (
 	ShareFormatJSON     ShareFormat = "json"
 	ShareFormatMarkdown ShareFormat = "md"
 	ShareFormatText     ShareFormat = "txt"
+	ShareFormatHTML     ShareFormat = "html"
 )
 
-// SharingService exports library data to files.
+// SharingService exports library data to files and imports it back.
 type SharingService struct {
-	bookRepo  port.BookRepository
-	sheetRepo port.ReadingSheetRepository
+	bookRepo      port.BookRepository
+	sheetRepo     port.ReadingSheetRepository
+	reminderRepo  port.ReminderRepository
+	sessionRepo   port.SessionRepository
+	annotRepo     port.AnnotationRepository
+	logger        *slog.Logger
+	clock         port.Clock
+	templateDir   string             // modèles utilisateur, voir SetTemplateDir
+	cards         port.CardRenderer  // cartes PNG, voir SetCardRenderer
+	contentReader port.ContentReader // texte des pages annotées, voir SetContentReader
+	tr            localizerRef       // langue des exports, voir SetLocalizer
+	tx            txRunner           // imports atomiques, voir SetUnitOfWork
+	events        eventSink
 }
 
 // NewSharingService creates a new SharingService with the given dependencies.
-func NewSharingService(bookRepo port.BookRepository, sheetRepo port.ReadingSheetRepository) *SharingService {
-	return &SharingService{bookRepo: bookRepo, sheetRepo: sheetRepo}
+// The reminder, session and annotation repositories may be nil; their data is
+// then left out of JSON exports and imports.
+func NewSharingService(bookRepo port.BookRepository, sheetRepo port.ReadingSheetRepository, reminderRepo port.ReminderRepository,
+	sessionRepo port.SessionRepository, annotRepo port.AnnotationRepository, logger *slog.Logger, clock port.Clock) *SharingService {
+	return &SharingService{bookRepo: bookRepo, sheetRepo: sheetRepo, reminderRepo: reminderRepo,
+		sessionRepo: sessionRepo, annotRepo: annotRepo, logger: componentLogger(logger, "sharing"), clock: clock}
+}
+
+// SetLocalizer sets the language of the exported documents, French by default.
+func (s *SharingService) SetLocalizer(tr *i18n.Localizer) { s.tr.set(tr) }
+
+// SetEvents sets the publisher of the BookImported, SheetUpdated, PageTurned
+// and AnnotationChanged events describing what an import added. They are
+// published once the import is saved.
+func (s *SharingService) SetEvents(pub port.EventPublisher) { s.events.pub = pub }
+
+// SetUnitOfWork makes library and CSV imports atomic: an import that fails
+// leaves the library as it was.
+func (s *SharingService) SetUnitOfWork(uow port.UnitOfWork) { s.tx.uow = uow }
+
+// inTx runs fn on a copy of s whose repositories belong to one unit of work,
+// then publishes the events of fn once it has succeeded. A dry run writes
+// nothing and runs on s.
+func (s *SharingService) inTx(ctx context.Context, dryRun bool, fn func(tx *SharingService) error) error {
+	if dryRun {
+		return fn(s)
+	}
+	own := port.Repositories{Books: s.bookRepo, Sheets: s.sheetRepo, Reminders: s.reminderRepo,
+		Sessions: s.sessionRepo, Annotations: s.annotRepo}
+	var queue eventQueue
+	err := s.tx.run(ctx, own, func(repos port.Repositories) error {
+		tx := &SharingService{bookRepo: repos.Books, sheetRepo: repos.Sheets, logger: s.logger, clock: s.clock,
+			templateDir: s.templateDir, cards: s.cards, contentReader: s.contentReader, events: eventSink{pub: &queue}}
+		// Les dépôts absents de s le restent : leurs données sont ignorées.
+		if s.reminderRepo != nil {
+			tx.reminderRepo = repos.Reminders
+		}
+		if s.sessionRepo != nil {
+			tx.sessionRepo = repos.Sessions
+		}
+		if s.annotRepo != nil {
+			tx.annotRepo = repos.Annotations
+		}
+		tx.tr.set(s.tr.get())
+		return fn(tx)
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range queue {
+		s.events.publish(ctx, event)
+	}
+	return nil
 }
 
 // PickExportDirectory ouvre le sélecteur de dossier natif de l'OS.
@@ -74,40 +138,37 @@ func (s *SharingService) ExportLibrary(ctx context.Context, format ShareFormat,
 	}
 	var sb strings.Builder
 	switch format {
-	case ShareFormatMarkdown:
-		sb.WriteString("# Ma bibliotheque Orus\n\n")
-		sb.WriteString(fmt.Sprintf("*Exporte le %s — %d livre(s)*\n\n---\n\n", time.Now().Format("02 janvier 2006"), len(books)))
-		for _, book := range books {
-			sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, book.ID)
-			sb.WriteString(s.buildBookMarkdown(book, sheet))
-			sb.WriteString("\n---\n\n")
-		}
 	case ShareFormatJSON:
-		type entry struct {
-			Book  *domain.Book         `json:"book"`
-			Sheet *domain.ReadingSheet `json:"reading_sheet,omitempty"`
+		doc, err := s.buildLibraryExport(ctx, books)
+		if err != nil {
+			return "", err
 		}
-		var entries []entry
-		for _, book := range books {
-			sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, book.ID)
-			entries = append(entries, entry{Book: book, Sheet: sheet})
-		}
-		data, _ := json.MarshalIndent(entries, "", "  ")
+		data, _ := json.MarshalIndent(doc, "", "  ")
 		sb.Write(data)
+	case ShareFormatHTML:
+		page, err := s.buildHTML(ctx, s.tr.get().T("export.library_title"), books)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(page)
+	case ShareFormatBibTeX, ShareFormatCSLJSON:
+		content, err := s.buildCitations(ctx, books, format)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(content)
 	default:
-		sb.WriteString(fmt.Sprintf("=== BIBLIOTHEQUE ORUS — %s ===\n\n", time.Now().Format("02/01/2006")))
-		for i, book := range books {
-			sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, book.ID)
-			sb.WriteString(fmt.Sprintf("[%d] ", i+1))
-			sb.WriteString(s.buildBookText(book, sheet))
-			sb.WriteString("\n\n")
+		content, _, err := s.renderTemplate(ctx, formatTemplate(format), ScopeLibrary, books, nil)
+		if err != nil {
+			return "", err
 		}
+		sb.WriteString(content)
 	}
 	ext := string(format)
 	if format == ShareFormatText {
 		ext = "txt"
 	}
-	fileName := fmt.Sprintf("orus_bibliotheque_%s.%s", time.Now().Format("20060102"), ext)
+	fileName := fmt.Sprintf("orus_bibliotheque_%s.%s", s.clock.Now().Format("20060102"), ext)
 	filePath := filepath.Join(outputDir, fileName)
 	if err := os.WriteFile(filePath, []byte(sb.String()), 0600); err != nil {
 		return "", fmt.Errorf("failed to write: %w", err)
@@ -121,24 +182,26 @@ func (s *SharingService) ExportBookInfo(ctx context.Context, bookID string, form
 	if err != nil {
 		return "", fmt.Errorf("book not found: %w", err)
 	}
-	sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, bookID)
 	var content string
 	var ext string
 	switch format {
 	case ShareFormatJSON:
+		sheet, _ := s.sheetRepo.GetSheetByBookID(ctx, bookID)
 		content, err = s.buildBookJSON(book, sheet)
 		ext = "json"
-	case ShareFormatMarkdown:
-		content = s.buildBookMarkdown(book, sheet)
-		ext = "md"
+	case ShareFormatHTML:
+		content, err = s.buildHTML(ctx, book.Title, []*domain.Book{book})
+		ext = "html"
+	case ShareFormatBibTeX, ShareFormatCSLJSON:
+		content, err = s.buildCitations(ctx, []*domain.Book{book}, format)
+		ext = string(format)
 	default:
-		content = s.buildBookText(book, sheet)
-		ext = "txt"
+		content, ext, err = s.renderTemplate(ctx, formatTemplate(format), ScopeBook, []*domain.Book{book}, nil)
 	}
 	if err != nil {
 		return "", err
 	}
-	filePath := filepath.Join(outputDir, fmt.Sprintf("orus_%s_%s.%s", sanitizeFileName(book.Title), time.Now().Format("20060102"), ext))
+	filePath := filepath.Join(outputDir, fmt.Sprintf("orus_%s_%s.%s", sanitizeFileName(book.Title), s.clock.Now().Format("20060102"), ext))
 	return filePath, os.WriteFile(filePath, []byte(content), 0600)
 }
 
@@ -155,70 +218,40 @@ func (s *SharingService) ExportReadingSheet(ctx context.Context, sheetID string,
 		data, _ := json.MarshalIndent(sheet, "", "  ")
 		content = string(data)
 		ext = "json"
-	case ShareFormatMarkdown:
-		content = s.buildSheetMarkdown(sheet)
-		ext = "md"
+	case ShareFormatHTML:
+		if content, err = s.buildHTML(ctx, sheet.BookTitle, []*domain.Book{s.sheetBook(ctx, sheet)}); err != nil {
+			return "", err
+		}
+		ext = "html"
 	default:
-		content = s.buildSheetText(sheet)
-		ext = "txt"
+		if content, ext, err = s.renderTemplate(ctx, formatTemplate(format), ScopeSheet, []*domain.Book{s.sheetBook(ctx, sheet)}, sheet); err != nil {
+			return "", err
+		}
 	}
-	filePath := filepath.Join(outputDir, fmt.Sprintf("fiche_%s_%s.%s", sanitizeFileName(sheet.BookTitle), time.Now().Format("20060102"), ext))
+	filePath := filepath.Join(outputDir, fmt.Sprintf("fiche_%s_%s.%s", sanitizeFileName(sheet.BookTitle), s.clock.Now().Format("20060102"), ext))
 	return filePath, os.WriteFile(filePath, []byte(content), 0600)
 }
 
 func (s *SharingService) buildBookJSON(book *domain.Book, sheet *domain.ReadingSheet) (string, error) {
-	data, err := json.MarshalIndent(map[string]any{"book": book, "reading_sheet": sheet, "exported_at": time.Now()}, "", "  ")
+	data, err := json.MarshalIndent(map[string]any{"book": book, "reading_sheet": sheet, "exported_at": s.clock.Now()}, "", "  ")
 	return string(data), err
 }
 
-func (s *SharingService) buildBookMarkdown(book *domain.Book, sheet *domain.ReadingSheet) string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("## %s\n\n**Auteur :** %s  \n**Format :** %s  \n**Pages :** %d  \n**Ajoute le :** %s  \n\n",
-		book.Title, orUnknown(book.Author), book.Format, book.TotalPages, book.AddedAt.Format("02 Jan 2006")))
-	if sheet != nil {
-		sb.WriteString(s.buildSheetMarkdown(sheet))
-	}
-	return sb.String()
-}
-
-func (s *SharingService) buildSheetMarkdown(sheet *domain.ReadingSheet) string {
-	var sb strings.Builder
-	sb.WriteString("### Fiche de lecture\n\n")
-	if sheet.Rating > 0 {
-		sb.WriteString(fmt.Sprintf("**Note :** %s (%d/5)\n\n", sheet.StarString(), sheet.Rating))
-	}
-	if len(sheet.Tags) > 0 {
-		sb.WriteString(fmt.Sprintf("**Tags :** %s\n\n", strings.Join(sheet.Tags, " - ")))
-	}
-	if sheet.Summary != "" {
-		sb.WriteString("**Resume :**\n\n" + sheet.Summary + "\n\n")
+// sheetBook returns the book of sheet, or a stand-in carrying the title the
+// sheet remembers when the book has been deleted.
+func (s *SharingService) sheetBook(ctx context.Context, sheet *domain.ReadingSheet) *domain.Book {
+	if book, err := s.bookRepo.GetByID(ctx, sheet.BookID); err == nil {
+		return book
 	}
-	for _, q := range sheet.Quotes {
-		sb.WriteString(fmt.Sprintf("> %s\n\n", q))
-	}
-	return sb.String()
+	return &domain.Book{ID: sheet.BookID, Title: sheet.BookTitle}
 }
 
-func (s *SharingService) buildBookText(book *domain.Book, sheet *domain.ReadingSheet) string {
-	out := fmt.Sprintf("TITRE: %s | AUTEUR: %s | FORMAT: %s | PAGES: %d\n", book.Title, orUnknown(book.Author), book.Format, book.TotalPages)
-	if sheet != nil {
-		out += s.buildSheetText(sheet)
+// formatTemplate names the template rendering a Markdown or text export.
+func formatTemplate(format ShareFormat) string {
+	if format == ShareFormatMarkdown {
+		return "markdown"
 	}
-	return out
-}
-
-func (s *SharingService) buildSheetText(sheet *domain.ReadingSheet) string {
-	var sb strings.Builder
-	if sheet.Rating > 0 {
-		sb.WriteString(fmt.Sprintf("Note: %s | ", sheet.StarString()))
-	}
-	if sheet.Summary != "" {
-		sb.WriteString("Resume: " + sheet.Summary + "\n")
-	}
-	for i, q := range sheet.Quotes {
-		sb.WriteString(fmt.Sprintf("Cit.%d: %s\n", i+1, q))
-	}
-	return sb.String()
+	return "text"
 }
 
 func sanitizeFileName(name string) string {
@@ -229,10 +262,3 @@ func sanitizeFileName(name string) string {
 	}
 	return out
 }
-
-func orUnknown(s string) string {
-	if s == "" {
-		return "Inconnu"
-	}
-	return s
-}
@@ -2,10 +2,12 @@ package service_test
 
 import (
 	"context"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 	"github.com/MiltonJ23/Orus/internal/service"
@@ -95,10 +97,10 @@ func TestSharingService_ExportLibrary(t *testing.T) {
 	}
 
 	sheetRepo := newMockSharingSheetRepo()
-	sheet, _ := domain.NewReadingSheet("b1", "Go Programming", "A great book about Go", 5, []string{"Go is simple"}, []string{"programming"})
+	sheet, _ := domain.NewReadingSheet("b1", "Go Programming", "A great book about Go", 5, []string{"Go is simple"}, []string{"programming"}, time.Now())
 	sheetRepo.sheets[sheet.ID] = sheet
 
-	svc := service.NewSharingService(bookRepo, sheetRepo)
+	svc := service.NewSharingService(bookRepo, sheetRepo, nil, nil, nil, nil, clock.NewSystemClock())
 
 	t.Run("Markdown", func(t *testing.T) {
 		path, err := svc.ExportLibrary(ctx, service.ShareFormatMarkdown, tmpDir)
@@ -175,7 +177,7 @@ func TestSharingService_ExportBookInfo(t *testing.T) {
 		},
 	}
 	sheetRepo := newMockSharingSheetRepo()
-	svc := service.NewSharingService(bookRepo, sheetRepo)
+	svc := service.NewSharingService(bookRepo, sheetRepo, nil, nil, nil, nil, clock.NewSystemClock())
 
 	t.Run("Markdown", func(t *testing.T) {
 		path, err := svc.ExportBookInfo(ctx, "b1", service.ShareFormatMarkdown, tmpDir)
@@ -222,10 +224,10 @@ func TestSharingService_ExportReadingSheet(t *testing.T) {
 	bookRepo := &mockSharingBookRepo{}
 	sheetRepo := newMockSharingSheetRepo()
 
-	sheet, _ := domain.NewReadingSheet("b1", "Go Programming", "A great book", 5, []string{"Simplicity is key"}, []string{"go", "programming"})
+	sheet, _ := domain.NewReadingSheet("b1", "Go Programming", "A great book", 5, []string{"Simplicity is key"}, []string{"go", "programming"}, time.Now())
 	sheetRepo.sheets[sheet.ID] = sheet
 
-	svc := service.NewSharingService(bookRepo, sheetRepo)
+	svc := service.NewSharingService(bookRepo, sheetRepo, nil, nil, nil, nil, clock.NewSystemClock())
 
 	t.Run("Markdown", func(t *testing.T) {
 		path, err := svc.ExportReadingSheet(ctx, sheet.ID, service.ShareFormatMarkdown, tmpDir)
@@ -280,9 +282,9 @@ func TestSharingService_ExportLibraryWithSheet(t *testing.T) {
 		},
 	}
 	sheetRepo := newMockSharingSheetRepo()
-	svc := service.NewSharingService(bookRepo, sheetRepo)
+	svc := service.NewSharingService(bookRepo, sheetRepo, nil, nil, nil, nil, clock.NewSystemClock())
 
-	// Export book with no author (tests orUnknown helper)
+	// Export book with no author (tests the orUnknown template function)
 	path, err := svc.ExportLibrary(ctx, service.ShareFormatMarkdown, tmpDir)
 	if err != nil {
 		t.Fatalf("expected nil error, got: %v", err)
@@ -304,12 +306,12 @@ func TestSharingService_ExportBookInfoWithSheet(t *testing.T) {
 		},
 	}
 	sheetRepo := newMockSharingSheetRepo()
-	sheet, _ := domain.NewReadingSheet("b1", "Rated Book", "Summary", 4, []string{"A quote"}, []string{"tag"})
+	sheet, _ := domain.NewReadingSheet("b1", "Rated Book", "Summary", 4, []string{"A quote"}, []string{"tag"}, time.Now())
 	sheetRepo.sheets[sheet.ID] = sheet
 
-	svc := service.NewSharingService(bookRepo, sheetRepo)
+	svc := service.NewSharingService(bookRepo, sheetRepo, nil, nil, nil, nil, clock.NewSystemClock())
 
-	// Export as text to test buildBookText and buildSheetText paths
+	// Export as text to test the book and sheet parts of the text template
 	path, err := svc.ExportBookInfo(ctx, "b1", service.ShareFormatText, tmpDir)
 	if err != nil {
 		t.Fatalf("expected nil error, got: %v", err)
@@ -326,3 +328,66 @@ func TestSharingService_ExportBookInfoWithSheet(t *testing.T) {
 		os.Remove(f)
 	}
 }
+
+func TestSharingService_ExportLibraryHTML(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	bookRepo := &mockSharingBookRepo{
+		books: []*domain.Book{
+			{ID: "b1", Title: "Dune", Author: "Frank Herbert", Series: "Dune", SeriesIndex: 1, Format: domain.FormatEPUB, TotalPages: 800, CoverImage: png},
+			{ID: "b2", Title: "<Sans titre>", Format: domain.FormatPDF, TotalPages: 12},
+		},
+	}
+	sheetRepo := newMockSharingSheetRepo()
+	sheet, _ := domain.NewReadingSheet("b1", "Dune", "Arrakis.", 4, []string{"La peur tue l'esprit <script>"}, []string{"sf"}, time.Now())
+	sheetRepo.sheets[sheet.ID] = sheet
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 42},
+		{ID: "a2", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 7},
+		{ID: "a3", BookID: "b1", AnnotationType: domain.AnnotationBookmark, PageNo: 100},
+	}}
+	svc := service.NewSharingService(bookRepo, sheetRepo, nil, nil, annots, nil, clock.NewSystemClock())
+
+	path, err := svc.ExportLibrary(ctx, service.ShareFormatHTML, tmpDir)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !strings.HasSuffix(path, ".html") {
+		t.Errorf("expected .html extension, got %q", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read exported file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"--gold: #F5A623",
+		`id="filter"`,
+		`<a href="#livre-1">Dune</a>`,
+		`src="data:image/png;base64,`,
+		"La peur tue l&#39;esprit &lt;script&gt;",
+		"Pages 7, 42",
+		"Pages 100",
+		"&lt;Sans titre&gt;",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+	if strings.Contains(content, "<link") || strings.Contains(content, `src="http`) {
+		t.Error("expected a self-contained page without external resources")
+	}
+
+	t.Run("ReadingSheet", func(t *testing.T) {
+		path, err := svc.ExportReadingSheet(ctx, sheet.ID, service.ShareFormatHTML, tmpDir)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), "Arrakis.") || strings.Contains(string(data), "Sans titre") {
+			t.Error("expected the sheet export to contain only its book")
+		}
+	})
+}
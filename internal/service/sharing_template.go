@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+)
+
+// Built-in templates, named "<name>.<ext>.tmpl". They reproduce the layouts
+// of ShareFormatMarkdown and ShareFormatText.
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+const templateSuffix = ".tmpl"
+
+var (
+	// ErrTemplateNotFound indicates that no template has the requested name.
+	ErrTemplateNotFound = errors.New("export template not found")
+	// ErrInvalidTemplate indicates a template that fails to parse or render.
+	ErrInvalidTemplate = errors.New("invalid export template")
+)
+
+// TemplateScope tells a template what is being exported.
+type TemplateScope string
+
+const (
+	ScopeLibrary TemplateScope = "library" // toute la bibliothèque
+	ScopeBook    TemplateScope = "book"    // un seul livre
+	ScopeSheet   TemplateScope = "sheet"   // une fiche de lecture
+)
+
+// TemplateData is the value export templates are executed with.
+type TemplateData struct {
+	Scope      TemplateScope
+	ExportedAt time.Time
+	Books      []TemplateBook
+	Stats      TemplateStats
+}
+
+// TemplateBook is one exported book. The embedded *domain.Book exposes
+// .Title, .Author, .Series, .Format, .TotalPages, .AddedAt and so on.
+type TemplateBook struct {
+	*domain.Book
+	Sheet       *domain.ReadingSheet // nil when the book has no sheet
+	Annotations []*domain.Annotation
+	Highlights  []int // highlighted pages, sorted
+	Bookmarks   []int // bookmarked pages, sorted
+}
+
+// TemplateStats summarises the exported books.
+type TemplateStats struct {
+	Books         int
+	Sheets        int
+	Quotes        int
+	Highlights    int
+	Bookmarks     int
+	TotalPages    int
+	AverageRating float64 // over rated sheets only, 0 when none
+}
+
+// ExportTemplate describes a template available for exports.
+type ExportTemplate struct {
+	Name string // nom du fichier sans ".<ext>.tmpl"
+	Ext  string // extension of the produced file, e.g. "md"
+	Path string // empty for built-in templates
+	Err  error  // non-nil when the template is invalid, as a *TemplateError
+}
+
+// Builtin reports whether the template ships with Orus.
+func (t ExportTemplate) Builtin() bool { return t.Path == "" }
+
+// TemplateError reports an invalid template in readable terms.
+type TemplateError struct {
+	Template string
+	Line     int // 0 when unknown
+	Msg      string
+}
+
+func (e *TemplateError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("modele %s, ligne %d : %s", e.Template, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("modele %s : %s", e.Template, e.Msg)
+}
+
+func (e *TemplateError) Unwrap() error { return ErrInvalidTemplate }
+
+// templateFuncs returns the functions available to export templates, with
+// the labels written in tr's language.
+func templateFuncs(tr *i18n.Localizer) template.FuncMap {
+	return template.FuncMap{
+		// date formats t with a Go layout: {{date "02/01/2006" .AddedAt}}.
+		"date": func(layout string, t time.Time) string { return t.Format(layout) },
+		// ldate formats t in a style of the catalogs: {{ldate "long" .ExportedAt}}.
+		"ldate": func(style string, t time.Time) (string, error) {
+			if _, ok := i18n.Message(i18n.English, "date."+style); !ok {
+				return "", fmt.Errorf("style de date %q inconnu", style)
+			}
+			return tr.Date(t, i18n.DateStyle("date."+style)), nil
+		},
+		// t and n translate a catalog message: {{t "export.author"}},
+		// {{n "export.books" (len .Books)}}.
+		"t":    tr.T,
+		"n":    tr.N,
+		"join": func(sep string, items []string) string { return strings.Join(items, sep) },
+		"inc":  func(i int) int { return i + 1 },
+		"orUnknown": func(s string) string {
+			if s == "" {
+				return tr.T("common.unknown")
+			}
+			return s
+		},
+	}
+}
+
+// SetTemplateDir sets the folder holding user templates. A user template
+// named like a built-in one ("markdown", "text") replaces it.
+func (s *SharingService) SetTemplateDir(dir string) { s.templateDir = dir }
+
+// TemplateDir returns the folder holding user templates.
+func (s *SharingService) TemplateDir() string { return s.templateDir }
+
+// ListTemplates returns the built-in and user templates, sorted by name.
+// Invalid user templates are listed with their error so the UI can show it.
+func (s *SharingService) ListTemplates() []ExportTemplate {
+	byName := make(map[string]ExportTemplate)
+	builtin, _ := fs.Glob(defaultTemplates, "templates/*"+templateSuffix)
+	for _, path := range builtin {
+		name, ext := splitTemplateName(filepath.Base(path))
+		byName[name] = ExportTemplate{Name: name, Ext: ext}
+	}
+	if s.templateDir != "" {
+		user, _ := filepath.Glob(filepath.Join(s.templateDir, "*"+templateSuffix))
+		for _, path := range user {
+			name, ext := splitTemplateName(filepath.Base(path))
+			t := ExportTemplate{Name: name, Ext: ext, Path: path}
+			if _, err := s.loadTemplate(t); err != nil {
+				t.Err = err
+			}
+			byName[name] = t
+		}
+	}
+	out := make([]ExportTemplate, 0, len(byName))
+	for _, t := range byName {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ExportLibraryWithTemplate renders the whole library with the named template.
+func (s *SharingService) ExportLibraryWithTemplate(ctx context.Context, name, outputDir string) (string, error) {
+	books, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list books: %w", err)
+	}
+	content, ext, err := s.renderTemplate(ctx, name, ScopeLibrary, books, nil)
+	if err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf("orus_bibliotheque_%s_%s.%s", sanitizeFileName(name), s.clock.Now().Format("20060102"), ext)
+	filePath := filepath.Join(outputDir, fileName)
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write: %w", err)
+	}
+	return filePath, nil
+}
+
+// ValidateTemplate parses src and renders it against sample data for every
+// scope, returning a *TemplateError describing the first problem.
+func ValidateTemplate(name, src string) error {
+	tmpl, err := template.New(name).Funcs(templateFuncs(defaultLocalizer)).Parse(src)
+	if err != nil {
+		return newTemplateError(name, err)
+	}
+	for _, scope := range []TemplateScope{ScopeLibrary, ScopeBook, ScopeSheet} {
+		if err := tmpl.Execute(io.Discard, sampleTemplateData(scope)); err != nil {
+			return newTemplateError(name, err)
+		}
+	}
+	return nil
+}
+
+// renderTemplate executes the named template for books. sheet, when set,
+// replaces the sheet looked up for the single book of a ScopeSheet export.
+func (s *SharingService) renderTemplate(ctx context.Context, name string, scope TemplateScope, books []*domain.Book, sheet *domain.ReadingSheet) (string, string, error) {
+	t, err := s.findTemplate(name)
+	if err != nil {
+		return "", "", err
+	}
+	tmpl, err := s.loadTemplate(t)
+	if err != nil {
+		return "", "", err
+	}
+	data, err := s.templateData(ctx, scope, books, sheet)
+	if err != nil {
+		return "", "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", "", newTemplateError(t.Name+"."+t.Ext+templateSuffix, err)
+	}
+	return sb.String(), t.Ext, nil
+}
+
+func (s *SharingService) findTemplate(name string) (ExportTemplate, error) {
+	if s.templateDir != "" {
+		if matches, _ := filepath.Glob(filepath.Join(s.templateDir, name+".*"+templateSuffix)); len(matches) > 0 {
+			_, ext := splitTemplateName(filepath.Base(matches[0]))
+			return ExportTemplate{Name: name, Ext: ext, Path: matches[0]}, nil
+		}
+	}
+	if matches, _ := fs.Glob(defaultTemplates, "templates/"+name+".*"+templateSuffix); len(matches) > 0 {
+		_, ext := splitTemplateName(filepath.Base(matches[0]))
+		return ExportTemplate{Name: name, Ext: ext}, nil
+	}
+	return ExportTemplate{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+}
+
+func (s *SharingService) loadTemplate(t ExportTemplate) (*template.Template, error) {
+	file := t.Name + "." + t.Ext + templateSuffix
+	var (
+		src []byte
+		err error
+	)
+	if t.Builtin() {
+		src, err = defaultTemplates.ReadFile("templates/" + file)
+	} else {
+		src, err = os.ReadFile(t.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	if err := ValidateTemplate(file, string(src)); err != nil {
+		return nil, err
+	}
+	return template.New(file).Funcs(templateFuncs(s.tr.get())).Parse(string(src))
+}
+
+func (s *SharingService) templateData(ctx context.Context, scope TemplateScope, books []*domain.Book, sheet *domain.ReadingSheet) (*TemplateData, error) {
+	data := &TemplateData{Scope: scope, ExportedAt: s.clock.Now()}
+	for _, book := range books {
+		entry := TemplateBook{Book: book, Sheet: sheet}
+		if entry.Sheet == nil {
+			entry.Sheet, _ = s.sheetRepo.GetSheetByBookID(ctx, book.ID)
+		}
+		if s.annotRepo != nil {
+			annotations, err := s.annotRepo.ListAllAnnotationOfABook(ctx, book.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list annotations: %w", err)
+			}
+			entry.Annotations = annotations
+		}
+		data.Books = append(data.Books, entry.withPages())
+	}
+	data.Stats = computeTemplateStats(data.Books)
+	return data, nil
+}
+
+// withPages fills Highlights and Bookmarks from Annotations.
+func (b TemplateBook) withPages() TemplateBook {
+	for _, a := range b.Annotations {
+		if a.AnnotationType == domain.AnnotationHighlight {
+			b.Highlights = append(b.Highlights, a.PageNo)
+		} else {
+			b.Bookmarks = append(b.Bookmarks, a.PageNo)
+		}
+	}
+	sort.Ints(b.Highlights)
+	sort.Ints(b.Bookmarks)
+	return b
+}
+
+func computeTemplateStats(books []TemplateBook) TemplateStats {
+	st := TemplateStats{Books: len(books)}
+	rated, ratingSum := 0, 0
+	for _, b := range books {
+		st.TotalPages += b.TotalPages
+		st.Highlights += len(b.Highlights)
+		st.Bookmarks += len(b.Bookmarks)
+		if b.Sheet == nil {
+			continue
+		}
+		st.Sheets++
+		st.Quotes += len(b.Sheet.Quotes)
+		if b.Sheet.Rating > 0 {
+			rated++
+			ratingSum += b.Sheet.Rating
+		}
+	}
+	if rated > 0 {
+		st.AverageRating = float64(ratingSum) / float64(rated)
+	}
+	return st
+}
+
+// sampleTemplateData exercises every field so that validation catches typos
+// in branches a real library might not reach.
+func sampleTemplateData(scope TemplateScope) *TemplateData {
+	now := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	book := &domain.Book{ID: "exemple", Title: "Exemple", Author: "Auteur", Series: "Serie", SeriesIndex: 1,
+		FilePath: "/exemple.epub", Format: domain.FormatEPUB, TotalPages: 100, AddedAt: now, UpdatedAt: now}
+	sheet := &domain.ReadingSheet{ID: "fiche", BookID: book.ID, BookTitle: book.Title, Summary: "Resume", Rating: 4,
+		Quotes: []string{"Citation"}, Tags: []string{"tag"}, CreatedAt: now, UpdatedAt: now}
+	entry := TemplateBook{Book: book, Sheet: sheet, Annotations: []*domain.Annotation{
+		{ID: "a1", BookID: book.ID, AnnotationType: domain.AnnotationHighlight, PageNo: 12, CreatedAt: now},
+		{ID: "a2", BookID: book.ID, AnnotationType: domain.AnnotationBookmark, PageNo: 30, CreatedAt: now},
+	}}.withPages()
+	books := []TemplateBook{entry}
+	return &TemplateData{Scope: scope, ExportedAt: now, Books: books, Stats: computeTemplateStats(books)}
+}
+
+// splitTemplateName splits "obsidian.md.tmpl" into ("obsidian", "md").
+func splitTemplateName(file string) (name, ext string) {
+	base := strings.TrimSuffix(file, templateSuffix)
+	ext = strings.TrimPrefix(filepath.Ext(base), ".")
+	if ext == "" {
+		return base, "txt"
+	}
+	return strings.TrimSuffix(base, "."+ext), ext
+}
+
+var (
+	templateErrPos   = regexp.MustCompile(`^template: [^:]+:(\d+):(?:\d+:)? ?(.*)$`)
+	templateExecAt   = regexp.MustCompile(`^executing "[^"]*" at <([^>]*)>: (.*)$`)
+	templateBadField = regexp.MustCompile(`can't evaluate field (\w+) in type \S+`)
+	templateBadFunc  = regexp.MustCompile(`function "(\w+)" not defined`)
+)
+
+// newTemplateError rewrites text/template errors, such as
+// `template: x.tmpl:3:9: executing "x" at <.Titel>: can't evaluate field
+// Titel in type service.TemplateBook`, into a *TemplateError.
+func newTemplateError(name string, err error) *TemplateError {
+	te := &TemplateError{Template: name, Msg: err.Error()}
+	if m := templateErrPos.FindStringSubmatch(te.Msg); m != nil {
+		te.Line, _ = strconv.Atoi(m[1])
+		te.Msg = m[2]
+	}
+	action := ""
+	if m := templateExecAt.FindStringSubmatch(te.Msg); m != nil {
+		action, te.Msg = m[1], m[2]
+	}
+	switch {
+	case templateBadField.MatchString(te.Msg):
+		te.Msg = templateBadField.ReplaceAllString(te.Msg, `champ "$1" inconnu (voir le modele de donnees)`)
+	case templateBadFunc.MatchString(te.Msg):
+		te.Msg = templateBadFunc.ReplaceAllString(te.Msg, `fonction "$1" inconnue (date, ldate, t, n, join, inc, orUnknown)`)
+	}
+	if action != "" {
+		te.Msg += " dans {{" + action + "}}"
+	}
+	return te
+}
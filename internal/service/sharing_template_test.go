@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// newTemplateFixture builds the library the golden files in testdata/golden
+// were produced from, before exports moved to templates.
+func newTemplateFixture(t *testing.T) (*service.SharingService, *mockAnnotationRepo) {
+	t.Helper()
+	added := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	books := &mockSharingBookRepo{books: []*domain.Book{
+		{ID: "b1", Title: "Dune", Author: "Frank Herbert", Format: domain.FormatEPUB, TotalPages: 812, AddedAt: added},
+		{ID: "b2", Title: "Notes sans auteur", Format: domain.FormatPDF, TotalPages: 40, AddedAt: added},
+	}}
+	sheets := newMockSharingSheetRepo()
+	sheet, _ := domain.NewReadingSheet("b1", "Dune", "Sur Arrakis, l'epice.", 4,
+		[]string{"La peur tue l'esprit.", "Le dormeur doit se reveiller."}, []string{"sf", "classique"}, added)
+	sheet.ID = "s1"
+	sheets.sheets[sheet.ID] = sheet
+	annots := &mockAnnotationRepo{annotations: []*domain.Annotation{
+		{ID: "a1", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 42},
+		{ID: "a2", BookID: "b1", AnnotationType: domain.AnnotationHighlight, PageNo: 7},
+	}}
+	fake := clock.NewFakeClock(time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC))
+	return service.NewSharingService(books, sheets, nil, nil, annots, nil, fake), annots
+}
+
+func TestSharingService_DefaultTemplatesMatchGolden(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTemplateFixture(t)
+	dir := t.TempDir()
+
+	for _, format := range []service.ShareFormat{service.ShareFormatMarkdown, service.ShareFormatText} {
+		exports := map[string]func() (string, error){
+			"library": func() (string, error) { return svc.ExportLibrary(ctx, format, dir) },
+			"book":    func() (string, error) { return svc.ExportBookInfo(ctx, "b1", format, dir) },
+			"sheet":   func() (string, error) { return svc.ExportReadingSheet(ctx, "s1", format, dir) },
+		}
+		for scope, export := range exports {
+			golden := scope + "." + string(format)
+			t.Run(golden, func(t *testing.T) {
+				path, err := export()
+				if err != nil {
+					t.Fatalf("expected nil error, got: %v", err)
+				}
+				got, _ := os.ReadFile(path)
+				want, err := os.ReadFile(filepath.Join("testdata", "golden", golden))
+				if err != nil {
+					t.Fatalf("failed to read golden file: %v", err)
+				}
+				if string(got) != string(want) {
+					t.Errorf("output differs from %s\n--- got ---\n%s\n--- want ---\n%s", golden, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestSharingService_ExportsFollowLocalizer(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTemplateFixture(t)
+	svc.SetLocalizer(i18n.New(i18n.English))
+	dir := t.TempDir()
+
+	checks := map[service.ShareFormat][]string{
+		service.ShareFormatMarkdown: {"# My Orus library", "*Exported on March 15, 2026 — 2 books*",
+			"**Author:** Unknown", "**Added on:** Nov 3, 2025", "### Reading sheet"},
+		service.ShareFormatText: {"=== ORUS LIBRARY — 03/15/2026 ===", "TITLE: Dune | AUTHOR: Frank Herbert"},
+		service.ShareFormatHTML: {`<html lang="en">`, "Exported on March 15, 2026 — 2 books", "812 pages · added Nov 3, 2025"},
+	}
+	for format, wants := range checks {
+		path, err := svc.ExportLibrary(ctx, format, dir)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		for _, want := range wants {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s export: expected %q in:\n%s", format, want, data)
+			}
+		}
+	}
+}
+
+func TestSharingService_UserTemplates(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTemplateFixture(t)
+	tplDir := t.TempDir()
+	svc.SetTemplateDir(tplDir)
+
+	custom := `{{range .Books}}- {{.Title}} ({{len .Highlights}} surlignage(s){{with .Sheet}}, {{.Rating}}/5{{end}})
+{{end}}{{.Stats.Books}} livres, {{.Stats.TotalPages}} pages, moyenne {{printf "%.1f" .Stats.AverageRating}}`
+	if err := os.WriteFile(filepath.Join(tplDir, "liste.md.tmpl"), []byte(custom), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tplDir, "casse.txt.tmpl"), []byte("{{.Titel}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	templates := svc.ListTemplates()
+	names := make([]string, len(templates))
+	for i, tpl := range templates {
+		names[i] = tpl.Name
+	}
+	if strings.Join(names, ",") != "casse,liste,markdown,text" {
+		t.Fatalf("unexpected templates: %v", names)
+	}
+	if templates[0].Err == nil || templates[1].Err != nil || !templates[2].Builtin() {
+		t.Errorf("unexpected template states: %+v", templates)
+	}
+
+	path, err := svc.ExportLibraryWithTemplate(ctx, "liste", t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !strings.HasSuffix(path, ".md") {
+		t.Errorf("expected the template extension, got %q", path)
+	}
+	data, _ := os.ReadFile(path)
+	want := "- Dune (2 surlignage(s), 4/5)\n- Notes sans auteur (0 surlignage(s))\n2 livres, 852 pages, moyenne 4.0"
+	if string(data) != want {
+		t.Errorf("expected %q, got %q", want, data)
+	}
+
+	if _, err := svc.ExportLibraryWithTemplate(ctx, "casse", t.TempDir()); !errors.Is(err, service.ErrInvalidTemplate) {
+		t.Errorf("expected ErrInvalidTemplate, got: %v", err)
+	}
+	if _, err := svc.ExportLibraryWithTemplate(ctx, "absent", t.TempDir()); !errors.Is(err, service.ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound, got: %v", err)
+	}
+
+	t.Run("OverrideBuiltin", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(tplDir, "text.txt.tmpl"), []byte("{{len .Books}} livre(s)"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		path, err := svc.ExportLibrary(ctx, service.ShareFormatText, t.TempDir())
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "2 livre(s)" {
+			t.Errorf("expected the user template to replace the built-in one, got %q", data)
+		}
+	})
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"Valid", "{{range .Books}}{{.Title}}{{end}}", ""},
+		{"UnknownField", "ligne 1\n{{range .Books}}{{.Titel}}{{end}}", `modele t.tmpl, ligne 2 : champ "Titel" inconnu (voir le modele de donnees) dans {{.Titel}}`},
+		{"UnknownFunc", "{{upper .Scope}}", `modele t.tmpl, ligne 1 : fonction "upper" inconnue (date, ldate, t, n, join, inc, orUnknown)`},
+		{"Unclosed", "{{if .Books}}", "modele t.tmpl, ligne 1 : unexpected EOF"},
+		// Le champ n'existe que dans une branche : les données d'exemple la parcourent.
+		{"NestedBranch", `{{range .Books}}{{with .Sheet}}{{.Note}}{{end}}{{end}}`, `champ "Note" inconnu`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateTemplate("t.tmpl", tt.src)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("expected nil error, got: %v", err)
+				}
+				return
+			}
+			var te *service.TemplateError
+			if !errors.As(err, &te) {
+				t.Fatalf("expected *TemplateError, got: %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q in %q", tt.want, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,779 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// ErrNoSyncFolder indicates that no sync folder or server has been configured.
+var ErrNoSyncFolder = errors.New("no sync folder configured")
+
+// ErrSyncEncrypted indicates that sync is off because the store encrypts its
+// sensitive fields: the change log would hold them in clear.
+var ErrSyncEncrypted = errors.New("sync is unavailable while encryption is enabled")
+
+// SyncInterval is how often the scheduler syncs with the other devices.
+const SyncInterval = 5 * time.Minute
+
+// Chaque appareil écrit son propre journal, orus-sync-<appareil>.jsonl : deux
+// appareils n'écrivent donc jamais le même fichier.
+const (
+	syncLogPrefix = "orus-sync-"
+	syncLogExt    = ".jsonl"
+	syncBooksDir  = "books" // fichiers des livres, nommés <ID du livre><extension>
+)
+
+// SyncKind is the type of record carried by a change log entry.
+type SyncKind string
+
+const (
+	SyncBook       SyncKind = "book"
+	SyncSheet      SyncKind = "sheet"
+	SyncSession    SyncKind = "session"
+	SyncAnnotation SyncKind = "annotation"
+	SyncReminder   SyncKind = "reminder"
+)
+
+// syncKinds orders the kinds so that a book is always logged, and applied,
+// before the records attached to it.
+var syncKinds = []SyncKind{SyncBook, SyncSheet, SyncSession, SyncAnnotation, SyncReminder}
+
+// SyncRecord is one line of a device change log: the full new state of a
+// record, or a tombstone when Deleted is set.
+type SyncRecord struct {
+	Device  string          `json:"device"`
+	Clock   uint64          `json:"clock"` // horloge de Lamport de l'appareil
+	Kind    SyncKind        `json:"kind"`
+	ID      string          `json:"id"`
+	Deleted bool            `json:"deleted,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (r SyncRecord) key() string { return string(r.Kind) + "/" + r.ID }
+
+// newerThan reports whether r wins over version v: the higher clock wins, and
+// the device ID breaks ties, so every device picks the same winner.
+func (r SyncRecord) newerThan(v syncVersion) bool {
+	if r.Clock != v.Clock {
+		return r.Clock > v.Clock
+	}
+	return r.Device > v.Device
+}
+
+// syncVersion is the winning version of a record as known by this device.
+type syncVersion struct {
+	Clock   uint64 `json:"clock"`
+	Device  string `json:"device"`
+	Hash    string `json:"hash,omitempty"` // empreinte de l'état local correspondant
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// syncState is the per-device state saved next to the database. It is never
+// shared: the backend only holds the change logs and book files.
+type syncState struct {
+	Target   port.SyncTarget        `json:"target"`
+	Device   string                 `json:"device"`
+	Clock    uint64                 `json:"clock"`
+	Versions map[string]syncVersion `json:"versions,omitempty"`
+	Offsets  map[string]int64       `json:"offsets,omitempty"` // octets lus par journal distant
+	ETags    map[string]string      `json:"etags,omitempty"`   // ETag des journaux distants déjà lus
+	LogETag  string                 `json:"log_etag,omitempty"`
+}
+
+// SyncReport counts what a sync did.
+type SyncReport struct {
+	Exported   int // changements locaux ajoutés au journal
+	Applied    int // changements distants appliqués
+	Superseded int // changements distants plus anciens que la version connue
+	Failed     int // changements distants impossibles à appliquer
+	Devices    int // autres appareils présents
+	Uploaded   int // fichiers de livres envoyés
+	Downloaded int // fichiers de livres reçus
+}
+
+// SyncService keeps several devices in step through a port.SyncBackend: a
+// folder shared by a file sync tool such as Syncthing or Nextcloud, or a
+// WebDAV server.
+//
+// Each device appends its changes to its own log on the backend and reads the
+// logs of the others. Local changes are found by comparing every record with
+// the fingerprint saved at the previous sync. Conflicts are resolved per
+// record by last writer wins on Lamport clocks, so that all devices converge
+// to the same state whatever the order in which they see the logs.
+type SyncService struct {
+	bookRepo     port.BookRepository
+	sheetRepo    port.ReadingSheetRepository
+	sessionRepo  port.SessionRepository
+	annotRepo    port.AnnotationRepository
+	reminderRepo port.ReminderRepository
+	logger       *slog.Logger
+	clock        port.Clock
+	open         port.SyncBackendOpener
+	booksDir     string
+	events       eventSink
+	crypt        port.EncryptedStore // voir SetEncryption
+
+	mu        sync.Mutex // sérialise les synchronisations et protège state
+	state     syncState
+	statePath string
+	backend   port.SyncBackend // ouvert à la première synchronisation
+	ownLog    []byte           // contenu du journal de l'appareil, nil tant qu'il n'est pas lu
+	stop      chan struct{}
+}
+
+// NewSyncService creates a new SyncService with the given dependencies.
+// SetBackendOpener must be called before the first sync.
+func NewSyncService(bookRepo port.BookRepository, sheetRepo port.ReadingSheetRepository, sessionRepo port.SessionRepository,
+	annotRepo port.AnnotationRepository, reminderRepo port.ReminderRepository, logger *slog.Logger, clock port.Clock) *SyncService {
+	return &SyncService{
+		bookRepo: bookRepo, sheetRepo: sheetRepo, sessionRepo: sessionRepo, annotRepo: annotRepo, reminderRepo: reminderRepo,
+		logger: componentLogger(logger, "sync"), clock: clock,
+		state: syncState{Device: uuid.New().String()},
+		stop:  make(chan struct{}),
+	}
+}
+
+// SetBackendOpener sets how sync targets are opened.
+func (s *SyncService) SetBackendOpener(open port.SyncBackendOpener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.open, s.backend = open, nil
+}
+
+// SetBooksDir sets the folder receiving the book files downloaded from the
+// backend. Without it, book files are only uploaded.
+func (s *SyncService) SetBooksDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.booksDir = dir
+}
+
+// SetEvents sets the publisher of the events describing the records applied
+// from other devices. Handlers run while the sync holds its lock and must not
+// call back into the SyncService.
+func (s *SyncService) SetEvents(pub port.EventPublisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events.pub = pub
+}
+
+// SetEncryption stops sync while store has encryption enabled: Sync then
+// returns ErrSyncEncrypted and the scheduler skips its turns. The change log
+// holds whole records, so it would carry the summaries, quotes and notes in
+// clear to the folder or server, and the other devices could not open values
+// sealed with this device's key.
+func (s *SyncService) SetEncryption(store port.EncryptedStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.crypt = store
+}
+
+func (s *SyncService) encrypted() bool {
+	return s.crypt != nil && s.crypt.EncryptionEnabled()
+}
+
+// LoadState reads the sync state of this device from path, which later syncs
+// keep up to date. A missing file leaves sync unconfigured, with a new device ID.
+func (s *SyncService) LoadState(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statePath = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s.saveState()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read sync state: %w", err)
+	}
+	var state syncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to decode sync state: %w", err)
+	}
+	if state.Device == "" {
+		state.Device = s.state.Device
+	}
+	s.state, s.backend, s.ownLog = state, nil, nil
+	return nil
+}
+
+func (s *SyncService) saveState() error {
+	if s.statePath == "" {
+		return nil
+	}
+	data, _ := json.Marshal(s.state)
+	tmp := s.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	if err := os.Rename(tmp, s.statePath); err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return nil
+}
+
+// Target returns where this device syncs; its Location is empty when sync is
+// not configured.
+func (s *SyncService) Target() port.SyncTarget {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.Target
+}
+
+// DeviceID returns the identifier of this device on the backend.
+func (s *SyncService) DeviceID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.Device
+}
+
+// SetTarget changes where this device syncs. Moving to another folder or
+// server starts over: the next sync logs the whole library again and reads
+// every log from the start. Changing only the credentials or the book files
+// option keeps the state.
+func (s *SyncService) SetTarget(target port.SyncTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if target.Kind == "" {
+		target.Kind = port.SyncTargetFolder
+	}
+	if target.Kind != s.state.Target.Kind || target.Location != s.state.Target.Location {
+		s.state.Versions, s.state.Offsets, s.state.ETags, s.state.LogETag = nil, nil, nil, ""
+	}
+	s.state.Target = target
+	s.backend, s.ownLog = nil, nil
+	return s.saveState()
+}
+
+// SetFolder syncs through a local folder shared by a file sync tool.
+func (s *SyncService) SetFolder(dir string) error {
+	target := s.Target()
+	if target.Kind != port.SyncTargetFolder {
+		target = port.SyncTarget{}
+	}
+	target.Kind, target.Location = port.SyncTargetFolder, dir
+	return s.SetTarget(target)
+}
+
+// Sync appends the local changes since the previous sync to this device's
+// log, then applies the changes of the other devices that win over the
+// version known here. With the Books option, it also uploads the book files
+// missing from the backend and downloads the ones missing locally.
+func (s *SyncService) Sync(ctx context.Context) (*SyncReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state.Target.Location == "" {
+		return nil, ErrNoSyncFolder
+	}
+	if s.encrypted() {
+		return nil, ErrSyncEncrypted
+	}
+	if s.backend == nil {
+		if s.open == nil {
+			return nil, errors.New("no sync backend available")
+		}
+		backend, err := s.open(s.state.Target)
+		if err != nil {
+			return nil, err
+		}
+		s.backend = backend
+	}
+	if s.state.Versions == nil {
+		s.state.Versions = map[string]syncVersion{}
+	}
+	if s.state.Offsets == nil {
+		s.state.Offsets = map[string]int64{}
+	}
+	if s.state.ETags == nil {
+		s.state.ETags = map[string]string{}
+	}
+
+	report := &SyncReport{}
+	local, err := s.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if report.Exported, err = s.export(ctx, local); err != nil {
+		return nil, err
+	}
+	touched, err := s.merge(ctx, report)
+	if err != nil {
+		return report, err
+	}
+	if s.state.Target.Books {
+		if err := s.syncBookFiles(ctx, report, touched); err != nil {
+			return report, err
+		}
+	}
+	if len(touched) > 0 {
+		// Les empreintes sont celles de l'état relu, pour que les champs
+		// propres à cet appareil (chemin du fichier) ne passent pas pour une
+		// modification locale.
+		local, err := s.snapshot(ctx)
+		if err != nil {
+			return report, err
+		}
+		for key := range touched {
+			if e, ok := local[key]; ok {
+				v := s.state.Versions[key]
+				v.Hash = e.hash
+				s.state.Versions[key] = v
+			}
+		}
+	}
+	return report, s.saveState()
+}
+
+// syncEntry is the current local state of one record.
+type syncEntry struct {
+	kind SyncKind
+	id   string
+	data []byte
+	hash string
+}
+
+// snapshot reads every synced record of the local library.
+func (s *SyncService) snapshot(ctx context.Context) (map[string]syncEntry, error) {
+	out := map[string]syncEntry{}
+	add := func(kind SyncKind, id string, v any) {
+		data, _ := json.Marshal(v)
+		sum := sha256.Sum256(data)
+		e := syncEntry{kind: kind, id: id, data: data, hash: hex.EncodeToString(sum[:])}
+		out[string(kind)+"/"+id] = e
+	}
+
+	books, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+	for _, b := range books {
+		add(SyncBook, b.ID, b)
+		sessions, err := s.sessionRepo.GetSessionByID(ctx, b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+		for _, ses := range sessions {
+			add(SyncSession, ses.SessionID, ses)
+		}
+		annotations, err := s.annotRepo.ListAllAnnotationOfABook(ctx, b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list annotations: %w", err)
+		}
+		for _, a := range annotations {
+			add(SyncAnnotation, a.ID, a)
+		}
+	}
+	sheets, err := s.sheetRepo.ListAllSheets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reading sheets: %w", err)
+	}
+	for _, sh := range sheets {
+		add(SyncSheet, sh.ID, sh)
+	}
+	reminders, err := s.reminderRepo.ListAllReminders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+	for _, r := range reminders {
+		add(SyncReminder, r.ID, r)
+	}
+	return out, nil
+}
+
+// export appends to this device's log a record for every local record whose
+// fingerprint changed, and a tombstone for every record gone since. The
+// versions are only recorded once the log is written.
+func (s *SyncService) export(ctx context.Context, local map[string]syncEntry) (int, error) {
+	clock := s.state.Clock
+	var records []SyncRecord
+	versions := map[string]syncVersion{}
+	for _, key := range sortedSyncKeys(local) {
+		e := local[key]
+		if v, ok := s.state.Versions[key]; ok && !v.Deleted && v.Hash == e.hash {
+			continue
+		}
+		clock++
+		records = append(records, SyncRecord{Device: s.state.Device, Clock: clock, Kind: e.kind, ID: e.id, Data: e.data})
+		versions[key] = syncVersion{Clock: clock, Device: s.state.Device, Hash: e.hash}
+	}
+	var gone []string
+	for key, v := range s.state.Versions {
+		if _, ok := local[key]; !ok && !v.Deleted {
+			gone = append(gone, key)
+		}
+	}
+	sort.Strings(gone)
+	for _, key := range gone {
+		kind, id, _ := strings.Cut(key, "/")
+		clock++
+		records = append(records, SyncRecord{Device: s.state.Device, Clock: clock, Kind: SyncKind(kind), ID: id, Deleted: true})
+		versions[key] = syncVersion{Clock: clock, Device: s.state.Device, Deleted: true}
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return 0, err
+		}
+	}
+	if err := s.appendLog(ctx, buf.Bytes()); err != nil {
+		return 0, err
+	}
+	s.state.Clock = clock
+	for key, v := range versions {
+		s.state.Versions[key] = v
+	}
+	return len(records), nil
+}
+
+// appendLog adds lines to this device's log. Backends have no append, so the
+// whole log is written back, guarded by its ETag; on a mismatch the log is
+// read again and the write retried once.
+func (s *SyncService) appendLog(ctx context.Context, lines []byte) error {
+	name := syncLogPrefix + s.state.Device + syncLogExt
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.ownLog == nil {
+			data, etag, err := s.backend.Get(ctx, name, "")
+			switch {
+			case errors.Is(err, port.ErrSyncObjectNotFound):
+				data, etag = []byte{}, ""
+			case err != nil:
+				return fmt.Errorf("failed to read change log: %w", err)
+			}
+			s.ownLog, s.state.LogETag = data, etag
+		}
+		next := append(append(make([]byte, 0, len(s.ownLog)+len(lines)), s.ownLog...), lines...)
+		etag, err := s.backend.Put(ctx, name, next, s.state.LogETag)
+		if errors.Is(err, port.ErrSyncETagMismatch) {
+			s.ownLog = nil
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write change log: %w", err)
+		}
+		s.ownLog, s.state.LogETag = next, etag
+		return nil
+	}
+	return fmt.Errorf("failed to write change log: %w", port.ErrSyncETagMismatch)
+}
+
+// sortedSyncKeys lists the keys of local in syncKinds order, then by ID.
+func sortedSyncKeys(local map[string]syncEntry) []string {
+	rank := map[SyncKind]int{}
+	for i, k := range syncKinds {
+		rank[k] = i
+	}
+	keys := make([]string, 0, len(local))
+	for key := range local {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := local[keys[i]], local[keys[j]]
+		if a.kind != b.kind {
+			return rank[a.kind] < rank[b.kind]
+		}
+		return a.id < b.id
+	})
+	return keys
+}
+
+// merge reads the new entries of the other devices' logs and applies them in
+// clock order. It returns the keys of the applied records.
+func (s *SyncService) merge(ctx context.Context, report *SyncReport) (map[string]bool, error) {
+	objects, err := s.backend.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var records []SyncRecord
+	for _, obj := range objects {
+		name := path.Base(obj.Name)
+		if !strings.HasPrefix(name, syncLogPrefix) || !strings.HasSuffix(name, syncLogExt) {
+			continue
+		}
+		device := strings.TrimSuffix(strings.TrimPrefix(name, syncLogPrefix), syncLogExt)
+		if device == s.state.Device {
+			continue
+		}
+		report.Devices++
+		known := s.state.ETags[device]
+		if known != "" && obj.ETag == known {
+			continue
+		}
+		data, etag, err := s.backend.Get(ctx, obj.Name, known)
+		if errors.Is(err, port.ErrSyncNotModified) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read change log of %s: %w", device, err)
+		}
+		read, offset := s.parseSyncLog(name, data, s.state.Offsets[device])
+		records = append(records, read...)
+		s.state.Offsets[device], s.state.ETags[device] = offset, etag
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Clock != records[j].Clock {
+			return records[i].Clock < records[j].Clock
+		}
+		return records[i].Device < records[j].Device
+	})
+
+	applied := map[string]bool{}
+	for _, r := range records {
+		if r.Clock > s.state.Clock {
+			s.state.Clock = r.Clock
+		}
+		key := r.key()
+		if v, ok := s.state.Versions[key]; ok && !r.newerThan(v) {
+			report.Superseded++
+			continue
+		}
+		if err := s.apply(ctx, r); err != nil {
+			s.logger.Warn("sync record ignored", "kind", r.Kind, "id", r.ID, "device", r.Device, "error", err)
+			report.Failed++
+			continue
+		}
+		s.state.Versions[key] = syncVersion{Clock: r.Clock, Device: r.Device, Deleted: r.Deleted}
+		applied[key] = true
+		report.Applied++
+	}
+	return applied, nil
+}
+
+// parseSyncLog decodes the complete lines of a log from offset on. A last
+// line still being written by the sync tool is left for the next sync.
+func (s *SyncService) parseSyncLog(name string, data []byte, offset int64) ([]SyncRecord, int64) {
+	if offset > int64(len(data)) {
+		offset = 0 // journal remplacé ou tronqué : on le relit en entier
+	}
+	chunk := data[offset:]
+	end := bytes.LastIndexByte(chunk, '\n')
+	if end < 0 {
+		return nil, offset
+	}
+	var records []SyncRecord
+	for _, line := range bytes.Split(chunk[:end], []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var r SyncRecord
+		if err := json.Unmarshal(line, &r); err != nil || r.ID == "" {
+			s.logger.Warn("unreadable sync log line", "log", name)
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, offset + int64(end) + 1
+}
+
+// syncBookFiles uploads the book files missing from the backend, then
+// downloads the ones this device lacks into booksDir. Downloaded books are
+// added to touched, their path being local.
+func (s *SyncService) syncBookFiles(ctx context.Context, report *SyncReport, touched map[string]bool) error {
+	objects, err := s.backend.List(ctx, syncBooksDir)
+	if err != nil {
+		return err
+	}
+	remote := map[string]string{} // ID du livre -> nom sur le serveur
+	for _, obj := range objects {
+		base := path.Base(obj.Name)
+		remote[strings.TrimSuffix(base, path.Ext(base))] = obj.Name
+	}
+	books, err := s.bookRepo.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list books: %w", err)
+	}
+	for _, b := range books {
+		_, statErr := os.Stat(b.FilePath)
+		local := b.FilePath != "" && statErr == nil
+		name, onServer := remote[b.ID]
+		switch {
+		case local && !onServer:
+			data, err := os.ReadFile(b.FilePath)
+			if err != nil {
+				s.logger.Warn("book file unreadable", "book_id", b.ID, "path", b.FilePath, "error", err)
+				continue
+			}
+			name = syncBooksDir + "/" + b.ID + strings.ToLower(filepath.Ext(b.FilePath))
+			if _, err := s.backend.Put(ctx, name, data, ""); err != nil && !errors.Is(err, port.ErrSyncETagMismatch) {
+				return fmt.Errorf("failed to upload %q: %w", b.Title, err)
+			}
+			report.Uploaded++
+		case !local && onServer && s.booksDir != "":
+			data, _, err := s.backend.Get(ctx, name, "")
+			if err != nil {
+				return fmt.Errorf("failed to download %q: %w", b.Title, err)
+			}
+			if err := os.MkdirAll(s.booksDir, 0o755); err != nil {
+				return err
+			}
+			dest := filepath.Join(s.booksDir, path.Base(name))
+			if err := os.WriteFile(dest, data, 0o644); err != nil {
+				return fmt.Errorf("failed to save %q: %w", b.Title, err)
+			}
+			b.FilePath = dest
+			if err := s.bookRepo.Save(ctx, b); err != nil {
+				return err
+			}
+			touched[string(SyncBook)+"/"+b.ID] = true
+			report.Downloaded++
+		}
+	}
+	return nil
+}
+
+// apply writes a remote record to the local repositories.
+func (s *SyncService) apply(ctx context.Context, r SyncRecord) error {
+	switch r.Kind {
+	case SyncBook:
+		if r.Deleted {
+			if err := s.bookRepo.Delete(ctx, r.ID); err != nil {
+				return err
+			}
+			s.events.publish(ctx, domain.BookDeleted{BookID: r.ID})
+			return nil
+		}
+		var book domain.Book
+		if err := json.Unmarshal(r.Data, &book); err != nil {
+			return err
+		}
+		// Le fichier du livre a son propre chemin sur chaque appareil.
+		existing, err := s.bookRepo.GetByID(ctx, r.ID)
+		known := err == nil && existing != nil
+		if known && existing.FilePath != "" {
+			book.FilePath = existing.FilePath
+		}
+		if err := s.bookRepo.Save(ctx, &book); err != nil {
+			return err
+		}
+		if !known {
+			s.events.publish(ctx, domain.BookImported{Book: &book})
+		}
+		return nil
+	case SyncSheet:
+		existing, err := s.sheetRepo.GetSheetByID(ctx, r.ID)
+		known := err == nil && existing != nil
+		if r.Deleted {
+			if err := s.sheetRepo.DeleteSheet(ctx, r.ID); err != nil {
+				return err
+			}
+			if known {
+				s.events.publish(ctx, domain.SheetUpdated{BookID: existing.BookID, SheetID: r.ID, Deleted: true})
+			}
+			return nil
+		}
+		var sheet domain.ReadingSheet
+		if err := json.Unmarshal(r.Data, &sheet); err != nil {
+			return err
+		}
+		if known {
+			err = s.sheetRepo.UpdateSheet(ctx, &sheet)
+		} else {
+			err = s.sheetRepo.SaveSheet(ctx, &sheet)
+		}
+		if err != nil {
+			return err
+		}
+		s.events.publish(ctx, domain.SheetUpdated{BookID: sheet.BookID, SheetID: sheet.ID})
+		return nil
+	case SyncSession:
+		if r.Deleted {
+			return nil // les sessions ne sont jamais supprimées, sauf avec leur livre
+		}
+		var session domain.ReadingSession
+		if err := json.Unmarshal(r.Data, &session); err != nil {
+			return err
+		}
+		if err := s.sessionRepo.SaveSession(ctx, &session); err != nil {
+			return err
+		}
+		// Pas de BookFinished : le livre a été terminé sur l'autre appareil.
+		s.events.publish(ctx, domain.PageTurned{BookID: session.BookID, SessionID: session.SessionID,
+			Page: session.CurrentPage, TotalPages: session.TotalPages})
+		return nil
+	case SyncAnnotation:
+		if err := s.annotRepo.DeleteAnnotation(ctx, r.ID); err != nil {
+			return err
+		}
+		if r.Deleted {
+			s.events.publish(ctx, domain.AnnotationChanged{AnnotationID: r.ID, Deleted: true})
+			return nil
+		}
+		var annotation domain.Annotation
+		if err := json.Unmarshal(r.Data, &annotation); err != nil {
+			return err
+		}
+		if err := s.annotRepo.SaveAnnotation(ctx, &annotation); err != nil {
+			return err
+		}
+		s.events.publish(ctx, domain.AnnotationChanged{BookID: annotation.BookID, AnnotationID: annotation.ID})
+		return nil
+	case SyncReminder:
+		if r.Deleted {
+			return s.reminderRepo.DeleteReminder(ctx, r.ID)
+		}
+		var reminder domain.Reminder
+		if err := json.Unmarshal(r.Data, &reminder); err != nil {
+			return err
+		}
+		if existing, err := s.reminderRepo.GetReminderByID(ctx, r.ID); err == nil && existing != nil {
+			return s.reminderRepo.UpdateReminder(ctx, &reminder)
+		}
+		return s.reminderRepo.SaveReminder(ctx, &reminder)
+	}
+	return fmt.Errorf("unknown record kind %q", r.Kind)
+}
+
+// StartScheduler blocks, syncing every SyncInterval while a target is
+// configured and encryption is off. Call Stop() to terminate it.
+func (s *SyncService) StartScheduler() {
+	ticker := s.clock.NewTicker(SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C():
+			if s.Target().Location == "" {
+				continue
+			}
+			start := s.clock.Now()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			report, err := s.Sync(ctx)
+			cancel()
+			if errors.Is(err, ErrSyncEncrypted) {
+				continue
+			}
+			if err != nil {
+				s.logger.Error("automatic sync failed", "error", err)
+			} else if report.Exported+report.Applied > 0 {
+				s.logger.Info("automatic sync", "exported", report.Exported, "applied", report.Applied,
+					"duration", s.clock.Now().Sub(start))
+			}
+		}
+	}
+}
+
+// Stop terminates the scheduler goroutine.
+func (s *SyncService) Stop() { close(s.stop) }
@@ -0,0 +1,288 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/adapters/storage/sqlite"
+	"github.com/MiltonJ23/Orus/internal/adapters/syncbackend"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+// syncDevice is one Orus install: its own repositories and state file, and
+// the sync folder shared with the other devices.
+type syncDevice struct {
+	books     *mockAnnotBookRepo
+	sheets    *mockSharingSheetRepo
+	sessions  *recordingSessionRepo
+	annots    *mockAnnotationRepo
+	reminders *mockReminderRepo
+	svc       *service.SyncService
+}
+
+func newSyncDevice(t *testing.T, folder string) *syncDevice {
+	t.Helper()
+	d := &syncDevice{
+		books:     &mockAnnotBookRepo{},
+		sheets:    newMockSharingSheetRepo(),
+		sessions:  &recordingSessionRepo{},
+		annots:    &mockAnnotationRepo{},
+		reminders: newMockReminderRepo(),
+	}
+	d.svc = service.NewSyncService(d.books, d.sheets, d.sessions, d.annots, d.reminders, nil, clock.NewFakeClock(fakeNow))
+	d.svc.SetBackendOpener(syncbackend.Open)
+	if err := d.svc.LoadState(filepath.Join(t.TempDir(), "sync.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.svc.SetFolder(folder); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func (d *syncDevice) sync(t *testing.T) *service.SyncReport {
+	t.Helper()
+	report, err := d.svc.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	return report
+}
+
+func TestSyncService_TwoDevicesConverge(t *testing.T) {
+	ctx := context.Background()
+	folder := filepath.Join(t.TempDir(), "Syncthing")
+	desktop, laptop := newSyncDevice(t, folder), newSyncDevice(t, folder)
+
+	book, _ := domain.NewBook("Dune", "Frank Herbert", "/home/moi/livres/dune.epub", domain.FormatEPUB, 600, fakeNow)
+	_ = desktop.books.Save(ctx, book)
+	sheet, _ := domain.NewReadingSheet(book.ID, "Dune", "Arrakis", 4, []string{"La peur tue l'esprit"}, []string{"sf"}, fakeNow)
+	_ = desktop.sheets.SaveSheet(ctx, sheet)
+	ses, _ := domain.NewSession(book.ID, 600, 120, fakeNow)
+	_ = desktop.sessions.SaveSession(ctx, ses)
+	_ = desktop.annots.SaveAnnotation(ctx, &domain.Annotation{ID: "h1", BookID: book.ID, AnnotationType: domain.AnnotationHighlight, PageNo: 88, CreatedAt: fakeNow})
+	reminder, _ := domain.NewReminder(book.ID, "Dune", "Lecture du soir", 21, 0, domain.FrequencyDaily, fakeNow)
+	_ = desktop.reminders.SaveReminder(ctx, reminder)
+
+	if r := desktop.sync(t); r.Exported != 5 || r.Devices != 0 {
+		t.Fatalf("expected 5 records exported, got %+v", r)
+	}
+	events := &eventRecorder{}
+	laptop.svc.SetEvents(events)
+	if r := laptop.sync(t); r.Applied != 5 || r.Devices != 1 {
+		t.Fatalf("expected 5 records applied, got %+v", r)
+	}
+	got := events.names()
+	slices.Sort(got)
+	if want := []string{"annotation.changed", "book.imported", "page.turned", "sheet.updated"}; !slices.Equal(got, want) {
+		t.Errorf("expected the applied records to publish %v, got %v", want, got)
+	}
+	if got, _ := laptop.sheets.GetSheetByID(ctx, sheet.ID); got == nil || got.Summary != "Arrakis" {
+		t.Fatalf("expected the sheet on the laptop, got %+v", got)
+	}
+	if len(laptop.annots.annotations) != 1 || len(laptop.sessions.saved) != 1 || len(laptop.reminders.reminders) != 1 {
+		t.Fatal("expected the highlight, session and reminder on the laptop")
+	}
+
+	// Rien ne doit repartir dans l'autre sens.
+	if r := laptop.sync(t); r.Exported != 0 {
+		t.Errorf("expected applied records not to be logged again, got %+v", r)
+	}
+	if r := desktop.sync(t); r.Exported != 0 || r.Applied != 0 {
+		t.Errorf("expected nothing new on the desktop, got %+v", r)
+	}
+
+	// Le livre garde son chemin local sur chaque appareil.
+	laptopBook, _ := laptop.books.GetByID(ctx, book.ID)
+	laptopBook.FilePath = "/Users/moi/Livres/dune.epub"
+	if r := laptop.sync(t); r.Exported != 1 {
+		t.Fatalf("expected the edited book to be logged, got %+v", r)
+	}
+	desktop.sync(t)
+	if got, _ := desktop.books.GetByID(ctx, book.ID); got.FilePath != "/home/moi/livres/dune.epub" {
+		t.Errorf("expected the desktop path to be kept, got %q", got.FilePath)
+	}
+	if r := desktop.sync(t); r.Exported != 0 {
+		t.Errorf("expected no echo of the applied book, got %+v", r)
+	}
+
+	// Les deux appareils modifient la fiche avant de se synchroniser.
+	desktop.sheets.sheets[sheet.ID].Summary = "Resume du bureau"
+	laptop.sheets.sheets[sheet.ID].Summary = "Resume du portable"
+	ses2, _ := domain.NewSession(book.ID, 600, 310, fakeNow.Add(20*time.Hour))
+	_ = laptop.sessions.SaveSession(ctx, ses2)
+	_ = desktop.annots.DeleteAnnotation(ctx, "h1")
+	desktop.sync(t)
+	laptop.sync(t)
+	desktop.sync(t)
+
+	want := "Resume du bureau"
+	if laptop.svc.DeviceID() > desktop.svc.DeviceID() {
+		want = "Resume du portable" // horloges égales : l'identifiant départage
+	}
+	for name, d := range map[string]*syncDevice{"desktop": desktop, "laptop": laptop} {
+		if got := d.sheets.sheets[sheet.ID].Summary; got != want {
+			t.Errorf("%s: expected %q after the conflict, got %q", name, want, got)
+		}
+		if len(d.annots.annotations) != 0 {
+			t.Errorf("%s: expected the deleted highlight to be gone", name)
+		}
+		if s := d.sessions.saved[ses2.SessionID]; s == nil || s.CurrentPage != 310 {
+			t.Errorf("%s: expected the latest reading position, got %+v", name, s)
+		}
+	}
+
+	// Une modification postérieure à la synchronisation l'emporte toujours.
+	laptop.sheets.sheets[sheet.ID].Rating = 5
+	laptop.sync(t)
+	desktop.sync(t)
+	if got := desktop.sheets.sheets[sheet.ID]; got.Rating != 5 || got.Summary != want {
+		t.Errorf("expected the later edit on the desktop, got %+v", got)
+	}
+}
+
+func TestSyncService_StateSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	folder := t.TempDir()
+	statePath := filepath.Join(t.TempDir(), "sync.json")
+	books := &mockAnnotBookRepo{}
+	newService := func() *service.SyncService {
+		svc := service.NewSyncService(books, newMockSharingSheetRepo(), &recordingSessionRepo{}, &mockAnnotationRepo{}, newMockReminderRepo(), nil, clock.NewFakeClock(fakeNow))
+		svc.SetBackendOpener(syncbackend.Open)
+		if err := svc.LoadState(statePath); err != nil {
+			t.Fatal(err)
+		}
+		return svc
+	}
+
+	svc := newService()
+	if _, err := svc.Sync(ctx); !errors.Is(err, service.ErrNoSyncFolder) {
+		t.Errorf("expected ErrNoSyncFolder, got %v", err)
+	}
+	_ = svc.SetFolder(folder)
+	book, _ := domain.NewBook("Fondation", "Isaac Asimov", "/livres/fondation.epub", domain.FormatEPUB, 250, fakeNow)
+	_ = books.Save(ctx, book)
+	if r, err := svc.Sync(ctx); err != nil || r.Exported != 1 {
+		t.Fatalf("expected the book exported, got %+v, %v", r, err)
+	}
+
+	restarted := newService()
+	if restarted.DeviceID() != svc.DeviceID() || restarted.Target().Location != folder {
+		t.Fatalf("expected the device and folder to be kept")
+	}
+	if r, _ := restarted.Sync(ctx); r.Exported != 0 {
+		t.Errorf("expected no re-export after a restart, got %+v", r)
+	}
+	_ = books.Delete(ctx, book.ID)
+	if r, _ := restarted.Sync(ctx); r.Exported != 1 {
+		t.Errorf("expected a tombstone for the deleted book, got %+v", r)
+	}
+}
+
+func TestSyncService_RefusesEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.NewStorage(filepath.Join(t.TempDir(), "orus.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	book, _ := domain.NewBook("Fondation", "Isaac Asimov", "/livres/fondation.epub", domain.FormatEPUB, 250, fakeNow)
+	sheet, _ := domain.NewReadingSheet(book.ID, book.Title, "résumé secret", 5, []string{"citation secrète"}, nil, fakeNow)
+	note, _ := domain.NewAnnotation(book.ID, domain.AnnotationHighlight, 12, fakeNow)
+	note.Note = "note secrète"
+	if err := errors.Join(store.Save(ctx, book), store.SaveSheet(ctx, sheet), store.SaveAnnotation(ctx, note)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.EnableEncryption(ctx, "phrase de passe"); err != nil {
+		t.Fatal(err)
+	}
+
+	folder := t.TempDir()
+	svc := service.NewSyncService(store, store, store, store, store, nil, clock.NewFakeClock(fakeNow))
+	svc.SetBackendOpener(syncbackend.Open)
+	svc.SetEncryption(store)
+	if err := svc.LoadState(filepath.Join(t.TempDir(), "sync.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetFolder(folder); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Sync(ctx); !errors.Is(err, service.ErrSyncEncrypted) {
+		t.Fatalf("expected ErrSyncEncrypted, got %v", err)
+	}
+	logText := func() string {
+		var text strings.Builder
+		filepath.WalkDir(folder, func(path string, e os.DirEntry, err error) error {
+			if err == nil && !e.IsDir() {
+				data, _ := os.ReadFile(path)
+				text.Write(data)
+			}
+			return err
+		})
+		return text.String()
+	}
+	for _, secret := range []string{"résumé secret", "citation secrète", "note secrète"} {
+		if strings.Contains(logText(), secret) {
+			t.Errorf("expected %q kept out of the sync folder", secret)
+		}
+	}
+
+	// Sans chiffrement, le même journal porte le texte : le refus est bien ce
+	// qui le protège.
+	if err := store.DisableEncryption(ctx, "phrase de passe"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logText(), "note secrète") {
+		t.Errorf("expected the note in the log once encryption is disabled")
+	}
+}
+
+func TestSyncService_BookFiles(t *testing.T) {
+	ctx := context.Background()
+	folder := t.TempDir()
+	desktop, laptop := newSyncDevice(t, folder), newSyncDevice(t, folder)
+	for _, d := range []*syncDevice{desktop, laptop} {
+		target := d.svc.Target()
+		target.Books = true
+		if err := d.svc.SetTarget(target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	laptopBooks := filepath.Join(t.TempDir(), "Livres")
+	laptop.svc.SetBooksDir(laptopBooks)
+
+	file := filepath.Join(t.TempDir(), "dune.epub")
+	_ = os.WriteFile(file, []byte("epub"), 0o644)
+	book, _ := domain.NewBook("Dune", "Frank Herbert", file, domain.FormatEPUB, 600, fakeNow)
+	_ = desktop.books.Save(ctx, book)
+
+	if r := desktop.sync(t); r.Uploaded != 1 {
+		t.Fatalf("expected the book file uploaded, got %+v", r)
+	}
+	// Sur une autre machine, le chemin du bureau n'existe pas.
+	_ = os.Remove(file)
+	if r := laptop.sync(t); r.Applied != 1 || r.Downloaded != 1 {
+		t.Fatalf("expected the book and its file, got %+v", r)
+	}
+	got, _ := laptop.books.GetByID(ctx, book.ID)
+	if filepath.Dir(got.FilePath) != laptopBooks {
+		t.Fatalf("expected the file in the laptop library, got %q", got.FilePath)
+	}
+	if data, _ := os.ReadFile(got.FilePath); string(data) != "epub" {
+		t.Errorf("expected the downloaded content, got %q", data)
+	}
+	if r := laptop.sync(t); r.Exported != 0 || r.Uploaded != 0 || r.Downloaded != 0 {
+		t.Errorf("expected nothing left to transfer, got %+v", r)
+	}
+}
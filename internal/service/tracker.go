@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
@@ -13,16 +14,25 @@ import (
 type TrackerService struct {
 	repo    port.BookRepository
 	session port.SessionRepository
+	clock   port.Clock
+	events  eventSink
+
+	mu       sync.Mutex      // protège finished
+	finished map[string]bool // sessions ayant déjà publié BookFinished
 }
 
 // NewTrackerService creates a new TrackerService with the given dependencies.
-func NewTrackerService(repository port.BookRepository, session port.SessionRepository) *TrackerService {
+func NewTrackerService(repository port.BookRepository, session port.SessionRepository, clock port.Clock) *TrackerService {
 	return &TrackerService{
 		repo:    repository,
 		session: session,
+		clock:   clock,
 	}
 }
 
+// SetEvents sets the publisher of the PageTurned and BookFinished events.
+func (t *TrackerService) SetEvents(pub port.EventPublisher) { t.events.pub = pub }
+
 // OpenBook creates or resumes a reading session for the given book.
 func (t *TrackerService) OpenBook(ctx context.Context, bookId string) (*domain.ReadingSession, error) {
 	book, err := t.repo.GetByID(ctx, bookId)
@@ -40,7 +50,7 @@ func (t *TrackerService) OpenBook(ctx context.Context, bookId string) (*domain.R
 		currentPage = session.CurrentPage
 	}
 
-	newSession, err := domain.NewSession(bookId, book.TotalPages, currentPage, time.Now())
+	newSession, err := domain.NewSession(bookId, book.TotalPages, currentPage, t.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("OpenBook: init session: %w", err)
 	}
@@ -51,16 +61,40 @@ func (t *TrackerService) OpenBook(ctx context.Context, bookId string) (*domain.R
 	return newSession, nil
 }
 
-// UpdateProgress updates the current page and persists the session.
+// UpdateProgress updates the current page and persists the session. It
+// publishes PageTurned, then BookFinished the first time the session reaches
+// the last page of a book it did not open already finished.
 func (t *TrackerService) UpdateProgress(ctx context.Context, page int, ses *domain.ReadingSession) error {
-	ses.UpdatePosition(page)
-	ses.LastReadingTime = time.Now()
+	wasComplete := ses.IsBookComplete()
+	now := t.clock.Now()
+	ses.UpdatePosition(page, now)
+	ses.LastReadingTime = now
 	if err := t.session.SaveSession(ctx, ses); err != nil {
 		return fmt.Errorf("UpdateProgress: %w", err)
 	}
+	t.events.publish(ctx, domain.PageTurned{BookID: ses.BookID, SessionID: ses.SessionID, Page: ses.CurrentPage, TotalPages: ses.TotalPages})
+	if !wasComplete && ses.IsBookComplete() && t.firstFinish(ses.SessionID) {
+		t.events.publish(ctx, domain.BookFinished{BookID: ses.BookID, SessionID: ses.SessionID, Duration: ses.Duration()})
+	}
 	return nil
 }
 
+// firstFinish records that sessionID finished its book and reports whether
+// it is the first time, so that paging back and forth on the last page does
+// not finish the book again.
+func (t *TrackerService) firstFinish(sessionID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.finished[sessionID] {
+		return false
+	}
+	if t.finished == nil {
+		t.finished = make(map[string]bool)
+	}
+	t.finished[sessionID] = true
+	return true
+}
+
 // GetMostRecentBook returns the book and its latest reading session.
 // It iterates all books and picks the one whose last session is most recent.
 func (t *TrackerService) GetMostRecentBook(ctx context.Context) (*domain.Book, *domain.ReadingSession, error) {
@@ -127,3 +161,16 @@ func (t *TrackerService) BookCompletionStatus(ctx context.Context) (map[string]s
 	}
 	return result, nil
 }
+
+// TotalReadingTime sums the duration of every recorded session of a book.
+func (t *TrackerService) TotalReadingTime(ctx context.Context, bookID string) (time.Duration, error) {
+	sessions, err := t.session.GetSessionByID(ctx, bookID)
+	if err != nil {
+		return 0, fmt.Errorf("TotalReadingTime: %w", err)
+	}
+	var total time.Duration
+	for _, ses := range sessions {
+		total += ses.Duration()
+	}
+	return total, nil
+}
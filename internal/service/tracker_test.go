@@ -3,7 +3,11 @@ package service_test
 import (
 	"context"
 	"errors"
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/MiltonJ23/Orus/internal/domain"
 	"github.com/MiltonJ23/Orus/internal/service"
@@ -37,6 +41,10 @@ func (m *mockSessionRepo) GetSessionByID(ctx context.Context, bookID string) ([]
 	return nil, nil
 }
 
+func (m *mockSessionRepo) ListSessionsSince(ctx context.Context, since time.Time) ([]*domain.ReadingSession, error) {
+	return nil, nil
+}
+
 func (m *mockSessionRepo) GetLastReadingSession(ctx context.Context, bookId string) (*domain.ReadingSession, error) {
 	if m.failGetLast {
 		return nil, errors.New("session retrieve error")
@@ -44,6 +52,33 @@ func (m *mockSessionRepo) GetLastReadingSession(ctx context.Context, bookId stri
 	return &domain.ReadingSession{CurrentPage: 42}, nil
 }
 
+// eventRecorder is a port.EventPublisher keeping the events it receives.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (r *eventRecorder) Publish(_ context.Context, event domain.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, len(r.events))
+	for i, e := range r.events {
+		names[i] = e.EventName()
+	}
+	return names
+}
+
+// eventFunc adapts a subscriber to port.EventPublisher.
+type eventFunc func(ctx context.Context, event domain.Event)
+
+func (f eventFunc) Publish(ctx context.Context, event domain.Event) { f(ctx, event) }
+
 // --- TESTS ---
 
 func TestTrackerService_OpenBook(t *testing.T) {
@@ -52,7 +87,7 @@ func TestTrackerService_OpenBook(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		bRepo := &mockTrackerBookRepo{}
 		sRepo := &mockSessionRepo{}
-		svc := service.NewTrackerService(bRepo, sRepo)
+		svc := service.NewTrackerService(bRepo, sRepo, clock.NewSystemClock())
 
 		session, err := svc.OpenBook(ctx, "book-123")
 		if err != nil {
@@ -66,7 +101,7 @@ func TestTrackerService_OpenBook(t *testing.T) {
 	t.Run("Session Retrieve Error", func(t *testing.T) {
 		bRepo := &mockTrackerBookRepo{}
 		sRepo := &mockSessionRepo{failGetLast: true}
-		svc := service.NewTrackerService(bRepo, sRepo)
+		svc := service.NewTrackerService(bRepo, sRepo, clock.NewSystemClock())
 
 		_, err := svc.OpenBook(ctx, "book-123")
 		if err == nil {
@@ -77,7 +112,7 @@ func TestTrackerService_OpenBook(t *testing.T) {
 	t.Run("Book Retrieve Error", func(t *testing.T) {
 		bRepo := &mockTrackerBookRepo{failGet: true}
 		sRepo := &mockSessionRepo{}
-		svc := service.NewTrackerService(bRepo, sRepo)
+		svc := service.NewTrackerService(bRepo, sRepo, clock.NewSystemClock())
 
 		_, err := svc.OpenBook(ctx, "book-123")
 		if err == nil {
@@ -89,7 +124,7 @@ func TestTrackerService_OpenBook(t *testing.T) {
 func TestTrackerService_UpdateProgress(t *testing.T) {
 	bRepo := &mockTrackerBookRepo{}
 	sRepo := &mockSessionRepo{}
-	svc := service.NewTrackerService(bRepo, sRepo)
+	svc := service.NewTrackerService(bRepo, sRepo, clock.NewSystemClock())
 	session := &domain.ReadingSession{
 		SessionID:   "test-session",
 		BookID:      "book-123",
@@ -105,3 +140,89 @@ func TestTrackerService_UpdateProgress(t *testing.T) {
 		t.Errorf("expected current page 20, got %d", session.CurrentPage)
 	}
 }
+
+func TestTrackerService_UpdateProgress_Events(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	svc := service.NewTrackerService(&mockTrackerBookRepo{}, &mockSessionRepo{}, fake)
+	events := &eventRecorder{}
+	svc.SetEvents(events)
+	session := &domain.ReadingSession{SessionID: "s1", BookID: "book-123", TotalPages: 100, CurrentPage: 90, StartedAt: fakeNow}
+
+	for _, page := range []int{95, 100, 99, 100} {
+		fake.Advance(10 * time.Minute)
+		if err := svc.UpdateProgress(ctx, page, session); err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+	}
+
+	want := "page.turned,page.turned,book.finished,page.turned,page.turned" // un seul BookFinished par session
+	if got := strings.Join(events.names(), ","); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if turned := events.events[1].(domain.PageTurned); turned.Page != 100 || turned.TotalPages != 100 || turned.SessionID != "s1" {
+		t.Errorf("unexpected PageTurned: %+v", turned)
+	}
+	if finished := events.events[2].(domain.BookFinished); finished.BookID != "book-123" || finished.Duration != 20*time.Minute {
+		t.Errorf("unexpected BookFinished: %+v", finished)
+	}
+}
+
+type recordingSessionRepo struct {
+	mockSessionRepo
+	saved map[string]*domain.ReadingSession
+}
+
+func (m *recordingSessionRepo) SaveSession(_ context.Context, s *domain.ReadingSession) error {
+	if m.saved == nil {
+		m.saved = make(map[string]*domain.ReadingSession)
+	}
+	m.saved[s.SessionID] = s
+	return nil
+}
+
+func (m *recordingSessionRepo) GetSessionByID(_ context.Context, bookID string) ([]*domain.ReadingSession, error) {
+	var out []*domain.ReadingSession
+	for _, s := range m.saved {
+		if s.BookID == bookID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func TestTrackerService_SessionDurationWithFakeClock(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC))
+	sRepo := &recordingSessionRepo{}
+	svc := service.NewTrackerService(&mockTrackerBookRepo{}, sRepo, fake)
+
+	first, err := svc.OpenBook(ctx, "book-123")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if !first.StartedAt.Equal(fake.Now()) {
+		t.Errorf("expected session to start at %s, got %s", fake.Now(), first.StartedAt)
+	}
+
+	fake.Advance(25 * time.Minute)
+	if err := svc.UpdateProgress(ctx, 50, first); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if got := first.Duration(); got != 25*time.Minute {
+		t.Errorf("expected 25m session, got %s", got)
+	}
+
+	fake.Advance(time.Hour)
+	second, _ := svc.OpenBook(ctx, "book-123")
+	fake.Advance(10 * time.Minute)
+	_ = svc.UpdateProgress(ctx, 60, second)
+
+	total, err := svc.TotalReadingTime(ctx, "book-123")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if total != 35*time.Minute {
+		t.Errorf("expected 35m total reading time, got %s", total)
+	}
+}
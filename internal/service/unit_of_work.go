@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// txRunner groups the writes of a service. Without a unit of work, set
+// through the SetUnitOfWork of the service, fn runs on the service's own
+// repositories and a failure halfway leaves the first writes in place.
+type txRunner struct {
+	uow port.UnitOfWork
+}
+
+func (r txRunner) run(ctx context.Context, own port.Repositories, fn func(repos port.Repositories) error) error {
+	if r.uow == nil {
+		return fn(own)
+	}
+	return r.uow.WithTx(ctx, fn)
+}
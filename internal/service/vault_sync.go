@@ -0,0 +1,558 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/i18n"
+	"github.com/MiltonJ23/Orus/internal/port"
+)
+
+// ErrNoVault indicates that no vault folder has been configured.
+var ErrNoVault = errors.New("no vault folder configured")
+
+// Marqueurs des blocs gérés par Orus dans les notes du coffre.
+const (
+	vaultBlockBegin = "<!-- orus:begin %s -->"
+	vaultBlockEnd   = "<!-- orus:end %s -->"
+)
+
+// vaultBlocks lists the managed body blocks, in the order of a new note.
+var vaultBlocks = []string{"summary", "quotes", "highlights"}
+
+// vaultKeys lists the front matter keys owned by Orus, in the order of a new
+// note. Other keys are the user's and are never touched.
+var vaultKeys = []string{"orus_id", "title", "author", "series", "series_index", "rating", "tags", "status", "added", "started", "finished", "updated"}
+
+// VaultConfig is the persisted vault sync setting.
+type VaultConfig struct {
+	Dir  string `json:"dir"`
+	Auto bool   `json:"auto"` // synchroniser après chaque modification
+}
+
+// VaultSyncReport counts the notes written by a sync.
+type VaultSyncReport struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// VaultSyncService mirrors books, reading sheets and highlights into an
+// Obsidian or Logseq vault, one Markdown note per book.
+//
+// Orus owns the front matter keys listed in vaultKeys and the body blocks
+// between "<!-- orus:begin x -->" and "<!-- orus:end x -->" markers;
+// everything else in a note is left as the user wrote it. Notes are found by
+// their orus_id key, so they can be renamed or moved within the vault.
+type VaultSyncService struct {
+	bookRepo    port.BookRepository
+	sheetRepo   port.ReadingSheetRepository
+	sessionRepo port.SessionRepository
+	annotRepo   port.AnnotationRepository
+	logger      *slog.Logger
+	clock       port.Clock
+
+	tr localizerRef // langue des titres de section, voir SetLocalizer
+
+	mu         sync.Mutex // sérialise les synchronisations et protège cfg
+	cfg        VaultConfig
+	configPath string
+
+	queueMu  sync.Mutex      // protège queued et draining
+	queued   map[string]bool // livres à resynchroniser, "" pour tout le coffre
+	draining bool
+	idle     sync.WaitGroup // voir Wait
+}
+
+// NewVaultSyncService creates a new VaultSyncService with the given
+// dependencies. The session and annotation repositories may be nil; the
+// status, dates and highlights are then left out.
+func NewVaultSyncService(bookRepo port.BookRepository, sheetRepo port.ReadingSheetRepository, sessionRepo port.SessionRepository,
+	annotRepo port.AnnotationRepository, logger *slog.Logger, clock port.Clock) *VaultSyncService {
+	return &VaultSyncService{bookRepo: bookRepo, sheetRepo: sheetRepo, sessionRepo: sessionRepo, annotRepo: annotRepo,
+		logger: componentLogger(logger, "vault"), clock: clock}
+}
+
+// LoadConfig reads the vault setting from path, which SetVault then keeps
+// up to date. A missing file leaves sync unconfigured.
+func (v *VaultSyncService) LoadConfig(path string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.configPath = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read vault config: %w", err)
+	}
+	if err := json.Unmarshal(data, &v.cfg); err != nil {
+		return fmt.Errorf("failed to decode vault config: %w", err)
+	}
+	return nil
+}
+
+// SetLocalizer sets the language of the section headings, French by default.
+// Notes already in the vault keep their headings until their block changes.
+func (v *VaultSyncService) SetLocalizer(tr *i18n.Localizer) { v.tr.set(tr) }
+
+// Config returns the current vault setting.
+func (v *VaultSyncService) Config() VaultConfig {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.cfg
+}
+
+// SetVault changes the vault setting and saves it when a config file was loaded.
+func (v *VaultSyncService) SetVault(cfg VaultConfig) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.cfg = cfg
+	if v.configPath == "" {
+		return nil
+	}
+	data, _ := json.MarshalIndent(cfg, "", "  ")
+	if err := os.WriteFile(v.configPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to save vault config: %w", err)
+	}
+	return nil
+}
+
+// HandleEvent keeps the vault up to date: with automatic sync enabled, a
+// SheetUpdated or AnnotationChanged event refreshes the note of its book, or
+// every note when the event does not know the book. The notes are written on
+// a worker goroutine, so the publisher never waits for the vault.
+func (v *VaultSyncService) HandleEvent(_ context.Context, event domain.Event) {
+	var bookID string
+	switch e := event.(type) {
+	case domain.SheetUpdated:
+		bookID = e.BookID
+	case domain.AnnotationChanged:
+		bookID = e.BookID
+	default:
+		return
+	}
+	cfg := v.Config()
+	if !cfg.Auto || cfg.Dir == "" {
+		return
+	}
+	v.queueMu.Lock()
+	defer v.queueMu.Unlock()
+	if v.queued == nil {
+		v.queued = make(map[string]bool)
+	}
+	v.queued[bookID] = true
+	if !v.draining {
+		v.draining = true
+		v.idle.Add(1)
+		go v.drain()
+	}
+}
+
+// Wait blocks until the automatic syncs queued by HandleEvent are written.
+func (v *VaultSyncService) Wait() { v.idle.Wait() }
+
+// drain runs the queued syncs until the queue is empty. Events arriving
+// meanwhile are merged: a book changed twice is written once.
+func (v *VaultSyncService) drain() {
+	defer v.idle.Done()
+	// Jamais de déchiffrement ici, quel que soit le contexte de l'éditeur.
+	ctx := context.Background()
+	for {
+		v.queueMu.Lock()
+		queued := v.queued
+		v.queued = nil
+		if len(queued) == 0 {
+			v.draining = false
+			v.queueMu.Unlock()
+			return
+		}
+		v.queueMu.Unlock()
+
+		if queued[""] {
+			if _, err := v.SyncAll(ctx); err != nil {
+				v.logger.Error("automatic vault sync failed", "error", err)
+			}
+			continue
+		}
+		for bookID := range queued {
+			if _, err := v.SyncBook(ctx, bookID); err != nil {
+				v.logger.Error("automatic vault sync failed", "book_id", bookID, "error", err)
+			}
+		}
+	}
+}
+
+// SyncAll writes or refreshes the note of every book.
+func (v *VaultSyncService) SyncAll(ctx context.Context) (*VaultSyncReport, error) {
+	books, err := v.bookRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+	return v.sync(ctx, books)
+}
+
+// SyncBook writes or refreshes the note of a single book.
+func (v *VaultSyncService) SyncBook(ctx context.Context, bookID string) (*VaultSyncReport, error) {
+	book, err := v.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("book not found: %w", err)
+	}
+	return v.sync(ctx, []*domain.Book{book})
+}
+
+func (v *VaultSyncService) sync(ctx context.Context, books []*domain.Book) (*VaultSyncReport, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	dir := v.cfg.Dir
+	if dir == "" {
+		return nil, ErrNoVault
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create vault folder: %w", err)
+	}
+	index, err := indexVault(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VaultSyncReport{}
+	for _, book := range books {
+		note, err := v.buildNote(ctx, book)
+		if err != nil {
+			return report, err
+		}
+		path, exists := index[book.ID]
+		if !exists {
+			path = uniqueNotePath(dir, book.Title, v.tr.get().T("common.untitled"))
+		}
+		var current []byte
+		if exists {
+			if current, err = os.ReadFile(path); err != nil {
+				return report, fmt.Errorf("failed to read note: %w", err)
+			}
+		}
+		updated := mergeNote(string(current), note)
+		switch {
+		case !exists:
+			report.Created++
+		case updated == string(current):
+			report.Unchanged++
+			continue
+		default:
+			report.Updated++
+		}
+		if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			return report, fmt.Errorf("failed to write note: %w", err)
+		}
+		index[book.ID] = path
+	}
+	v.logger.Info("vault synced", "dir", dir, "created", report.Created, "updated", report.Updated, "unchanged", report.Unchanged)
+	return report, nil
+}
+
+// vaultNote is the Orus-owned content of a note.
+type vaultNote struct {
+	title       string
+	frontMatter map[string][]string // clé -> lignes YAML, absente si vide
+	blocks      map[string]string   // bloc -> contenu Markdown
+}
+
+func (v *VaultSyncService) buildNote(ctx context.Context, book *domain.Book) (*vaultNote, error) {
+	sheet, err := v.sheetRepo.GetSheetByBookID(ctx, book.ID)
+	if err != nil && !errors.Is(err, domain.ErrReadingSheetNotFound) {
+		return nil, fmt.Errorf("failed to get sheet: %w", err)
+	}
+	var (
+		sessions    []*domain.ReadingSession
+		annotations []*domain.Annotation
+	)
+	if v.sessionRepo != nil {
+		if sessions, err = v.sessionRepo.GetSessionByID(ctx, book.ID); err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+	}
+	if v.annotRepo != nil {
+		if annotations, err = v.annotRepo.ListAllAnnotationOfABook(ctx, book.ID); err != nil {
+			return nil, fmt.Errorf("failed to list annotations: %w", err)
+		}
+	}
+
+	fm := map[string][]string{}
+	set := func(key, value string) {
+		if value != "" {
+			fm[key] = []string{key + ": " + value}
+		}
+	}
+	set("orus_id", yamlString(book.ID))
+	set("title", yamlString(book.Title))
+	set("author", yamlString(book.Author))
+	set("series", yamlString(book.Series))
+	if book.Series != "" && book.SeriesIndex > 0 {
+		set("series_index", strconv.FormatFloat(book.SeriesIndex, 'f', -1, 64))
+	}
+	set("added", yamlDate(book.AddedAt))
+	if sheet != nil {
+		if sheet.Rating > 0 {
+			set("rating", strconv.Itoa(sheet.Rating))
+		}
+		if len(sheet.Tags) > 0 {
+			lines := []string{"tags:"}
+			for _, tag := range sheet.Tags {
+				lines = append(lines, "  - "+yamlString(strings.ReplaceAll(strings.TrimSpace(tag), " ", "-")))
+			}
+			fm["tags"] = lines
+		}
+		set("updated", yamlDate(sheet.UpdatedAt))
+	}
+	if v.sessionRepo != nil {
+		status, started, finished := readingStatus(sessions)
+		set("status", status)
+		set("started", yamlDate(started))
+		set("finished", yamlDate(finished))
+	}
+
+	tr := v.tr.get()
+	blocks := map[string]string{}
+	if sheet != nil && sheet.Summary != "" {
+		blocks["summary"] = "## " + tr.T("export.summary") + "\n\n" + sheet.Summary + "\n"
+	}
+	if sheet != nil && len(sheet.Quotes) > 0 {
+		var sb strings.Builder
+		sb.WriteString("## " + tr.T("export.quotes") + "\n\n")
+		for _, q := range sheet.Quotes {
+			sb.WriteString("> " + strings.ReplaceAll(q, "\n", "\n> ") + "\n\n")
+		}
+		blocks["quotes"] = strings.TrimSuffix(sb.String(), "\n")
+	}
+	var highlights []*domain.Annotation
+	for _, a := range annotations {
+		if a.AnnotationType == domain.AnnotationHighlight {
+			highlights = append(highlights, a)
+		}
+	}
+	if len(highlights) > 0 {
+		sort.Slice(highlights, func(i, j int) bool { return highlights[i].PageNo < highlights[j].PageNo })
+		var sb strings.Builder
+		sb.WriteString("## " + tr.T("export.highlights") + "\n\n")
+		for _, h := range highlights {
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", tr.T("export.page", h.PageNo), tr.Date(h.CreatedAt, i18n.DateShort)))
+		}
+		blocks["highlights"] = sb.String()
+	}
+	return &vaultNote{title: book.Title, frontMatter: fm, blocks: blocks}, nil
+}
+
+// readingStatus mirrors TrackerService.BookCompletionStatus and dates the
+// first session and the completion.
+func readingStatus(sessions []*domain.ReadingSession) (status string, started, finished time.Time) {
+	if len(sessions) == 0 {
+		return "unread", time.Time{}, time.Time{}
+	}
+	last := sessions[0]
+	for _, s := range sessions {
+		if s.LastReadingTime.After(last.LastReadingTime) {
+			last = s
+		}
+		if !s.StartedAt.IsZero() && (started.IsZero() || s.StartedAt.Before(started)) {
+			started = s.StartedAt
+		}
+	}
+	if last.IsBookComplete() {
+		return "done", started, last.LastReadingTime
+	}
+	return "reading", started, time.Time{}
+}
+
+// mergeNote applies note to the current content of a file (empty for a new
+// note), replacing only the Orus-owned keys and blocks.
+func mergeNote(current string, note *vaultNote) string {
+	fmLines, body, hasFM := splitFrontMatter(current)
+	if !hasFM && strings.TrimSpace(body) == "" {
+		body = "# " + note.title + "\n"
+		for _, name := range vaultBlocks {
+			body += "\n" + fmt.Sprintf(vaultBlockBegin, name) + "\n" + fmt.Sprintf(vaultBlockEnd, name) + "\n"
+		}
+		body += "\n## Notes\n\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	for _, line := range mergeFrontMatter(fmLines, note.frontMatter) {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("---\n")
+	if !strings.HasPrefix(body, "\n") {
+		sb.WriteString("\n")
+	}
+	for _, name := range vaultBlocks {
+		body = replaceBlock(body, name, note.blocks[name])
+	}
+	sb.WriteString(body)
+	return sb.String()
+}
+
+// splitFrontMatter separates the YAML front matter lines from the body.
+func splitFrontMatter(content string) (fm []string, body string, ok bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, content, false
+	}
+	rest := content[len("---\n"):]
+	end := strings.Index(rest, "\n---\n")
+	if strings.HasPrefix(rest, "---\n") {
+		return nil, rest[len("---\n"):], true
+	}
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			return strings.Split(strings.TrimSuffix(rest, "\n---"), "\n"), "", true
+		}
+		return nil, content, false
+	}
+	return strings.Split(rest[:end], "\n"), rest[end+len("\n---\n"):], true
+}
+
+// mergeFrontMatter replaces the Orus-owned keys in place, drops the ones that
+// became empty and appends the new ones; user keys keep their position.
+func mergeFrontMatter(lines []string, managed map[string][]string) []string {
+	owned := make(map[string]bool, len(vaultKeys))
+	for _, k := range vaultKeys {
+		owned[k] = true
+	}
+	var out []string
+	seen := map[string]bool{}
+	for i := 0; i < len(lines); {
+		// Une entrée = la ligne "clé:" et ses lignes indentées (listes, blocs).
+		j := i + 1
+		for j < len(lines) && (strings.HasPrefix(lines[j], " ") || strings.HasPrefix(lines[j], "\t") || strings.HasPrefix(lines[j], "- ")) {
+			j++
+		}
+		key, _, _ := strings.Cut(lines[i], ":")
+		key = strings.TrimSpace(key)
+		switch {
+		case !owned[key]:
+			out = append(out, lines[i:j]...)
+		case !seen[key]:
+			out = append(out, managed[key]...)
+			seen[key] = true
+		}
+		i = j
+	}
+	for _, k := range vaultKeys {
+		if !seen[k] {
+			out = append(out, managed[k]...)
+		}
+	}
+	return out
+}
+
+// replaceBlock swaps the content between the markers of block name, or
+// appends the block when the user removed its markers and it has content.
+func replaceBlock(body, name, content string) string {
+	begin := fmt.Sprintf(vaultBlockBegin, name)
+	end := fmt.Sprintf(vaultBlockEnd, name)
+	i := strings.Index(body, begin)
+	j := strings.Index(body, end)
+	if i < 0 || j < i {
+		if content == "" {
+			return body
+		}
+		if !strings.HasSuffix(body, "\n") {
+			body += "\n"
+		}
+		return body + "\n" + begin + "\n" + content + end + "\n"
+	}
+	return body[:i+len(begin)] + "\n" + content + body[j:]
+}
+
+// indexVault maps the orus_id of every note in dir (recursively, hidden
+// folders such as .obsidian excepted) to its path.
+func indexVault(dir string) (map[string]string, error) {
+	index := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || !bytes.HasPrefix(data, []byte("---")) {
+			return nil
+		}
+		fm, _, _ := splitFrontMatter(string(data))
+		for _, line := range fm {
+			if value, ok := strings.CutPrefix(line, "orus_id:"); ok {
+				index[unquoteYAML(strings.TrimSpace(value))] = path
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan vault: %w", err)
+	}
+	return index, nil
+}
+
+// uniqueNotePath names a new note after the book title, or untitled when it
+// is blank, avoiding the characters Obsidian rejects in links and existing
+// files.
+func uniqueNotePath(dir, title, untitled string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]#^|\/:*?"<>`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = untitled
+	}
+	path := filepath.Join(dir, name+".md")
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s (%d).md", name, n))
+	}
+}
+
+func yamlString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return strconv.Quote(s)
+}
+
+func unquoteYAML(s string) string {
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return strings.Trim(s, `'"`)
+}
+
+func yamlDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MiltonJ23/Orus/internal/adapters/clock"
+	"github.com/MiltonJ23/Orus/internal/domain"
+	"github.com/MiltonJ23/Orus/internal/service"
+)
+
+type vaultFixture struct {
+	books    *mockAnnotBookRepo
+	sheets   *mockSharingSheetRepo
+	sessions *recordingSessionRepo
+	annots   *mockAnnotationRepo
+	sheetSvc *service.ReadingSheetService
+	vault    *service.VaultSyncService
+	dir      string
+	book     *domain.Book
+}
+
+func newVaultFixture(t *testing.T) *vaultFixture {
+	t.Helper()
+	ctx := context.Background()
+	fake := clock.NewFakeClock(fakeNow)
+	f := &vaultFixture{
+		books:    &mockAnnotBookRepo{},
+		sheets:   newMockSharingSheetRepo(),
+		sessions: &recordingSessionRepo{},
+		annots:   &mockAnnotationRepo{},
+		dir:      filepath.Join(t.TempDir(), "Coffre"),
+	}
+	f.book, _ = domain.NewBook("Dune: Le Messie", "Frank Herbert", "/livres/dune.epub", domain.FormatEPUB, 300, fake.Now())
+	f.book.Series, f.book.SeriesIndex = "Dune", 2
+	_ = f.books.Save(ctx, f.book)
+	ses, _ := domain.NewSession(f.book.ID, 300, 120, fakeNow.AddDate(0, 0, -3))
+	_ = f.sessions.SaveSession(ctx, ses)
+	f.annots.annotations = []*domain.Annotation{
+		{ID: "h2", BookID: f.book.ID, AnnotationType: domain.AnnotationHighlight, PageNo: 88, CreatedAt: fakeNow},
+		{ID: "h1", BookID: f.book.ID, AnnotationType: domain.AnnotationHighlight, PageNo: 12, CreatedAt: fakeNow},
+		{ID: "b1", BookID: f.book.ID, AnnotationType: domain.AnnotationBookmark, PageNo: 50, CreatedAt: fakeNow},
+	}
+	f.sheetSvc = service.NewReadingSheetService(f.sheets, f.books, fake)
+	f.vault = service.NewVaultSyncService(f.books, f.sheets, f.sessions, f.annots, nil, fake)
+	if err := f.vault.SetVault(service.VaultConfig{Dir: f.dir}); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *vaultFixture) read(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(f.dir, name))
+	if err != nil {
+		t.Fatalf("failed to read note: %v", err)
+	}
+	return string(data)
+}
+
+func TestVaultSyncService_SyncAll(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultFixture(t)
+	sheet, err := f.sheetSvc.CreateSheet(ctx, f.book.ID, "Paul devient empereur.", 4, []string{"Le pouvoir corrompt."}, []string{"science fiction", "dune"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := f.vault.SyncAll(ctx)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.Created != 1 {
+		t.Errorf("expected 1 created note, got %+v", report)
+	}
+	note := f.read(t, "Dune- Le Messie.md")
+	for _, want := range []string{
+		"---\norus_id: \"" + f.book.ID + "\"\ntitle: \"Dune: Le Messie\"\nauthor: \"Frank Herbert\"\nseries: \"Dune\"\nseries_index: 2\nrating: 4\ntags:\n  - \"science-fiction\"\n  - \"dune\"\nstatus: reading\nadded: 2026-03-10\nstarted: 2026-03-07\nupdated: 2026-03-10\n---\n",
+		"# Dune: Le Messie\n",
+		"<!-- orus:begin summary -->\n## Resume\n\nPaul devient empereur.\n<!-- orus:end summary -->",
+		"> Le pouvoir corrompt.\n",
+		"## Surlignages\n\n- Page 12 (10/03/2026)\n- Page 88 (10/03/2026)\n<!-- orus:end highlights -->",
+	} {
+		if !strings.Contains(note, want) {
+			t.Errorf("expected note to contain %q, got:\n%s", want, note)
+		}
+	}
+	if strings.Contains(note, "Page 50") || strings.Contains(note, "finished:") {
+		t.Errorf("expected no bookmark nor finish date, got:\n%s", note)
+	}
+
+	// L'utilisateur renomme la note, ajoute une propriété, des notes et retouche un bloc géré.
+	edited := strings.Replace(note, "status: reading\n", "status: reading\ncover_seen: true\n", 1)
+	edited = strings.Replace(edited, "Paul devient empereur.", "texte modifie a la main", 1)
+	edited += "Mes reflexions sur le jihad.\n"
+	if err := os.Remove(filepath.Join(f.dir, "Dune- Le Messie.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(f.dir, "Lectures"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	moved := filepath.Join("Lectures", "Messie.md")
+	if err := os.WriteFile(filepath.Join(f.dir, moved), []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.sheetSvc.SetRating(ctx, sheet.ID, 5); err != nil {
+		t.Fatal(err)
+	}
+	report, err = f.vault.SyncAll(ctx)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.Updated != 1 || report.Created != 0 {
+		t.Errorf("expected the moved note to be updated, got %+v", report)
+	}
+	note = f.read(t, moved)
+	for _, want := range []string{"rating: 5\n", "cover_seen: true\n", "Paul devient empereur.", "Mes reflexions sur le jihad.\n"} {
+		if !strings.Contains(note, want) {
+			t.Errorf("expected note to contain %q, got:\n%s", want, note)
+		}
+	}
+	if strings.Contains(note, "texte modifie a la main") {
+		t.Error("expected the managed block to be rewritten")
+	}
+
+	report, err = f.vault.SyncAll(ctx)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	if report.Unchanged != 1 || report.Updated != 0 {
+		t.Errorf("expected an idempotent sync, got %+v", report)
+	}
+	if again := f.read(t, moved); again != note {
+		t.Errorf("expected the note to be left as is, got:\n%s", again)
+	}
+}
+
+func TestVaultSyncService_AutoSync(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultFixture(t)
+	f.sheetSvc.SetEvents(eventFunc(f.vault.HandleEvent))
+
+	if _, err := f.sheetSvc.CreateSheet(ctx, f.book.ID, "Premier jet.", 0, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	f.vault.Wait()
+	if _, err := os.Stat(filepath.Join(f.dir, "Dune- Le Messie.md")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected no sync while automatic sync is off")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "vault.json")
+	if err := f.vault.LoadConfig(configPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.vault.SetVault(service.VaultConfig{Dir: f.dir, Auto: true}); err != nil {
+		t.Fatal(err)
+	}
+	sheet, _ := f.sheetSvc.GetSheetForBook(ctx, f.book.ID)
+	if err := f.sheetSvc.AddQuote(ctx, sheet.ID, "Une citation ajoutee."); err != nil {
+		t.Fatal(err)
+	}
+	f.vault.Wait()
+	if note := f.read(t, "Dune- Le Messie.md"); !strings.Contains(note, "> Une citation ajoutee.") {
+		t.Errorf("expected the quote to be synced, got:\n%s", note)
+	}
+
+	reloaded := service.NewVaultSyncService(f.books, f.sheets, nil, nil, nil, clock.NewFakeClock(fakeNow))
+	if err := reloaded.LoadConfig(configPath); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := reloaded.Config(); cfg.Dir != f.dir || !cfg.Auto {
+		t.Errorf("expected the setting to persist, got %+v", cfg)
+	}
+}
+
+// blockingBookRepo holds GetByID until release is closed.
+type blockingBookRepo struct {
+	*mockAnnotBookRepo
+	release chan struct{}
+}
+
+func (r *blockingBookRepo) GetByID(ctx context.Context, id string) (*domain.Book, error) {
+	<-r.release
+	return r.mockAnnotBookRepo.GetByID(ctx, id)
+}
+
+func TestVaultSyncService_AutoSyncDoesNotBlockPublisher(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultFixture(t)
+	books := &blockingBookRepo{mockAnnotBookRepo: f.books, release: make(chan struct{})}
+	vault := service.NewVaultSyncService(books, f.sheets, f.sessions, f.annots, nil, clock.NewFakeClock(fakeNow))
+	if err := vault.SetVault(service.VaultConfig{Dir: f.dir, Auto: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Le livre reste bloqué : HandleEvent doit rendre la main quand même.
+	for range 3 {
+		vault.HandleEvent(ctx, domain.SheetUpdated{BookID: f.book.ID})
+	}
+	if _, err := os.Stat(filepath.Join(f.dir, "Dune- Le Messie.md")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected the note to wait for the worker")
+	}
+	close(books.release)
+	vault.Wait()
+	if note := f.read(t, "Dune- Le Messie.md"); !strings.Contains(note, `orus_id: "`+f.book.ID) {
+		t.Errorf("expected the note written by the worker, got:\n%s", note)
+	}
+}
+
+func TestVaultSyncService_NoVault(t *testing.T) {
+	f := newVaultFixture(t)
+	_ = f.vault.SetVault(service.VaultConfig{})
+	if _, err := f.vault.SyncAll(context.Background()); !errors.Is(err, service.ErrNoVault) {
+		t.Errorf("expected ErrNoVault, got: %v", err)
+	}
+}
+
+func TestVaultSyncService_FinishedBook(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultFixture(t)
+	done, _ := domain.NewSession(f.book.ID, 300, 300, fakeNow.AddDate(0, 0, -1))
+	_ = f.sessions.SaveSession(ctx, done)
+
+	if _, err := f.vault.SyncBook(ctx, f.book.ID); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	note := f.read(t, "Dune- Le Messie.md")
+	if !strings.Contains(note, "status: done\n") || !strings.Contains(note, "finished: 2026-03-09\n") {
+		t.Errorf("expected a finished book, got:\n%s", note)
+	}
+	// Sans fiche, les blocs restent en place mais vides.
+	if !strings.Contains(note, "<!-- orus:begin summary -->\n<!-- orus:end summary -->") || strings.Contains(note, "rating:") {
+		t.Errorf("expected empty sheet blocks, got:\n%s", note)
+	}
+}